@@ -0,0 +1,73 @@
+package auth_api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxBodySize = 1 << 20
+
+type AuthClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewAuthClient(baseURL string, httpClient *http.Client) (*AuthClient, error) {
+	if strings.TrimSpace(baseURL) == "" {
+		return nil, fmt.Errorf("baseURL cannot be empty")
+	}
+
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 5 * time.Second}
+	}
+
+	return &AuthClient{baseURL: baseURL, client: httpClient}, nil
+}
+
+func (c *AuthClient) IntrospectSession(ctx context.Context, sessionID uuid.UUID) (*SessionResponse, error) {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "sessions", sessionID.String())
+
+	if err != nil {
+		return nil, fmt.Errorf("build endpoint: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized,
+		resp.StatusCode == http.StatusForbidden,
+		resp.StatusCode == http.StatusNotFound:
+		return nil, ErrSessionInactive
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+
+		return nil, fmt.Errorf("auth service error (%d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var out SessionResponse
+
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxBodySize)).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+
+	return &out, nil
+}
@@ -0,0 +1,14 @@
+package auth_api
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SessionResponse struct {
+	SessionID uuid.UUID `json:"session_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	DeviceID  uuid.UUID `json:"device_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
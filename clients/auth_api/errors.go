@@ -0,0 +1,7 @@
+package auth_api
+
+import "errors"
+
+// ErrSessionInactive is returned when the session was revoked, has expired
+// or belongs to a blocked or deleted user.
+var ErrSessionInactive = errors.New("session is not active")
@@ -0,0 +1,11 @@
+package auth_api
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Client interface {
+	IntrospectSession(ctx context.Context, sessionID uuid.UUID) (*SessionResponse, error)
+}
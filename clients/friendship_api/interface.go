@@ -1,10 +0,0 @@
-package friendship_api
-
-import (
-	"context"
-	"github.com/google/uuid"
-)
-
-type Client interface {
-	GetRelationship(ctx context.Context, viewerID, targetID uuid.UUID) (RelationshipResponse, error)
-}
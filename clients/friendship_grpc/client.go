@@ -0,0 +1,256 @@
+package friendship_grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	friendshipv1 "github.com/rockkley/pushpost/services/friendship_service/gen/friendshipv1"
+)
+
+const (
+	defaultTimeout = 3 * time.Second
+	// maxBatch совпадает с лимитом friendship_service на один GetRelationshipsBatch.
+	maxBatch = 100
+	// friendPageSize — сколько id друзей запрашивается за один ListFriendIDs.
+	friendPageSize = 1000
+)
+
+// Relationship — отношение viewer к target.
+// Blocked: viewer заблокировал target; BlockedBy: target заблокировал viewer.
+type Relationship struct {
+	AreFriends             bool
+	PendingRequestSent     bool
+	PendingRequestReceived bool
+	Blocked                bool
+	BlockedBy              bool
+}
+
+// Status — статус для ответа клиенту. О том, что target заблокировал viewer,
+// не сообщаем: для viewer это выглядит как "not_friends".
+func (r Relationship) Status() string {
+	switch {
+	case r.Blocked:
+		return "blocked"
+	case r.AreFriends:
+		return "friends"
+	case r.PendingRequestSent:
+		return "request_sent"
+	case r.PendingRequestReceived:
+		return "request_received"
+	default:
+		return "not_friends"
+	}
+}
+
+// Blocks — кого заблокировал пользователь и кто заблокировал его.
+type Blocks struct {
+	Blocked   []uuid.UUID
+	BlockedBy []uuid.UUID
+}
+
+// Client держит одно соединение на всё время работы приложения и разделяется между горутинами.
+type Client struct {
+	conn    *grpc.ClientConn
+	grpc    friendshipv1.FriendshipServiceClient
+	timeout time.Duration
+}
+
+// NewClient — timeout ограничивает каждый вызов, если у ctx нет более раннего дедлайна.
+func NewClient(addr string, timeout time.Duration) (*Client, error) {
+	if addr == "" {
+		return nil, fmt.Errorf("friendship grpc addr cannot be empty")
+	}
+
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+	if err != nil {
+		return nil, fmt.Errorf("dial friendship service: %w", err)
+	}
+
+	return &Client{
+		conn:    conn,
+		grpc:    friendshipv1.NewFriendshipServiceClient(conn),
+		timeout: timeout,
+	}, nil
+}
+
+// Close закрывает gRPC-соединение. Должен вызываться при завершении работы приложения.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+
+	return c.conn.Close()
+}
+
+func (c *Client) GetRelationship(ctx context.Context, viewerID, targetID uuid.UUID) (Relationship, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.grpc.GetRelationship(ctx, &friendshipv1.GetRelationshipRequest{
+		ViewerId: viewerID.String(),
+		TargetId: targetID.String(),
+	})
+
+	if err != nil {
+		return Relationship{}, fmt.Errorf("friendship grpc: %w", err)
+	}
+
+	return fromProto(resp.Relationship), nil
+}
+
+// GetRelationships возвращает отношение viewer к каждому из targetIDs.
+// Длинные списки разбиваются на запросы по maxBatch.
+func (c *Client) GetRelationships(ctx context.Context, viewerID uuid.UUID, targetIDs []uuid.UUID) (map[uuid.UUID]Relationship, error) {
+	out := make(map[uuid.UUID]Relationship, len(targetIDs))
+
+	for start := 0; start < len(targetIDs); start += maxBatch {
+		chunk := targetIDs[start:min(start+maxBatch, len(targetIDs))]
+
+		callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		resp, err := c.grpc.GetRelationshipsBatch(callCtx, &friendshipv1.GetRelationshipsBatchRequest{
+			ViewerId:  viewerID.String(),
+			TargetIds: uuidStrings(chunk),
+		})
+		cancel()
+
+		if err != nil {
+			return nil, fmt.Errorf("friendship grpc: %w", err)
+		}
+
+		for _, rel := range resp.Relationships {
+			id, err := uuid.Parse(rel.GetTargetId())
+
+			if err != nil {
+				return nil, fmt.Errorf("friendship grpc: invalid target_id %q: %w", rel.GetTargetId(), err)
+			}
+
+			out[id] = fromProto(rel)
+		}
+	}
+
+	return out, nil
+}
+
+// AreBlocked — есть ли блокировка между пользователями в любую сторону.
+func (c *Client) AreBlocked(ctx context.Context, user1, user2 uuid.UUID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.grpc.AreBlocked(ctx, &friendshipv1.AreBlockedRequest{
+		User1Id: user1.String(),
+		User2Id: user2.String(),
+	})
+
+	if err != nil {
+		return false, fmt.Errorf("friendship grpc: %w", err)
+	}
+
+	return resp.Blocked, nil
+}
+
+func (c *Client) GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) (Blocks, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.grpc.GetBlockedUserIDs(ctx, &friendshipv1.GetBlockedUserIDsRequest{UserId: userID.String()})
+
+	if err != nil {
+		return Blocks{}, fmt.Errorf("friendship grpc: %w", err)
+	}
+
+	blocked, err := parseUUIDs(resp.BlockedIds)
+
+	if err != nil {
+		return Blocks{}, err
+	}
+
+	blockedBy, err := parseUUIDs(resp.BlockedByIds)
+
+	if err != nil {
+		return Blocks{}, err
+	}
+
+	return Blocks{Blocked: blocked, BlockedBy: blockedBy}, nil
+}
+
+// ListFriendIDs выгружает всех друзей постранично; timeout действует на каждую страницу.
+func (c *Client) ListFriendIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var (
+		ids   []uuid.UUID
+		token string
+	)
+
+	for {
+		callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		resp, err := c.grpc.ListFriendIDs(callCtx, &friendshipv1.ListFriendIDsRequest{
+			UserId:    userID.String(),
+			PageSize:  friendPageSize,
+			PageToken: token,
+		})
+		cancel()
+
+		if err != nil {
+			return nil, fmt.Errorf("friendship grpc: %w", err)
+		}
+
+		page, err := parseUUIDs(resp.FriendIds)
+
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, page...)
+
+		if resp.NextPageToken == "" {
+			return ids, nil
+		}
+
+		token = resp.NextPageToken
+	}
+}
+
+func fromProto(rel *friendshipv1.Relationship) Relationship {
+	return Relationship{
+		AreFriends:             rel.GetAreFriends(),
+		PendingRequestSent:     rel.GetPendingRequestSent(),
+		PendingRequestReceived: rel.GetPendingRequestReceived(),
+		Blocked:                rel.GetBlocked(),
+		BlockedBy:              rel.GetBlockedBy(),
+	}
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	out := make([]string, len(ids))
+
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+
+	return out
+}
+
+func parseUUIDs(raw []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(raw))
+
+	for _, s := range raw {
+		id, err := uuid.Parse(s)
+
+		if err != nil {
+			return nil, fmt.Errorf("friendship grpc: invalid id %q: %w", s, err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
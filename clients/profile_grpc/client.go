@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
@@ -89,3 +90,20 @@ func (c *Client) GetByUsername(ctx context.Context, username string) (ProfileRes
 		IsPrivate:    resp.IsPrivate,
 	}, nil
 }
+
+// IsPrivate — закрыт ли профиль пользователя; для несуществующего профиля возвращает ErrNotFound.
+func (c *Client) IsPrivate(ctx context.Context, userID uuid.UUID) (bool, error) {
+	resp, err := c.grpc.GetProfilePrivacy(ctx, &profilev1.GetProfilePrivacyRequest{
+		UserId: userID.String(),
+	})
+
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			return false, ErrNotFound
+		}
+
+		return false, fmt.Errorf("profile grpc: %w", err)
+	}
+
+	return resp.IsPrivate, nil
+}
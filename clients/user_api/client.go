@@ -193,6 +193,11 @@ func decodeError(resp *http.Response) error {
 	switch resp.StatusCode {
 	case http.StatusNotFound:
 		return ErrNotFound
+	case http.StatusForbidden:
+		if errResp.Code != "" {
+			return apperror.Forbidden(errResp.Code, "forbidden")
+		}
+		return apperror.Forbidden(apperror.CodeUnauthorized, strings.TrimSpace(string(body)))
 	case http.StatusConflict:
 		if errResp.Code != "" {
 			return apperror.Conflict(errResp.Code, errResp.Field, "conflict")
@@ -248,3 +253,82 @@ func (c *UserClient) ActivateUser(ctx context.Context, email string) error {
 	}
 	return nil
 }
+
+func (c *UserClient) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "users", id.String(), "password")
+
+	if err != nil {
+		return fmt.Errorf("build update password endpoint: %w", err)
+	}
+
+	body, err := json.Marshal(map[string]string{"password_hash": passwordHash})
+
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(body))
+
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return decodeError(resp)
+	}
+	return nil
+}
+
+func (c *UserClient) RehashPassword(ctx context.Context, id uuid.UUID, currentHash, newHash string) error {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "users", id.String(), "password-hash")
+
+	if err != nil {
+		return fmt.Errorf("build rehash password endpoint: %w", err)
+	}
+
+	return c.sendJSON(ctx, http.MethodPut, endpoint, map[string]string{
+		"current_hash": currentHash,
+		"new_hash":     newHash,
+	})
+}
+
+func (c *UserClient) ChangeEmail(ctx context.Context, id uuid.UUID, email string) error {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "users", id.String(), "email")
+
+	if err != nil {
+		return fmt.Errorf("build change email endpoint: %w", err)
+	}
+
+	body, err := json.Marshal(map[string]string{"email": email})
+
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(body))
+
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return decodeError(resp)
+	}
+	return nil
+}
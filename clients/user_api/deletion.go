@@ -0,0 +1,44 @@
+package user_api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleDeletion переводит аккаунт в pending_deletion; после at user_service удалит его сам.
+func (c *UserClient) ScheduleDeletion(ctx context.Context, id uuid.UUID, at time.Time) error {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "users", id.String(), "deletion")
+
+	if err != nil {
+		return fmt.Errorf("build deletion endpoint: %w", err)
+	}
+
+	return c.sendJSON(ctx, http.MethodPut, endpoint, map[string]time.Time{"scheduled_for": at})
+}
+
+// CancelDeletion возвращает 409 deletion_not_scheduled, если аккаунт не ожидает удаления.
+func (c *UserClient) CancelDeletion(ctx context.Context, id uuid.UUID) error {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "users", id.String(), "deletion")
+
+	if err != nil {
+		return fmt.Errorf("build deletion endpoint: %w", err)
+	}
+
+	return c.sendJSON(ctx, http.MethodDelete, endpoint, nil)
+}
+
+// DeleteUser удаляет аккаунт сразу, минуя срок восстановления.
+func (c *UserClient) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "users", id.String())
+
+	if err != nil {
+		return fmt.Errorf("build user endpoint: %w", err)
+	}
+
+	return c.sendJSON(ctx, http.MethodDelete, endpoint, nil)
+}
@@ -17,7 +17,34 @@ type UserResponse struct {
 	Email        string    `json:"email"`
 	PasswordHash string    `json:"password_hash"`
 	Status       string    `json:"status"`
+	Role         string    `json:"role"`
 	CreatedAt    time.Time `json:"created_at"`
+	// DeletionScheduledAt задан, пока аккаунт ожидает удаления.
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+	// SuspendedUntil задан для временной блокировки; у бессрочной он пуст.
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
 }
 
-func (u *UserResponse) IsActive() bool { return u.Status == "active" }
+func (u *UserResponse) IsActive() bool  { return u.Status == "active" }
+func (u *UserResponse) IsBlocked() bool { return u.Status == "blocked" }
+func (u *UserResponse) IsDeleted() bool { return u.Status == "deleted" }
+
+func (u *UserResponse) IsPendingDeletion() bool { return u.Status == "pending_deletion" }
+
+type MFAResponse struct {
+	UserID            uuid.UUID `json:"user_id"`
+	TOTPSecret        string    `json:"totp_secret"`
+	RecoveryCodesLeft int       `json:"recovery_codes_left"`
+	EnabledAt         time.Time `json:"enabled_at"`
+}
+
+type EnableMFARequest struct {
+	TOTPSecret         string   `json:"totp_secret"`
+	RecoveryCodeHashes []string `json:"recovery_code_hashes"`
+}
+
+// SuspendUserRequest — Until == nil означает бессрочную блокировку.
+type SuspendUserRequest struct {
+	Until  *time.Time `json:"until,omitempty"`
+	Reason string     `json:"reason"`
+}
@@ -2,6 +2,7 @@ package user_api
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -11,5 +12,20 @@ type Client interface {
 	GetUserByID(ctx context.Context, id uuid.UUID) (*UserResponse, error)
 	GetUserByEmail(ctx context.Context, email string) (*UserResponse, error)
 	GetUserByUsername(ctx context.Context, username string) (*UserResponse, error)
+	GetUserByFormerUsername(ctx context.Context, username string) (*UserResponse, error)
 	ActivateUser(ctx context.Context, email string) error
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error
+	// RehashPassword заменяет хеш, только если текущий всё ещё currentHash; иначе 409.
+	RehashPassword(ctx context.Context, id uuid.UUID, currentHash, newHash string) error
+	ChangeEmail(ctx context.Context, id uuid.UUID, email string) error
+	SetRole(ctx context.Context, id uuid.UUID, role string) error
+	GetMFA(ctx context.Context, id uuid.UUID) (*MFAResponse, error)
+	EnableMFA(ctx context.Context, id uuid.UUID, req EnableMFARequest) error
+	DisableMFA(ctx context.Context, id uuid.UUID) error
+	ConsumeRecoveryCode(ctx context.Context, id uuid.UUID, codeHash string) error
+	ScheduleDeletion(ctx context.Context, id uuid.UUID, at time.Time) error
+	CancelDeletion(ctx context.Context, id uuid.UUID) error
+	DeleteUser(ctx context.Context, id uuid.UUID) error
+	SuspendUser(ctx context.Context, id uuid.UUID, req SuspendUserRequest) error
+	ReinstateUser(ctx context.Context, id uuid.UUID) error
 }
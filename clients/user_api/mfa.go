@@ -0,0 +1,116 @@
+package user_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// GetMFA возвращает ErrNotFound, если у пользователя не включена 2FA.
+func (c *UserClient) GetMFA(ctx context.Context, id uuid.UUID) (*MFAResponse, error) {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "users", id.String(), "mfa")
+
+	if err != nil {
+		return nil, fmt.Errorf("build mfa endpoint: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, decodeError(resp)
+	}
+
+	var mfa MFAResponse
+
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxBodySize)).Decode(&mfa); err != nil {
+		return nil, fmt.Errorf("decode mfa: %w", err)
+	}
+
+	return &mfa, nil
+}
+
+func (c *UserClient) EnableMFA(ctx context.Context, id uuid.UUID, req EnableMFARequest) error {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "users", id.String(), "mfa")
+
+	if err != nil {
+		return fmt.Errorf("build mfa endpoint: %w", err)
+	}
+
+	return c.sendJSON(ctx, http.MethodPut, endpoint, req)
+}
+
+func (c *UserClient) DisableMFA(ctx context.Context, id uuid.UUID) error {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "users", id.String(), "mfa")
+
+	if err != nil {
+		return fmt.Errorf("build mfa endpoint: %w", err)
+	}
+
+	return c.sendJSON(ctx, http.MethodDelete, endpoint, nil)
+}
+
+// ConsumeRecoveryCode возвращает ErrNotFound, если код неверный или уже использован.
+func (c *UserClient) ConsumeRecoveryCode(ctx context.Context, id uuid.UUID, codeHash string) error {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "users", id.String(), "mfa", "recovery-codes", "consume")
+
+	if err != nil {
+		return fmt.Errorf("build recovery code endpoint: %w", err)
+	}
+
+	return c.sendJSON(ctx, http.MethodPost, endpoint, map[string]string{"code_hash": codeHash})
+}
+
+func (c *UserClient) sendJSON(ctx context.Context, method, endpoint string, payload any) error {
+	var body io.Reader
+
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return decodeError(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package user_api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// SetRole меняет роль пользователя: "user", "moderator" или "admin".
+func (c *UserClient) SetRole(ctx context.Context, id uuid.UUID, role string) error {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "users", id.String(), "role")
+
+	if err != nil {
+		return fmt.Errorf("build role endpoint: %w", err)
+	}
+
+	return c.sendJSON(ctx, http.MethodPut, endpoint, map[string]string{"role": role})
+}
@@ -0,0 +1,32 @@
+package user_api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// SuspendUser блокирует аккаунт; повторный вызов меняет срок и причину блокировки.
+func (c *UserClient) SuspendUser(ctx context.Context, id uuid.UUID, req SuspendUserRequest) error {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "users", id.String(), "suspension")
+
+	if err != nil {
+		return fmt.Errorf("build suspension endpoint: %w", err)
+	}
+
+	return c.sendJSON(ctx, http.MethodPut, endpoint, req)
+}
+
+// ReinstateUser возвращает 409 user_not_suspended, если аккаунт не заблокирован.
+func (c *UserClient) ReinstateUser(ctx context.Context, id uuid.UUID) error {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "users", id.String(), "suspension")
+
+	if err != nil {
+		return fmt.Errorf("build suspension endpoint: %w", err)
+	}
+
+	return c.sendJSON(ctx, http.MethodDelete, endpoint, nil)
+}
@@ -0,0 +1,38 @@
+package user_api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// GetUserByFormerUsername находит пользователя по имени, от которого он недавно отказался.
+// ErrNotFound, если переходный период истёк или имя никому не принадлежало.
+func (c *UserClient) GetUserByFormerUsername(ctx context.Context, username string) (*UserResponse, error) {
+	endpoint, err := url.JoinPath(c.baseURL, "internal", "users", "by-former-username", username)
+
+	if err != nil {
+		return nil, fmt.Errorf("build users endpoint: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, decodeError(resp)
+	}
+
+	return decodeUser(resp)
+}
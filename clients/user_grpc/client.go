@@ -0,0 +1,220 @@
+package user_grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	userv1 "github.com/rockkley/pushpost/services/user_service/gen/userv1"
+)
+
+var ErrNotFound = errors.New("user not found")
+
+const (
+	defaultTimeout = 3 * time.Second
+	// maxBatch совпадает с лимитом user_service на один GetUsers.
+	maxBatch = 100
+)
+
+type User struct {
+	ID        uuid.UUID
+	Username  string
+	Email     string
+	Status    string
+	Role      string
+	CreatedAt time.Time
+}
+
+// Status — статус аккаунта; для удалённых пользователей Status равен "deleted".
+type Status struct {
+	Status              string
+	SuspendedUntil      *time.Time
+	DeletionScheduledAt *time.Time
+}
+
+// Client держит одно соединение на всё время работы приложения: gRPC мультиплексирует
+// запросы поверх него, поэтому клиент создаётся один раз и разделяется между горутинами.
+type Client struct {
+	conn    *grpc.ClientConn
+	grpc    userv1.UserServiceClient
+	timeout time.Duration
+}
+
+// NewClient — timeout ограничивает каждый вызов, если у ctx нет более раннего дедлайна.
+func NewClient(addr string, timeout time.Duration) (*Client, error) {
+	if addr == "" {
+		return nil, fmt.Errorf("user grpc addr cannot be empty")
+	}
+
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+	if err != nil {
+		return nil, fmt.Errorf("dial user service: %w", err)
+	}
+
+	return &Client{
+		conn:    conn,
+		grpc:    userv1.NewUserServiceClient(conn),
+		timeout: timeout,
+	}, nil
+}
+
+// Close закрывает gRPC-соединение. Должен вызываться при завершении работы приложения.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+
+	return c.conn.Close()
+}
+
+// GetUsers возвращает найденных пользователей в порядке ids, пропуская удалённых
+// и несуществующих. Длинные списки разбиваются на запросы по maxBatch.
+func (c *Client) GetUsers(ctx context.Context, ids []uuid.UUID) ([]User, error) {
+	users := make([]User, 0, len(ids))
+
+	for start := 0; start < len(ids); start += maxBatch {
+		chunk := ids[start:min(start+maxBatch, len(ids))]
+
+		raw := make([]string, len(chunk))
+
+		for i, id := range chunk {
+			raw[i] = id.String()
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		resp, err := c.grpc.GetUsers(callCtx, &userv1.GetUsersRequest{Ids: raw})
+		cancel()
+
+		if err != nil {
+			return nil, fmt.Errorf("user grpc: %w", err)
+		}
+
+		for _, u := range resp.Users {
+			user, err := fromProto(u)
+
+			if err != nil {
+				return nil, err
+			}
+
+			users = append(users, user)
+		}
+	}
+
+	return users, nil
+}
+
+func (c *Client) GetByUsername(ctx context.Context, username string) (User, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.grpc.GetByUsername(ctx, &userv1.GetByUsernameRequest{Username: username})
+
+	if err != nil {
+		return User{}, mapError(err)
+	}
+
+	return fromProto(resp.User)
+}
+
+func (c *Client) GetByEmail(ctx context.Context, email string) (User, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.grpc.GetByEmail(ctx, &userv1.GetByEmailRequest{Email: email})
+
+	if err != nil {
+		return User{}, mapError(err)
+	}
+
+	return fromProto(resp.User)
+}
+
+func (c *Client) GetStatus(ctx context.Context, userID uuid.UUID) (Status, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.grpc.GetStatus(ctx, &userv1.GetStatusRequest{UserId: userID.String()})
+
+	if err != nil {
+		return Status{}, mapError(err)
+	}
+
+	suspendedUntil, err := parseTime(resp.SuspendedUntil)
+
+	if err != nil {
+		return Status{}, err
+	}
+
+	deletionScheduledAt, err := parseTime(resp.DeletionScheduledAt)
+
+	if err != nil {
+		return Status{}, err
+	}
+
+	return Status{
+		Status:              resp.Status,
+		SuspendedUntil:      suspendedUntil,
+		DeletionScheduledAt: deletionScheduledAt,
+	}, nil
+}
+
+func mapError(err error) error {
+	if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+		return ErrNotFound
+	}
+
+	return fmt.Errorf("user grpc: %w", err)
+}
+
+func fromProto(u *userv1.User) (User, error) {
+	if u == nil {
+		return User{}, fmt.Errorf("user grpc: empty user in response")
+	}
+
+	id, err := uuid.Parse(u.Id)
+
+	if err != nil {
+		return User{}, fmt.Errorf("user grpc: invalid id %q: %w", u.Id, err)
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, u.CreatedAt)
+
+	if err != nil {
+		return User{}, fmt.Errorf("user grpc: invalid created_at %q: %w", u.CreatedAt, err)
+	}
+
+	return User{
+		ID:        id,
+		Username:  u.Username,
+		Email:     u.Email,
+		Status:    u.Status,
+		Role:      u.Role,
+		CreatedAt: createdAt,
+	}, nil
+}
+
+func parseTime(raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+
+	if err != nil {
+		return nil, fmt.Errorf("user grpc: invalid time %q: %w", raw, err)
+	}
+
+	return &t, nil
+}
@@ -45,11 +45,10 @@
         const s = window.loadSession()
         if (!s?.token) return
 
-        const res = await window.api("GET", "/friends", null, s.token)
+        const res = await window.api("GET", "/friends/summary", null, s.token)
         if (!res.ok) return
 
-        const ids = res.data?.friend_ids || []
-        setFriendsCount(ids.length)
+        setFriendsCount(res.data?.friends_count)
     }
 
     function patchHooks() {
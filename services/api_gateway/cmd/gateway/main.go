@@ -12,11 +12,15 @@ import (
 	"syscall"
 
 	"github.com/joho/godotenv"
-	"github.com/rockkley/pushpost/clients/friendship_api"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/rockkley/pushpost/clients/auth_api"
+	"github.com/rockkley/pushpost/clients/friendship_grpc"
 	"github.com/rockkley/pushpost/clients/profile_grpc"
+	"github.com/rockkley/pushpost/clients/user_api"
 	"github.com/rockkley/pushpost/services/api_gateway/internal/config"
 	gwmiddleware "github.com/rockkley/pushpost/services/api_gateway/internal/middleware"
 	"github.com/rockkley/pushpost/services/api_gateway/internal/proxy"
+	"github.com/rockkley/pushpost/services/api_gateway/internal/session"
 	"github.com/rockkley/pushpost/services/api_gateway/internal/transport"
 	myHTTP "github.com/rockkley/pushpost/services/api_gateway/internal/transport/http"
 	"github.com/rockkley/pushpost/services/common_service/jwt"
@@ -107,19 +111,63 @@ func main() {
 		}
 	}()
 
-	friendshipClient, err := friendship_api.NewFriendshipClient(
-		cfg.Services.FriendshipService,
+	friendshipClient, err := friendship_grpc.NewClient(cfg.Services.FriendshipServiceGRPC, cfg.Services.Timeout)
+
+	if err != nil {
+		appLog.Error("failed to create friendship grpc client", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	defer func() {
+		if closeErr := friendshipClient.Close(); closeErr != nil {
+			appLog.Error("failed to close friendship grpc connection", slog.Any("error", closeErr))
+		}
+	}()
+
+	userClient, err := user_api.NewUserClient(
+		cfg.Services.UserService,
+		&http.Client{Timeout: cfg.Services.Timeout},
+	)
+
+	if err != nil {
+		appLog.Error("failed to create user client", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	authClient, err := auth_api.NewAuthClient(
+		cfg.Services.AuthService,
 		&http.Client{Timeout: cfg.Services.Timeout},
 	)
 
 	if err != nil {
-		appLog.Error("failed to create friendship client", slog.Any("error", err))
+		appLog.Error("failed to create auth client", slog.Any("error", err))
 		os.Exit(1)
 	}
 
-	jwtManager := jwt.NewManager(cfg.JWT.Secret, nil)
-	authMW := gwmiddleware.NewAuthMiddleware(jwtManager)
-	profileHandler := myHTTP.NewProfileHandler(profileClient, friendshipClient)
+	rdb := goredis.NewClient(&goredis.Options{
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+
+	if err = rdb.Ping(context.Background()).Err(); err != nil {
+		appLog.Error("failed to connect to redis", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	defer rdb.Close()
+
+	sessionChecker := session.NewChecker(authClient, rdb, cfg.Session.CacheTTL, appLog)
+
+	jwtKeys := jwt.NewRemoteKeySet(
+		cfg.JWT.JWKSURL,
+		&http.Client{Timeout: cfg.Services.Timeout},
+		cfg.JWT.RefreshInterval,
+		appLog,
+	)
+	jwtManager := jwt.NewVerifier(jwtKeys)
+	authMW := gwmiddleware.NewAuthMiddleware(jwtManager, sessionChecker, []byte(cfg.Internal.IdentitySecret))
+	profileHandler := myHTTP.NewProfileHandler(profileClient, friendshipClient, userClient)
 
 	mux := transport.NewRouter(
 		appLog,
@@ -145,6 +193,12 @@ func main() {
 		WriteTimeout: cfg.HTTP.WriteTimeout,
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go sessionChecker.Run(ctx)
+	go jwtKeys.Run(ctx)
+
 	serverErr := make(chan error, 1)
 
 	go func() {
@@ -165,6 +219,8 @@ func main() {
 		appLog.Info("api gateway shutting down...")
 	}
 
+	cancel()
+
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
 	defer shutdownCancel()
 
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -12,6 +13,9 @@ type Config struct {
 	HTTP     HTTPConfig
 	JWT      JWTConfig
 	CORS     CorsConfig
+	Redis    RedisConfig
+	Session  SessionConfig
+	Internal InternalConfig
 	Services ServicesConfig
 }
 
@@ -22,8 +26,29 @@ type HTTPConfig struct {
 	ShutdownTimeout time.Duration `env:"HTTP_SHUTDOWN_TIMEOUT" env-default:"30s"`
 }
 
+// JWTConfig — gateway только проверяет токены; ключи берутся из JWKS auth_service.
+// Пустой JWKSURL означает <AUTH_SERVICE_URL>/.well-known/jwks.json.
 type JWTConfig struct {
-	Secret string `env:"JWT_SECRET" env-required:"true"`
+	JWKSURL         string        `env:"JWT_JWKS_URL"              env-default:""`
+	RefreshInterval time.Duration `env:"JWT_JWKS_REFRESH_INTERVAL" env-default:"5m"`
+}
+
+type RedisConfig struct {
+	Addr     string `env:"REDIS_ADDR"     env-default:"localhost:6379"`
+	Password string `env:"REDIS_PASSWORD" env-default:""`
+	DB       int    `env:"REDIS_DB"       env-default:"0"`
+}
+
+type SessionConfig struct {
+	// Сколько gateway доверяет ответу auth_service без повторной проверки.
+	// Отзыв через pub/sub срабатывает сразу, TTL страхует от пропущенных сообщений.
+	CacheTTL time.Duration `env:"SESSION_CACHE_TTL" env-default:"30s"`
+}
+
+// InternalConfig — IdentitySecret подписывает X-User-Role; тот же секрет задаётся сервисам,
+// которые проверяют роль через RequireRole.
+type InternalConfig struct {
+	IdentitySecret string `env:"INTERNAL_IDENTITY_SECRET" env-required:"true"`
 }
 
 type CorsConfig struct {
@@ -49,15 +74,16 @@ func (c *CorsConfig) AllowedOrigins() []string {
 }
 
 type ServicesConfig struct {
-	AuthService         string        `env:"AUTH_SERVICE_URL"          env-required:"true"`
-	UserService         string        `env:"USER_SERVICE_URL"          env-required:"true"`
-	FriendshipService   string        `env:"FRIENDSHIP_SERVICE_URL"    env-required:"true"`
-	ProfileServiceGRPC  string        `env:"PROFILE_SERVICE_GRPC_ADDR" env-required:"true"`
-	ProfileService      string        `env:"PROFILE_SERVICE_URL"       env-required:"true"`
-	MessageService      string        `env:"MESSAGE_SERVICE_URL"     env-required:"true"`
-	PostService         string        `env:"POST_SERVICE_URL"          env-required:"true"`
-	NotificationService string        `env:"NOTIFICATION_SERVICE_URL"  env-required:"true"`
-	Timeout             time.Duration `env:"UPSTREAM_TIMEOUT"          env-default:"10s"`
+	AuthService           string        `env:"AUTH_SERVICE_URL"             env-required:"true"`
+	UserService           string        `env:"USER_SERVICE_URL"             env-required:"true"`
+	FriendshipService     string        `env:"FRIENDSHIP_SERVICE_URL"       env-required:"true"`
+	FriendshipServiceGRPC string        `env:"FRIENDSHIP_SERVICE_GRPC_ADDR" env-default:"friendship-service:9082"`
+	ProfileServiceGRPC    string        `env:"PROFILE_SERVICE_GRPC_ADDR"    env-required:"true"`
+	ProfileService        string        `env:"PROFILE_SERVICE_URL"          env-required:"true"`
+	MessageService        string        `env:"MESSAGE_SERVICE_URL"          env-required:"true"`
+	PostService           string        `env:"POST_SERVICE_URL"             env-required:"true"`
+	NotificationService   string        `env:"NOTIFICATION_SERVICE_URL"     env-required:"true"`
+	Timeout               time.Duration `env:"UPSTREAM_TIMEOUT"             env-default:"10s"`
 }
 
 func Load() (*Config, error) {
@@ -77,8 +103,26 @@ func Load() (*Config, error) {
 }
 
 func (c *Config) validate() error {
-	if len(c.JWT.Secret) < 32 {
-		return fmt.Errorf("jwt_secret must be at least 32 characters, got %d", len(c.JWT.Secret))
+	if c.JWT.JWKSURL == "" {
+		jwksURL, err := url.JoinPath(c.Services.AuthService, ".well-known", "jwks.json")
+
+		if err != nil {
+			return fmt.Errorf("auth_service_url: %w", err)
+		}
+
+		c.JWT.JWKSURL = jwksURL
+	}
+
+	if c.JWT.RefreshInterval <= 0 {
+		return fmt.Errorf("jwt_jwks_refresh_interval must be positive")
+	}
+
+	if len(c.Internal.IdentitySecret) < 32 {
+		return fmt.Errorf("internal_identity_secret must be at least 32 characters")
+	}
+
+	if c.Session.CacheTTL <= 0 {
+		return fmt.Errorf("session_cache_ttl must be positive")
 	}
 
 	if len(c.CORS.AllowedOrigins()) == 0 {
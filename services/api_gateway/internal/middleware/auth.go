@@ -2,32 +2,50 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	jwtlib "github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/api_gateway/internal/session"
 	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
 	"github.com/rockkley/pushpost/services/common_service/ctxlog"
 	"github.com/rockkley/pushpost/services/common_service/httperror"
 	"github.com/rockkley/pushpost/services/common_service/jwt"
+	commonmiddleware "github.com/rockkley/pushpost/services/common_service/middleware"
+	"github.com/rockkley/pushpost/services/common_service/roles"
 	"log/slog"
 	"net/http"
+	"time"
 )
 
-const HeaderUserID = "X-User-ID"
+const HeaderUserID = commonmiddleware.HeaderUserID
 
 type contextKey string
 
-const ctxUserIDKey contextKey = "userID"
+const (
+	ctxUserIDKey contextKey = "userID"
+	ctxRoleKey   contextKey = "role"
+)
+
+// SessionChecker reports whether the session behind a token is still active.
+type SessionChecker interface {
+	Check(ctx context.Context, sessionID, userID uuid.UUID) error
+}
 
+// AuthMiddleware передаёт сервисам пользователя в X-User-ID, а роль — в X-User-Role
+// вместе с подписью identitySecret, которую проверяет commonmiddleware.RequireRole.
 type AuthMiddleware struct {
-	jwtManager *jwt.Manager
+	jwtManager     *jwt.Manager
+	sessions       SessionChecker
+	identitySecret []byte
 }
 
-func NewAuthMiddleware(jwtManager *jwt.Manager) *AuthMiddleware {
-	return &AuthMiddleware{jwtManager: jwtManager}
+func NewAuthMiddleware(jwtManager *jwt.Manager, sessions SessionChecker, identitySecret []byte) *AuthMiddleware {
+	return &AuthMiddleware{jwtManager: jwtManager, sessions: sessions, identitySecret: identitySecret}
 }
 
 func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		r.Header.Del(HeaderUserID)
+		stripIdentity(r)
 		tokenStr := extractBearer(r.Header.Get("Authorization"))
 
 		if tokenStr == "" {
@@ -68,16 +86,19 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 			return
 		}
 
-		r.Header.Set(HeaderUserID, userID.String())
+		if err = m.checkSession(r.Context(), claims, userID); err != nil {
+			httperror.HandleError(w, r, err)
+
+			return
+		}
 
-		ctx := context.WithValue(r.Context(), ctxUserIDKey, userID)
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, m.forwardIdentity(r, claims, userID))
 	})
 }
 
 func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		r.Header.Del(HeaderUserID)
+		stripIdentity(r)
 		tokenStr := extractBearer(r.Header.Get("Authorization"))
 		if tokenStr == "" {
 			next.ServeHTTP(w, r)
@@ -103,12 +124,91 @@ func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 			return
 		}
 
-		r.Header.Set(HeaderUserID, userID.String())
-		ctx := context.WithValue(r.Context(), ctxUserIDKey, userID)
-		next.ServeHTTP(w, r.WithContext(ctx))
+		if err = m.checkSession(r.Context(), claims, userID); err != nil {
+			ctxlog.From(r.Context()).Debug("optional auth: inactive session", slog.Any("error", err))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, m.forwardIdentity(r, claims, userID))
 	})
 }
 
+// RequireRole ставится после RequireAuth и отсекает запрос ещё на gateway.
+func RequireRole(min roles.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, _ := r.Context().Value(ctxRoleKey).(roles.Role)
+
+			if !role.AtLeast(min) {
+				httperror.HandleError(w, r, commonapperr.Forbidden(
+					commonapperr.CodeForbidden, "insufficient role",
+				))
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardIdentity ставит заголовки для сервисов. Токены без claim "rol" выпущены
+// до появления ролей и получают роль user.
+func (m *AuthMiddleware) forwardIdentity(r *http.Request, claims jwtlib.MapClaims, userID uuid.UUID) *http.Request {
+	role, ok := roles.Parse(stringClaim(claims, "rol"))
+
+	if !ok {
+		role = roles.User
+	}
+
+	r.Header.Set(HeaderUserID, userID.String())
+	r.Header.Set(commonmiddleware.HeaderUserRole, role.String())
+	r.Header.Set(commonmiddleware.HeaderIdentitySignature,
+		commonmiddleware.SignIdentity(m.identitySecret, userID, role, time.Now()))
+
+	ctx := context.WithValue(r.Context(), ctxUserIDKey, userID)
+	ctx = context.WithValue(ctx, ctxRoleKey, role)
+
+	return r.WithContext(ctx)
+}
+
+// stripIdentity удаляет заголовки, которые клиент мог подделать.
+func stripIdentity(r *http.Request) {
+	r.Header.Del(HeaderUserID)
+	r.Header.Del(commonmiddleware.HeaderUserRole)
+	r.Header.Del(commonmiddleware.HeaderIdentitySignature)
+}
+
+func stringClaim(claims jwtlib.MapClaims, name string) string {
+	s, _ := claims[name].(string)
+	return s
+}
+
+func (m *AuthMiddleware) checkSession(ctx context.Context, claims jwtlib.MapClaims, userID uuid.UUID) error {
+	sidStr, ok := claims["sid"].(string)
+
+	if !ok || sidStr == "" {
+		return commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "token missing session id")
+	}
+
+	sessionID, err := uuid.Parse(sidStr)
+
+	if err != nil {
+		return commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "invalid session id in token")
+	}
+
+	if err = m.sessions.Check(ctx, sessionID, userID); err != nil {
+		if errors.Is(err, session.ErrSessionInactive) {
+			return commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "session is no longer active")
+		}
+
+		return commonapperr.Service("failed to verify session", err)
+	}
+
+	return nil
+}
+
 func UserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
 	userID, ok := ctx.Value(ctxUserIDKey).(uuid.UUID)
 	return userID, ok && userID != uuid.Nil
@@ -26,6 +26,7 @@ func New(upstreamURL string, transport *http.Transport) (*httputil.ReverseProxy,
 	return &httputil.ReverseProxy{
 		Rewrite: func(r *httputil.ProxyRequest) {
 			r.SetURL(target)
+			r.SetXForwarded()
 			r.Out.Header.Set("X-Forwarded-Host", r.In.Host)
 		},
 		Transport: transport,
@@ -41,6 +42,7 @@ func NewStrippingAuth(upstreamURL string, transport *http.Transport) (*httputil.
 	return &httputil.ReverseProxy{
 		Rewrite: func(r *httputil.ProxyRequest) {
 			r.SetURL(target)
+			r.SetXForwarded()
 			r.Out.Header.Set("X-Forwarded-Host", r.In.Host)
 			r.Out.Header.Del("Authorization")
 		},
@@ -62,6 +64,7 @@ func NewStrippingAuthWithRewrite(
 	return &httputil.ReverseProxy{
 		Rewrite: func(r *httputil.ProxyRequest) {
 			r.SetURL(target)
+			r.SetXForwarded()
 			r.Out.Header.Set("X-Forwarded-Host", r.In.Host)
 			r.Out.Header.Del("Authorization")
 
@@ -0,0 +1,171 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/rockkley/pushpost/clients/auth_api"
+	"github.com/rockkley/pushpost/services/common_service/revocation"
+)
+
+var ErrSessionInactive = errors.New("session is not active")
+
+type entry struct {
+	userID    uuid.UUID
+	active    bool
+	expiresAt time.Time
+}
+
+// Checker answers whether a session is still valid. Answers from auth_service
+// are cached for ttl; revocations published by auth_service evict the cache
+// immediately, so the ttl only bounds how stale a missed revocation can be.
+type Checker struct {
+	authClient auth_api.Client
+	rdb        *goredis.Client
+	ttl        time.Duration
+	log        *slog.Logger
+
+	mu      sync.RWMutex
+	entries map[uuid.UUID]entry
+}
+
+func NewChecker(authClient auth_api.Client, rdb *goredis.Client, ttl time.Duration, log *slog.Logger) *Checker {
+	return &Checker{
+		authClient: authClient,
+		rdb:        rdb,
+		ttl:        ttl,
+		log:        log.With("component", "session_checker"),
+		entries:    make(map[uuid.UUID]entry),
+	}
+}
+
+func (c *Checker) Check(ctx context.Context, sessionID, userID uuid.UUID) error {
+	if e, ok := c.lookup(sessionID); ok {
+		return verdict(e, userID)
+	}
+
+	resp, err := c.authClient.IntrospectSession(ctx, sessionID)
+
+	if err != nil {
+		if !errors.Is(err, auth_api.ErrSessionInactive) {
+			return fmt.Errorf("introspect session: %w", err)
+		}
+
+		e := c.store(sessionID, entry{userID: userID, active: false})
+
+		return verdict(e, userID)
+	}
+
+	e := c.store(sessionID, entry{userID: resp.UserID, active: true})
+
+	return verdict(e, userID)
+}
+
+// Run subscribes to the revocation channel and periodically drops expired
+// entries. It blocks until ctx is cancelled.
+func (c *Checker) Run(ctx context.Context) {
+	pubsub := c.rdb.Subscribe(ctx, revocation.Channel)
+	defer pubsub.Close()
+
+	messages := pubsub.Channel()
+
+	ticker := time.NewTicker(c.ttl)
+	defer ticker.Stop()
+
+	c.log.Info("session revocation listener started", slog.Duration("cache_ttl", c.ttl))
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info("session revocation listener stopped")
+
+			return
+
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			var event revocation.Event
+
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				c.log.Warn("invalid revocation message", slog.Any("error", err))
+
+				continue
+			}
+
+			c.evict(event)
+
+		case <-ticker.C:
+			c.sweep()
+		}
+	}
+}
+
+func (c *Checker) lookup(sessionID uuid.UUID) (entry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.entries[sessionID]
+
+	if !ok || time.Now().After(e.expiresAt) {
+		return entry{}, false
+	}
+
+	return e, true
+}
+
+func (c *Checker) store(sessionID uuid.UUID, e entry) entry {
+	e.expiresAt = time.Now().Add(c.ttl)
+
+	c.mu.Lock()
+	c.entries[sessionID] = e
+	c.mu.Unlock()
+
+	return e
+}
+
+func (c *Checker) evict(event revocation.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !event.AllSessions() {
+		delete(c.entries, event.SessionID)
+
+		return
+	}
+
+	for id, e := range c.entries {
+		if e.userID == event.UserID {
+			delete(c.entries, id)
+		}
+	}
+}
+
+func (c *Checker) sweep() {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, e := range c.entries {
+		if now.After(e.expiresAt) {
+			delete(c.entries, id)
+		}
+	}
+}
+
+func verdict(e entry, userID uuid.UUID) error {
+	if !e.active || e.userID != userID {
+		return ErrSessionInactive
+	}
+
+	return nil
+}
@@ -9,8 +9,9 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
-	"github.com/rockkley/pushpost/clients/friendship_api"
+	"github.com/rockkley/pushpost/clients/friendship_grpc"
 	"github.com/rockkley/pushpost/clients/profile_grpc"
+	"github.com/rockkley/pushpost/clients/user_api"
 	gwmiddleware "github.com/rockkley/pushpost/services/api_gateway/internal/middleware"
 	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
 	"github.com/rockkley/pushpost/services/common_service/ctxlog"
@@ -21,7 +22,8 @@ var usernamePathRegex = regexp.MustCompile(`^[a-zA-Z0-9_]{3,30}$`)
 
 type ProfileHandler struct {
 	profileClient    *profile_grpc.Client
-	friendshipClient friendship_api.Client
+	friendshipClient *friendship_grpc.Client
+	userClient       user_api.Client
 }
 
 type ProfileResponse struct {
@@ -40,10 +42,15 @@ type ProfileResponse struct {
 	FriendshipStatus string    `json:"friendship_status,omitempty"`
 }
 
-func NewProfileHandler(profileClient *profile_grpc.Client, friendshipClient friendship_api.Client) *ProfileHandler {
+func NewProfileHandler(
+	profileClient *profile_grpc.Client,
+	friendshipClient *friendship_grpc.Client,
+	userClient user_api.Client,
+) *ProfileHandler {
 	return &ProfileHandler{
 		profileClient:    profileClient,
 		friendshipClient: friendshipClient,
+		userClient:       userClient,
 	}
 }
 
@@ -60,7 +67,7 @@ func (h *ProfileHandler) GetProfileByUsername(w http.ResponseWriter, r *http.Req
 
 	if err != nil {
 		if errors.Is(err, profile_grpc.ErrNotFound) {
-			return commonapperr.NotFound("user_not_found", "user not found")
+			return h.redirectFormerUsername(w, r, username)
 		}
 
 		return commonapperr.Service("failed to fetch profile", err)
@@ -91,7 +98,7 @@ func (h *ProfileHandler) GetProfileByUsername(w http.ResponseWriter, r *http.Req
 	if ok && viewerID != userID {
 		rel, relErr := h.friendshipClient.GetRelationship(r.Context(), viewerID, userID)
 		if relErr == nil {
-			resp.FriendshipStatus = friendship_api.ResolveStatus(rel)
+			resp.FriendshipStatus = rel.Status()
 		} else {
 			log.Warn("failed to fetch friendship status",
 				slog.String("viewer_id", viewerID.String()),
@@ -103,3 +110,21 @@ func (h *ProfileHandler) GetProfileByUsername(w http.ResponseWriter, r *http.Req
 
 	return httperror.WriteJSON(w, http.StatusOK, resp)
 }
+
+// redirectFormerUsername отправляет старые ссылки на профиль по текущему имени,
+// пока прежнее имя не освободилось.
+func (h *ProfileHandler) redirectFormerUsername(w http.ResponseWriter, r *http.Request, username string) error {
+	user, err := h.userClient.GetUserByFormerUsername(r.Context(), username)
+
+	if err != nil {
+		if errors.Is(err, user_api.ErrNotFound) {
+			return commonapperr.NotFound("user_not_found", "user not found")
+		}
+
+		return commonapperr.Service("failed to resolve former username", err)
+	}
+
+	http.Redirect(w, r, "/"+user.Username, http.StatusMovedPermanently)
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	handlerhttp "github.com/rockkley/pushpost/services/common_service/http"
 	"github.com/rockkley/pushpost/services/common_service/httplog"
 	"github.com/rockkley/pushpost/services/common_service/metrics"
+	"github.com/rockkley/pushpost/services/common_service/roles"
 	"log/slog"
 	"net/http"
 	"net/http/httputil"
@@ -70,6 +71,8 @@ func NewRouter(
 		r.Use(authMW.RequireAuth)
 		r.Use(chimiddleware.Timeout(30 * time.Second))
 
+		// удаление аккаунта ведёт auth_service: он проверяет пароль и отзывает сессии
+		r.Delete("/users/me", http.HandlerFunc(p.Auth.ServeHTTP))
 		r.Handle("/users/*", http.HandlerFunc(p.User.ServeHTTP))
 		r.Handle("/friends", http.HandlerFunc(p.Friendship.ServeHTTP))
 		r.Handle("/friends/*", http.HandlerFunc(p.Friendship.ServeHTTP))
@@ -83,9 +86,21 @@ func NewRouter(
 		r.Handle("/notifications/*", http.HandlerFunc(p.Notification.ServeHTTP))
 		r.Handle("/blocks", http.HandlerFunc(p.Friendship.ServeHTTP))
 		r.Handle("/blocks/*", http.HandlerFunc(p.Friendship.ServeHTTP))
+		r.Handle("/follows", http.HandlerFunc(p.Friendship.ServeHTTP))
+		r.Handle("/follows/*", http.HandlerFunc(p.Friendship.ServeHTTP))
 
 	})
 
+	// модерация и операции; auth_service дополнительно проверяет подписанную роль
+	// и требует admin для смены ролей
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(authMW.RequireAuth)
+		r.Use(middleware.RequireRole(roles.Moderator))
+		r.Use(chimiddleware.Timeout(30 * time.Second))
+
+		r.Handle("/*", http.HandlerFunc(p.Auth.ServeHTTP))
+	})
+
 	r.Group(func(r chi.Router) {
 		r.Use(authMW.OptionalAuth)
 		r.Get("/{username}", handlerhttp.MakeHandler(profileHandler.GetProfileByUsername))
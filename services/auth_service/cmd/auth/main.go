@@ -16,13 +16,25 @@ import (
 	"github.com/rockkley/pushpost/clients/user_api"
 	"github.com/rockkley/pushpost/services/auth_service/internal/config"
 	"github.com/rockkley/pushpost/services/auth_service/internal/domain/usecase"
+	"github.com/rockkley/pushpost/services/auth_service/internal/email"
+	fileemail "github.com/rockkley/pushpost/services/auth_service/internal/email/file"
 	smtpemail "github.com/rockkley/pushpost/services/auth_service/internal/email/smtp"
+	"github.com/rockkley/pushpost/services/auth_service/internal/magiclink"
+	"github.com/rockkley/pushpost/services/auth_service/internal/oauth"
+	"github.com/rockkley/pushpost/services/auth_service/internal/ratelimit"
+	"github.com/rockkley/pushpost/services/auth_service/internal/repository/postgres"
 	redisrepo "github.com/rockkley/pushpost/services/auth_service/internal/repository/redis"
+	"github.com/rockkley/pushpost/services/auth_service/internal/secretbox"
 	"github.com/rockkley/pushpost/services/auth_service/internal/transport"
 	myHTTP "github.com/rockkley/pushpost/services/auth_service/internal/transport/http"
 	"github.com/rockkley/pushpost/services/auth_service/internal/transport/http/middleware"
+	"github.com/rockkley/pushpost/services/common_service/database"
 	"github.com/rockkley/pushpost/services/common_service/jwt"
 	"github.com/rockkley/pushpost/services/common_service/logger"
+	"github.com/rockkley/pushpost/services/common_service/outbox"
+	"github.com/rockkley/pushpost/services/common_service/outbox/kafka"
+	outboxpg "github.com/rockkley/pushpost/services/common_service/outbox/postgres"
+	"github.com/rockkley/pushpost/services/common_service/password"
 )
 
 func main() {
@@ -54,6 +66,18 @@ func main() {
 		os.Exit(1)
 	}
 
+	db, err := database.Connect(database.Config{
+		URL:          cfg.Database.URL,
+		MaxOpenConns: cfg.Database.MaxOpenConns,
+		MaxIdleConns: cfg.Database.MaxIdleConns,
+	})
+
+	if err != nil {
+		appLog.Error("failed to connect to database", slog.Any("error", err))
+		os.Exit(1)
+	}
+	defer db.Close()
+
 	rdb := redis.NewClient(&redis.Options{
 		Addr:     cfg.Redis.Addr,
 		Password: cfg.Redis.Password,
@@ -68,21 +92,174 @@ func main() {
 
 	sessionStore := redisrepo.NewSessionStore(rdb, cfg.Redis.Timeout)
 	otpStore := redisrepo.NewOTPStore(rdb, cfg.Redis.Timeout)
+	resetOTPStore := redisrepo.NewScopedOTPStore(rdb, cfg.Redis.Timeout, "password_reset")
+	emailChangeOTPStore := redisrepo.NewScopedOTPStore(rdb, cfg.Redis.Timeout, "email_change")
+	mfaStore := redisrepo.NewMFAStore(rdb, cfg.Redis.Timeout)
+	limiter := ratelimit.New(redisrepo.NewAttemptStore(rdb, cfg.Redis.Timeout))
+	revocationPublisher := redisrepo.NewRevocationPublisher(rdb, cfg.Redis.Timeout)
+	auditLog := postgres.NewAuditLog(db)
+	settingsRepo := postgres.NewSettingsRepository(db)
+	magicLinkStore := redisrepo.NewMagicLinkStore(rdb, cfg.Redis.Timeout)
+	oauthStateStore := redisrepo.NewOAuthStateStore(rdb, cfg.Redis.Timeout)
+	identityRepo := postgres.NewIdentityRepository(db)
 
-	emailSender := smtpemail.NewSender(smtpemail.Config{
-		Host:     cfg.SMTP.Host,
-		Port:     cfg.SMTP.Port,
-		Username: cfg.SMTP.User,
-		Password: cfg.SMTP.Pass,
-		From:     cfg.SMTP.From,
-		AppName:  cfg.SMTP.AppName,
-	})
+	// ключ уже проверен в config.Load
+	mfaKey, _ := cfg.MFA.Key()
+	mfaSecrets, err := secretbox.New(mfaKey)
 
-	jwtManager := jwt.NewManager(cfg.JWT.Secret, &cfg.JWT.AccessTTL)
-	authUsecase := usecase.NewAuthUsecase(userClient, sessionStore, otpStore, emailSender, jwtManager)
+	if err != nil {
+		appLog.Error("failed to init mfa secret box", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	magicLinkKey, _ := cfg.MagicLink.Key()
+	magicLinks, err := magiclink.New(magicLinkKey, cfg.MagicLink.TTL, cfg.MagicLink.URL)
+
+	if err != nil {
+		appLog.Error("failed to init magic links", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	oauthProviders := make(map[string]*oauth.Provider)
+
+	if cfg.OAuth.Name != "" {
+		provider, err := oauth.NewProvider(oauth.Config{
+			Name:         cfg.OAuth.Name,
+			ClientID:     cfg.OAuth.ClientID,
+			ClientSecret: cfg.OAuth.ClientSecret,
+			AuthURL:      cfg.OAuth.AuthURL,
+			TokenURL:     cfg.OAuth.TokenURL,
+			UserInfoURL:  cfg.OAuth.UserInfoURL,
+			RedirectURL:  cfg.OAuth.RedirectURL,
+			Scopes:       cfg.OAuth.Scopes,
+		}, &http.Client{Timeout: cfg.UserSvc.Timeout})
+
+		if err != nil {
+			appLog.Error("failed to init oauth provider", slog.Any("error", err))
+			os.Exit(1)
+		}
+
+		oauthProviders[provider.Name()] = provider
+	}
+
+	var mailTransport email.Transport
+
+	switch cfg.Mail.Backend {
+	case "file":
+		mailTransport, err = fileemail.NewSender(cfg.Mail.Dir)
+
+		if err != nil {
+			appLog.Error("failed to init file mail backend", slog.Any("error", err))
+			os.Exit(1)
+		}
+	default:
+		mailTransport = smtpemail.NewSender(smtpemail.Config{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.User,
+			Password: cfg.SMTP.Pass,
+		})
+	}
+
+	mailRenderer, err := email.NewRenderer(cfg.SMTP.AppName, cfg.Mail.DefaultLocale)
+
+	if err != nil {
+		appLog.Error("failed to load email templates", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	mailQueue := email.NewQueue(mailTransport, email.QueueConfig{
+		Size:        cfg.Mail.QueueSize,
+		Workers:     cfg.Mail.Workers,
+		MaxAttempts: cfg.Mail.MaxAttempts,
+		BaseDelay:   email.DefaultQueueConfig().BaseDelay,
+		MaxDelay:    email.DefaultQueueConfig().MaxDelay,
+	}, appLog)
+	emailSender := email.NewMailer(mailRenderer, mailQueue, cfg.SMTP.From)
+
+	passwordParams := password.DefaultParams()
+	passwordParams.Memory = cfg.Password.MemoryKiB
+	passwordParams.Iterations = cfg.Password.Iterations
+	passwordParams.Parallelism = cfg.Password.Parallelism
+
+	hasher, err := password.NewHasher(passwordParams)
+
+	if err != nil {
+		appLog.Error("invalid password hashing parameters", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	signingKeys, err := jwt.ParseSigningKeys(cfg.JWT.SigningKeys)
+
+	if err != nil {
+		appLog.Error("invalid jwt signing keys", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	jwtManager, err := jwt.NewSigner(signingKeys, cfg.JWT.ActiveKeyID, &cfg.JWT.AccessTTL)
+
+	if err != nil {
+		appLog.Error("failed to init jwt signer", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	authUsecase := usecase.NewAuthUsecase(
+		userClient,
+		sessionStore,
+		otpStore,
+		resetOTPStore,
+		emailChangeOTPStore,
+		mfaStore,
+		limiter,
+		auditLog,
+		revocationPublisher,
+		emailSender,
+		jwtManager,
+		mfaSecrets,
+		hasher,
+		magicLinks,
+		magicLinkStore,
+		settingsRepo,
+		oauthProviders,
+		oauthStateStore,
+		identityRepo,
+		cfg.JWT.RefreshTTL,
+		cfg.Account.DeletionGracePeriod,
+		cfg.MFA.Issuer,
+	)
 	authHandler := myHTTP.NewAuthHandler(authUsecase)
 	authMiddleware := middleware.NewAuthMiddleware(authUsecase)
-	mux := transport.NewRouter(appLog, authMiddleware, authHandler)
+	jwksHandler := myHTTP.NewJWKSHandler(jwtManager.JWKS())
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(limiter)
+	mux := transport.NewRouter(
+		appLog,
+		authMiddleware,
+		rateLimitMiddleware,
+		authHandler,
+		jwksHandler,
+		[]byte(cfg.Internal.IdentitySecret),
+	)
+
+	kafkaPublisher := kafka.NewPublisher(cfg.Kafka.Brokers(), appLog)
+
+	defer func() {
+		if err = kafkaPublisher.Close(); err != nil {
+			appLog.Error("failed to close kafka publisher", slog.Any("error", err))
+		}
+	}()
+
+	outboxWorker := outbox.NewWorker(
+		outboxpg.NewOutboxRepository(db),
+		kafkaPublisher,
+		outbox.DefaultWorkerConfig(),
+		appLog,
+	)
+
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	defer stopWorker()
+
+	go outboxWorker.Run(workerCtx)
+	go mailQueue.Run(workerCtx)
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.HTTP.Port),
@@ -110,6 +287,8 @@ func main() {
 		appLog.Info("auth service shutting down...")
 	}
 
+	stopWorker()
+
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
 	defer cancel()
 
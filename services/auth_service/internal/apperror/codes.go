@@ -4,9 +4,44 @@ const (
 	CodeInvalidCredentials = "invalid_credentials"
 	CodeSessionExpired     = "session_expired"
 	CodeAccountDeleted     = "account_deleted"
+	CodeAccountBlocked     = "account_blocked"
+	CodeAccountSuspended   = "account_suspended"
+	CodeSessionRevoked     = "session_revoked"
+	CodeSessionNotFound    = "session_not_found"
+	CodeRefreshInvalid     = "refresh_token_invalid"
+	CodeRefreshReused      = "refresh_token_reused"
 	CodeAccountNotVerified = "account_not_verified"
 	CodeOTPExpired         = "otp_expired"
 	CodeOTPInvalid         = "otp_invalid"
 	CodeOTPResendCooldown  = "otp_resend_cooldown"
 	CodeTooManyOTPAttempts = "too_many_otp_attempts"
+
+	CodeInvalidCurrentPassword = "invalid_current_password"
+	CodeEmailAlreadyExists     = "email_already_exists"
+	CodeEmailUnchanged         = "email_unchanged"
+
+	CodeMFAAlreadyEnabled   = "mfa_already_enabled"
+	CodeMFANotEnabled       = "mfa_not_enabled"
+	CodeMFASetupExpired     = "mfa_setup_expired"
+	CodeMFACodeInvalid      = "mfa_code_invalid"
+	CodeMFAChallengeInvalid = "mfa_challenge_invalid"
+	CodeTooManyMFAAttempts  = "too_many_mfa_attempts"
+
+	CodeTooManyLoginAttempts = "too_many_login_attempts"
+	CodeAccountLocked        = "account_locked"
+
+	CodeMagicLinkInvalid = "magic_link_invalid"
+
+	CodeOAuthProviderUnknown  = "oauth_provider_unknown"
+	CodeOAuthStateInvalid     = "oauth_state_invalid"
+	CodeOAuthFailed           = "oauth_failed"
+	CodeOAuthEmailUnverified  = "oauth_email_unverified"
+	CodeOAuthEmailTaken       = "oauth_email_taken"
+	CodeIdentityAlreadyLinked = "identity_already_linked"
+	CodeProviderAlreadyLinked = "provider_already_linked"
+	CodeIdentityNotFound      = "identity_not_found"
+	CodeLastSignInMethod      = "last_sign_in_method"
+
+	CodeUserNotFound       = "user_not_found"
+	CodeCannotModerateUser = "cannot_moderate_user"
 )
@@ -1,6 +1,10 @@
 package apperror
 
-import "github.com/rockkley/pushpost/services/common_service/apperror"
+import (
+	"time"
+
+	"github.com/rockkley/pushpost/services/common_service/apperror"
+)
 
 func InvalidCredentials() apperror.AppError {
 	return apperror.Unauthorized(CodeInvalidCredentials, "invalid credentials")
@@ -14,6 +18,38 @@ func AccountDeleted() apperror.AppError {
 	return apperror.Forbidden(CodeAccountDeleted, "account has been deleted")
 }
 
+func AccountBlocked() apperror.AppError {
+	return apperror.Forbidden(CodeAccountBlocked, "account has been blocked")
+}
+
+// AccountSuspended отвечает на попытку входа в заблокированный аккаунт; until пуст
+// для бессрочной блокировки. Причину блокировки клиенту не показываем.
+func AccountSuspended(until *time.Time) apperror.AppError {
+	if until == nil {
+		return apperror.Forbidden(CodeAccountSuspended, "account has been suspended")
+	}
+
+	return apperror.ForbiddenFields(CodeAccountSuspended, "account has been suspended", map[string]string{
+		"suspended_until": until.UTC().Format(time.RFC3339),
+	})
+}
+
+func SessionRevoked() apperror.AppError {
+	return apperror.Unauthorized(CodeSessionRevoked, "session has been revoked")
+}
+
+func SessionNotFound() apperror.AppError {
+	return apperror.NotFound(CodeSessionNotFound, "session not found")
+}
+
+func RefreshTokenInvalid() apperror.AppError {
+	return apperror.Unauthorized(CodeRefreshInvalid, "invalid refresh token")
+}
+
+func RefreshTokenReused() apperror.AppError {
+	return apperror.Unauthorized(CodeRefreshReused, "refresh token has already been used, please log in again")
+}
+
 func AccountNotVerified() apperror.AppError {
 	return apperror.Forbidden(CodeAccountNotVerified, "email not verified, please check your inbox")
 }
@@ -33,3 +69,115 @@ func OTPResendCooldown() apperror.AppError {
 func TooManyOTPAttempts() apperror.AppError {
 	return apperror.BadRequest(CodeTooManyOTPAttempts, "too many incorrect attempts, please request a new OTP")
 }
+
+func InvalidCurrentPassword() apperror.AppError {
+	return apperror.Validation(CodeInvalidCurrentPassword, "current_password", "current password is incorrect")
+}
+
+func EmailAlreadyExists() apperror.AppError {
+	return apperror.Conflict(CodeEmailAlreadyExists, "new_email", "email already exists")
+}
+
+func EmailUnchanged() apperror.AppError {
+	return apperror.BadRequest(CodeEmailUnchanged, "new email matches the current one")
+}
+
+func MFAAlreadyEnabled() apperror.AppError {
+	return apperror.Conflict(CodeMFAAlreadyEnabled, "", "two-factor authentication is already enabled")
+}
+
+func MFANotEnabled() apperror.AppError {
+	return apperror.BadRequest(CodeMFANotEnabled, "two-factor authentication is not enabled")
+}
+
+func MFASetupExpired() apperror.AppError {
+	return apperror.BadRequest(CodeMFASetupExpired, "two-factor setup has expired, please start again")
+}
+
+func MFACodeInvalid() apperror.AppError {
+	return apperror.BadRequest(CodeMFACodeInvalid, "invalid authentication code")
+}
+
+func MFAChallengeInvalid() apperror.AppError {
+	return apperror.Unauthorized(CodeMFAChallengeInvalid, "login challenge has expired, please sign in again")
+}
+
+func TooManyMFAAttempts() apperror.AppError {
+	return apperror.BadRequest(CodeTooManyMFAAttempts, "too many incorrect attempts, please sign in again")
+}
+
+func TooManyLoginAttempts(retryAfter time.Duration) apperror.AppError {
+	return apperror.TooManyRequests(CodeTooManyLoginAttempts, "too many failed sign-in attempts, try again later", retryAfter)
+}
+
+func AccountLocked(retryAfter time.Duration) apperror.AppError {
+	return apperror.TooManyRequests(CodeAccountLocked, "account temporarily locked after repeated failed sign-in attempts", retryAfter)
+}
+
+func MagicLinkInvalid() apperror.AppError {
+	return apperror.Unauthorized(CodeMagicLinkInvalid, "sign-in link is invalid, expired or already used")
+}
+
+func OAuthProviderUnknown() apperror.AppError {
+	return apperror.NotFound(CodeOAuthProviderUnknown, "unknown sign-in provider")
+}
+
+func OAuthStateInvalid() apperror.AppError {
+	return apperror.BadRequest(CodeOAuthStateInvalid, "sign-in request is invalid or expired, please start again")
+}
+
+func OAuthFailed() apperror.AppError {
+	return apperror.Unauthorized(CodeOAuthFailed, "sign-in provider rejected the request")
+}
+
+func OAuthEmailUnverified() apperror.AppError {
+	return apperror.Forbidden(CodeOAuthEmailUnverified, "provider did not confirm the email address")
+}
+
+// OAuthEmailTaken — адрес уже занят аккаунтом с паролем; автоматически не связываем,
+// иначе владелец чужого аккаунта у провайдера получил бы доступ к аккаунту у нас.
+func OAuthEmailTaken() apperror.AppError {
+	return apperror.Conflict(CodeOAuthEmailTaken, "email",
+		"an account with this email already exists, sign in and link the provider in settings")
+}
+
+func IdentityAlreadyLinked() apperror.AppError {
+	return apperror.Conflict(CodeIdentityAlreadyLinked, "provider", "this provider account is linked to another user")
+}
+
+func ProviderAlreadyLinked() apperror.AppError {
+	return apperror.Conflict(CodeProviderAlreadyLinked, "provider", "a different account of this provider is already linked")
+}
+
+func IdentityNotFound() apperror.AppError {
+	return apperror.NotFound(CodeIdentityNotFound, "provider is not linked")
+}
+
+func LastSignInMethod() apperror.AppError {
+	return apperror.Conflict(CodeLastSignInMethod, "provider", "set a password before unlinking the last sign-in provider")
+}
+
+func RateLimited(retryAfter time.Duration) apperror.AppError {
+	return apperror.TooManyRequests(apperror.CodeRateLimited, "too many requests, try again later", retryAfter)
+}
+
+func MapConstraint(constraintName string) apperror.AppError {
+	switch constraintName {
+	case "external_identities_pkey":
+		return IdentityAlreadyLinked()
+	case "external_identities_user_provider_key":
+		return ProviderAlreadyLinked()
+	default:
+		return nil
+	}
+}
+
+func UserNotFound() apperror.AppError {
+	return apperror.NotFound(CodeUserNotFound, "user not found")
+}
+
+// CannotModerateUser — модерировать можно только пользователей с ролью ниже своей,
+// в том числе поэтому нельзя сменить роль самому себе.
+func CannotModerateUser() apperror.AppError {
+	return apperror.Forbidden(CodeCannotModerateUser, "cannot act on a user with an equal or higher role")
+}
@@ -1,17 +1,28 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
 	"github.com/ilyakaznacheev/cleanenv"
+	"strings"
 	"time"
 )
 
 type Config struct {
-	HTTP    HTTPConfig
-	JWT     JWTConfig
-	UserSvc UserServiceConfig
-	Redis   RedisConfig
-	SMTP    SMTPConfig
+	HTTP      HTTPConfig
+	JWT       JWTConfig
+	UserSvc   UserServiceConfig
+	Redis     RedisConfig
+	SMTP      SMTPConfig
+	Mail      MailConfig
+	MFA       MFAConfig
+	MagicLink MagicLinkConfig
+	OAuth     OAuthConfig
+	Database  DatabaseConfig
+	Password  PasswordConfig
+	Kafka     KafkaConfig
+	Account   AccountConfig
+	Internal  InternalConfig
 }
 
 type HTTPConfig struct {
@@ -21,18 +32,93 @@ type HTTPConfig struct {
 	ShutdownTimeout time.Duration `env:"HTTP_SHUTDOWN_TIMEOUT" env-default:"30s"`
 }
 
+// SMTPConfig — Host, User и Pass обязательны только при MAIL_BACKEND=smtp.
 type SMTPConfig struct {
-	Host    string `env:"SMTP_HOST"     env-required:"true"`
+	Host    string `env:"SMTP_HOST"`
 	Port    int    `env:"SMTP_PORT"     env-default:"587"`
-	User    string `env:"SMTP_USER"     env-required:"true"`
-	Pass    string `env:"SMTP_PASS"     env-required:"true"`
+	User    string `env:"SMTP_USER"`
+	Pass    string `env:"SMTP_PASS"`
 	From    string `env:"SMTP_FROM"     env-required:"true"`
 	AppName string `env:"APP_NAME"      env-default:"PushPost"`
 }
 
+// MailConfig — Backend "smtp" или "file"; file пишет .eml в Dir вместо отправки.
+type MailConfig struct {
+	Backend       string `env:"MAIL_BACKEND"        env-default:"smtp"`
+	Dir           string `env:"MAIL_DIR"            env-default:"./mail"`
+	DefaultLocale string `env:"MAIL_DEFAULT_LOCALE" env-default:"en"`
+	QueueSize     int    `env:"MAIL_QUEUE_SIZE"     env-default:"256"`
+	Workers       int    `env:"MAIL_WORKERS"        env-default:"2"`
+	MaxAttempts   int    `env:"MAIL_MAX_ATTEMPTS"   env-default:"5"`
+}
+
+// JWTConfig — SigningKeys в виде "kid:base64seed,kid:base64seed". При ротации новый ключ
+// добавляется и становится активным, старый удаляется после истечения AccessTTL.
 type JWTConfig struct {
-	Secret    string        `env:"JWT_SECRET"     env-required:"true"`
-	AccessTTL time.Duration `env:"JWT_ACCESS_TTL" env-default:"24h"`
+	SigningKeys map[string]string `env:"JWT_SIGNING_KEYS"  env-required:"true"`
+	ActiveKeyID string            `env:"JWT_ACTIVE_KEY_ID" env-required:"true"`
+	AccessTTL   time.Duration     `env:"JWT_ACCESS_TTL"    env-default:"15m"`
+	RefreshTTL  time.Duration     `env:"JWT_REFRESH_TTL"   env-default:"720h"`
+}
+
+// MFAConfig — EncryptionKey (base64, 32 байта) шифрует TOTP-секреты перед сохранением в user_service.
+type MFAConfig struct {
+	EncryptionKey string `env:"MFA_ENCRYPTION_KEY" env-required:"true"`
+	Issuer        string `env:"MFA_ISSUER"         env-default:"PushPost"`
+}
+
+// MagicLinkConfig — Secret (base64, от 32 байт) подписывает ссылки для входа без пароля,
+// URL — страница фронтенда, которая отправляет токен в /auth/magic-link/consume.
+type MagicLinkConfig struct {
+	Secret string        `env:"MAGIC_LINK_SECRET" env-required:"true"`
+	URL    string        `env:"MAGIC_LINK_URL"    env-required:"true"`
+	TTL    time.Duration `env:"MAGIC_LINK_TTL"    env-default:"15m"`
+}
+
+// OAuthConfig — внешний провайдер для входа (OIDC или OAuth2 в стиле GitHub). Пустой Name
+// отключает вход через провайдера. RedirectURL — страница фронтенда, которая передаёт
+// code и state в /auth/oauth/{provider}/callback, а при привязке к аккаунту — в
+// /auth/identities/{provider}/callback с токеном сессии, начавшей привязку.
+type OAuthConfig struct {
+	Name         string   `env:"OAUTH_PROVIDER"`
+	ClientID     string   `env:"OAUTH_CLIENT_ID"`
+	ClientSecret string   `env:"OAUTH_CLIENT_SECRET"`
+	AuthURL      string   `env:"OAUTH_AUTH_URL"`
+	TokenURL     string   `env:"OAUTH_TOKEN_URL"`
+	UserInfoURL  string   `env:"OAUTH_USERINFO_URL"`
+	RedirectURL  string   `env:"OAUTH_REDIRECT_URL"`
+	Scopes       []string `env:"OAUTH_SCOPES"        env-default:"openid,email,profile"`
+}
+
+// AccountConfig — DeletionGracePeriod: сколько аккаунт ждёт окончательного удаления
+// и может быть восстановлен входом.
+type AccountConfig struct {
+	DeletionGracePeriod time.Duration `env:"ACCOUNT_DELETION_GRACE_PERIOD" env-default:"720h"`
+}
+
+// InternalConfig — IdentitySecret совпадает с секретом gateway и проверяет подпись
+// X-User-Role на маршрутах /admin.
+type InternalConfig struct {
+	IdentitySecret string `env:"INTERNAL_IDENTITY_SECRET" env-required:"true"`
+}
+
+// PasswordConfig — параметры argon2id для новых хешей; хеши со старыми параметрами
+// и bcrypt пересчитываются при следующем входе.
+type PasswordConfig struct {
+	MemoryKiB   uint32 `env:"PASSWORD_ARGON2_MEMORY_KIB"  env-default:"65536"`
+	Iterations  uint32 `env:"PASSWORD_ARGON2_ITERATIONS"  env-default:"3"`
+	Parallelism uint8  `env:"PASSWORD_ARGON2_PARALLELISM" env-default:"2"`
+}
+
+// DatabaseConfig — Postgres нужен только журналу безопасности и его outbox.
+type DatabaseConfig struct {
+	URL          string `env:"AUTH_DATABASE_URL" env-required:"true"`
+	MaxOpenConns int    `env:"DB_MAX_OPEN_CONNS" env-default:"10"`
+	MaxIdleConns int    `env:"DB_MAX_IDLE_CONNS" env-default:"5"`
+}
+
+type KafkaConfig struct {
+	BrokersRaw string `env:"KAFKA_BROKERS" env-default:"kafka:9092"`
 }
 
 type UserServiceConfig struct {
@@ -62,11 +148,91 @@ func Load() (*Config, error) {
 }
 
 func (c *Config) validate() error {
-	if len(c.JWT.Secret) < 32 {
+	if _, ok := c.JWT.SigningKeys[c.JWT.ActiveKeyID]; !ok {
+		return fmt.Errorf("jwt_active_key_id %q is not in jwt_signing_keys", c.JWT.ActiveKeyID)
+	}
+
+	if c.JWT.RefreshTTL <= c.JWT.AccessTTL {
+		return fmt.Errorf("jwt_refresh_ttl must be greater than jwt_access_ttl")
+	}
+
+	if c.Account.DeletionGracePeriod <= 0 {
+		return fmt.Errorf("account_deletion_grace_period must be positive")
+	}
+
+	if len(c.Internal.IdentitySecret) < 32 {
+		return fmt.Errorf("internal_identity_secret must be at least 32 characters")
+	}
+
+	if _, err := c.MFA.Key(); err != nil {
+		return err
+	}
+
+	if _, err := c.MagicLink.Key(); err != nil {
+		return err
+	}
+
+	switch c.Mail.Backend {
+	case "smtp":
+		if c.SMTP.Host == "" || c.SMTP.User == "" || c.SMTP.Pass == "" {
+			return fmt.Errorf("smtp_host, smtp_user and smtp_pass are required for mail_backend=smtp")
+		}
+	case "file":
+	default:
+		return fmt.Errorf("unknown mail_backend %q, expected smtp or file", c.Mail.Backend)
+	}
+
+	if c.Database.MaxIdleConns > c.Database.MaxOpenConns {
 		return fmt.Errorf(
-			"jwt_secret must be at least 32 characters, got %d",
-			len(c.JWT.Secret),
+			"max idle connections (%d) cannot exceed max open connections (%d)",
+			c.Database.MaxIdleConns, c.Database.MaxOpenConns,
 		)
 	}
+
+	if len(c.Kafka.Brokers()) == 0 {
+		return fmt.Errorf("kafka brokers list is empty")
+	}
+
 	return nil
 }
+
+func (c MFAConfig) Key() ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(c.EncryptionKey)
+
+	if err != nil {
+		return nil, fmt.Errorf("mfa_encryption_key must be base64: %w", err)
+	}
+
+	if len(key) != 32 {
+		return nil, fmt.Errorf("mfa_encryption_key must decode to 32 bytes, got %d", len(key))
+	}
+
+	return key, nil
+}
+
+func (c MagicLinkConfig) Key() ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(c.Secret)
+
+	if err != nil {
+		return nil, fmt.Errorf("magic_link_secret must be base64: %w", err)
+	}
+
+	if len(key) < 32 {
+		return nil, fmt.Errorf("magic_link_secret must decode to at least 32 bytes, got %d", len(key))
+	}
+
+	return key, nil
+}
+
+func (k KafkaConfig) Brokers() []string {
+	brokers := strings.Split(k.BrokersRaw, ",")
+	result := make([]string, 0, len(brokers))
+
+	for _, b := range brokers {
+		if b = strings.TrimSpace(b); b != "" {
+			result = append(result, b)
+		}
+	}
+
+	return result
+}
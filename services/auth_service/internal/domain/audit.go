@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Типы событий журнала безопасности. Они же — топики Kafka.
+const (
+	AuditLoginSucceeded     = "auth.login.succeeded"
+	AuditLoginFailed        = "auth.login.failed"
+	AuditAccountLocked      = "auth.account.locked"
+	AuditLogout             = "auth.logout"
+	AuditEmailVerified      = "auth.email.verified"
+	AuditSessionRevoked     = "auth.session.revoked"
+	AuditRefreshTokenReused = "auth.refresh_token.reused"
+	AuditPasswordChanged    = "auth.password.changed"
+	AuditPasswordReset      = "auth.password.reset"
+	AuditEmailChanged       = "auth.email.changed"
+	AuditMFAEnabled         = "auth.mfa.enabled"
+	AuditMFADisabled        = "auth.mfa.disabled"
+	AuditMagicLinkSent      = "auth.magic_link.sent"
+	AuditMagicLinkReused    = "auth.magic_link.reused"
+	AuditSettingsChanged    = "auth.settings.changed"
+	AuditIdentityLinked     = "auth.identity.linked"
+	AuditIdentityUnlinked   = "auth.identity.unlinked"
+	AuditDeletionScheduled  = "auth.account.deletion_scheduled"
+	AuditAccountRestored    = "auth.account.restored"
+	AuditAccountSuspended   = "auth.account.suspended"
+	AuditAccountReinstated  = "auth.account.reinstated"
+	AuditRoleChanged        = "auth.account.role_changed"
+)
+
+// AuditEvent — запись журнала безопасности. UserID пуст, если аккаунт не найден
+// (например, вход на несуществующий адрес).
+type AuditEvent struct {
+	ID         uuid.UUID         `json:"id"`
+	Type       string            `json:"event_type"`
+	UserID     uuid.UUID         `json:"user_id"`
+	SessionID  uuid.UUID         `json:"session_id"`
+	IP         string            `json:"ip"`
+	UserAgent  string            `json:"user_agent"`
+	DeviceName string            `json:"device_name"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// Client — откуда пришёл запрос. Кладётся в контекст на транспортном уровне,
+// чтобы события вне логина тоже получали IP и user-agent.
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+type clientCtxKey struct{}
+
+func WithClient(ctx context.Context, client Client) context.Context {
+	return context.WithValue(ctx, clientCtxKey{}, client)
+}
+
+func ClientFrom(ctx context.Context) Client {
+	client, _ := ctx.Value(clientCtxKey{}).(Client)
+
+	return client
+}
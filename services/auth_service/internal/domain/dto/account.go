@@ -0,0 +1,8 @@
+package dto
+
+import "time"
+
+// AccountDeletionDTO — до ScheduledFor аккаунт восстанавливается обычным входом.
+type AccountDeletionDTO struct {
+	ScheduledFor time.Time `json:"scheduled_for"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/roles"
+)
+
+// Actor — модератор или админ, от имени которого действие пришло через /admin.
+type Actor struct {
+	UserID uuid.UUID
+	Role   roles.Role
+}
+
+type ChangeRoleDTO struct {
+	Role  roles.Role
+	Actor Actor
+}
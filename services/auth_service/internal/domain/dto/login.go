@@ -3,12 +3,20 @@ package dto
 import (
 	"errors"
 	"github.com/google/uuid"
+	"unicode/utf8"
 )
 
+const maxDeviceNameLength = 64
+
 type LoginUserDTO struct {
-	Email    string    `json:"email"`
-	Password string    `json:"password"`
-	DeviceID uuid.UUID `json:"deviceID"`
+	Email      string    `json:"email"`
+	Password   string    `json:"password"`
+	DeviceID   uuid.UUID `json:"deviceID"`
+	DeviceName string    `json:"deviceName"`
+
+	// заполняются хендлером из запроса
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
 }
 
 func (dto *LoginUserDTO) Validate() error {
@@ -19,8 +27,58 @@ func (dto *LoginUserDTO) Validate() error {
 	if dto.Password == "" {
 		return errors.New("password is required")
 	}
+	if utf8.RuneCountInString(dto.DeviceName) > maxDeviceNameLength {
+		return errors.New("device name is too long")
+	}
 	//if dto.DeviceID == uuid.Nil {
 	//	return errors.New("device ID is required")
 	//}
 	return nil
 }
+
+// LoginResultDTO — при включённой 2FA токенов ещё нет, вместо них выдаётся challenge.
+type LoginResultDTO struct {
+	*TokenPairDTO
+	MFARequired    bool   `json:"mfa_required,omitempty"`
+	ChallengeToken string `json:"challenge_token,omitempty"`
+}
+
+type LoginMFADTO struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+func (dto *LoginMFADTO) Validate() error {
+	if dto.ChallengeToken == "" {
+		return errors.New("challenge_token is required")
+	}
+
+	if dto.Code == "" && dto.RecoveryCode == "" {
+		return errors.New("code or recovery_code is required")
+	}
+
+	return nil
+}
+
+type MagicLinkConsumeDTO struct {
+	Token      string    `json:"token"`
+	DeviceID   uuid.UUID `json:"deviceID"`
+	DeviceName string    `json:"deviceName"`
+
+	// заполняются хендлером из запроса
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
+}
+
+func (dto *MagicLinkConsumeDTO) Validate() error {
+	if dto.Token == "" {
+		return errors.New("token is required")
+	}
+
+	if utf8.RuneCountInString(dto.DeviceName) > maxDeviceNameLength {
+		return errors.New("device name is too long")
+	}
+
+	return nil
+}
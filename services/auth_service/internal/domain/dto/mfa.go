@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+type MFASetupDTO struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+type MFAStatusDTO struct {
+	Enabled           bool       `json:"enabled"`
+	RecoveryCodesLeft int        `json:"recovery_codes_left"`
+	EnabledAt         *time.Time `json:"enabled_at,omitempty"`
+}
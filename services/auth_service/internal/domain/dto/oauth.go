@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"errors"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// OAuthStartDTO — устройство запоминается в state и попадает в сессию после callback.
+type OAuthStartDTO struct {
+	DeviceID   uuid.UUID
+	DeviceName string
+}
+
+func (dto *OAuthStartDTO) Validate() error {
+	if utf8.RuneCountInString(dto.DeviceName) > maxDeviceNameLength {
+		return errors.New("device name is too long")
+	}
+
+	return nil
+}
+
+type OAuthAuthorizationDTO struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+type OAuthCallbackDTO struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+
+	// заполняются хендлером из запроса
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
+}
+
+func (dto *OAuthCallbackDTO) Validate() error {
+	if dto.Code == "" {
+		return errors.New("code is required")
+	}
+
+	if dto.State == "" {
+		return errors.New("state is required")
+	}
+
+	return nil
+}
+
+// OAuthCallbackResultDTO — либо результат входа, либо LinkedProvider, если callback
+// завершал привязку провайдера к уже вошедшему пользователю.
+type OAuthCallbackResultDTO struct {
+	*LoginResultDTO
+	LinkedProvider string `json:"linked_provider,omitempty"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type SettingsDTO struct {
+	MagicLinkEnabled bool `json:"magic_link_enabled"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+// SuspendUserDTO — Until == nil означает бессрочную блокировку.
+type SuspendUserDTO struct {
+	Until  *time.Time
+	Reason string
+	Actor  Actor
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "errors"
+
+// TokenPairDTO — поле access-токена оставлено как "token" для совместимости со старыми клиентами.
+type TokenPairDTO struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+type RefreshTokenDTO struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (dto *RefreshTokenDTO) Validate() error {
+	if dto.RefreshToken == "" {
+		return errors.New("refresh_token is required")
+	}
+
+	return nil
+}
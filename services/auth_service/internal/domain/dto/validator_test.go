@@ -225,7 +225,7 @@ func TestValidatePassword_NoLetters(t *testing.T) {
 // ── ValidateRegisterUser ──────────────────────────────────────────────────────
 
 func TestValidateRegisterUser_AllValid(t *testing.T) {
-	req := RegisterUserDto{
+	req := RegisterUserDTO{
 		Username: "validuser",
 		Email:    "message@example.com",
 		Password: "Password1",
@@ -237,7 +237,7 @@ func TestValidateRegisterUser_AllValid(t *testing.T) {
 
 func TestValidateRegisterUser_CollectsAllErrors(t *testing.T) {
 	// All three fields invalid → all three errors collected in one pass.
-	req := RegisterUserDto{
+	req := RegisterUserDTO{
 		Username: "ab",    // too short
 		Email:    "",      // required
 		Password: "short", // too short
@@ -257,7 +257,7 @@ func TestValidateRegisterUser_CollectsAllErrors(t *testing.T) {
 }
 
 func TestValidateRegisterUser_SingleFieldError(t *testing.T) {
-	req := RegisterUserDto{
+	req := RegisterUserDTO{
 		Username: "validuser",
 		Email:    "notanemail",
 		Password: "Password1",
@@ -270,7 +270,7 @@ func TestValidateRegisterUser_SingleFieldError(t *testing.T) {
 }
 
 func TestValidateRegisterUser_WeakPassword(t *testing.T) {
-	req := RegisterUserDto{
+	req := RegisterUserDTO{
 		Username: "validuser",
 		Email:    "message@example.com",
 		Password: "onlyletters",
@@ -284,7 +284,7 @@ func TestValidateRegisterUser_WeakPassword(t *testing.T) {
 
 func TestValidateRegisterUser_ErrorsAreUnique(t *testing.T) {
 	// Each field must appear at most once in the result.
-	req := RegisterUserDto{
+	req := RegisterUserDTO{
 		Username: "ab",
 		Email:    "bad",
 		Password: "bad",
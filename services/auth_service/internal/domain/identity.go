@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExternalIdentity связывает аккаунт внешнего провайдера с пользователем.
+// Subject — идентификатор на стороне провайдера, он не меняется в отличие от email.
+type ExternalIdentity struct {
+	Provider  string
+	Subject   string
+	UserID    uuid.UUID
+	Email     string
+	CreatedAt time.Time
+}
+
+// OAuthState — незавершённый вход через провайдера, хранится до callback под значением state.
+// LinkUserID и LinkSessionID заполнены, если пользователь привязывает провайдера к уже
+// существующему аккаунту: завершить привязку можно только из той же сессии.
+type OAuthState struct {
+	Provider      string
+	CodeVerifier  string
+	LinkUserID    uuid.UUID
+	LinkSessionID uuid.UUID
+	Device        Device
+}
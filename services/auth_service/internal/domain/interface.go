@@ -4,14 +4,45 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/rockkley/pushpost/services/auth_service/internal/domain/dto"
+	"time"
 )
 
 type AuthUsecase interface {
 	Register(ctx context.Context, data dto.RegisterUserDTO) (*dto.RegisterResponseDTO, error)
-	Login(ctx context.Context, dto dto.LoginUserDTO) (string, error)
+	Login(ctx context.Context, dto dto.LoginUserDTO) (*dto.LoginResultDTO, error)
+	LoginMFA(ctx context.Context, dto dto.LoginMFADTO) (*dto.TokenPairDTO, error)
+	Refresh(ctx context.Context, refreshToken string) (*dto.TokenPairDTO, error)
 	Logout(ctx context.Context, tokenID uuid.UUID) error
 	//GetSessionByToken(ctx context.Context, tokenStr string) (*domain.Session, error)
 	AuthenticateRequest(ctx context.Context, tokenStr string) (*Session, error)
+	IntrospectSession(ctx context.Context, sessionID uuid.UUID) (*Session, error)
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]*Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeOtherSessions(ctx context.Context, userID, currentSessionID uuid.UUID) (int, error)
 	VerifyEmail(ctx context.Context, email, code string) error
 	ResendOTP(ctx context.Context, email string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, email, code, newPassword string) error
+	ChangePassword(ctx context.Context, userID, sessionID uuid.UUID, currentPassword, newPassword string) error
+	RequestEmailChange(ctx context.Context, userID uuid.UUID, newEmail string) error
+	ConfirmEmailChange(ctx context.Context, userID, sessionID uuid.UUID, newEmail, code string) error
+	ListAuditEvents(ctx context.Context, userID uuid.UUID, before time.Time, beforeID uuid.UUID, limit int) ([]*AuditEvent, error)
+	GetMFAStatus(ctx context.Context, userID uuid.UUID) (*dto.MFAStatusDTO, error)
+	SetupMFA(ctx context.Context, userID uuid.UUID) (*dto.MFASetupDTO, error)
+	ConfirmMFA(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	DisableMFA(ctx context.Context, userID, sessionID uuid.UUID, password, code, recoveryCode string) error
+	RequestMagicLink(ctx context.Context, email string) error
+	ConsumeMagicLink(ctx context.Context, dto dto.MagicLinkConsumeDTO) (*dto.LoginResultDTO, error)
+	GetSettings(ctx context.Context, userID uuid.UUID) (*dto.SettingsDTO, error)
+	UpdateSettings(ctx context.Context, userID uuid.UUID, dto dto.SettingsDTO) (*dto.SettingsDTO, error)
+	StartOAuth(ctx context.Context, provider string, dto dto.OAuthStartDTO) (*dto.OAuthAuthorizationDTO, error)
+	StartOAuthLink(ctx context.Context, userID, sessionID uuid.UUID, provider string) (*dto.OAuthAuthorizationDTO, error)
+	CompleteOAuth(ctx context.Context, provider string, dto dto.OAuthCallbackDTO) (*dto.OAuthCallbackResultDTO, error)
+	CompleteOAuthLink(ctx context.Context, userID, sessionID uuid.UUID, provider string, dto dto.OAuthCallbackDTO) (*dto.OAuthCallbackResultDTO, error)
+	ListIdentities(ctx context.Context, userID uuid.UUID) ([]*ExternalIdentity, error)
+	UnlinkIdentity(ctx context.Context, userID uuid.UUID, provider string) error
+	DeleteAccount(ctx context.Context, userID uuid.UUID, password string) (*dto.AccountDeletionDTO, error)
+	SuspendUser(ctx context.Context, userID uuid.UUID, dto dto.SuspendUserDTO) error
+	ReinstateUser(ctx context.Context, userID uuid.UUID, actor dto.Actor) error
+	ChangeUserRole(ctx context.Context, userID uuid.UUID, dto dto.ChangeRoleDTO) error
 }
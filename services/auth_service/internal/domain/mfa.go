@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Device — данные клиента, с которого идёт вход; переносятся в сессию.
+type Device struct {
+	ID        uuid.UUID
+	Name      string
+	UserAgent string
+	IP        string
+}
+
+// MFAChallenge — пароль уже проверен, ждём второй фактор. Статус и роль перечитываются
+// после второго фактора: за время проверки аккаунт могли заблокировать или удалить.
+// MagicLinkID заполнен, если вход начат по ссылке: сессию нужно привязать к ней.
+type MFAChallenge struct {
+	UserID             uuid.UUID
+	Device             Device
+	MagicLinkID        string
+	MagicLinkExpiresAt time.Time
+}
@@ -7,4 +7,13 @@ type Session struct {
 	UserID    uuid.UUID
 	DeviceID  uuid.UUID
 	Expires   int64
+	// Role — роль владельца на момент последнего выпуска access-токена.
+	Role string
+
+	// метаданные устройства, показываются в списке активных сессий
+	DeviceName string
+	UserAgent  string
+	IP         string
+	CreatedAt  int64
+	LastSeenAt int64
 }
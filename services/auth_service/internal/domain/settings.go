@@ -0,0 +1,13 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Settings — настройки входа пользователя.
+type Settings struct {
+	UserID           uuid.UUID
+	MagicLinkEnabled bool
+}
+
+func DefaultSettings(userID uuid.UUID) *Settings {
+	return &Settings{UserID: userID, MagicLinkEnabled: true}
+}
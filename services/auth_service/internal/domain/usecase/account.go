@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/clients/user_api"
+	apperr "github.com/rockkley/pushpost/services/auth_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain/dto"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/ctxlog"
+)
+
+// codeDeletionNotScheduled — ответ user_service, если аккаунт уже не ожидает удаления.
+const codeDeletionNotScheduled = "deletion_not_scheduled"
+
+// DeleteAccount переводит аккаунт в ожидание удаления и завершает все его сессии.
+// Окончательно аккаунт удаляет user_service по истечении deletionGrace; до этого любой
+// успешный вход его восстанавливает. Без пароля удалить нельзя: аккаунту, созданному
+// через OAuth, нужно сначала задать пароль через сброс.
+func (s *AuthUsecase) DeleteAccount(ctx context.Context, userID uuid.UUID, password string) (*dto.AccountDeletionDTO, error) {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.DeleteAccount"),
+		slog.String("user_id", userID.String()),
+	)
+
+	user, err := s.userClient.GetUserByID(ctx, userID)
+
+	if err != nil {
+		log.Error("failed to get user", slog.Any("error", err))
+
+		return nil, err
+	}
+
+	if err = s.checkCurrentPassword(ctx, user, password, log); err != nil {
+		return nil, err
+	}
+
+	scheduledFor := time.Now().Add(s.deletionGrace).UTC()
+
+	if err = s.userClient.ScheduleDeletion(ctx, userID, scheduledFor); err != nil {
+		log.Warn("failed to schedule account deletion", slog.Any("error", err))
+
+		return nil, err
+	}
+
+	if err = s.revokeAllSessions(ctx, userID, log); err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, &domain.AuditEvent{
+		Type:     domain.AuditDeletionScheduled,
+		UserID:   userID,
+		Metadata: map[string]string{"scheduled_for": scheduledFor.Format(time.RFC3339)},
+	}, log)
+
+	log.Info("account deletion scheduled", slog.Time("scheduled_for", scheduledFor))
+
+	return &dto.AccountDeletionDTO{ScheduledFor: scheduledFor}, nil
+}
+
+// checkSignInStatus — можно ли выдать сессию этому аккаунту. Ожидающий удаления
+// пропускается: вход его восстанавливает.
+func checkSignInStatus(user *user_api.UserResponse) error {
+	switch {
+	case user.IsDeleted():
+		return apperr.AccountDeleted()
+	case user.IsBlocked():
+		return apperr.AccountSuspended(user.SuspendedUntil)
+	case !user.IsActive() && !user.IsPendingDeletion():
+		return apperr.AccountNotVerified()
+	}
+
+	return nil
+}
+
+// restoreIfPendingDeletion вызывается перед выдачей сессии: вход в аккаунт, ожидающий
+// удаления, отменяет удаление.
+func (s *AuthUsecase) restoreIfPendingDeletion(ctx context.Context, user *user_api.UserResponse, log *slog.Logger) error {
+	if !user.IsPendingDeletion() {
+		return nil
+	}
+
+	return s.restoreAccount(ctx, user.ID, log)
+}
+
+func (s *AuthUsecase) restoreAccount(ctx context.Context, userID uuid.UUID, log *slog.Logger) error {
+	err := s.userClient.CancelDeletion(ctx, userID)
+
+	// параллельный вход мог восстановить аккаунт раньше
+	if err != nil && !isAppErrorCode(err, codeDeletionNotScheduled) {
+		log.Error("failed to restore account", slog.Any("error", err))
+
+		return commonapperr.Service("restore account", err)
+	}
+
+	if err == nil {
+		s.recordAudit(ctx, &domain.AuditEvent{Type: domain.AuditAccountRestored, UserID: userID}, log)
+
+		log.Info("account restored", slog.String("user_id", userID.String()))
+	}
+
+	return nil
+}
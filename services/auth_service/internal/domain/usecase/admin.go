@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/clients/user_api"
+	apperr "github.com/rockkley/pushpost/services/auth_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain/dto"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/ctxlog"
+	"github.com/rockkley/pushpost/services/common_service/roles"
+)
+
+// ChangeUserRole меняет роль в user_service. Повышение вступает в силу при следующем
+// refresh, а при понижении сессии отзываются, чтобы токены со старой ролью не дожили свой срок.
+func (s *AuthUsecase) ChangeUserRole(ctx context.Context, userID uuid.UUID, req dto.ChangeRoleDTO) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.ChangeUserRole"),
+		slog.String("user_id", userID.String()),
+	)
+
+	target, err := s.moderationTarget(ctx, req.Actor, userID, log)
+
+	if err != nil {
+		return err
+	}
+
+	// назначить можно только роль не выше своей
+	if !req.Actor.Role.AtLeast(req.Role) {
+		return apperr.CannotModerateUser()
+	}
+
+	previous := userRole(target)
+
+	if err = s.userClient.SetRole(ctx, userID, req.Role.String()); err != nil {
+		log.Warn("failed to set role", slog.Any("error", err))
+
+		return err
+	}
+
+	if !req.Role.AtLeast(previous) {
+		if err = s.revokeAllSessions(ctx, userID, log); err != nil {
+			return err
+		}
+	}
+
+	metadata := actorMetadata(req.Actor)
+	metadata["role"] = req.Role.String()
+	metadata["previous_role"] = previous.String()
+
+	s.recordAudit(ctx, &domain.AuditEvent{Type: domain.AuditRoleChanged, UserID: userID, Metadata: metadata}, log)
+
+	log.Info("role changed", slog.String("role", req.Role.String()), slog.String("previous_role", previous.String()))
+
+	return nil
+}
+
+// moderationTarget проверяет, что actor может действовать над пользователем: роль цели
+// должна быть строго ниже.
+func (s *AuthUsecase) moderationTarget(
+	ctx context.Context,
+	actor dto.Actor,
+	userID uuid.UUID,
+	log *slog.Logger,
+) (*user_api.UserResponse, error) {
+	target, err := s.userClient.GetUserByID(ctx, userID)
+
+	if err != nil {
+		if errors.Is(err, user_api.ErrNotFound) {
+			return nil, apperr.UserNotFound()
+		}
+
+		log.Error("failed to get moderation target", slog.Any("error", err))
+
+		return nil, commonapperr.Service("get user", err)
+	}
+
+	if actor.UserID == target.ID || userRole(target).AtLeast(actor.Role) {
+		return nil, apperr.CannotModerateUser()
+	}
+
+	return target, nil
+}
+
+// userRole — пользователи, созданные до появления ролей, считаются обычными.
+func userRole(user *user_api.UserResponse) roles.Role {
+	role, ok := roles.Parse(user.Role)
+
+	if !ok {
+		return roles.User
+	}
+
+	return role
+}
+
+func actorMetadata(actor dto.Actor) map[string]string {
+	return map[string]string{"actor_id": actor.UserID.String(), "actor_role": actor.Role.String()}
+}
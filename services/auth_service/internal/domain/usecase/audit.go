@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/ctxlog"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 200
+)
+
+// ListAuditEvents отдаёт журнал безопасности пользователя страницами, от новых к старым.
+// Курсор — пара (before, beforeID) последнего события страницы; нулевой before означает
+// «с самого начала», нулевой beforeID — все события строго раньше before.
+func (s *AuthUsecase) ListAuditEvents(
+	ctx context.Context,
+	userID uuid.UUID,
+	before time.Time,
+	beforeID uuid.UUID,
+	limit int,
+) ([]*domain.AuditEvent, error) {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.ListAuditEvents"),
+		slog.String("user_id", userID.String()),
+	)
+
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+
+	limit = min(limit, maxAuditLimit)
+
+	if before.IsZero() {
+		before, beforeID = time.Now(), uuid.Max
+	}
+
+	events, err := s.auditLog.ListByUser(ctx, userID, before, beforeID, limit)
+
+	if err != nil {
+		log.Error("failed to list audit events", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("list audit events", err)
+	}
+
+	return events, nil
+}
+
+// recordAudit пишет событие в журнал. Ошибка только логируется: недоступность
+// журнала не должна мешать входу или смене пароля.
+func (s *AuthUsecase) recordAudit(ctx context.Context, event *domain.AuditEvent, log *slog.Logger) {
+	event.ID = uuid.New()
+	event.CreatedAt = time.Now().UTC()
+
+	if event.IP == "" && event.UserAgent == "" {
+		client := domain.ClientFrom(ctx)
+		event.IP, event.UserAgent = client.IP, client.UserAgent
+	}
+
+	if err := s.auditLog.Record(ctx, event); err != nil {
+		log.Error("failed to record audit event",
+			slog.String("event_type", event.Type),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func deviceAudit(eventType string, userID uuid.UUID, device domain.Device) *domain.AuditEvent {
+	return &domain.AuditEvent{
+		Type:       eventType,
+		UserID:     userID,
+		IP:         device.IP,
+		UserAgent:  device.UserAgent,
+		DeviceName: device.Name,
+	}
+}
+
+func sessionAudit(eventType string, session *domain.Session) *domain.AuditEvent {
+	return &domain.AuditEvent{
+		Type:       eventType,
+		UserID:     session.UserID,
+		SessionID:  session.SessionID,
+		DeviceName: session.DeviceName,
+	}
+}
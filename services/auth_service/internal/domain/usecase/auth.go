@@ -2,9 +2,12 @@ package usecase
 
 import (
 	"context"
-	"crypto/subtle"
 	"errors"
 	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,49 +16,108 @@ import (
 	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
 	"github.com/rockkley/pushpost/services/auth_service/internal/domain/dto"
 	"github.com/rockkley/pushpost/services/auth_service/internal/email"
+	"github.com/rockkley/pushpost/services/auth_service/internal/magiclink"
+	"github.com/rockkley/pushpost/services/auth_service/internal/oauth"
 	"github.com/rockkley/pushpost/services/auth_service/internal/otp"
+	"github.com/rockkley/pushpost/services/auth_service/internal/ratelimit"
+	"github.com/rockkley/pushpost/services/auth_service/internal/refreshtoken"
 	"github.com/rockkley/pushpost/services/auth_service/internal/repository"
-	redisotp "github.com/rockkley/pushpost/services/auth_service/internal/repository/redis"
+	redisrepo "github.com/rockkley/pushpost/services/auth_service/internal/repository/redis"
+	"github.com/rockkley/pushpost/services/auth_service/internal/secretbox"
 	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
 	"github.com/rockkley/pushpost/services/common_service/ctxlog"
 	"github.com/rockkley/pushpost/services/common_service/jwt"
 	passwordTools "github.com/rockkley/pushpost/services/common_service/password"
+	"github.com/rockkley/pushpost/services/common_service/revocation"
 )
 
 const (
 	otpTTL         = 5 * time.Minute
+	resetOTPTTL    = 15 * time.Minute
+	emailChangeTTL = 15 * time.Minute
 	resendCooldown = 60 * time.Second
 	maxOTPAttempts = 5
 )
 
 type AuthUsecase struct {
-	userClient   user_api.Client
-	sessionStore repository.SessionStore
-	otpStore     repository.OTPStore
-	emailSender  email.Sender
-	jwtManager   *jwt.Manager
+	userClient     user_api.Client
+	sessionStore   repository.SessionStore
+	otpStore       repository.OTPStore
+	resetOTPs      repository.OTPStore
+	emailOTPs      repository.OTPStore
+	mfaStore       repository.MFAStore
+	limiter        *ratelimit.Limiter
+	auditLog       repository.AuditLog
+	revocations    repository.RevocationPublisher
+	emailSender    email.Sender
+	jwtManager     *jwt.Manager
+	mfaSecrets     *secretbox.Box
+	hasher         *passwordTools.Hasher
+	magicLinks     *magiclink.Issuer
+	magicLinkStore repository.MagicLinkStore
+	settings       repository.SettingsRepository
+	oauthProviders map[string]*oauth.Provider
+	oauthStates    repository.OAuthStateStore
+	identities     repository.IdentityRepository
+	refreshTTL     time.Duration
+	deletionGrace  time.Duration
+	mfaIssuer      string
 }
 
 func NewAuthUsecase(
 	userClient user_api.Client,
 	sessionStore repository.SessionStore,
 	otpStore repository.OTPStore,
+	resetOTPs repository.OTPStore,
+	emailOTPs repository.OTPStore,
+	mfaStore repository.MFAStore,
+	limiter *ratelimit.Limiter,
+	auditLog repository.AuditLog,
+	revocations repository.RevocationPublisher,
 	emailSender email.Sender,
 	jwtManager *jwt.Manager,
+	mfaSecrets *secretbox.Box,
+	hasher *passwordTools.Hasher,
+	magicLinks *magiclink.Issuer,
+	magicLinkStore repository.MagicLinkStore,
+	settings repository.SettingsRepository,
+	oauthProviders map[string]*oauth.Provider,
+	oauthStates repository.OAuthStateStore,
+	identities repository.IdentityRepository,
+	refreshTTL time.Duration,
+	deletionGrace time.Duration,
+	mfaIssuer string,
 ) *AuthUsecase {
 	return &AuthUsecase{
-		userClient:   userClient,
-		sessionStore: sessionStore,
-		otpStore:     otpStore,
-		emailSender:  emailSender,
-		jwtManager:   jwtManager,
+		userClient:     userClient,
+		sessionStore:   sessionStore,
+		otpStore:       otpStore,
+		resetOTPs:      resetOTPs,
+		emailOTPs:      emailOTPs,
+		mfaStore:       mfaStore,
+		limiter:        limiter,
+		auditLog:       auditLog,
+		revocations:    revocations,
+		emailSender:    emailSender,
+		jwtManager:     jwtManager,
+		mfaSecrets:     mfaSecrets,
+		hasher:         hasher,
+		magicLinks:     magicLinks,
+		magicLinkStore: magicLinkStore,
+		settings:       settings,
+		oauthProviders: oauthProviders,
+		oauthStates:    oauthStates,
+		identities:     identities,
+		refreshTTL:     refreshTTL,
+		deletionGrace:  deletionGrace,
+		mfaIssuer:      mfaIssuer,
 	}
 }
 
 func (s *AuthUsecase) Register(ctx context.Context, data dto.RegisterUserDTO) (*dto.RegisterResponseDTO, error) {
 	log := ctxlog.From(ctx).With(slog.String("op", "AuthUsecase.Register"))
 
-	hash, err := passwordTools.Hash(data.Password)
+	hash, err := s.hasher.Hash(data.Password)
 
 	if err != nil {
 		log.Error("failed to hash password", slog.Any("error", err))
@@ -91,52 +153,134 @@ func (s *AuthUsecase) Register(ctx context.Context, data dto.RegisterUserDTO) (*
 	}, nil
 }
 
-func (s *AuthUsecase) Login(ctx context.Context, req dto.LoginUserDTO) (string, error) {
+func (s *AuthUsecase) Login(ctx context.Context, req dto.LoginUserDTO) (*dto.LoginResultDTO, error) {
 	log := ctxlog.From(ctx).With(slog.String("op", "AuthUsecase.Login"))
 
+	account := strings.ToLower(strings.TrimSpace(req.Email))
+
+	if err := s.checkLoginLimits(ctx, account, req.IP, log); err != nil {
+		return nil, err
+	}
+
+	deviceID := req.DeviceID
+
+	if deviceID == uuid.Nil {
+		deviceID = uuid.New()
+	}
+
+	device := domain.Device{ID: deviceID, Name: req.DeviceName, UserAgent: req.UserAgent, IP: req.IP}
+
 	user, err := s.userClient.GetUserByEmail(ctx, req.Email)
 
-	if err != nil {
+	if errors.Is(err, user_api.ErrNotFound) {
 		log.Debug("login attempt: user not found")
+		// несуществующий адрес считается так же, как и существующий, чтобы лимиты не выдавали аккаунты
+		s.registerLoginFailure(ctx, account, nil, device, log)
+
+		return nil, apperr.InvalidCredentials()
+	}
+
+	// сбой user_service не считаем попыткой: иначе на время аварии блокировались бы все
+	if err != nil {
+		log.Error("failed to get user", slog.Any("error", err))
 
-		return "", apperr.InvalidCredentials()
+		return nil, commonapperr.Service("get user", err)
 	}
 
-	if err = passwordTools.Compare(req.Password, user.PasswordHash); err != nil {
+	if err = s.hasher.Compare(req.Password, user.PasswordHash); err != nil {
 		log.Debug("login attempt: password mismatch", slog.String("user_id", user.ID.String()))
+		s.registerLoginFailure(ctx, account, user, device, log)
 
-		return "", apperr.InvalidCredentials()
+		return nil, apperr.InvalidCredentials()
 	}
 
-	// block login if email not verified
-	if !user.IsActive() {
+	// счётчик по IP не сбрасываем: иначе вход в свой аккаунт обнулял бы перебор чужих
+	if err = s.limiter.Reset(ctx, ratelimit.LoginAccount, account); err != nil {
+		log.Warn("failed to reset login attempts", slog.Any("error", err))
+	}
+
+	if s.hasher.NeedsRehash(user.PasswordHash) {
+		s.rehashPassword(ctx, user, req.Password, log)
+	}
+
+	if user.IsBlocked() {
+		log.Debug("login attempt: account suspended", slog.String("user_id", user.ID.String()))
+
+		return nil, apperr.AccountSuspended(user.SuspendedUntil)
+	}
+
+	// block login if email not verified; аккаунт, ожидающий удаления, вход восстанавливает
+	if !user.IsActive() && !user.IsPendingDeletion() {
 		log.Debug("login attempt: account not verified", slog.String("user_id", user.ID.String()))
 
-		return "", apperr.AccountNotVerified()
+		return nil, apperr.AccountNotVerified()
 	}
 
-	deviceID := req.DeviceID
+	mfaEnabled, err := s.mfaEnabled(ctx, user.ID, log)
 
-	if deviceID == uuid.Nil {
-		deviceID = uuid.New()
+	if err != nil {
+		return nil, err
 	}
 
-	sessionID := uuid.New()
+	if mfaEnabled {
+		return s.startMFAChallenge(ctx, &domain.MFAChallenge{UserID: user.ID, Device: device}, log)
+	}
+
+	if err = s.restoreIfPendingDeletion(ctx, user, log); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.startSession(ctx, user.ID, user.Role, device, log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResultDTO{TokenPairDTO: tokens}, nil
+}
+
+// startSession создаёт сессию и выдаёт для неё первую пару токенов.
+func (s *AuthUsecase) startSession(
+	ctx context.Context,
+	userID uuid.UUID,
+	role string,
+	device domain.Device,
+	log *slog.Logger,
+) (*dto.TokenPairDTO, error) {
+	return s.openSession(ctx, uuid.New(), userID, role, device, log)
+}
+
+// openSession — startSession с заранее выбранным id, когда сессию нужно где-то
+// запомнить до выдачи токенов.
+func (s *AuthUsecase) openSession(
+	ctx context.Context,
+	sessionID, userID uuid.UUID,
+	role string,
+	device domain.Device,
+	log *slog.Logger,
+) (*dto.TokenPairDTO, error) {
+	now := time.Now()
 
 	session := &domain.Session{
-		SessionID: sessionID,
-		UserID:    user.ID,
-		DeviceID:  deviceID,
-		Expires:   time.Now().Add(s.jwtManager.TTL()).Unix(),
+		SessionID:  sessionID,
+		UserID:     userID,
+		DeviceID:   device.ID,
+		Expires:    now.Add(s.refreshTTL).Unix(),
+		Role:       role,
+		DeviceName: device.Name,
+		UserAgent:  device.UserAgent,
+		IP:         device.IP,
+		CreatedAt:  now.Unix(),
+		LastSeenAt: now.Unix(),
 	}
 
-	if err = s.sessionStore.Save(ctx, session); err != nil {
+	if err := s.sessionStore.Save(ctx, session); err != nil {
 		log.Error("failed to save session", slog.Any("error", err))
 
-		return "", commonapperr.Internal("failed to create session", err)
+		return nil, commonapperr.Internal("failed to create session", err)
 	}
 
-	token, err := s.jwtManager.Generate(user.ID, deviceID, sessionID)
+	tokens, err := s.issueTokens(ctx, session)
 
 	if err != nil {
 		if delErr := s.sessionStore.Delete(ctx, sessionID); delErr != nil {
@@ -145,17 +289,115 @@ func (s *AuthUsecase) Login(ctx context.Context, req dto.LoginUserDTO) (string,
 				slog.Any("error", delErr),
 			)
 		}
-		log.Error("failed to generate token", slog.Any("error", err))
+		log.Error("failed to issue tokens", slog.Any("error", err))
 
-		return "", commonapperr.Internal("failed to generate token", err)
+		return nil, commonapperr.Internal("failed to issue tokens", err)
 	}
 
+	event := deviceAudit(domain.AuditLoginSucceeded, userID, device)
+	event.SessionID = sessionID
+	s.recordAudit(ctx, event, log)
+
 	log.Info("user logged in",
-		slog.String("user_id", user.ID.String()),
+		slog.String("user_id", userID.String()),
 		slog.String("session_id", sessionID.String()),
 	)
 
-	return token, nil
+	return tokens, nil
+}
+
+// Refresh ротирует refresh-токен: старый становится недействительным, а его повторное
+// предъявление считается кражей и отзывает всю сессию вместе с выданными от неё токенами.
+func (s *AuthUsecase) Refresh(ctx context.Context, refreshToken string) (*dto.TokenPairDTO, error) {
+	log := ctxlog.From(ctx).With(slog.String("op", "AuthUsecase.Refresh"))
+
+	sessionID, err := refreshtoken.SessionID(refreshToken)
+
+	if err != nil {
+		return nil, apperr.RefreshTokenInvalid()
+	}
+
+	log = log.With(slog.String("session_id", sessionID.String()))
+
+	session, err := s.sessionStore.Get(ctx, sessionID)
+
+	if err != nil {
+		if errors.Is(err, redisrepo.ErrSessionNotFound) {
+			return nil, apperr.RefreshTokenInvalid()
+		}
+
+		log.Error("failed to get session", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("get session", err)
+	}
+
+	if time.Now().Unix() > session.Expires {
+		if delErr := s.sessionStore.Delete(ctx, sessionID); delErr != nil {
+			log.Error("failed to delete expired session", slog.Any("error", delErr))
+		}
+
+		return nil, apperr.SessionExpired()
+	}
+
+	owner, err := s.checkSessionOwner(ctx, session, log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// роль могли сменить после входа: новый access-токен выпускается с актуальной
+	session.Role = owner.Role
+
+	next, err := refreshtoken.Generate(sessionID)
+
+	if err != nil {
+		log.Error("failed to generate refresh token", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("failed to generate refresh token", err)
+	}
+
+	// access-токен подписываем до ротации, чтобы не потерять новый refresh при ошибке
+	tokens, err := s.tokenPair(session, next)
+
+	if err != nil {
+		log.Error("failed to generate token", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("failed to generate token", err)
+	}
+
+	err = s.sessionStore.RotateRefreshToken(ctx, sessionID, refreshtoken.Hash(refreshToken), refreshtoken.Hash(next))
+
+	switch {
+	case err == nil:
+	case errors.Is(err, repository.ErrRefreshTokenReused):
+		log.Warn("refresh token reuse detected, revoking session",
+			slog.String("user_id", session.UserID.String()),
+		)
+
+		if revokeErr := s.revokeSession(ctx, session, log); revokeErr != nil {
+			return nil, revokeErr
+		}
+
+		s.recordAudit(ctx, sessionAudit(domain.AuditRefreshTokenReused, session), log)
+
+		return nil, apperr.RefreshTokenReused()
+	case errors.Is(err, repository.ErrRefreshTokenInvalid), errors.Is(err, redisrepo.ErrSessionNotFound):
+		return nil, apperr.RefreshTokenInvalid()
+	default:
+		log.Error("failed to rotate refresh token", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("failed to rotate refresh token", err)
+	}
+
+	// last-seen обновляется на refresh: раз в время жизни access-токена достаточно точно.
+	// Touch, а не Save: сессию могли отозвать между ротацией и этой записью
+	if err = s.sessionStore.Touch(ctx, sessionID, time.Now().Unix()); err != nil {
+		log.Warn("failed to update session last-seen time", slog.Any("error", err))
+	}
+
+	log.Debug("tokens refreshed", slog.String("user_id", session.UserID.String()))
+
+	return tokens, nil
 }
 
 func (s *AuthUsecase) Logout(ctx context.Context, sessionID uuid.UUID) error {
@@ -172,12 +414,12 @@ func (s *AuthUsecase) Logout(ctx context.Context, sessionID uuid.UUID) error {
 		return nil
 	}
 
-	if err = s.sessionStore.Delete(ctx, sessionID); err != nil {
-		log.Error("failed to delete session", slog.Any("error", err))
-
-		return commonapperr.Internal("failed to delete session", err)
+	if err = s.revokeSession(ctx, session, log); err != nil {
+		return err
 	}
 
+	s.recordAudit(ctx, sessionAudit(domain.AuditLogout, session), log)
+
 	log.Info("user logged out", slog.String("user_id", session.UserID.String()))
 
 	return nil
@@ -222,47 +464,147 @@ func (s *AuthUsecase) AuthenticateRequest(ctx context.Context, tokenStr string)
 	return session, nil
 }
 
-func (s *AuthUsecase) VerifyEmail(ctx context.Context, email, code string) error {
-	log := ctxlog.From(ctx).With(slog.String("op", "AuthUsecase.VerifyEmail"))
+// IntrospectSession is used by the gateway to check that a token's session is
+// still alive and that its owner is neither blocked nor deleted.
+func (s *AuthUsecase) IntrospectSession(ctx context.Context, sessionID uuid.UUID) (*domain.Session, error) {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.IntrospectSession"),
+		slog.String("session_id", sessionID.String()),
+	)
 
-	stored, err := s.otpStore.Get(ctx, email)
+	session, err := s.sessionStore.Get(ctx, sessionID)
 
 	if err != nil {
-		if errors.Is(err, redisotp.ErrOTPNotFound) {
-			return apperr.OTPExpired()
+		if errors.Is(err, redisrepo.ErrSessionNotFound) {
+			return nil, apperr.SessionRevoked()
 		}
 
-		log.Error("failed to get otp", slog.Any("error", err))
+		log.Error("failed to get session", slog.Any("error", err))
 
-		return commonapperr.Internal("get otp", err)
+		return nil, commonapperr.Internal("get session", err)
 	}
 
-	if subtle.ConstantTimeCompare([]byte(stored), []byte(code)) != 1 {
-		attempts, incrErr := s.otpStore.IncrAttempts(ctx, email, otpTTL)
+	if time.Now().Unix() > session.Expires {
+		if delErr := s.sessionStore.Delete(ctx, sessionID); delErr != nil {
+			log.Error("failed to delete expired session", slog.Any("error", delErr))
+		}
 
-		if incrErr != nil {
-			log.Error("failed to increment otp attempts", slog.Any("error", incrErr))
+		return nil, apperr.SessionExpired()
+	}
+
+	if _, err = s.checkSessionOwner(ctx, session, log); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *AuthUsecase) ListSessions(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.ListSessions"),
+		slog.String("user_id", userID.String()),
+	)
+
+	sessions, err := s.sessionStore.ListByUser(ctx, userID)
+
+	if err != nil {
+		log.Error("failed to list sessions", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("list sessions", err)
+	}
+
+	now := time.Now().Unix()
+	active := make([]*domain.Session, 0, len(sessions))
+
+	for _, session := range sessions {
+		if session.Expires >= now {
+			active = append(active, session)
 		}
+	}
 
-		if attempts >= maxOTPAttempts {
-			if delErr := s.otpStore.Delete(ctx, email); delErr != nil {
-				log.Error("failed to delete otp after max attempts", slog.Any("error", delErr))
-			}
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].LastSeenAt > active[j].LastSeenAt
+	})
 
-			return apperr.TooManyOTPAttempts()
+	return active, nil
+}
+
+func (s *AuthUsecase) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.RevokeSession"),
+		slog.String("user_id", userID.String()),
+		slog.String("session_id", sessionID.String()),
+	)
+
+	session, err := s.sessionStore.Get(ctx, sessionID)
+
+	if err != nil {
+		if errors.Is(err, redisrepo.ErrSessionNotFound) {
+			return apperr.SessionNotFound()
 		}
 
-		return apperr.OTPInvalid()
+		log.Error("failed to get session", slog.Any("error", err))
+
+		return commonapperr.Internal("get session", err)
 	}
 
-	if err = s.userClient.ActivateUser(ctx, email); err != nil {
+	// чужую сессию не раскрываем, отвечаем так же, как на несуществующую
+	if session.UserID != userID {
+		return apperr.SessionNotFound()
+	}
+
+	if err = s.revokeSession(ctx, session, log); err != nil {
+		return err
+	}
+
+	s.recordAudit(ctx, sessionAudit(domain.AuditSessionRevoked, session), log)
+
+	log.Info("session revoked by user")
+
+	return nil
+}
+
+func (s *AuthUsecase) RevokeOtherSessions(ctx context.Context, userID, currentSessionID uuid.UUID) (int, error) {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.RevokeOtherSessions"),
+		slog.String("user_id", userID.String()),
+	)
+
+	revoked, err := s.revokeOtherSessions(ctx, userID, currentSessionID, log)
+
+	if err != nil {
+		return revoked, err
+	}
+
+	s.recordAudit(ctx, &domain.AuditEvent{
+		Type:      domain.AuditSessionRevoked,
+		UserID:    userID,
+		SessionID: currentSessionID,
+		Metadata:  map[string]string{"scope": "others", "count": strconv.Itoa(revoked)},
+	}, log)
+
+	log.Info("other sessions revoked", slog.Int("count", revoked))
+
+	return revoked, nil
+}
+
+func (s *AuthUsecase) VerifyEmail(ctx context.Context, email, code string) error {
+	log := ctxlog.From(ctx).With(slog.String("op", "AuthUsecase.VerifyEmail"))
+
+	if err := s.consumeOTP(ctx, s.otpStore, email, code, otpTTL, log); err != nil {
+		return err
+	}
+
+	if err := s.userClient.ActivateUser(ctx, email); err != nil {
 		log.Error("failed to activate user", slog.String("email", email), slog.Any("error", err))
 
 		return err
 	}
 
-	if err = s.otpStore.Delete(ctx, email); err != nil {
-		log.Warn("failed to delete otp after successful verification", slog.Any("error", err))
+	if user, err := s.userClient.GetUserByEmail(ctx, email); err == nil {
+		s.recordAudit(ctx, &domain.AuditEvent{Type: domain.AuditEmailVerified, UserID: user.ID}, log)
+	} else {
+		log.Warn("failed to get verified user for audit", slog.Any("error", err))
 	}
 
 	log.Info("email verified", slog.String("email", email))
@@ -300,6 +642,589 @@ func (s *AuthUsecase) ResendOTP(ctx context.Context, userEmail string) error {
 	return nil
 }
 
+// ForgotPassword отвечает одинаково для существующих и несуществующих адресов,
+// поэтому cooldown ставится до поиска пользователя.
+func (s *AuthUsecase) ForgotPassword(ctx context.Context, userEmail string) error {
+	log := ctxlog.From(ctx).With(slog.String("op", "AuthUsecase.ForgotPassword"))
+
+	onCooldown, err := s.resetOTPs.HasCooldown(ctx, userEmail)
+
+	if err != nil {
+		log.Error("failed to check reset cooldown", slog.Any("error", err))
+
+		return commonapperr.Internal("check reset cooldown", err)
+	}
+
+	if onCooldown {
+		return apperr.OTPResendCooldown()
+	}
+
+	if err = s.resetOTPs.SetCooldown(ctx, userEmail, resendCooldown); err != nil {
+		log.Warn("failed to set reset cooldown", slog.Any("error", err))
+	}
+
+	user, err := s.userClient.GetUserByEmail(ctx, userEmail)
+
+	if err != nil || user.IsBlocked() || user.IsDeleted() {
+		log.Debug("password reset requested for unknown or disabled account")
+
+		return nil
+	}
+
+	code, err := otp.Generate()
+
+	if err != nil {
+		log.Error("failed to generate reset code", slog.Any("error", err))
+
+		return nil
+	}
+
+	if err = s.resetOTPs.Save(ctx, userEmail, code, resetOTPTTL); err != nil {
+		log.Error("failed to save reset code", slog.Any("error", err))
+
+		return nil
+	}
+
+	if err = s.emailSender.Send(ctx, email.TemplatePasswordReset, userEmail, email.Data{
+		"Code":       code,
+		"TTLMinutes": email.Minutes(resetOTPTTL),
+	}); err != nil {
+		log.Error("failed to send reset code", slog.Any("error", err))
+
+		return nil
+	}
+
+	log.Info("password reset code sent", slog.String("user_id", user.ID.String()))
+
+	return nil
+}
+
+func (s *AuthUsecase) ResetPassword(ctx context.Context, userEmail, code, newPassword string) error {
+	log := ctxlog.From(ctx).With(slog.String("op", "AuthUsecase.ResetPassword"))
+
+	if err := s.consumeOTP(ctx, s.resetOTPs, userEmail, code, resetOTPTTL, log); err != nil {
+		return err
+	}
+
+	user, err := s.userClient.GetUserByEmail(ctx, userEmail)
+
+	if err != nil {
+		if errors.Is(err, user_api.ErrNotFound) {
+			return apperr.OTPInvalid()
+		}
+
+		log.Error("failed to get user", slog.Any("error", err))
+
+		return commonapperr.Service("get user", err)
+	}
+
+	hash, err := s.hasher.Hash(newPassword)
+
+	if err != nil {
+		log.Error("failed to hash password", slog.Any("error", err))
+
+		return commonapperr.Internal("failed to hash password", err)
+	}
+
+	if err = s.userClient.UpdatePassword(ctx, user.ID, hash); err != nil {
+		log.Error("failed to update password", slog.String("user_id", user.ID.String()), slog.Any("error", err))
+
+		return err
+	}
+
+	if err = s.revokeAllSessions(ctx, user.ID, log); err != nil {
+		return err
+	}
+
+	s.recordAudit(ctx, &domain.AuditEvent{Type: domain.AuditPasswordReset, UserID: user.ID}, log)
+
+	log.Info("password reset", slog.String("user_id", user.ID.String()))
+
+	return nil
+}
+
+func (s *AuthUsecase) ChangePassword(
+	ctx context.Context,
+	userID, sessionID uuid.UUID,
+	currentPassword, newPassword string,
+) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.ChangePassword"),
+		slog.String("user_id", userID.String()),
+	)
+
+	user, err := s.userClient.GetUserByID(ctx, userID)
+
+	if err != nil {
+		log.Error("failed to get user", slog.Any("error", err))
+
+		return err
+	}
+
+	if err = s.checkCurrentPassword(ctx, user, currentPassword, log); err != nil {
+		return err
+	}
+
+	hash, err := s.hasher.Hash(newPassword)
+
+	if err != nil {
+		log.Error("failed to hash password", slog.Any("error", err))
+
+		return commonapperr.Internal("failed to hash password", err)
+	}
+
+	if err = s.userClient.UpdatePassword(ctx, userID, hash); err != nil {
+		log.Error("failed to update password", slog.Any("error", err))
+
+		return err
+	}
+
+	if _, err = s.revokeOtherSessions(ctx, userID, sessionID, log); err != nil {
+		return err
+	}
+
+	s.recordAudit(ctx, &domain.AuditEvent{Type: domain.AuditPasswordChanged, UserID: userID, SessionID: sessionID}, log)
+
+	log.Info("password changed")
+
+	return nil
+}
+
+// RequestEmailChange шлёт код на новый адрес; сам адрес меняется только в ConfirmEmailChange.
+func (s *AuthUsecase) RequestEmailChange(ctx context.Context, userID uuid.UUID, newEmail string) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.RequestEmailChange"),
+		slog.String("user_id", userID.String()),
+	)
+
+	// регистр и пробелы не должны давать новый ключ кулдауна для того же ящика
+	newEmail = strings.ToLower(strings.TrimSpace(newEmail))
+	key := emailChangeKey(userID, newEmail)
+
+	onCooldown, err := s.emailOTPs.HasCooldown(ctx, key)
+
+	if err != nil {
+		log.Error("failed to check email change cooldown", slog.Any("error", err))
+
+		return commonapperr.Internal("check email change cooldown", err)
+	}
+
+	if onCooldown {
+		return apperr.OTPResendCooldown()
+	}
+
+	user, err := s.userClient.GetUserByID(ctx, userID)
+
+	if err != nil {
+		log.Error("failed to get user", slog.Any("error", err))
+
+		return err
+	}
+
+	if strings.EqualFold(user.Email, newEmail) {
+		return apperr.EmailUnchanged()
+	}
+
+	if err = s.ensureEmailAvailable(ctx, newEmail, log); err != nil {
+		return err
+	}
+
+	code, err := otp.Generate()
+
+	if err != nil {
+		return commonapperr.Internal("generate otp", err)
+	}
+
+	if err = s.emailOTPs.Save(ctx, key, code, emailChangeTTL); err != nil {
+		log.Error("failed to save email change code", slog.Any("error", err))
+
+		return commonapperr.Internal("save otp", err)
+	}
+
+	if err = s.emailOTPs.SetCooldown(ctx, key, resendCooldown); err != nil {
+		log.Warn("failed to set email change cooldown", slog.Any("error", err))
+	}
+
+	if err = s.emailSender.Send(ctx, email.TemplateEmailChange, newEmail, email.Data{
+		"Code":       code,
+		"TTLMinutes": email.Minutes(emailChangeTTL),
+	}); err != nil {
+		log.Error("failed to send email change code", slog.Any("error", err))
+
+		return commonapperr.Internal("send otp", err)
+	}
+
+	log.Info("email change requested")
+
+	return nil
+}
+
+func (s *AuthUsecase) ConfirmEmailChange(ctx context.Context, userID, sessionID uuid.UUID, newEmail, code string) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.ConfirmEmailChange"),
+		slog.String("user_id", userID.String()),
+	)
+
+	newEmail = strings.ToLower(strings.TrimSpace(newEmail))
+	key := emailChangeKey(userID, newEmail)
+
+	if err := s.consumeOTP(ctx, s.emailOTPs, key, code, emailChangeTTL, log); err != nil {
+		return err
+	}
+
+	if err := s.userClient.ChangeEmail(ctx, userID, newEmail); err != nil {
+		log.Error("failed to change email", slog.Any("error", err))
+
+		return err
+	}
+
+	if _, err := s.revokeOtherSessions(ctx, userID, sessionID, log); err != nil {
+		return err
+	}
+
+	s.recordAudit(ctx, &domain.AuditEvent{Type: domain.AuditEmailChanged, UserID: userID, SessionID: sessionID}, log)
+
+	log.Info("email changed")
+
+	return nil
+}
+
+func (s *AuthUsecase) ensureEmailAvailable(ctx context.Context, userEmail string, log *slog.Logger) error {
+	_, err := s.userClient.GetUserByEmail(ctx, userEmail)
+
+	switch {
+	case err == nil:
+		return apperr.EmailAlreadyExists()
+	case errors.Is(err, user_api.ErrNotFound):
+		return nil
+	default:
+		log.Error("failed to check email availability", slog.Any("error", err))
+
+		return commonapperr.Service("check email availability", err)
+	}
+}
+
+// emailChangeKey привязывает код и к пользователю, и к новому адресу.
+func emailChangeKey(userID uuid.UUID, newEmail string) string {
+	return userID.String() + ":" + newEmail
+}
+
+// consumeOTP гасит код одной атомарной операцией и считает неудачные попытки;
+// после maxOTPAttempts код сгорает. Верный код второй раз уже не пройдёт.
+func (s *AuthUsecase) consumeOTP(
+	ctx context.Context,
+	store repository.OTPStore,
+	userEmail, code string,
+	ttl time.Duration,
+	log *slog.Logger,
+) error {
+	consumed, err := store.Consume(ctx, userEmail, code)
+
+	if err != nil {
+		if errors.Is(err, redisrepo.ErrOTPNotFound) {
+			return apperr.OTPExpired()
+		}
+
+		log.Error("failed to consume otp", slog.Any("error", err))
+
+		return commonapperr.Internal("consume otp", err)
+	}
+
+	if !consumed {
+		attempts, incrErr := store.IncrAttempts(ctx, userEmail, ttl)
+
+		if incrErr != nil {
+			log.Error("failed to increment otp attempts", slog.Any("error", incrErr))
+		}
+
+		if attempts >= maxOTPAttempts {
+			if delErr := store.Delete(ctx, userEmail); delErr != nil {
+				log.Error("failed to delete otp after max attempts", slog.Any("error", delErr))
+			}
+
+			return apperr.TooManyOTPAttempts()
+		}
+
+		return apperr.OTPInvalid()
+	}
+
+	return nil
+}
+
+// checkLoginLimits не даёт даже проверить пароль, пока действует задержка или блокировка.
+// При недоступном Redis пропускаем: без него всё равно не создать сессию.
+func (s *AuthUsecase) checkLoginLimits(ctx context.Context, account, ip string, log *slog.Logger) error {
+	if ip != "" {
+		status, err := s.limiter.Check(ctx, ratelimit.LoginIP, ip)
+
+		if err != nil {
+			log.Error("failed to check login ip limit", slog.Any("error", err))
+		} else if status.RetryAfter > 0 {
+			return apperr.TooManyLoginAttempts(status.RetryAfter)
+		}
+	}
+
+	status, err := s.limiter.Check(ctx, ratelimit.LoginAccount, account)
+
+	if err != nil {
+		log.Error("failed to check login account limit", slog.Any("error", err))
+
+		return nil
+	}
+
+	switch {
+	case status.Lockout:
+		return apperr.AccountLocked(status.RetryAfter)
+	case status.RetryAfter > 0:
+		return apperr.TooManyLoginAttempts(status.RetryAfter)
+	}
+
+	return nil
+}
+
+// checkCurrentPassword сверяет пароль под тем же счётчиком, что и вход:
+// с украденным access-токеном перебирать текущий пароль без ограничений не выйдет.
+func (s *AuthUsecase) checkCurrentPassword(ctx context.Context, user *user_api.UserResponse, password string, log *slog.Logger) error {
+	account := strings.ToLower(strings.TrimSpace(user.Email))
+
+	if err := s.checkLoginLimits(ctx, account, "", log); err != nil {
+		return err
+	}
+
+	if err := s.hasher.Compare(password, user.PasswordHash); err != nil {
+		log.Debug("current password mismatch")
+
+		if _, failErr := s.limiter.Fail(ctx, ratelimit.LoginAccount, account); failErr != nil {
+			log.Error("failed to count current password failure", slog.Any("error", failErr))
+		}
+
+		return apperr.InvalidCurrentPassword()
+	}
+
+	if err := s.limiter.Reset(ctx, ratelimit.LoginAccount, account); err != nil {
+		log.Warn("failed to reset login attempts", slog.Any("error", err))
+	}
+
+	return nil
+}
+
+// registerLoginFailure считает неудачу и, если аккаунт только что заблокирован,
+// предупреждает владельца письмом. user равен nil, если пользователя нет.
+func (s *AuthUsecase) registerLoginFailure(
+	ctx context.Context,
+	account string,
+	user *user_api.UserResponse,
+	device domain.Device,
+	log *slog.Logger,
+) {
+	event := deviceAudit(domain.AuditLoginFailed, uuid.Nil, device)
+	event.Metadata = map[string]string{"reason": "unknown_account"}
+
+	if user != nil {
+		event.UserID = user.ID
+		event.Metadata["reason"] = "invalid_password"
+	}
+
+	s.recordAudit(ctx, event, log)
+
+	if device.IP != "" {
+		if _, err := s.limiter.Fail(ctx, ratelimit.LoginIP, device.IP); err != nil {
+			log.Error("failed to count login failure by ip", slog.Any("error", err))
+		}
+	}
+
+	status, err := s.limiter.Fail(ctx, ratelimit.LoginAccount, account)
+
+	if err != nil {
+		log.Error("failed to count login failure by account", slog.Any("error", err))
+
+		return
+	}
+
+	if !status.Locked || user == nil {
+		return
+	}
+
+	log.Warn("account locked after repeated failed logins", slog.Duration("locked_for", status.RetryAfter))
+
+	locked := deviceAudit(domain.AuditAccountLocked, user.ID, device)
+	locked.Metadata = map[string]string{"locked_for": status.RetryAfter.String()}
+	s.recordAudit(ctx, locked, log)
+
+	if err = s.emailSender.Send(ctx, email.TemplateAccountLocked, user.Email, email.Data{
+		"LockedMinutes": email.Minutes(status.RetryAfter),
+	}); err != nil {
+		log.Error("failed to send account locked notification", slog.Any("error", err))
+	}
+}
+
+// rehashPassword переводит хеш на текущий алгоритм и параметры. Пароль известен только
+// в момент входа, поэтому апгрейд происходит здесь; ошибка не мешает входу.
+func (s *AuthUsecase) rehashPassword(ctx context.Context, user *user_api.UserResponse, password string, log *slog.Logger) {
+	hash, err := s.hasher.Hash(password)
+
+	if err != nil {
+		log.Error("failed to rehash password", slog.Any("error", err))
+
+		return
+	}
+
+	if err = s.userClient.RehashPassword(ctx, user.ID, user.PasswordHash, hash); err != nil {
+		log.Warn("failed to store upgraded password hash", slog.String("user_id", user.ID.String()), slog.Any("error", err))
+
+		return
+	}
+
+	log.Info("password hash upgraded", slog.String("user_id", user.ID.String()))
+}
+
+// checkSessionOwner отзывает сессию, если её владелец заблокирован или удалён,
+// и возвращает владельца, чтобы Refresh взял из него актуальную роль.
+func (s *AuthUsecase) checkSessionOwner(
+	ctx context.Context,
+	session *domain.Session,
+	log *slog.Logger,
+) (*user_api.UserResponse, error) {
+	user, err := s.userClient.GetUserByID(ctx, session.UserID)
+
+	if err != nil {
+		var appErr commonapperr.AppError
+
+		if !errors.Is(err, user_api.ErrNotFound) &&
+			!(errors.As(err, &appErr) && appErr.HTTPStatus() == http.StatusForbidden) {
+			log.Error("failed to get session owner", slog.Any("error", err))
+
+			return nil, commonapperr.Service("get session owner", err)
+		}
+
+		user = &user_api.UserResponse{ID: session.UserID, Status: "deleted"}
+	}
+
+	switch {
+	case user.IsDeleted():
+		if err = s.revokeSession(ctx, session, log); err != nil {
+			return nil, err
+		}
+
+		return nil, apperr.AccountDeleted()
+	case user.IsBlocked():
+		if err = s.revokeSession(ctx, session, log); err != nil {
+			return nil, err
+		}
+
+		return nil, apperr.AccountBlocked()
+	}
+
+	return user, nil
+}
+
+// issueTokens выдаёт первую пару токенов для только что созданной сессии.
+func (s *AuthUsecase) issueTokens(ctx context.Context, session *domain.Session) (*dto.TokenPairDTO, error) {
+	refreshToken, err := refreshtoken.Generate(session.SessionID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.sessionStore.SaveRefreshToken(ctx, session, refreshtoken.Hash(refreshToken)); err != nil {
+		return nil, err
+	}
+
+	return s.tokenPair(session, refreshToken)
+}
+
+func (s *AuthUsecase) tokenPair(session *domain.Session, refreshToken string) (*dto.TokenPairDTO, error) {
+	accessToken, err := s.jwtManager.Generate(session.UserID, session.DeviceID, session.SessionID, session.Role)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TokenPairDTO{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.jwtManager.TTL().Seconds()),
+	}, nil
+}
+
+// revokeSession удаляет сессию и оповещает gateway, чтобы тот сбросил её из кэша.
+func (s *AuthUsecase) revokeSession(ctx context.Context, session *domain.Session, log *slog.Logger) error {
+	if err := s.sessionStore.Delete(ctx, session.SessionID); err != nil {
+		log.Error("failed to delete session", slog.Any("error", err))
+
+		return commonapperr.Internal("failed to delete session", err)
+	}
+
+	event := revocation.Event{UserID: session.UserID, SessionID: session.SessionID}
+
+	if err := s.revocations.Publish(ctx, event); err != nil {
+		log.Warn("failed to publish session revocation, gateway cache will expire it",
+			slog.String("session_id", session.SessionID.String()),
+			slog.Any("error", err),
+		)
+	}
+
+	return nil
+}
+
+func (s *AuthUsecase) revokeOtherSessions(
+	ctx context.Context,
+	userID, currentSessionID uuid.UUID,
+	log *slog.Logger,
+) (int, error) {
+	sessions, err := s.sessionStore.ListByUser(ctx, userID)
+
+	if err != nil {
+		log.Error("failed to list sessions", slog.Any("error", err))
+
+		return 0, commonapperr.Internal("list sessions", err)
+	}
+
+	revoked := 0
+
+	for _, session := range sessions {
+		if session.SessionID == currentSessionID {
+			continue
+		}
+
+		if err = s.revokeSession(ctx, session, log); err != nil {
+			return revoked, err
+		}
+
+		revoked++
+	}
+
+	return revoked, nil
+}
+
+// revokeAllSessions удаляет все сессии пользователя; gateway получает одно событие без SessionID.
+func (s *AuthUsecase) revokeAllSessions(ctx context.Context, userID uuid.UUID, log *slog.Logger) error {
+	sessions, err := s.sessionStore.ListByUser(ctx, userID)
+
+	if err != nil {
+		log.Error("failed to list sessions", slog.Any("error", err))
+
+		return commonapperr.Internal("list sessions", err)
+	}
+
+	for _, session := range sessions {
+		if err = s.sessionStore.Delete(ctx, session.SessionID); err != nil {
+			log.Error("failed to delete session", slog.Any("error", err))
+
+			return commonapperr.Internal("failed to delete session", err)
+		}
+	}
+
+	if err = s.revocations.Publish(ctx, revocation.Event{UserID: userID}); err != nil {
+		log.Warn("failed to publish sessions revocation, gateway cache will expire them",
+			slog.String("user_id", userID.String()),
+			slog.Any("error", err),
+		)
+	}
+
+	return nil
+}
+
 // sendOTP генерирует код, сохраняет в Redis, отправляет письмо.
 func (s *AuthUsecase) sendOTP(ctx context.Context, userEmail string, log *slog.Logger) error {
 	code, err := otp.Generate()
@@ -316,7 +1241,10 @@ func (s *AuthUsecase) sendOTP(ctx context.Context, userEmail string, log *slog.L
 		log.Warn("failed to set otp resend cooldown", slog.Any("error", err))
 	}
 
-	if err = s.emailSender.SendOTP(ctx, userEmail, code); err != nil {
+	if err = s.emailSender.Send(ctx, email.TemplateVerifyEmail, userEmail, email.Data{
+		"Code":       code,
+		"TTLMinutes": email.Minutes(otpTTL),
+	}); err != nil {
 		return err
 	}
 
@@ -1,26 +1,58 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
 	"errors"
+	"fmt"
 	"github.com/rockkley/pushpost/clients/user_api"
 	jwtpkg "github.com/rockkley/pushpost/services/common_service/jwt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 
+	apperr "github.com/rockkley/pushpost/services/auth_service/internal/apperror"
 	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
 	"github.com/rockkley/pushpost/services/auth_service/internal/domain/dto"
+	"github.com/rockkley/pushpost/services/auth_service/internal/email"
+	"github.com/rockkley/pushpost/services/auth_service/internal/magiclink"
+	"github.com/rockkley/pushpost/services/auth_service/internal/oauth"
+	"github.com/rockkley/pushpost/services/auth_service/internal/oauth/oauthtest"
+	"github.com/rockkley/pushpost/services/auth_service/internal/ratelimit"
+	"github.com/rockkley/pushpost/services/auth_service/internal/refreshtoken"
 	authrep "github.com/rockkley/pushpost/services/auth_service/internal/repository"
+	"github.com/rockkley/pushpost/services/auth_service/internal/repository/memory"
+	redisrepo "github.com/rockkley/pushpost/services/auth_service/internal/repository/redis"
+	"github.com/rockkley/pushpost/services/auth_service/internal/secretbox"
+	"github.com/rockkley/pushpost/services/auth_service/internal/totp"
 	"github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/password"
+	"github.com/rockkley/pushpost/services/common_service/revocation"
+	"github.com/rockkley/pushpost/services/common_service/roles"
 	"github.com/stretchr/testify/require"
 )
 
 // ── Helpers ───────────────────────────────────────────────────────────────────
 
-const testJWTSecret = "test-secret-that-is-exactly-32-chars!!"
+const testKeyID = "test-key"
+
+// newTestJWTManager — ключ детерминирован, чтобы токены одного теста проверялись другим менеджером.
+func newTestJWTManager() *jwtpkg.Manager {
+	return newJWTManagerFromSeed(1)
+}
+
+func newJWTManagerFromSeed(b byte) *jwtpkg.Manager {
+	key := jwtpkg.SigningKey{ID: testKeyID, Private: ed25519.NewKeyFromSeed(bytes.Repeat([]byte{b}, ed25519.SeedSize))}
+	m, err := jwtpkg.NewSigner([]jwtpkg.SigningKey{key}, testKeyID, nil)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
 
 // lowCostHash generates a bcrypt hash at MinCost (4) for fast test execution.
 func lowCostHash(t testing.TB, password string) string {
@@ -31,8 +63,33 @@ func lowCostHash(t testing.TB, password string) string {
 }
 
 func newTestUsecase(client user_api.Client, store authrep.SessionStore) *AuthUsecase {
-	jm := jwtpkg.NewManager(testJWTSecret, nil)
-	return NewAuthUsecase(client, store, jm)
+	jm := newTestJWTManager()
+	return NewAuthUsecase(client, store, &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockMFAStore{}, newTestLimiter(), &mockAuditLog{}, &mockRevocationPublisher{}, &mockEmailSender{}, jm, newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+}
+
+// newTestHasher — argon2id с минимальными параметрами, чтобы тесты не тратили 64 МиБ на хеш.
+func newTestHasher() *password.Hasher {
+	h, err := password.NewHasher(password.Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+func newTestMagicLinks() *magiclink.Issuer {
+	links, err := magiclink.New(bytes.Repeat([]byte{7}, magiclink.MinKeySize), 15*time.Minute, "https://pushpost.test/magic")
+	if err != nil {
+		panic(err)
+	}
+	return links
+}
+
+func newTestSecretBox() *secretbox.Box {
+	box, err := secretbox.New(make([]byte, secretbox.KeySize))
+	if err != nil {
+		panic(err)
+	}
+	return box
 }
 
 // ── Mock: user_api.Client ──────────────────────────────────────────────────────
@@ -42,6 +99,17 @@ type mockUserClient struct {
 	getUserByIDFunc      func(ctx context.Context, id uuid.UUID) (*user_api.UserResponse, error)
 	getUserByEmailFunc   func(ctx context.Context, email string) (*user_api.UserResponse, error)
 	authenticateUserFunc func(ctx context.Context, email, password string) (*user_api.UserResponse, error)
+	updatePasswordFunc   func(ctx context.Context, id uuid.UUID, passwordHash string) error
+	changeEmailFunc      func(ctx context.Context, id uuid.UUID, email string) error
+	getMFAFunc           func(ctx context.Context, id uuid.UUID) (*user_api.MFAResponse, error)
+	enableMFAFunc        func(ctx context.Context, id uuid.UUID, req user_api.EnableMFARequest) error
+	consumeRecoveryFunc  func(ctx context.Context, id uuid.UUID, codeHash string) error
+	rehashPasswordFunc   func(ctx context.Context, id uuid.UUID, currentHash, newHash string) error
+	scheduleDeletionFunc func(ctx context.Context, id uuid.UUID, at time.Time) error
+	cancelDeletionFunc   func(ctx context.Context, id uuid.UUID) error
+	deleteUserFunc       func(ctx context.Context, id uuid.UUID) error
+	suspendUserFunc      func(ctx context.Context, id uuid.UUID, req user_api.SuspendUserRequest) error
+	setRoleFunc          func(ctx context.Context, id uuid.UUID, role string) error
 }
 
 func (m *mockUserClient) CreateUser(ctx context.Context, req user_api.CreateUserRequest) (*user_api.UserResponse, error) {
@@ -58,13 +126,45 @@ func (m *mockUserClient) GetUserByID(ctx context.Context, id uuid.UUID) (*user_a
 	return nil, nil
 }
 
+// GetUserByEmail: фикстуры без статуса описывают обычного подтверждённого пользователя,
+// проверки статуса покрыты отдельными тестами с явным Status.
 func (m *mockUserClient) GetUserByEmail(ctx context.Context, email string) (*user_api.UserResponse, error) {
 	if m.getUserByEmailFunc != nil {
-		return m.getUserByEmailFunc(ctx, email)
+		user, err := m.getUserByEmailFunc(ctx, email)
+		if user != nil && user.Status == "" {
+			user.Status = "active"
+		}
+		return user, err
 	}
 	return nil, nil
 }
 
+func (m *mockUserClient) GetUserByUsername(_ context.Context, _ string) (*user_api.UserResponse, error) {
+	return nil, user_api.ErrNotFound
+}
+
+func (m *mockUserClient) GetUserByFormerUsername(_ context.Context, _ string) (*user_api.UserResponse, error) {
+	return nil, user_api.ErrNotFound
+}
+
+func (m *mockUserClient) ActivateUser(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockUserClient) RehashPassword(ctx context.Context, id uuid.UUID, currentHash, newHash string) error {
+	if m.rehashPasswordFunc != nil {
+		return m.rehashPasswordFunc(ctx, id, currentHash, newHash)
+	}
+	return nil
+}
+
+func (m *mockUserClient) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	if m.updatePasswordFunc != nil {
+		return m.updatePasswordFunc(ctx, id, passwordHash)
+	}
+	return nil
+}
+
 //func (m *mockUserClient) AuthenticateUser(ctx context.Context, email, password string) (*user_api.UserResponse, error) {
 //	if m.authenticateUserFunc != nil {
 //		return m.authenticateUserFunc(ctx, email, password)
@@ -72,12 +172,86 @@ func (m *mockUserClient) GetUserByEmail(ctx context.Context, email string) (*use
 //	return nil, nil
 //}
 
+func (m *mockUserClient) ChangeEmail(ctx context.Context, id uuid.UUID, email string) error {
+	if m.changeEmailFunc != nil {
+		return m.changeEmailFunc(ctx, id, email)
+	}
+	return nil
+}
+
+func (m *mockUserClient) GetMFA(ctx context.Context, id uuid.UUID) (*user_api.MFAResponse, error) {
+	if m.getMFAFunc != nil {
+		return m.getMFAFunc(ctx, id)
+	}
+	return nil, user_api.ErrNotFound
+}
+
+func (m *mockUserClient) EnableMFA(ctx context.Context, id uuid.UUID, req user_api.EnableMFARequest) error {
+	if m.enableMFAFunc != nil {
+		return m.enableMFAFunc(ctx, id, req)
+	}
+	return nil
+}
+
+func (m *mockUserClient) DisableMFA(_ context.Context, _ uuid.UUID) error {
+	return nil
+}
+
+func (m *mockUserClient) ConsumeRecoveryCode(ctx context.Context, id uuid.UUID, codeHash string) error {
+	if m.consumeRecoveryFunc != nil {
+		return m.consumeRecoveryFunc(ctx, id, codeHash)
+	}
+	return user_api.ErrNotFound
+}
+
+func (m *mockUserClient) ScheduleDeletion(ctx context.Context, id uuid.UUID, at time.Time) error {
+	if m.scheduleDeletionFunc != nil {
+		return m.scheduleDeletionFunc(ctx, id, at)
+	}
+	return nil
+}
+
+func (m *mockUserClient) CancelDeletion(ctx context.Context, id uuid.UUID) error {
+	if m.cancelDeletionFunc != nil {
+		return m.cancelDeletionFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *mockUserClient) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	if m.deleteUserFunc != nil {
+		return m.deleteUserFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *mockUserClient) SuspendUser(ctx context.Context, id uuid.UUID, req user_api.SuspendUserRequest) error {
+	if m.suspendUserFunc != nil {
+		return m.suspendUserFunc(ctx, id, req)
+	}
+	return nil
+}
+
+func (m *mockUserClient) ReinstateUser(_ context.Context, _ uuid.UUID) error {
+	return nil
+}
+
+func (m *mockUserClient) SetRole(ctx context.Context, id uuid.UUID, role string) error {
+	if m.setRoleFunc != nil {
+		return m.setRoleFunc(ctx, id, role)
+	}
+	return nil
+}
+
 // ── Mock: repository.SessionStore ─────────────────────────────────────────────
 
 type mockSessionStore struct {
 	saveFunc   func(ctx context.Context, session *domain.Session) error
 	getFunc    func(ctx context.Context, sessionID uuid.UUID) (*domain.Session, error)
 	deleteFunc func(ctx context.Context, sessionID uuid.UUID) error
+	listFunc   func(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error)
+	rotateFunc func(ctx context.Context, sessionID uuid.UUID, presentedHash, nextHash string) error
+	touchFunc  func(ctx context.Context, sessionID uuid.UUID, lastSeenAt int64) error
 }
 
 func (m *mockSessionStore) Save(ctx context.Context, session *domain.Session) error {
@@ -101,6 +275,375 @@ func (m *mockSessionStore) Delete(ctx context.Context, sessionID uuid.UUID) erro
 	return nil
 }
 
+func (m *mockSessionStore) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	if m.listFunc != nil {
+		return m.listFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockSessionStore) Touch(ctx context.Context, sessionID uuid.UUID, lastSeenAt int64) error {
+	if m.touchFunc != nil {
+		return m.touchFunc(ctx, sessionID, lastSeenAt)
+	}
+	return nil
+}
+
+func (m *mockSessionStore) SaveRefreshToken(_ context.Context, _ *domain.Session, _ string) error {
+	return nil
+}
+
+func (m *mockSessionStore) RotateRefreshToken(ctx context.Context, sessionID uuid.UUID, presentedHash, nextHash string) error {
+	if m.rotateFunc != nil {
+		return m.rotateFunc(ctx, sessionID, presentedHash, nextHash)
+	}
+	return nil
+}
+
+// ── Mock: repository.OTPStore ─────────────────────────────────────────────────
+
+type mockOTPStore struct {
+	code string
+}
+
+func (m *mockOTPStore) Save(_ context.Context, _, _ string, _ time.Duration) error { return nil }
+func (m *mockOTPStore) Get(_ context.Context, _ string) (string, error)            { return m.code, nil }
+func (m *mockOTPStore) Delete(_ context.Context, _ string) error                   { return nil }
+func (m *mockOTPStore) Consume(_ context.Context, _, code string) (bool, error) {
+	if m.code == "" {
+		return false, redisrepo.ErrOTPNotFound
+	}
+	if m.code != code {
+		return false, nil
+	}
+	m.code = ""
+	return true, nil
+}
+func (m *mockOTPStore) IncrAttempts(_ context.Context, _ string, _ time.Duration) (int64, error) {
+	return 0, nil
+}
+func (m *mockOTPStore) SetCooldown(_ context.Context, _ string, _ time.Duration) error { return nil }
+func (m *mockOTPStore) HasCooldown(_ context.Context, _ string) (bool, error)          { return false, nil }
+
+// ── Mock: repository.MFAStore ─────────────────────────────────────────────────
+
+type mockMFAStore struct {
+	pending    map[uuid.UUID]string
+	challenges map[string]*domain.MFAChallenge
+	attempts   map[string]int64
+	usedSteps  map[string]bool
+}
+
+func (m *mockMFAStore) init() {
+	if m.pending == nil {
+		m.pending = make(map[uuid.UUID]string)
+		m.challenges = make(map[string]*domain.MFAChallenge)
+		m.attempts = make(map[string]int64)
+		m.usedSteps = make(map[string]bool)
+	}
+}
+
+func (m *mockMFAStore) SavePendingSecret(_ context.Context, userID uuid.UUID, secret string, _ time.Duration) error {
+	m.init()
+	m.pending[userID] = secret
+	return nil
+}
+
+func (m *mockMFAStore) GetPendingSecret(_ context.Context, userID uuid.UUID) (string, error) {
+	m.init()
+	secret, ok := m.pending[userID]
+	if !ok {
+		return "", redisrepo.ErrMFASetupNotFound
+	}
+	return secret, nil
+}
+
+func (m *mockMFAStore) DeletePendingSecret(_ context.Context, userID uuid.UUID) error {
+	m.init()
+	delete(m.pending, userID)
+	return nil
+}
+
+func (m *mockMFAStore) SaveChallenge(_ context.Context, tokenHash string, c *domain.MFAChallenge, _ time.Duration) error {
+	m.init()
+	m.challenges[tokenHash] = c
+	return nil
+}
+
+func (m *mockMFAStore) GetChallenge(_ context.Context, tokenHash string) (*domain.MFAChallenge, error) {
+	m.init()
+	c, ok := m.challenges[tokenHash]
+	if !ok {
+		return nil, redisrepo.ErrChallengeNotFound
+	}
+	return c, nil
+}
+
+func (m *mockMFAStore) DeleteChallenge(_ context.Context, tokenHash string) error {
+	m.init()
+	delete(m.challenges, tokenHash)
+	delete(m.attempts, tokenHash)
+	return nil
+}
+
+func (m *mockMFAStore) IncrChallengeAttempts(_ context.Context, tokenHash string, _ time.Duration) (int64, error) {
+	m.init()
+	m.attempts[tokenHash]++
+	return m.attempts[tokenHash], nil
+}
+
+func (m *mockMFAStore) MarkTOTPUsed(_ context.Context, userID uuid.UUID, step int64, _ time.Duration) (bool, error) {
+	m.init()
+	key := fmt.Sprintf("%s:%d", userID, step)
+	if m.usedSteps[key] {
+		return false, nil
+	}
+	m.usedSteps[key] = true
+	return true, nil
+}
+
+// ── Mock: repository.AuditLog ─────────────────────────────────────────────────
+
+type mockAuditLog struct {
+	events    []*domain.AuditEvent
+	recordErr error
+}
+
+func (m *mockAuditLog) Record(_ context.Context, event *domain.AuditEvent) error {
+	if m.recordErr != nil {
+		return m.recordErr
+	}
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *mockAuditLog) ListByUser(_ context.Context, userID uuid.UUID, before time.Time, beforeID uuid.UUID, limit int) ([]*domain.AuditEvent, error) {
+	var out []*domain.AuditEvent
+	for i := len(m.events) - 1; i >= 0 && len(out) < limit; i-- {
+		e := m.events[i]
+		older := e.CreatedAt.Before(before) || (e.CreatedAt.Equal(before) && bytes.Compare(e.ID[:], beforeID[:]) < 0)
+		if e.UserID == userID && older {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockAuditLog) types() []string {
+	out := make([]string, 0, len(m.events))
+	for _, e := range m.events {
+		out = append(out, e.Type)
+	}
+	return out
+}
+
+// ── Mock: repository.AttemptStore ─────────────────────────────────────────────
+
+type mockAttemptStore struct {
+	failures map[string]int64
+	blocks   map[string]string
+	ttls     map[string]time.Duration
+}
+
+func newTestLimiter() *ratelimit.Limiter {
+	return ratelimit.New(&mockAttemptStore{})
+}
+
+func (m *mockAttemptStore) init() {
+	if m.failures == nil {
+		m.failures = make(map[string]int64)
+		m.blocks = make(map[string]string)
+		m.ttls = make(map[string]time.Duration)
+	}
+}
+
+func (m *mockAttemptStore) Blocked(_ context.Context, key string) (time.Duration, string, error) {
+	m.init()
+	return m.ttls[key], m.blocks[key], nil
+}
+
+func (m *mockAttemptStore) Fail(_ context.Context, key string, _ time.Duration) (int64, error) {
+	m.init()
+	m.failures[key]++
+	return m.failures[key], nil
+}
+
+func (m *mockAttemptStore) Block(_ context.Context, key, reason string, ttl time.Duration) error {
+	m.init()
+	m.blocks[key] = reason
+	m.ttls[key] = ttl
+	return nil
+}
+
+func (m *mockAttemptStore) Reset(_ context.Context, key string) error {
+	m.init()
+	delete(m.failures, key)
+	delete(m.blocks, key)
+	delete(m.ttls, key)
+	return nil
+}
+
+// unblock имитирует истечение задержки, не трогая счётчик.
+func (m *mockAttemptStore) unblock() {
+	m.init()
+	m.blocks = make(map[string]string)
+	m.ttls = make(map[string]time.Duration)
+}
+
+// ── Mock: repository.RevocationPublisher ──────────────────────────────────────
+
+type mockRevocationPublisher struct {
+	published []revocation.Event
+}
+
+func (m *mockRevocationPublisher) Publish(_ context.Context, event revocation.Event) error {
+	m.published = append(m.published, event)
+	return nil
+}
+
+// ── Mock: email.Sender ────────────────────────────────────────────────────────
+
+type mockEmailSender struct {
+	resetSentTo       []string
+	emailChangeSentTo []string
+	lockedSentTo      []string
+	magicLinks        []string
+}
+
+func (m *mockEmailSender) Send(_ context.Context, template, to string, data email.Data) error {
+	switch template {
+	case email.TemplatePasswordReset:
+		m.resetSentTo = append(m.resetSentTo, to)
+	case email.TemplateEmailChange:
+		m.emailChangeSentTo = append(m.emailChangeSentTo, to)
+	case email.TemplateAccountLocked:
+		m.lockedSentTo = append(m.lockedSentTo, to)
+	case email.TemplateMagicLink:
+		m.magicLinks = append(m.magicLinks, data["Link"].(string))
+	}
+	return nil
+}
+
+// ── Mock: repository.MagicLinkStore / SettingsRepository ──────────────────────
+
+type mockMagicLinkStore struct {
+	used   map[string]uuid.UUID
+	reused map[string]bool
+}
+
+func newMockMagicLinkStore() *mockMagicLinkStore {
+	return &mockMagicLinkStore{used: make(map[string]uuid.UUID), reused: make(map[string]bool)}
+}
+
+func (m *mockMagicLinkStore) Consume(_ context.Context, tokenID string, _ time.Duration) (bool, error) {
+	if _, ok := m.used[tokenID]; ok {
+		return false, nil
+	}
+	m.used[tokenID] = uuid.Nil
+	return true, nil
+}
+
+func (m *mockMagicLinkStore) BindSession(_ context.Context, tokenID string, sessionID uuid.UUID, _ time.Duration) error {
+	if m.reused[tokenID] {
+		return redisrepo.ErrMagicLinkReused
+	}
+	m.used[tokenID] = sessionID
+	return nil
+}
+
+func (m *mockMagicLinkStore) MarkReused(_ context.Context, tokenID string) (uuid.UUID, error) {
+	if m.used[tokenID] == uuid.Nil {
+		m.reused[tokenID] = true
+	}
+	return m.used[tokenID], nil
+}
+
+type mockSettings struct {
+	optedOut bool
+}
+
+func (m *mockSettings) Get(_ context.Context, userID uuid.UUID) (*domain.Settings, error) {
+	return &domain.Settings{UserID: userID, MagicLinkEnabled: !m.optedOut}, nil
+}
+
+func (m *mockSettings) Save(_ context.Context, settings *domain.Settings) error {
+	m.optedOut = !settings.MagicLinkEnabled
+	return nil
+}
+
+// ── Mock: repository.OAuthStateStore / IdentityRepository ────────────────────
+
+type mockOAuthStates struct {
+	states map[string]*domain.OAuthState
+}
+
+func (m *mockOAuthStates) Save(_ context.Context, state string, data *domain.OAuthState, _ time.Duration) error {
+	if m.states == nil {
+		m.states = make(map[string]*domain.OAuthState)
+	}
+	m.states[state] = data
+	return nil
+}
+
+func (m *mockOAuthStates) Take(_ context.Context, state string) (*domain.OAuthState, error) {
+	data, ok := m.states[state]
+	if !ok {
+		return nil, redisrepo.ErrOAuthStateNotFound
+	}
+	delete(m.states, state)
+	return data, nil
+}
+
+type mockIdentities struct {
+	items     []*domain.ExternalIdentity
+	createErr error
+}
+
+func (m *mockIdentities) Get(_ context.Context, provider, subject string) (*domain.ExternalIdentity, error) {
+	for _, i := range m.items {
+		if i.Provider == provider && i.Subject == subject {
+			return i, nil
+		}
+	}
+	return nil, apperr.IdentityNotFound()
+}
+
+func (m *mockIdentities) ListByUser(_ context.Context, userID uuid.UUID) ([]*domain.ExternalIdentity, error) {
+	var out []*domain.ExternalIdentity
+	for _, i := range m.items {
+		if i.UserID == userID {
+			out = append(out, i)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockIdentities) Create(_ context.Context, identity *domain.ExternalIdentity) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	for _, i := range m.items {
+		switch {
+		case i.Provider == identity.Provider && i.Subject == identity.Subject:
+			return apperr.IdentityAlreadyLinked()
+		case i.Provider == identity.Provider && i.UserID == identity.UserID:
+			return apperr.ProviderAlreadyLinked()
+		}
+	}
+	m.items = append(m.items, identity)
+	return nil
+}
+
+func (m *mockIdentities) Delete(_ context.Context, userID uuid.UUID, provider string) error {
+	for n, i := range m.items {
+		if i.UserID == userID && i.Provider == provider {
+			m.items = append(m.items[:n], m.items[n+1:]...)
+			return nil
+		}
+	}
+	return apperr.IdentityNotFound()
+}
+
 // ── Register ──────────────────────────────────────────────────────────────────
 
 func TestAuthUsecase_Register_Success(t *testing.T) {
@@ -111,13 +654,13 @@ func TestAuthUsecase_Register_Success(t *testing.T) {
 			require.Equal(t, "alice@example.com", req.Email)
 			// Password must be hashed, not plain-text.
 			require.NotEqual(t, "Password1", req.PasswordHash)
-			require.NoError(t, bcrypt.CompareHashAndPassword([]byte(req.PasswordHash), []byte("Password1")))
+			require.NoError(t, newTestHasher().Compare("Password1", req.PasswordHash))
 			return &user_api.UserResponse{ID: userID, Username: "alice", Email: "alice@example.com"}, nil
 		},
 	}
 
 	uc := newTestUsecase(client, &mockSessionStore{})
-	resp, err := uc.Register(context.Background(), dto.RegisterUserDto{
+	resp, err := uc.Register(context.Background(), dto.RegisterUserDTO{
 		Username: "alice",
 		Email:    "alice@example.com",
 		Password: "Password1",
@@ -132,12 +675,12 @@ func TestAuthUsecase_Register_Success(t *testing.T) {
 func TestAuthUsecase_Register_UserClientError(t *testing.T) {
 	client := &mockUserClient{
 		createUserFunc: func(_ context.Context, _ user_api.CreateUserRequest) (*user_api.UserResponse, error) {
-			return nil, apperror.EmailAlreadyExists()
+			return nil, apperr.EmailAlreadyExists()
 		},
 	}
 
 	uc := newTestUsecase(client, &mockSessionStore{})
-	_, err := uc.Register(context.Background(), dto.RegisterUserDto{
+	_, err := uc.Register(context.Background(), dto.RegisterUserDTO{
 		Username: "bob",
 		Email:    "bob@example.com",
 		Password: "Password1",
@@ -146,7 +689,7 @@ func TestAuthUsecase_Register_UserClientError(t *testing.T) {
 	require.Error(t, err)
 	var appErr apperror.AppError
 	require.ErrorAs(t, err, &appErr)
-	require.Equal(t, apperror.CodeEmailExists, appErr.Code())
+	require.Equal(t, apperr.CodeEmailAlreadyExists, appErr.Code())
 }
 
 func TestAuthUsecase_Register_PasswordIsHashedBeforeSend(t *testing.T) {
@@ -160,7 +703,7 @@ func TestAuthUsecase_Register_PasswordIsHashedBeforeSend(t *testing.T) {
 	}
 
 	uc := newTestUsecase(client, &mockSessionStore{})
-	_, err := uc.Register(context.Background(), dto.RegisterUserDto{
+	_, err := uc.Register(context.Background(), dto.RegisterUserDTO{
 		Username: "validuser",
 		Email:    "u@e.com",
 		Password: plainPwd,
@@ -168,7 +711,7 @@ func TestAuthUsecase_Register_PasswordIsHashedBeforeSend(t *testing.T) {
 
 	require.NoError(t, err)
 	require.NotEqual(t, plainPwd, capturedHash, "plain password must not be sent to message service")
-	require.NoError(t, bcrypt.CompareHashAndPassword([]byte(capturedHash), []byte(plainPwd)))
+	require.NoError(t, newTestHasher().Compare(plainPwd, capturedHash))
 }
 
 // ── Login ─────────────────────────────────────────────────────────────────────
@@ -187,14 +730,15 @@ func TestAuthUsecase_Login_Success(t *testing.T) {
 	store := &mockSessionStore{}
 
 	uc := newTestUsecase(client, store)
-	token, err := uc.Login(context.Background(), dto.LoginUserDTO{
+	tokens, err := uc.Login(context.Background(), dto.LoginUserDTO{
 		Email:    "message@example.com",
 		Password: "Password1",
 		DeviceID: deviceID,
 	})
 
 	require.NoError(t, err)
-	require.NotEmpty(t, token)
+	require.NotEmpty(t, tokens.AccessToken)
+	require.NotEmpty(t, tokens.RefreshToken)
 }
 
 func TestAuthUsecase_Login_GeneratesDeviceIDWhenNil(t *testing.T) {
@@ -228,7 +772,7 @@ func TestAuthUsecase_Login_GeneratesDeviceIDWhenNil(t *testing.T) {
 func TestAuthUsecase_Login_UserNotFound(t *testing.T) {
 	client := &mockUserClient{
 		getUserByEmailFunc: func(_ context.Context, _ string) (*user_api.UserResponse, error) {
-			return nil, errors.New("message not found")
+			return nil, user_api.ErrNotFound
 		},
 	}
 
@@ -238,7 +782,7 @@ func TestAuthUsecase_Login_UserNotFound(t *testing.T) {
 	require.Error(t, err)
 	var appErr apperror.AppError
 	require.ErrorAs(t, err, &appErr)
-	require.Equal(t, apperror.CodeInvalidCredentials, appErr.Code())
+	require.Equal(t, apperr.CodeInvalidCredentials, appErr.Code())
 	require.Equal(t, 401, appErr.HTTPStatus())
 }
 
@@ -256,7 +800,7 @@ func TestAuthUsecase_Login_WrongPassword(t *testing.T) {
 	require.Error(t, err)
 	var appErr apperror.AppError
 	require.ErrorAs(t, err, &appErr)
-	require.Equal(t, apperror.CodeInvalidCredentials, appErr.Code())
+	require.Equal(t, apperr.CodeInvalidCredentials, appErr.Code())
 }
 
 func TestAuthUsecase_Login_SessionSaveFails(t *testing.T) {
@@ -292,12 +836,12 @@ func TestAuthUsecase_Login_TokenWrittenToResponse(t *testing.T) {
 	}
 
 	uc := newTestUsecase(client, &mockSessionStore{})
-	token, err := uc.Login(context.Background(), dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+	tokens, err := uc.Login(context.Background(), dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
 
 	require.NoError(t, err)
 	// Token is a non-empty JWT (three dot-separated segments).
-	require.Contains(t, token, ".")
-	parts := len(splitDots(token))
+	require.Contains(t, tokens.AccessToken, ".")
+	parts := len(splitDots(tokens.AccessToken))
 	require.Equal(t, 3, parts, "JWT must have 3 parts separated by '.'")
 }
 
@@ -377,12 +921,12 @@ func TestAuthUsecase_Logout_DeleteFails(t *testing.T) {
 // ── AuthenticateRequest ───────────────────────────────────────────────────────
 
 func TestAuthUsecase_AuthenticateRequest_Success(t *testing.T) {
-	jm := jwtpkg.NewManager(testJWTSecret, nil)
+	jm := newTestJWTManager()
 	userID := uuid.New()
 	deviceID := uuid.New()
 	sessionID := uuid.New()
 
-	token, err := jm.Generate(userID, deviceID, sessionID)
+	token, err := jm.Generate(userID, deviceID, sessionID, "user")
 	require.NoError(t, err)
 
 	store := &mockSessionStore{
@@ -425,9 +969,9 @@ func TestAuthUsecase_AuthenticateRequest_EmptyToken(t *testing.T) {
 	require.Equal(t, 401, appErr.HTTPStatus())
 }
 
-func TestAuthUsecase_AuthenticateRequest_TokenSignedWithWrongSecret(t *testing.T) {
-	otherJM := jwtpkg.NewManager("completely-different-secret-value!!", nil)
-	token, err := otherJM.Generate(uuid.New(), uuid.New(), uuid.New())
+func TestAuthUsecase_AuthenticateRequest_TokenSignedWithWrongKey(t *testing.T) {
+	otherJM := newJWTManagerFromSeed(2)
+	token, err := otherJM.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
 	require.NoError(t, err)
 
 	uc := newTestUsecase(&mockUserClient{}, &mockSessionStore{})
@@ -440,8 +984,8 @@ func TestAuthUsecase_AuthenticateRequest_TokenSignedWithWrongSecret(t *testing.T
 }
 
 func TestAuthUsecase_AuthenticateRequest_SessionNotFound(t *testing.T) {
-	jm := jwtpkg.NewManager(testJWTSecret, nil)
-	token, err := jm.Generate(uuid.New(), uuid.New(), uuid.New())
+	jm := newTestJWTManager()
+	token, err := jm.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
 	require.NoError(t, err)
 
 	store := &mockSessionStore{
@@ -460,11 +1004,11 @@ func TestAuthUsecase_AuthenticateRequest_SessionNotFound(t *testing.T) {
 }
 
 func TestAuthUsecase_AuthenticateRequest_ExpiredSession(t *testing.T) {
-	jm := jwtpkg.NewManager(testJWTSecret, nil)
+	jm := newTestJWTManager()
 	userID := uuid.New()
 	deviceID := uuid.New()
 	sessionID := uuid.New()
-	token, err := jm.Generate(userID, deviceID, sessionID)
+	token, err := jm.Generate(userID, deviceID, sessionID, "user")
 	require.NoError(t, err)
 
 	deleteCalled := false
@@ -490,5 +1034,1591 @@ func TestAuthUsecase_AuthenticateRequest_ExpiredSession(t *testing.T) {
 	require.True(t, deleteCalled, "expired session must be deleted")
 	var appErr apperror.AppError
 	require.ErrorAs(t, err, &appErr)
-	require.Equal(t, apperror.CodeSessionExpired, appErr.Code())
+	require.Equal(t, apperr.CodeSessionExpired, appErr.Code())
+}
+
+// ── IntrospectSession ─────────────────────────────────────────────────────────
+
+func activeSessionStore(sessionID, userID uuid.UUID, deleted *bool) *mockSessionStore {
+	return &mockSessionStore{
+		getFunc: func(_ context.Context, _ uuid.UUID) (*domain.Session, error) {
+			return &domain.Session{
+				SessionID: sessionID,
+				UserID:    userID,
+				Expires:   time.Now().Add(time.Hour).Unix(),
+			}, nil
+		},
+		deleteFunc: func(_ context.Context, _ uuid.UUID) error {
+			*deleted = true
+			return nil
+		},
+	}
+}
+
+func TestAuthUsecase_IntrospectSession_Active(t *testing.T) {
+	sessionID, userID := uuid.New(), uuid.New()
+	deleted := false
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+			require.Equal(t, userID, id)
+			return &user_api.UserResponse{ID: userID, Status: "active"}, nil
+		},
+	}
+
+	uc := newTestUsecase(client, activeSessionStore(sessionID, userID, &deleted))
+	session, err := uc.IntrospectSession(context.Background(), sessionID)
+
+	require.NoError(t, err)
+	require.Equal(t, userID, session.UserID)
+	require.False(t, deleted)
+}
+
+func TestAuthUsecase_IntrospectSession_SessionNotFound(t *testing.T) {
+	store := &mockSessionStore{
+		getFunc: func(_ context.Context, id uuid.UUID) (*domain.Session, error) {
+			return nil, fmt.Errorf("%w: %s", redisrepo.ErrSessionNotFound, id)
+		},
+	}
+
+	uc := newTestUsecase(&mockUserClient{}, store)
+	_, err := uc.IntrospectSession(context.Background(), uuid.New())
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeSessionRevoked, appErr.Code())
+	require.Equal(t, 401, appErr.HTTPStatus())
+}
+
+func TestAuthUsecase_IntrospectSession_BlockedUserRevokesSession(t *testing.T) {
+	sessionID, userID := uuid.New(), uuid.New()
+	deleted := false
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, _ uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: userID, Status: "blocked"}, nil
+		},
+	}
+	revocations := &mockRevocationPublisher{}
+
+	uc := NewAuthUsecase(
+		client,
+		activeSessionStore(sessionID, userID, &deleted),
+		&mockOTPStore{},
+		&mockOTPStore{},
+		&mockOTPStore{},
+		&mockMFAStore{},
+		newTestLimiter(),
+		&mockAuditLog{},
+		revocations,
+		&mockEmailSender{},
+		newTestJWTManager(),
+		newTestSecretBox(),
+		newTestHasher(),
+		newTestMagicLinks(),
+		newMockMagicLinkStore(),
+		&mockSettings{},
+		nil,
+		&mockOAuthStates{},
+		&mockIdentities{},
+		time.Hour,
+		30*24*time.Hour,
+		"PushPost",
+	)
+	_, err := uc.IntrospectSession(context.Background(), sessionID)
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeAccountBlocked, appErr.Code())
+	require.True(t, deleted, "session of a blocked user must be deleted")
+	require.Len(t, revocations.published, 1)
+	require.Equal(t, sessionID, revocations.published[0].SessionID)
+}
+
+func TestAuthUsecase_IntrospectSession_DeletedUser(t *testing.T) {
+	sessionID, userID := uuid.New(), uuid.New()
+	deleted := false
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, _ uuid.UUID) (*user_api.UserResponse, error) {
+			return nil, apperror.Forbidden("user_deleted", "account has been deleted")
+		},
+	}
+
+	uc := newTestUsecase(client, activeSessionStore(sessionID, userID, &deleted))
+	_, err := uc.IntrospectSession(context.Background(), sessionID)
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeAccountDeleted, appErr.Code())
+	require.True(t, deleted)
+}
+
+func TestAuthUsecase_IntrospectSession_UserServiceDown(t *testing.T) {
+	sessionID, userID := uuid.New(), uuid.New()
+	deleted := false
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, _ uuid.UUID) (*user_api.UserResponse, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+
+	uc := newTestUsecase(client, activeSessionStore(sessionID, userID, &deleted))
+	_, err := uc.IntrospectSession(context.Background(), sessionID)
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, 500, appErr.HTTPStatus())
+	require.False(t, deleted, "session must survive an upstream outage")
+}
+
+func TestAuthUsecase_IntrospectSession_ExpiredSessionIsDeleted(t *testing.T) {
+	sessionID, userID := uuid.New(), uuid.New()
+	deleted := false
+	store := &mockSessionStore{
+		getFunc: func(_ context.Context, _ uuid.UUID) (*domain.Session, error) {
+			return &domain.Session{
+				SessionID: sessionID,
+				UserID:    userID,
+				Expires:   time.Now().Add(-time.Minute).Unix(),
+			}, nil
+		},
+		deleteFunc: func(_ context.Context, id uuid.UUID) error {
+			require.Equal(t, sessionID, id)
+			deleted = true
+			return nil
+		},
+	}
+
+	uc := newTestUsecase(&mockUserClient{}, store)
+	_, err := uc.IntrospectSession(context.Background(), sessionID)
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeSessionExpired, appErr.Code())
+	require.True(t, deleted, "expired session must be deleted")
+}
+
+func TestAuthUsecase_Login_UnverifiedAccount(t *testing.T) {
+	hash := lowCostHash(t, "Password1")
+	client := &mockUserClient{
+		getUserByEmailFunc: func(_ context.Context, email string) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: uuid.New(), Email: email, PasswordHash: hash, Status: "pending"}, nil
+		},
+	}
+	store := &mockSessionStore{
+		saveFunc: func(_ context.Context, _ *domain.Session) error {
+			t.Fatal("no session must be created for an unverified account")
+			return nil
+		},
+	}
+
+	uc := newTestUsecase(client, store)
+	_, err := uc.Login(context.Background(), dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeAccountNotVerified, appErr.Code())
+}
+
+// ── Refresh ───────────────────────────────────────────────────────────────────
+
+func TestAuthUsecase_Refresh_RotatesAndDetectsReuse(t *testing.T) {
+	hash := lowCostHash(t, "Password1")
+	userID := uuid.New()
+	client := &mockUserClient{
+		getUserByEmailFunc: func(_ context.Context, _ string) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: userID, PasswordHash: hash, Status: "active"}, nil
+		},
+		getUserByIDFunc: func(_ context.Context, _ uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: userID, Status: "active"}, nil
+		},
+	}
+	store := memory.NewSessionStore()
+	revocations := &mockRevocationPublisher{}
+
+	uc := NewAuthUsecase(client, store, &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockMFAStore{}, newTestLimiter(), &mockAuditLog{}, revocations, &mockEmailSender{},
+		newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+
+	first, err := uc.Login(context.Background(), dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+	require.NoError(t, err)
+
+	second, err := uc.Refresh(context.Background(), first.RefreshToken)
+	require.NoError(t, err)
+	require.NotEqual(t, first.RefreshToken, second.RefreshToken, "refresh token must rotate")
+	require.NotEmpty(t, second.AccessToken)
+
+	// Replaying the rotated token revokes the whole session.
+	_, err = uc.Refresh(context.Background(), first.RefreshToken)
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeRefreshReused, appErr.Code())
+	require.Len(t, revocations.published, 1)
+
+	sessionID, err := refreshtoken.SessionID(first.RefreshToken)
+	require.NoError(t, err)
+	_, err = store.Get(context.Background(), sessionID)
+	require.Error(t, err, "session must be deleted after reuse")
+}
+
+func TestAuthUsecase_Refresh_MalformedToken(t *testing.T) {
+	uc := newTestUsecase(&mockUserClient{}, &mockSessionStore{})
+	_, err := uc.Refresh(context.Background(), "garbage")
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeRefreshInvalid, appErr.Code())
+}
+
+func TestAuthUsecase_Refresh_UnknownTokenKeepsSession(t *testing.T) {
+	sessionID, userID := uuid.New(), uuid.New()
+	deleted := false
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, _ uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: userID, Status: "active"}, nil
+		},
+	}
+	store := activeSessionStore(sessionID, userID, &deleted)
+	store.rotateFunc = func(_ context.Context, _ uuid.UUID, _, _ string) error {
+		return authrep.ErrRefreshTokenInvalid
+	}
+
+	token, err := refreshtoken.Generate(sessionID)
+	require.NoError(t, err)
+
+	uc := newTestUsecase(client, store)
+	_, err = uc.Refresh(context.Background(), token)
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeRefreshInvalid, appErr.Code())
+	require.False(t, deleted, "a forged token must not revoke the session")
+}
+
+func TestAuthUsecase_Refresh_DoesNotRecreateRevokedSession(t *testing.T) {
+	sessionID, userID := uuid.New(), uuid.New()
+	deleted := false
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, _ uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: userID, Status: "active"}, nil
+		},
+	}
+	store := activeSessionStore(sessionID, userID, &deleted)
+	touched := false
+	store.saveFunc = func(_ context.Context, _ *domain.Session) error {
+		t.Fatal("refresh must not write the whole session back")
+		return nil
+	}
+	// Сессию отозвали между ротацией и обновлением last-seen.
+	store.touchFunc = func(_ context.Context, id uuid.UUID, _ int64) error {
+		require.Equal(t, sessionID, id)
+		touched = true
+		return errors.New("session not found")
+	}
+
+	token, err := refreshtoken.Generate(sessionID)
+	require.NoError(t, err)
+
+	uc := newTestUsecase(client, store)
+	_, err = uc.Refresh(context.Background(), token)
+	require.NoError(t, err)
+	require.True(t, touched)
+}
+
+// ── Sessions management ───────────────────────────────────────────────────────
+
+func TestAuthUsecase_ListSessions_SkipsExpiredAndSortsByLastSeen(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+	older := &domain.Session{SessionID: uuid.New(), UserID: userID, Expires: now.Add(time.Hour).Unix(), LastSeenAt: now.Add(-time.Hour).Unix()}
+	newer := &domain.Session{SessionID: uuid.New(), UserID: userID, Expires: now.Add(time.Hour).Unix(), LastSeenAt: now.Unix()}
+	expired := &domain.Session{SessionID: uuid.New(), UserID: userID, Expires: now.Add(-time.Minute).Unix()}
+
+	store := &mockSessionStore{
+		listFunc: func(_ context.Context, id uuid.UUID) ([]*domain.Session, error) {
+			require.Equal(t, userID, id)
+			return []*domain.Session{older, expired, newer}, nil
+		},
+	}
+
+	uc := newTestUsecase(&mockUserClient{}, store)
+	sessions, err := uc.ListSessions(context.Background(), userID)
+
+	require.NoError(t, err)
+	require.Equal(t, []*domain.Session{newer, older}, sessions)
+}
+
+func TestAuthUsecase_RevokeSession_ForeignSessionNotFound(t *testing.T) {
+	sessionID, ownerID := uuid.New(), uuid.New()
+	deleted := false
+
+	uc := newTestUsecase(&mockUserClient{}, activeSessionStore(sessionID, ownerID, &deleted))
+	err := uc.RevokeSession(context.Background(), uuid.New(), sessionID)
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeSessionNotFound, appErr.Code())
+	require.False(t, deleted, "foreign session must not be revoked")
+}
+
+func TestAuthUsecase_RevokeOtherSessions_KeepsCurrent(t *testing.T) {
+	userID := uuid.New()
+	store := memory.NewSessionStore()
+	ctx := context.Background()
+
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	for _, id := range ids {
+		require.NoError(t, store.Save(ctx, &domain.Session{SessionID: id, UserID: userID, Expires: time.Now().Add(time.Hour).Unix()}))
+	}
+	require.NoError(t, store.Save(ctx, &domain.Session{SessionID: uuid.New(), UserID: uuid.New(), Expires: time.Now().Add(time.Hour).Unix()}))
+
+	uc := newTestUsecase(&mockUserClient{}, store)
+	revoked, err := uc.RevokeOtherSessions(ctx, userID, ids[0])
+
+	require.NoError(t, err)
+	require.Equal(t, 2, revoked)
+
+	left, err := store.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, left, 1)
+	require.Equal(t, ids[0], left[0].SessionID)
+}
+
+// ── Password reset ────────────────────────────────────────────────────────────
+
+func TestAuthUsecase_ForgotPassword_UnknownEmailIsSilent(t *testing.T) {
+	client := &mockUserClient{
+		getUserByEmailFunc: func(_ context.Context, _ string) (*user_api.UserResponse, error) {
+			return nil, user_api.ErrNotFound
+		},
+	}
+	sender := &mockEmailSender{}
+
+	uc := NewAuthUsecase(client, &mockSessionStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockMFAStore{}, newTestLimiter(), &mockAuditLog{}, &mockRevocationPublisher{}, sender,
+		newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+
+	require.NoError(t, uc.ForgotPassword(context.Background(), "nobody@example.com"))
+	require.Empty(t, sender.resetSentTo)
+}
+
+func TestAuthUsecase_ForgotPassword_SendsCode(t *testing.T) {
+	client := &mockUserClient{
+		getUserByEmailFunc: func(_ context.Context, email string) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: uuid.New(), Email: email, Status: "active"}, nil
+		},
+	}
+	sender := &mockEmailSender{}
+
+	uc := NewAuthUsecase(client, &mockSessionStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockMFAStore{}, newTestLimiter(), &mockAuditLog{}, &mockRevocationPublisher{}, sender,
+		newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+
+	require.NoError(t, uc.ForgotPassword(context.Background(), "u@e.com"))
+	require.Equal(t, []string{"u@e.com"}, sender.resetSentTo)
+}
+
+func TestAuthUsecase_ResetPassword_UpdatesHashAndRevokesSessions(t *testing.T) {
+	userID := uuid.New()
+	var updatedHash string
+	client := &mockUserClient{
+		getUserByEmailFunc: func(_ context.Context, _ string) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: userID, Status: "active"}, nil
+		},
+		updatePasswordFunc: func(_ context.Context, id uuid.UUID, hash string) error {
+			require.Equal(t, userID, id)
+			updatedHash = hash
+			return nil
+		},
+	}
+	store := memory.NewSessionStore()
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		require.NoError(t, store.Save(ctx, &domain.Session{SessionID: uuid.New(), UserID: userID, Expires: time.Now().Add(time.Hour).Unix()}))
+	}
+	revocations := &mockRevocationPublisher{}
+
+	uc := NewAuthUsecase(client, store, &mockOTPStore{}, &mockOTPStore{code: "123456"}, &mockOTPStore{}, &mockMFAStore{}, newTestLimiter(), &mockAuditLog{}, revocations, &mockEmailSender{},
+		newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+
+	require.NoError(t, uc.ResetPassword(ctx, "u@e.com", "123456", "NewPassword1"))
+	require.NoError(t, newTestHasher().Compare("NewPassword1", updatedHash))
+
+	left, err := store.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Empty(t, left, "all sessions must be revoked")
+	require.Len(t, revocations.published, 1)
+	require.True(t, revocations.published[0].AllSessions())
+}
+
+func TestAuthUsecase_ResetPassword_WrongCode(t *testing.T) {
+	updated := false
+	client := &mockUserClient{
+		updatePasswordFunc: func(_ context.Context, _ uuid.UUID, _ string) error {
+			updated = true
+			return nil
+		},
+	}
+
+	uc := NewAuthUsecase(client, &mockSessionStore{}, &mockOTPStore{}, &mockOTPStore{code: "123456"}, &mockOTPStore{}, &mockMFAStore{}, newTestLimiter(), &mockAuditLog{}, &mockRevocationPublisher{}, &mockEmailSender{},
+		newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+
+	err := uc.ResetPassword(context.Background(), "u@e.com", "000000", "NewPassword1")
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeOTPInvalid, appErr.Code())
+	require.False(t, updated)
+}
+
+func TestAuthUsecase_ResetPassword_CodeIsSingleUse(t *testing.T) {
+	updates := 0
+	client := &mockUserClient{
+		getUserByEmailFunc: func(_ context.Context, _ string) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: uuid.New(), Status: "active"}, nil
+		},
+		updatePasswordFunc: func(_ context.Context, _ uuid.UUID, _ string) error {
+			updates++
+			return nil
+		},
+	}
+
+	uc := NewAuthUsecase(client, memory.NewSessionStore(), &mockOTPStore{}, &mockOTPStore{code: "123456"}, &mockOTPStore{}, &mockMFAStore{}, newTestLimiter(), &mockAuditLog{}, &mockRevocationPublisher{}, &mockEmailSender{},
+		newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+
+	require.NoError(t, uc.ResetPassword(context.Background(), "u@e.com", "123456", "NewPassword1"))
+	err := uc.ResetPassword(context.Background(), "u@e.com", "123456", "OtherPassword1")
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeOTPExpired, appErr.Code())
+	require.Equal(t, 1, updates)
+}
+
+// ── Change password / email ───────────────────────────────────────────────────
+
+func TestAuthUsecase_ChangePassword_WrongCurrentPassword(t *testing.T) {
+	hash := lowCostHash(t, "Password1")
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: id, PasswordHash: hash, Status: "active"}, nil
+		},
+		updatePasswordFunc: func(_ context.Context, _ uuid.UUID, _ string) error {
+			t.Fatal("password must not be updated")
+			return nil
+		},
+	}
+
+	uc := newTestUsecase(client, &mockSessionStore{})
+	err := uc.ChangePassword(context.Background(), uuid.New(), uuid.New(), "WrongPass1", "NewPassword1")
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeInvalidCurrentPassword, appErr.Code())
+}
+
+func TestAuthUsecase_ChangePassword_CurrentPasswordIsRateLimited(t *testing.T) {
+	hash := lowCostHash(t, "Password1")
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: id, Email: "u@e.com", PasswordHash: hash, Status: "active"}, nil
+		},
+		updatePasswordFunc: func(_ context.Context, _ uuid.UUID, _ string) error {
+			t.Fatal("password must not be updated")
+			return nil
+		},
+	}
+
+	uc := newTestUsecase(client, &mockSessionStore{})
+	userID := uuid.New()
+
+	for i := int64(0); i <= ratelimit.LoginAccount.FreeAttempts; i++ {
+		_ = uc.ChangePassword(context.Background(), userID, uuid.New(), "WrongPass1", "NewPassword1")
+	}
+
+	// Даже верный пароль не проверяется, пока действует задержка.
+	err := uc.ChangePassword(context.Background(), userID, uuid.New(), "Password1", "NewPassword1")
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeTooManyLoginAttempts, appErr.Code())
+}
+
+func TestAuthUsecase_ChangePassword_RevokesOtherSessions(t *testing.T) {
+	userID := uuid.New()
+	hash := lowCostHash(t, "Password1")
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: id, PasswordHash: hash, Status: "active"}, nil
+		},
+	}
+	store := memory.NewSessionStore()
+	ctx := context.Background()
+	current := uuid.New()
+	for _, id := range []uuid.UUID{current, uuid.New()} {
+		require.NoError(t, store.Save(ctx, &domain.Session{SessionID: id, UserID: userID, Expires: time.Now().Add(time.Hour).Unix()}))
+	}
+
+	uc := newTestUsecase(client, store)
+	require.NoError(t, uc.ChangePassword(ctx, userID, current, "Password1", "NewPassword1"))
+
+	left, err := store.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, left, 1)
+	require.Equal(t, current, left[0].SessionID)
+}
+
+func TestAuthUsecase_RequestEmailChange_EmailTaken(t *testing.T) {
+	userID := uuid.New()
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: id, Email: "old@e.com", Status: "active"}, nil
+		},
+		getUserByEmailFunc: func(_ context.Context, email string) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: uuid.New(), Email: email}, nil
+		},
+	}
+	sender := &mockEmailSender{}
+
+	uc := NewAuthUsecase(client, &mockSessionStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockMFAStore{}, newTestLimiter(), &mockAuditLog{}, &mockRevocationPublisher{}, sender,
+		newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+	err := uc.RequestEmailChange(context.Background(), userID, "taken@e.com")
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeEmailAlreadyExists, appErr.Code())
+	require.Empty(t, sender.emailChangeSentTo)
+}
+
+func TestAuthUsecase_RequestEmailChange_SameAddressDifferentCase(t *testing.T) {
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: id, Email: "old@e.com", Status: "active"}, nil
+		},
+		getUserByEmailFunc: func(_ context.Context, email string) (*user_api.UserResponse, error) {
+			t.Fatal("own address must not be checked for availability")
+			return nil, nil
+		},
+	}
+	sender := &mockEmailSender{}
+
+	uc := NewAuthUsecase(client, &mockSessionStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockMFAStore{}, newTestLimiter(), &mockAuditLog{}, &mockRevocationPublisher{}, sender,
+		newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+	err := uc.RequestEmailChange(context.Background(), uuid.New(), " Old@E.com ")
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeEmailUnchanged, appErr.Code())
+	require.Empty(t, sender.emailChangeSentTo)
+}
+
+func TestAuthUsecase_ConfirmEmailChange_Success(t *testing.T) {
+	userID := uuid.New()
+	var changedTo string
+	client := &mockUserClient{
+		changeEmailFunc: func(_ context.Context, id uuid.UUID, email string) error {
+			require.Equal(t, userID, id)
+			changedTo = email
+			return nil
+		},
+	}
+
+	uc := NewAuthUsecase(client, &mockSessionStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{code: "654321"}, &mockMFAStore{}, newTestLimiter(), &mockAuditLog{}, &mockRevocationPublisher{}, &mockEmailSender{},
+		newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+
+	require.NoError(t, uc.ConfirmEmailChange(context.Background(), userID, uuid.New(), " New@E.com", "654321"))
+	require.Equal(t, "new@e.com", changedTo)
+}
+
+// ── Two-factor authentication ─────────────────────────────────────────────────
+
+// activeUserClient — активный пользователь с паролем "Password1" и любым email.
+func activeUserClient(t *testing.T, userID uuid.UUID) *mockUserClient {
+	hash := lowCostHash(t, "Password1")
+	return &mockUserClient{
+		getUserByEmailFunc: func(_ context.Context, email string) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: userID, Email: email, PasswordHash: hash, Status: "active"}, nil
+		},
+	}
+}
+
+func mfaUserClient(t *testing.T, userID uuid.UUID, sealedSecret string) *mockUserClient {
+	client := activeUserClient(t, userID)
+	client.getMFAFunc = func(_ context.Context, id uuid.UUID) (*user_api.MFAResponse, error) {
+		return &user_api.MFAResponse{UserID: id, TOTPSecret: sealedSecret}, nil
+	}
+	// LoginMFA перечитывает пользователя после второго фактора
+	client.getUserByIDFunc = func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+		return &user_api.UserResponse{ID: id, Email: "u@e.com", PasswordHash: lowCostHash(t, "Password1"), Status: "active"}, nil
+	}
+	return client
+}
+
+func newMFATestUsecase(client user_api.Client, store authrep.SessionStore, mfaStore authrep.MFAStore) *AuthUsecase {
+	return NewAuthUsecase(client, store, &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{}, mfaStore, newTestLimiter(), &mockAuditLog{}, &mockRevocationPublisher{}, &mockEmailSender{},
+		newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+}
+
+func TestAuthUsecase_Login_MFAEnabled_RequiresSecondFactor(t *testing.T) {
+	userID := uuid.New()
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	sealed, err := newTestSecretBox().Seal([]byte(secret))
+	require.NoError(t, err)
+
+	store := memory.NewSessionStore()
+	uc := newMFATestUsecase(mfaUserClient(t, userID, sealed), store, &mockMFAStore{})
+	ctx := context.Background()
+
+	result, err := uc.Login(ctx, dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+	require.NoError(t, err)
+	require.True(t, result.MFARequired)
+	require.NotEmpty(t, result.ChallengeToken)
+	require.Nil(t, result.TokenPairDTO)
+
+	sessions, err := store.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Empty(t, sessions, "no session must exist before the second factor")
+
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	tokens, err := uc.LoginMFA(ctx, dto.LoginMFADTO{ChallengeToken: result.ChallengeToken, Code: code})
+	require.NoError(t, err)
+	require.NotEmpty(t, tokens.AccessToken)
+
+	// challenge одноразовый
+	_, err = uc.LoginMFA(ctx, dto.LoginMFADTO{ChallengeToken: result.ChallengeToken, Code: code})
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeMFAChallengeInvalid, appErr.Code())
+}
+
+func TestAuthUsecase_LoginMFA_RejectsReplayedCode(t *testing.T) {
+	userID := uuid.New()
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	sealed, err := newTestSecretBox().Seal([]byte(secret))
+	require.NoError(t, err)
+
+	uc := newMFATestUsecase(mfaUserClient(t, userID, sealed), memory.NewSessionStore(), &mockMFAStore{})
+	ctx := context.Background()
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	first, err := uc.Login(ctx, dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+	require.NoError(t, err)
+	_, err = uc.LoginMFA(ctx, dto.LoginMFADTO{ChallengeToken: first.ChallengeToken, Code: code})
+	require.NoError(t, err)
+
+	second, err := uc.Login(ctx, dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+	require.NoError(t, err)
+	_, err = uc.LoginMFA(ctx, dto.LoginMFADTO{ChallengeToken: second.ChallengeToken, Code: code})
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeMFACodeInvalid, appErr.Code())
+}
+
+func TestAuthUsecase_LoginMFA_RecoveryCode(t *testing.T) {
+	userID := uuid.New()
+	client := mfaUserClient(t, userID, "")
+	var consumed string
+	client.consumeRecoveryFunc = func(_ context.Context, _ uuid.UUID, codeHash string) error {
+		consumed = codeHash
+		return nil
+	}
+
+	uc := newMFATestUsecase(client, memory.NewSessionStore(), &mockMFAStore{})
+	ctx := context.Background()
+
+	result, err := uc.Login(ctx, dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+	require.NoError(t, err)
+
+	tokens, err := uc.LoginMFA(ctx, dto.LoginMFADTO{ChallengeToken: result.ChallengeToken, RecoveryCode: "ABCDE-FGHJK"})
+	require.NoError(t, err)
+	require.NotEmpty(t, tokens.AccessToken)
+	require.Equal(t, totp.HashRecoveryCode("abcde-fghjk"), consumed)
+}
+
+func TestAuthUsecase_LoginMFA_SuspendedDuringChallenge(t *testing.T) {
+	userID := uuid.New()
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	sealed, err := newTestSecretBox().Seal([]byte(secret))
+	require.NoError(t, err)
+
+	client := mfaUserClient(t, userID, sealed)
+	store := memory.NewSessionStore()
+	uc := newMFATestUsecase(client, store, &mockMFAStore{})
+	ctx := context.Background()
+
+	result, err := uc.Login(ctx, dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+	require.NoError(t, err)
+
+	// Аккаунт заблокировали, пока пользователь вводил код.
+	client.getUserByIDFunc = func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+		return &user_api.UserResponse{ID: id, Status: "blocked"}, nil
+	}
+
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	_, err = uc.LoginMFA(ctx, dto.LoginMFADTO{ChallengeToken: result.ChallengeToken, Code: code})
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeAccountSuspended, appErr.Code())
+
+	sessions, err := store.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
+
+func TestAuthUsecase_DisableMFA_RevokesOtherSessions(t *testing.T) {
+	userID, currentID := uuid.New(), uuid.New()
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	sealed, err := newTestSecretBox().Seal([]byte(secret))
+	require.NoError(t, err)
+
+	store := memory.NewSessionStore()
+	ctx := context.Background()
+	expires := time.Now().Add(time.Hour).Unix()
+	require.NoError(t, store.Save(ctx, &domain.Session{SessionID: currentID, UserID: userID, Expires: expires}))
+	require.NoError(t, store.Save(ctx, &domain.Session{SessionID: uuid.New(), UserID: userID, Expires: expires}))
+
+	uc := newMFATestUsecase(mfaUserClient(t, userID, sealed), store, &mockMFAStore{})
+
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, uc.DisableMFA(ctx, userID, currentID, "Password1", code, ""))
+
+	left, err := store.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, left, 1)
+	require.Equal(t, currentID, left[0].SessionID)
+}
+
+func TestAuthUsecase_LoginMFA_TooManyAttempts(t *testing.T) {
+	userID := uuid.New()
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	sealed, err := newTestSecretBox().Seal([]byte(secret))
+	require.NoError(t, err)
+
+	uc := newMFATestUsecase(mfaUserClient(t, userID, sealed), memory.NewSessionStore(), &mockMFAStore{})
+	ctx := context.Background()
+
+	result, err := uc.Login(ctx, dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+	require.NoError(t, err)
+
+	var appErr apperror.AppError
+	for i := 0; i < maxMFAAttempts; i++ {
+		_, err = uc.LoginMFA(ctx, dto.LoginMFADTO{ChallengeToken: result.ChallengeToken, RecoveryCode: "wrong"})
+		require.ErrorAs(t, err, &appErr)
+		require.Equal(t, apperr.CodeMFACodeInvalid, appErr.Code())
+	}
+
+	_, err = uc.LoginMFA(ctx, dto.LoginMFADTO{ChallengeToken: result.ChallengeToken, RecoveryCode: "wrong"})
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeTooManyMFAAttempts, appErr.Code())
+}
+
+func TestAuthUsecase_SetupAndConfirmMFA(t *testing.T) {
+	userID := uuid.New()
+	var enabled user_api.EnableMFARequest
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: id, Email: "u@e.com", Status: "active"}, nil
+		},
+		enableMFAFunc: func(_ context.Context, _ uuid.UUID, req user_api.EnableMFARequest) error {
+			enabled = req
+			return nil
+		},
+	}
+	uc := newMFATestUsecase(client, &mockSessionStore{}, &mockMFAStore{})
+	ctx := context.Background()
+
+	setup, err := uc.SetupMFA(ctx, userID)
+	require.NoError(t, err)
+	require.Contains(t, setup.URI, "secret="+setup.Secret)
+
+	code, err := totp.GenerateCode(setup.Secret, time.Now())
+	require.NoError(t, err)
+
+	codes, err := uc.ConfirmMFA(ctx, userID, code)
+	require.NoError(t, err)
+	require.Len(t, codes, totp.RecoveryCodeCount)
+	require.Len(t, enabled.RecoveryCodeHashes, totp.RecoveryCodeCount)
+	require.Equal(t, totp.HashRecoveryCode(codes[0]), enabled.RecoveryCodeHashes[0])
+	require.NotEqual(t, setup.Secret, enabled.TOTPSecret, "secret must be stored encrypted")
+
+	_, err = uc.ConfirmMFA(ctx, userID, code)
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeMFASetupExpired, appErr.Code())
+}
+
+// ── Brute-force protection ────────────────────────────────────────────────────
+
+func newLimitedTestUsecase(client user_api.Client, attempts *mockAttemptStore, sender *mockEmailSender) *AuthUsecase {
+	return NewAuthUsecase(client, memory.NewSessionStore(), &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockMFAStore{},
+		ratelimit.New(attempts), &mockAuditLog{}, &mockRevocationPublisher{}, sender, newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+}
+
+func TestAuthUsecase_Login_ProgressiveDelay(t *testing.T) {
+	client := activeUserClient(t, uuid.New())
+	attempts := &mockAttemptStore{}
+	uc := newLimitedTestUsecase(client, attempts, &mockEmailSender{})
+	ctx := context.Background()
+	req := dto.LoginUserDTO{Email: "u@e.com", Password: "WrongPass1", IP: "10.0.0.1"}
+
+	for i := int64(0); i < ratelimit.LoginAccount.FreeAttempts; i++ {
+		_, err := uc.Login(ctx, req)
+		var appErr apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		require.Equal(t, apperr.CodeInvalidCredentials, appErr.Code())
+	}
+
+	// следующая неудача включает задержку
+	_, err := uc.Login(ctx, req)
+	require.Error(t, err)
+
+	req.Password = "Password1"
+	_, err = uc.Login(ctx, req)
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeTooManyLoginAttempts, appErr.Code())
+
+	var retryErr apperror.RetryAfterError
+	require.ErrorAs(t, err, &retryErr)
+	require.Equal(t, ratelimit.LoginAccount.BaseDelay, retryErr.RetryAfter())
+
+	attempts.unblock()
+	result, err := uc.Login(ctx, req)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.AccessToken)
+	require.Zero(t, attempts.failures["login_account:u@e.com"], "successful login must reset the account counter")
+	require.NotZero(t, attempts.failures["login_ip:10.0.0.1"], "ip counter must survive a successful login")
+}
+
+func TestAuthUsecase_Login_LockoutNotifiesOwner(t *testing.T) {
+	client := activeUserClient(t, uuid.New())
+	attempts := &mockAttemptStore{}
+	sender := &mockEmailSender{}
+	uc := newLimitedTestUsecase(client, attempts, sender)
+	ctx := context.Background()
+
+	for i := int64(0); i < ratelimit.LoginAccount.LockoutAfter; i++ {
+		attempts.unblock()
+		_, err := uc.Login(ctx, dto.LoginUserDTO{Email: "U@e.com", Password: "WrongPass1"})
+		require.Error(t, err)
+	}
+
+	require.Equal(t, []string{"U@e.com"}, sender.lockedSentTo)
+
+	_, err := uc.Login(ctx, dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeAccountLocked, appErr.Code())
+	require.Equal(t, 429, appErr.HTTPStatus())
+}
+
+func TestAuthUsecase_Login_UnknownEmailCountsWithoutNotification(t *testing.T) {
+	client := &mockUserClient{
+		getUserByEmailFunc: func(_ context.Context, _ string) (*user_api.UserResponse, error) {
+			return nil, user_api.ErrNotFound
+		},
+	}
+	attempts := &mockAttemptStore{}
+	sender := &mockEmailSender{}
+	uc := newLimitedTestUsecase(client, attempts, sender)
+
+	for i := int64(0); i < ratelimit.LoginAccount.LockoutAfter; i++ {
+		attempts.unblock()
+		_, _ = uc.Login(context.Background(), dto.LoginUserDTO{Email: "ghost@e.com", Password: "Password1"})
+	}
+
+	require.Equal(t, ratelimit.LoginAccount.LockoutAfter, attempts.failures["login_account:ghost@e.com"])
+	require.Empty(t, sender.lockedSentTo)
+}
+
+func TestAuthUsecase_Login_UserServiceDownDoesNotCount(t *testing.T) {
+	client := &mockUserClient{
+		getUserByEmailFunc: func(_ context.Context, _ string) (*user_api.UserResponse, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	attempts := &mockAttemptStore{}
+	uc := newLimitedTestUsecase(client, attempts, &mockEmailSender{})
+
+	_, err := uc.Login(context.Background(), dto.LoginUserDTO{Email: "u@e.com", Password: "Password1", IP: "10.0.0.1"})
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperror.CodeServiceError, appErr.Code())
+	require.Zero(t, attempts.failures["login_account:u@e.com"])
+	require.Zero(t, attempts.failures["login_ip:10.0.0.1"])
+}
+
+// ── Audit log ─────────────────────────────────────────────────────────────────
+
+func TestAuthUsecase_Login_RecordsAuditEvents(t *testing.T) {
+	userID := uuid.New()
+	audit := &mockAuditLog{}
+	uc := NewAuthUsecase(activeUserClient(t, userID), memory.NewSessionStore(), &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{},
+		&mockMFAStore{}, newTestLimiter(), audit, &mockRevocationPublisher{}, &mockEmailSender{}, newTestJWTManager(),
+		newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+	ctx := domain.WithClient(context.Background(), domain.Client{IP: "10.0.0.9", UserAgent: "curl/8"})
+
+	_, err := uc.Login(ctx, dto.LoginUserDTO{Email: "u@e.com", Password: "WrongPass1", IP: "10.0.0.1", UserAgent: "Firefox"})
+	require.Error(t, err)
+
+	result, err := uc.Login(ctx, dto.LoginUserDTO{Email: "u@e.com", Password: "Password1", IP: "10.0.0.1", DeviceName: "laptop"})
+	require.NoError(t, err)
+
+	session, err := uc.AuthenticateRequest(ctx, result.AccessToken)
+	require.NoError(t, err)
+	require.NoError(t, uc.Logout(ctx, session.SessionID))
+
+	require.Equal(t, []string{domain.AuditLoginFailed, domain.AuditLoginSucceeded, domain.AuditLogout}, audit.types())
+
+	failed, succeeded, logout := audit.events[0], audit.events[1], audit.events[2]
+	require.Equal(t, userID, failed.UserID)
+	require.Equal(t, "invalid_password", failed.Metadata["reason"])
+	require.Equal(t, "10.0.0.1", failed.IP, "login events use the device address from the request")
+	require.Equal(t, session.SessionID, succeeded.SessionID)
+	require.Equal(t, "laptop", succeeded.DeviceName)
+	require.Equal(t, "10.0.0.9", logout.IP, "other events fall back to the client from context")
+
+	events, err := uc.ListAuditEvents(ctx, userID, time.Time{}, uuid.Nil, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, domain.AuditLogout, events[0].Type, "newest first")
+}
+
+func TestAuthUsecase_Login_AuditFailureDoesNotBlockLogin(t *testing.T) {
+	audit := &mockAuditLog{recordErr: errors.New("postgres down")}
+	uc := NewAuthUsecase(activeUserClient(t, uuid.New()), memory.NewSessionStore(), &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{},
+		&mockMFAStore{}, newTestLimiter(), audit, &mockRevocationPublisher{}, &mockEmailSender{}, newTestJWTManager(),
+		newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+
+	result, err := uc.Login(context.Background(), dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.AccessToken)
+}
+
+// ── Password hash upgrade ─────────────────────────────────────────────────────
+
+func TestAuthUsecase_Login_UpgradesLegacyBcryptHash(t *testing.T) {
+	userID := uuid.New()
+	client := activeUserClient(t, userID)
+	legacy := lowCostHash(t, "Password1")
+	client.getUserByEmailFunc = func(_ context.Context, email string) (*user_api.UserResponse, error) {
+		return &user_api.UserResponse{ID: userID, Email: email, PasswordHash: legacy, Status: "active"}, nil
+	}
+
+	var rehashed string
+	client.rehashPasswordFunc = func(_ context.Context, id uuid.UUID, currentHash, newHash string) error {
+		require.Equal(t, userID, id)
+		require.Equal(t, legacy, currentHash, "rehash must be conditional on the hash that was verified")
+		rehashed = newHash
+		return nil
+	}
+
+	uc := newTestUsecase(client, memory.NewSessionStore())
+	_, err := uc.Login(context.Background(), dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+	require.NoError(t, err)
+
+	require.True(t, strings.HasPrefix(rehashed, "$argon2id$"), rehashed)
+	require.NoError(t, newTestHasher().Compare("Password1", rehashed))
+	require.False(t, newTestHasher().NeedsRehash(rehashed))
+}
+
+func TestAuthUsecase_Login_CurrentHashIsNotRehashed(t *testing.T) {
+	hash, err := newTestHasher().Hash("Password1")
+	require.NoError(t, err)
+
+	client := &mockUserClient{
+		getUserByEmailFunc: func(_ context.Context, email string) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: uuid.New(), Email: email, PasswordHash: hash, Status: "active"}, nil
+		},
+		rehashPasswordFunc: func(context.Context, uuid.UUID, string, string) error {
+			t.Fatal("up-to-date hash must not be rewritten")
+			return nil
+		},
+	}
+
+	uc := newTestUsecase(client, memory.NewSessionStore())
+	_, err = uc.Login(context.Background(), dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+	require.NoError(t, err)
+}
+
+func TestAuthUsecase_Login_RehashFailureDoesNotBlockLogin(t *testing.T) {
+	client := activeUserClient(t, uuid.New())
+	client.rehashPasswordFunc = func(context.Context, uuid.UUID, string, string) error {
+		return apperror.Conflict("password_hash_changed", "current_hash", "conflict")
+	}
+
+	uc := newTestUsecase(client, memory.NewSessionStore())
+	result, err := uc.Login(context.Background(), dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.AccessToken)
+}
+
+// ── Magic link ────────────────────────────────────────────────────────────────
+
+func newMagicLinkUsecase(client user_api.Client, sessions authrep.SessionStore, sender *mockEmailSender, settings *mockSettings) (*AuthUsecase, *mockAuditLog) {
+	audit := &mockAuditLog{}
+	return NewAuthUsecase(client, sessions, &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockMFAStore{},
+		newTestLimiter(), audit, &mockRevocationPublisher{}, sender, newTestJWTManager(), newTestSecretBox(),
+		newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), settings, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost"), audit
+}
+
+func magicLinkToken(t *testing.T, link string) string {
+	t.Helper()
+	_, token, ok := strings.Cut(link, "token=")
+	require.True(t, ok, link)
+	return token
+}
+
+func TestAuthUsecase_MagicLink_SignsIn(t *testing.T) {
+	userID := uuid.New()
+	client := activeUserClient(t, userID)
+	client.getUserByIDFunc = func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+		return &user_api.UserResponse{ID: id, Status: "active"}, nil
+	}
+	sender := &mockEmailSender{}
+
+	uc, audit := newMagicLinkUsecase(client, memory.NewSessionStore(), sender, &mockSettings{})
+	require.NoError(t, uc.RequestMagicLink(context.Background(), "u@e.com"))
+	require.Len(t, sender.magicLinks, 1)
+
+	result, err := uc.ConsumeMagicLink(context.Background(), dto.MagicLinkConsumeDTO{Token: magicLinkToken(t, sender.magicLinks[0])})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.AccessToken)
+	require.Equal(t, []string{domain.AuditMagicLinkSent, domain.AuditLoginSucceeded}, audit.types())
+}
+
+func TestAuthUsecase_MagicLink_ReuseRevokesSession(t *testing.T) {
+	userID := uuid.New()
+	client := activeUserClient(t, userID)
+	client.getUserByIDFunc = func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+		return &user_api.UserResponse{ID: id, Status: "active"}, nil
+	}
+	sender := &mockEmailSender{}
+	sessions := memory.NewSessionStore()
+
+	uc, audit := newMagicLinkUsecase(client, sessions, sender, &mockSettings{})
+	require.NoError(t, uc.RequestMagicLink(context.Background(), "u@e.com"))
+	token := magicLinkToken(t, sender.magicLinks[0])
+
+	result, err := uc.ConsumeMagicLink(context.Background(), dto.MagicLinkConsumeDTO{Token: token})
+	require.NoError(t, err)
+	sessionID, err := refreshtoken.SessionID(result.RefreshToken)
+	require.NoError(t, err)
+
+	_, err = uc.ConsumeMagicLink(context.Background(), dto.MagicLinkConsumeDTO{Token: token})
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeMagicLinkInvalid, appErr.Code())
+
+	_, err = sessions.Get(context.Background(), sessionID)
+	require.Error(t, err, "session created by a reused link must be revoked")
+	require.Contains(t, audit.types(), domain.AuditMagicLinkReused)
+}
+
+func TestAuthUsecase_MagicLink_ReuseRevokesSessionCreatedAfterMFA(t *testing.T) {
+	userID := uuid.New()
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	sealed, err := newTestSecretBox().Seal([]byte(secret))
+	require.NoError(t, err)
+
+	sender := &mockEmailSender{}
+	sessions := memory.NewSessionStore()
+	uc, _ := newMagicLinkUsecase(mfaUserClient(t, userID, sealed), sessions, sender, &mockSettings{})
+	ctx := context.Background()
+
+	require.NoError(t, uc.RequestMagicLink(ctx, "u@e.com"))
+	token := magicLinkToken(t, sender.magicLinks[0])
+
+	result, err := uc.ConsumeMagicLink(ctx, dto.MagicLinkConsumeDTO{Token: token})
+	require.NoError(t, err)
+	require.True(t, result.MFARequired)
+
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+	tokens, err := uc.LoginMFA(ctx, dto.LoginMFADTO{ChallengeToken: result.ChallengeToken, Code: code})
+	require.NoError(t, err)
+	sessionID, err := refreshtoken.SessionID(tokens.RefreshToken)
+	require.NoError(t, err)
+
+	_, err = uc.ConsumeMagicLink(ctx, dto.MagicLinkConsumeDTO{Token: token})
+	require.Error(t, err)
+
+	_, err = sessions.Get(ctx, sessionID)
+	require.Error(t, err, "session created after the second factor must be revoked on reuse")
+}
+
+func TestAuthUsecase_MagicLink_ReuseDuringMFABlocksSession(t *testing.T) {
+	userID := uuid.New()
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	sealed, err := newTestSecretBox().Seal([]byte(secret))
+	require.NoError(t, err)
+
+	sender := &mockEmailSender{}
+	sessions := memory.NewSessionStore()
+	uc, _ := newMagicLinkUsecase(mfaUserClient(t, userID, sealed), sessions, sender, &mockSettings{})
+	ctx := context.Background()
+
+	require.NoError(t, uc.RequestMagicLink(ctx, "u@e.com"))
+	token := magicLinkToken(t, sender.magicLinks[0])
+
+	result, err := uc.ConsumeMagicLink(ctx, dto.MagicLinkConsumeDTO{Token: token})
+	require.NoError(t, err)
+
+	// Ссылку предъявили повторно, пока первый вход ждал второй фактор.
+	_, err = uc.ConsumeMagicLink(ctx, dto.MagicLinkConsumeDTO{Token: token})
+	require.Error(t, err)
+
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+	_, err = uc.LoginMFA(ctx, dto.LoginMFADTO{ChallengeToken: result.ChallengeToken, Code: code})
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeMagicLinkInvalid, appErr.Code())
+
+	left, err := sessions.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Empty(t, left)
+}
+
+func TestAuthUsecase_MagicLink_OptOut(t *testing.T) {
+	client := activeUserClient(t, uuid.New())
+	sender := &mockEmailSender{}
+
+	uc, _ := newMagicLinkUsecase(client, memory.NewSessionStore(), sender, &mockSettings{optedOut: true})
+	require.NoError(t, uc.RequestMagicLink(context.Background(), "u@e.com"))
+	require.Empty(t, sender.magicLinks)
+}
+
+func TestAuthUsecase_MagicLink_InvalidToken(t *testing.T) {
+	uc, _ := newMagicLinkUsecase(&mockUserClient{}, memory.NewSessionStore(), &mockEmailSender{}, &mockSettings{})
+
+	_, err := uc.ConsumeMagicLink(context.Background(), dto.MagicLinkConsumeDTO{Token: "not-a-token"})
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeMagicLinkInvalid, appErr.Code())
+}
+
+// ── OAuth ─────────────────────────────────────────────────────────────────────
+
+func newOAuthUsecase(t *testing.T, client user_api.Client) (*AuthUsecase, *oauthtest.Provider, *mockIdentities) {
+	t.Helper()
+	fake := oauthtest.NewProvider()
+	t.Cleanup(fake.Close)
+
+	provider, err := oauth.NewProvider(fake.Config("fake"), nil)
+	require.NoError(t, err)
+
+	identities := &mockIdentities{}
+	uc := NewAuthUsecase(client, memory.NewSessionStore(), &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockMFAStore{},
+		newTestLimiter(), &mockAuditLog{}, &mockRevocationPublisher{}, &mockEmailSender{}, newTestJWTManager(), newTestSecretBox(),
+		newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{},
+		map[string]*oauth.Provider{"fake": provider}, &mockOAuthStates{}, identities, time.Hour, 30*24*time.Hour, "PushPost")
+	return uc, fake, identities
+}
+
+// completeOAuth проходит редирект на провайдера и обратно, как это сделал бы браузер.
+func completeOAuth(
+	t *testing.T,
+	uc *AuthUsecase,
+	fake *oauthtest.Provider,
+	start *dto.OAuthAuthorizationDTO,
+	claims map[string]any,
+) (*dto.OAuthCallbackResultDTO, error) {
+	t.Helper()
+	code, state, err := fake.Authorize(start.AuthorizationURL, claims)
+	require.NoError(t, err)
+	return uc.CompleteOAuth(context.Background(), "fake", dto.OAuthCallbackDTO{Code: code, State: state})
+}
+
+// oauthUserClient хранит созданных пользователей, чтобы повторный вход находил их по id.
+func oauthUserClient(created *[]user_api.CreateUserRequest) *mockUserClient {
+	users := map[uuid.UUID]*user_api.UserResponse{}
+	return &mockUserClient{
+		getUserByEmailFunc: func(context.Context, string) (*user_api.UserResponse, error) {
+			return nil, user_api.ErrNotFound
+		},
+		createUserFunc: func(_ context.Context, req user_api.CreateUserRequest) (*user_api.UserResponse, error) {
+			*created = append(*created, req)
+			u := &user_api.UserResponse{ID: uuid.New(), Username: req.Username, Email: req.Email, PasswordHash: req.PasswordHash, Status: "active"}
+			users[u.ID] = u
+			return u, nil
+		},
+		getUserByIDFunc: func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+			if u, ok := users[id]; ok {
+				return u, nil
+			}
+			return nil, user_api.ErrNotFound
+		},
+	}
+}
+
+func TestAuthUsecase_OAuth_ProvisionsUserThenSignsIn(t *testing.T) {
+	var created []user_api.CreateUserRequest
+	uc, fake, identities := newOAuthUsecase(t, oauthUserClient(&created))
+	claims := map[string]any{"sub": "42", "email": "Alice@Example.com", "email_verified": true, "preferred_username": "Alice.Smith"}
+
+	for range 2 {
+		start, err := uc.StartOAuth(context.Background(), "fake", dto.OAuthStartDTO{})
+		require.NoError(t, err)
+
+		result, err := completeOAuth(t, uc, fake, start, claims)
+		require.NoError(t, err)
+		require.NotEmpty(t, result.AccessToken)
+	}
+
+	require.Len(t, created, 1, "second sign-in must reuse the linked user")
+	require.Equal(t, "alice_smith", created[0].Username)
+	require.Equal(t, "alice@example.com", created[0].Email)
+	require.Equal(t, unusablePasswordHash, created[0].PasswordHash)
+	require.Len(t, identities.items, 1)
+}
+
+func TestAuthUsecase_OAuth_ReservedUsernameGetsSuffix(t *testing.T) {
+	var created []user_api.CreateUserRequest
+	uc, fake, _ := newOAuthUsecase(t, oauthUserClient(&created))
+
+	start, err := uc.StartOAuth(context.Background(), "fake", dto.OAuthStartDTO{})
+	require.NoError(t, err)
+	_, err = completeOAuth(t, uc, fake, start, map[string]any{"sub": "1", "email": "a@e.com", "email_verified": true, "login": "admin"})
+	require.NoError(t, err)
+
+	require.Len(t, created, 1)
+	require.Regexp(t, `^admin_\d{4}$`, created[0].Username)
+}
+
+func TestAuthUsecase_OAuth_ExistingEmailIsNotLinkedAutomatically(t *testing.T) {
+	client := activeUserClient(t, uuid.New())
+	client.createUserFunc = func(context.Context, user_api.CreateUserRequest) (*user_api.UserResponse, error) {
+		t.Fatal("must not create a user for a taken email")
+		return nil, nil
+	}
+	uc, fake, identities := newOAuthUsecase(t, client)
+
+	start, err := uc.StartOAuth(context.Background(), "fake", dto.OAuthStartDTO{})
+	require.NoError(t, err)
+	_, err = completeOAuth(t, uc, fake, start, map[string]any{"sub": "42", "email": "u@e.com", "email_verified": true})
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeOAuthEmailTaken, appErr.Code())
+	require.Empty(t, identities.items)
+}
+
+func TestAuthUsecase_OAuth_LinkAndUnlink(t *testing.T) {
+	var created []user_api.CreateUserRequest
+	client := oauthUserClient(&created)
+	userID := uuid.New()
+	client.getUserByIDFunc = func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+		return &user_api.UserResponse{ID: id, PasswordHash: unusablePasswordHash, Status: "active"}, nil
+	}
+	uc, fake, identities := newOAuthUsecase(t, client)
+	sessionID := uuid.New()
+
+	start, err := uc.StartOAuthLink(context.Background(), userID, sessionID, "fake")
+	require.NoError(t, err)
+	code, state, err := fake.Authorize(start.AuthorizationURL, map[string]any{"id": float64(7), "login": "octocat"})
+	require.NoError(t, err)
+	result, err := uc.CompleteOAuthLink(context.Background(), userID, sessionID, "fake", dto.OAuthCallbackDTO{Code: code, State: state})
+	require.NoError(t, err)
+	require.Equal(t, "fake", result.LinkedProvider)
+	require.Nil(t, result.LoginResultDTO)
+	require.Empty(t, created)
+	require.Equal(t, userID, identities.items[0].UserID)
+
+	// пароля нет — последнего провайдера отвязать нельзя
+	err = uc.UnlinkIdentity(context.Background(), userID, "fake")
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeLastSignInMethod, appErr.Code())
+}
+
+func TestAuthUsecase_OAuth_LinkStateRequiresStartingSession(t *testing.T) {
+	var created []user_api.CreateUserRequest
+	uc, fake, identities := newOAuthUsecase(t, oauthUserClient(&created))
+	victimID, victimSession := uuid.New(), uuid.New()
+	claims := map[string]any{"id": float64(7), "login": "attacker"}
+
+	// Анонимный callback не завершает привязку, начатую в чужой сессии.
+	start, err := uc.StartOAuthLink(context.Background(), victimID, victimSession, "fake")
+	require.NoError(t, err)
+	_, err = completeOAuth(t, uc, fake, start, claims)
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeOAuthStateInvalid, appErr.Code())
+
+	// Как и вошедший пользователь из другой сессии.
+	start, err = uc.StartOAuthLink(context.Background(), uuid.New(), uuid.New(), "fake")
+	require.NoError(t, err)
+	code, state, err := fake.Authorize(start.AuthorizationURL, claims)
+	require.NoError(t, err)
+	_, err = uc.CompleteOAuthLink(context.Background(), victimID, victimSession, "fake", dto.OAuthCallbackDTO{Code: code, State: state})
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeOAuthStateInvalid, appErr.Code())
+
+	require.Empty(t, identities.items)
+	require.Empty(t, created)
+}
+
+func TestAuthUsecase_OAuth_FailedLinkRollsBackProvisionedUser(t *testing.T) {
+	var created []user_api.CreateUserRequest
+	var deleted []uuid.UUID
+	client := oauthUserClient(&created)
+	client.deleteUserFunc = func(_ context.Context, id uuid.UUID) error {
+		deleted = append(deleted, id)
+		return nil
+	}
+	uc, fake, identities := newOAuthUsecase(t, client)
+	identities.createErr = errors.New("postgres down")
+	claims := map[string]any{"sub": "42", "email": "a@e.com", "email_verified": true}
+
+	start, err := uc.StartOAuth(context.Background(), "fake", dto.OAuthStartDTO{})
+	require.NoError(t, err)
+	_, err = completeOAuth(t, uc, fake, start, claims)
+	require.Error(t, err)
+	require.Len(t, created, 1)
+	require.Len(t, deleted, 1, "user without a linked identity must be removed")
+
+	// Email свободен, повторная попытка проходит.
+	identities.createErr = nil
+	start, err = uc.StartOAuth(context.Background(), "fake", dto.OAuthStartDTO{})
+	require.NoError(t, err)
+	result, err := completeOAuth(t, uc, fake, start, claims)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.AccessToken)
+}
+
+func TestAuthUsecase_OAuth_StateIsSingleUse(t *testing.T) {
+	var created []user_api.CreateUserRequest
+	uc, fake, _ := newOAuthUsecase(t, oauthUserClient(&created))
+
+	start, err := uc.StartOAuth(context.Background(), "fake", dto.OAuthStartDTO{})
+	require.NoError(t, err)
+	claims := map[string]any{"sub": "42", "email": "a@e.com", "email_verified": true}
+	_, err = completeOAuth(t, uc, fake, start, claims)
+	require.NoError(t, err)
+
+	_, err = completeOAuth(t, uc, fake, start, claims)
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeOAuthStateInvalid, appErr.Code())
+}
+
+// ── Account deletion ──────────────────────────────────────────────────────────
+
+func TestAuthUsecase_DeleteAccount_SchedulesAndRevokesSessions(t *testing.T) {
+	userID := uuid.New()
+	hash := lowCostHash(t, "Password1")
+	var scheduledFor time.Time
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: id, PasswordHash: hash, Status: "active"}, nil
+		},
+		scheduleDeletionFunc: func(_ context.Context, id uuid.UUID, at time.Time) error {
+			require.Equal(t, userID, id)
+			scheduledFor = at
+			return nil
+		},
+	}
+	store := memory.NewSessionStore()
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		require.NoError(t, store.Save(ctx, &domain.Session{SessionID: uuid.New(), UserID: userID, Expires: time.Now().Add(time.Hour).Unix()}))
+	}
+	revocations := &mockRevocationPublisher{}
+	audit := &mockAuditLog{}
+
+	uc := NewAuthUsecase(client, store, &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockMFAStore{}, newTestLimiter(), audit, revocations, &mockEmailSender{},
+		newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+
+	result, err := uc.DeleteAccount(ctx, userID, "Password1")
+	require.NoError(t, err)
+	require.Equal(t, scheduledFor, result.ScheduledFor)
+	require.WithinDuration(t, time.Now().Add(30*24*time.Hour), scheduledFor, time.Minute)
+
+	left, err := store.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Empty(t, left, "all sessions must be revoked")
+	require.Len(t, revocations.published, 1)
+	require.True(t, revocations.published[0].AllSessions())
+	require.Equal(t, []string{domain.AuditDeletionScheduled}, audit.types())
+}
+
+func TestAuthUsecase_DeleteAccount_WrongPassword(t *testing.T) {
+	hash := lowCostHash(t, "Password1")
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: id, PasswordHash: hash, Status: "active"}, nil
+		},
+		scheduleDeletionFunc: func(_ context.Context, _ uuid.UUID, _ time.Time) error {
+			t.Fatal("deletion must not be scheduled")
+			return nil
+		},
+	}
+
+	uc := newTestUsecase(client, &mockSessionStore{})
+	_, err := uc.DeleteAccount(context.Background(), uuid.New(), "WrongPass1")
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeInvalidCurrentPassword, appErr.Code())
+}
+
+func TestAuthUsecase_Login_RestoresPendingDeletion(t *testing.T) {
+	userID := uuid.New()
+	hash := lowCostHash(t, "Password1")
+	cancelled := false
+	client := &mockUserClient{
+		getUserByEmailFunc: func(_ context.Context, email string) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: userID, Email: email, PasswordHash: hash, Status: "pending_deletion"}, nil
+		},
+		cancelDeletionFunc: func(_ context.Context, id uuid.UUID) error {
+			require.Equal(t, userID, id)
+			cancelled = true
+			return nil
+		},
+	}
+	audit := &mockAuditLog{}
+
+	uc := NewAuthUsecase(client, memory.NewSessionStore(), &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockMFAStore{}, newTestLimiter(), audit, &mockRevocationPublisher{}, &mockEmailSender{},
+		newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+
+	result, err := uc.Login(context.Background(), dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.AccessToken)
+	require.True(t, cancelled, "login must cancel the scheduled deletion")
+	require.Equal(t, []string{domain.AuditAccountRestored, domain.AuditLoginSucceeded}, audit.types())
+}
+
+// ── Suspension ────────────────────────────────────────────────────────────────
+
+func TestAuthUsecase_SuspendUser_RevokesSessions(t *testing.T) {
+	userID := uuid.New()
+	until := time.Now().Add(24 * time.Hour)
+	var suspended user_api.SuspendUserRequest
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: id, Status: "active", Role: "user"}, nil
+		},
+		suspendUserFunc: func(_ context.Context, id uuid.UUID, req user_api.SuspendUserRequest) error {
+			require.Equal(t, userID, id)
+			suspended = req
+			return nil
+		},
+	}
+	store := memory.NewSessionStore()
+	ctx := context.Background()
+	require.NoError(t, store.Save(ctx, &domain.Session{SessionID: uuid.New(), UserID: userID, Expires: time.Now().Add(time.Hour).Unix()}))
+	revocations := &mockRevocationPublisher{}
+	audit := &mockAuditLog{}
+
+	uc := NewAuthUsecase(client, store, &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockMFAStore{}, newTestLimiter(), audit, revocations, &mockEmailSender{},
+		newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+
+	require.NoError(t, uc.SuspendUser(ctx, userID, dto.SuspendUserDTO{
+		Until:  &until,
+		Reason: "spam",
+		Actor:  dto.Actor{UserID: uuid.New(), Role: roles.Moderator},
+	}))
+	require.Equal(t, "spam", suspended.Reason)
+	require.Equal(t, &until, suspended.Until)
+
+	left, err := store.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Empty(t, left, "all sessions must be revoked")
+	require.Len(t, revocations.published, 1)
+	require.True(t, revocations.published[0].AllSessions())
+	require.Equal(t, []string{domain.AuditAccountSuspended}, audit.types())
+	require.Equal(t, "spam", audit.events[0].Metadata["reason"])
+}
+
+func TestAuthUsecase_Login_SuspendedAccount(t *testing.T) {
+	hash := lowCostHash(t, "Password1")
+	until := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	client := &mockUserClient{
+		getUserByEmailFunc: func(_ context.Context, email string) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: uuid.New(), Email: email, PasswordHash: hash, Status: "blocked", SuspendedUntil: &until}, nil
+		},
+	}
+
+	uc := newTestUsecase(client, &mockSessionStore{})
+	_, err := uc.Login(context.Background(), dto.LoginUserDTO{Email: "u@e.com", Password: "Password1"})
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeAccountSuspended, appErr.Code())
+	require.Equal(t, "2030-01-02T03:04:05Z", appErr.Fields()["suspended_until"])
+}
+
+func TestAuthUsecase_SuspendUser_ModeratorCannotSuspendAdmin(t *testing.T) {
+	adminID := uuid.New()
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: id, Status: "active", Role: "admin"}, nil
+		},
+		suspendUserFunc: func(_ context.Context, _ uuid.UUID, _ user_api.SuspendUserRequest) error {
+			t.Fatal("suspend must not reach user_service")
+			return nil
+		},
+	}
+
+	uc := newTestUsecase(client, &mockSessionStore{})
+	err := uc.SuspendUser(context.Background(), adminID, dto.SuspendUserDTO{
+		Reason: "spam",
+		Actor:  dto.Actor{UserID: uuid.New(), Role: roles.Moderator},
+	})
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperr.CodeCannotModerateUser, appErr.Code())
+}
+
+func TestAuthUsecase_ChangeUserRole_DemotionRevokesSessions(t *testing.T) {
+	userID := uuid.New()
+	var newRole string
+	client := &mockUserClient{
+		getUserByIDFunc: func(_ context.Context, id uuid.UUID) (*user_api.UserResponse, error) {
+			return &user_api.UserResponse{ID: id, Status: "active", Role: "moderator"}, nil
+		},
+		setRoleFunc: func(_ context.Context, id uuid.UUID, role string) error {
+			require.Equal(t, userID, id)
+			newRole = role
+			return nil
+		},
+	}
+	store := memory.NewSessionStore()
+	ctx := context.Background()
+	require.NoError(t, store.Save(ctx, &domain.Session{SessionID: uuid.New(), UserID: userID, Role: "moderator", Expires: time.Now().Add(time.Hour).Unix()}))
+	revocations := &mockRevocationPublisher{}
+	audit := &mockAuditLog{}
+
+	uc := NewAuthUsecase(client, store, &mockOTPStore{}, &mockOTPStore{}, &mockOTPStore{}, &mockMFAStore{}, newTestLimiter(), audit, revocations, &mockEmailSender{},
+		newTestJWTManager(), newTestSecretBox(), newTestHasher(), newTestMagicLinks(), newMockMagicLinkStore(), &mockSettings{}, nil, &mockOAuthStates{}, &mockIdentities{}, time.Hour, 30*24*time.Hour, "PushPost")
+
+	adminID := uuid.New()
+	require.NoError(t, uc.ChangeUserRole(ctx, userID, dto.ChangeRoleDTO{
+		Role:  roles.User,
+		Actor: dto.Actor{UserID: adminID, Role: roles.Admin},
+	}))
+	require.Equal(t, "user", newRole)
+
+	left, err := store.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Empty(t, left, "demotion must revoke sessions")
+	require.Equal(t, []string{domain.AuditRoleChanged}, audit.types())
+	require.Equal(t, "moderator", audit.events[0].Metadata["previous_role"])
+	require.Equal(t, adminID.String(), audit.events[0].Metadata["actor_id"])
 }
@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/clients/user_api"
+	apperr "github.com/rockkley/pushpost/services/auth_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain/dto"
+	"github.com/rockkley/pushpost/services/auth_service/internal/email"
+	"github.com/rockkley/pushpost/services/auth_service/internal/magiclink"
+	"github.com/rockkley/pushpost/services/auth_service/internal/ratelimit"
+	redisrepo "github.com/rockkley/pushpost/services/auth_service/internal/repository/redis"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/ctxlog"
+)
+
+// RequestMagicLink, как и ForgotPassword, отвечает одинаково для любых адресов:
+// лимит считается до поиска пользователя, а отказ в отправке не возвращается клиенту.
+func (s *AuthUsecase) RequestMagicLink(ctx context.Context, userEmail string) error {
+	log := ctxlog.From(ctx).With(slog.String("op", "AuthUsecase.RequestMagicLink"))
+
+	account := strings.ToLower(strings.TrimSpace(userEmail))
+
+	status, err := s.limiter.Check(ctx, ratelimit.MagicLinkEmail, account)
+
+	if err != nil {
+		log.Error("failed to check magic link limit", slog.Any("error", err))
+	} else if status.RetryAfter > 0 {
+		return apperr.RateLimited(status.RetryAfter)
+	}
+
+	if _, err = s.limiter.Fail(ctx, ratelimit.MagicLinkEmail, account); err != nil {
+		log.Error("failed to count magic link request", slog.Any("error", err))
+	}
+
+	user, err := s.userClient.GetUserByEmail(ctx, account)
+
+	if err != nil || user.IsBlocked() || user.IsDeleted() || (!user.IsActive() && !user.IsPendingDeletion()) {
+		log.Debug("magic link requested for unknown or disabled account")
+
+		return nil
+	}
+
+	log = log.With(slog.String("user_id", user.ID.String()))
+
+	settings, err := s.settings.Get(ctx, user.ID)
+
+	if err != nil {
+		log.Error("failed to get auth settings", slog.Any("error", err))
+
+		return nil
+	}
+
+	if !settings.MagicLinkEnabled {
+		log.Debug("magic link requested, but user opted out")
+
+		return nil
+	}
+
+	_, link, err := s.magicLinks.Issue(user.ID, time.Now())
+
+	if err != nil {
+		log.Error("failed to issue magic link", slog.Any("error", err))
+
+		return nil
+	}
+
+	if err = s.emailSender.Send(ctx, email.TemplateMagicLink, user.Email, email.Data{
+		"Link":       link,
+		"TTLMinutes": email.Minutes(s.magicLinks.TTL()),
+	}); err != nil {
+		log.Error("failed to send magic link", slog.Any("error", err))
+
+		return nil
+	}
+
+	s.recordAudit(ctx, &domain.AuditEvent{Type: domain.AuditMagicLinkSent, UserID: user.ID}, log)
+
+	log.Info("magic link sent")
+
+	return nil
+}
+
+// ConsumeMagicLink входит по ссылке так же, как Login по паролю. Ссылка одноразовая:
+// повторное предъявление считается утечкой письма и отзывает созданную по ней сессию.
+func (s *AuthUsecase) ConsumeMagicLink(ctx context.Context, req dto.MagicLinkConsumeDTO) (*dto.LoginResultDTO, error) {
+	log := ctxlog.From(ctx).With(slog.String("op", "AuthUsecase.ConsumeMagicLink"))
+
+	now := time.Now()
+
+	claims, err := s.magicLinks.Verify(req.Token, now)
+
+	if err != nil {
+		log.Debug("magic link rejected", slog.Any("error", err))
+
+		return nil, apperr.MagicLinkInvalid()
+	}
+
+	log = log.With(slog.String("user_id", claims.UserID.String()))
+
+	ttl := claims.ExpiresAt.Sub(now)
+
+	fresh, err := s.magicLinkStore.Consume(ctx, claims.TokenID, ttl)
+
+	if err != nil {
+		log.Error("failed to consume magic link", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("consume magic link", err)
+	}
+
+	if !fresh {
+		s.handleMagicLinkReuse(ctx, claims, log)
+
+		return nil, apperr.MagicLinkInvalid()
+	}
+
+	user, err := s.userClient.GetUserByID(ctx, claims.UserID)
+
+	if err != nil {
+		if errors.Is(err, user_api.ErrNotFound) {
+			return nil, apperr.MagicLinkInvalid()
+		}
+
+		log.Error("failed to get user", slog.Any("error", err))
+
+		return nil, commonapperr.Service("get user", err)
+	}
+
+	if err = checkSignInStatus(user); err != nil {
+		return nil, err
+	}
+
+	// пользователь мог отключить вход по ссылке уже после того, как письмо ушло
+	settings, err := s.settings.Get(ctx, user.ID)
+
+	if err != nil {
+		log.Error("failed to get auth settings", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("get auth settings", err)
+	}
+
+	if !settings.MagicLinkEnabled {
+		return nil, apperr.MagicLinkInvalid()
+	}
+
+	deviceID := req.DeviceID
+
+	if deviceID == uuid.Nil {
+		deviceID = uuid.New()
+	}
+
+	device := domain.Device{ID: deviceID, Name: req.DeviceName, UserAgent: req.UserAgent, IP: req.IP}
+
+	mfaEnabled, err := s.mfaEnabled(ctx, user.ID, log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if mfaEnabled {
+		return s.startMFAChallenge(ctx, &domain.MFAChallenge{
+			UserID:             user.ID,
+			Device:             device,
+			MagicLinkID:        claims.TokenID,
+			MagicLinkExpiresAt: claims.ExpiresAt,
+		}, log)
+	}
+
+	if err = s.restoreIfPendingDeletion(ctx, user, log); err != nil {
+		return nil, err
+	}
+
+	sessionID := uuid.New()
+
+	if err = s.bindMagicLinkSession(ctx, claims.TokenID, sessionID, claims.ExpiresAt, log); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.openSession(ctx, sessionID, user.ID, user.Role, device, log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResultDTO{TokenPairDTO: tokens}, nil
+}
+
+// bindMagicLinkSession привязывает сессию к ссылке до её выдачи: иначе повторное
+// предъявление в промежутке не нашло бы, что отзывать. Не удалось — сессию не выдаём.
+func (s *AuthUsecase) bindMagicLinkSession(
+	ctx context.Context,
+	tokenID string,
+	sessionID uuid.UUID,
+	expiresAt time.Time,
+	log *slog.Logger,
+) error {
+	ttl := time.Until(expiresAt)
+
+	// просроченную ссылку повторно уже не предъявить: отзывать будет нечего
+	if ttl <= 0 {
+		return nil
+	}
+
+	err := s.magicLinkStore.BindSession(ctx, tokenID, sessionID, ttl)
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, redisrepo.ErrMagicLinkReused):
+		log.Warn("magic link reused before session was issued")
+
+		return apperr.MagicLinkInvalid()
+	default:
+		log.Error("failed to bind session to magic link", slog.Any("error", err))
+
+		return commonapperr.Internal("bind magic link session", err)
+	}
+}
+
+func (s *AuthUsecase) handleMagicLinkReuse(ctx context.Context, claims magiclink.Claims, log *slog.Logger) {
+	log.Warn("magic link reuse detected")
+
+	event := &domain.AuditEvent{Type: domain.AuditMagicLinkReused, UserID: claims.UserID}
+
+	sessionID, err := s.magicLinkStore.MarkReused(ctx, claims.TokenID)
+
+	if err != nil {
+		log.Error("failed to get magic link session", slog.Any("error", err))
+	}
+
+	if sessionID != uuid.Nil {
+		session, err := s.sessionStore.Get(ctx, sessionID)
+
+		switch {
+		case err == nil:
+			if err = s.revokeSession(ctx, session, log); err != nil {
+				log.Error("failed to revoke magic link session", slog.Any("error", err))
+			}
+
+			event = sessionAudit(domain.AuditMagicLinkReused, session)
+		case !errors.Is(err, redisrepo.ErrSessionNotFound):
+			log.Error("failed to get magic link session", slog.Any("error", err))
+		}
+	}
+
+	s.recordAudit(ctx, event, log)
+}
+
+func (s *AuthUsecase) GetSettings(ctx context.Context, userID uuid.UUID) (*dto.SettingsDTO, error) {
+	settings, err := s.settings.Get(ctx, userID)
+
+	if err != nil {
+		ctxlog.From(ctx).Error("failed to get auth settings",
+			slog.String("op", "AuthUsecase.GetSettings"),
+			slog.Any("error", err),
+		)
+
+		return nil, commonapperr.Internal("get auth settings", err)
+	}
+
+	return &dto.SettingsDTO{MagicLinkEnabled: settings.MagicLinkEnabled}, nil
+}
+
+func (s *AuthUsecase) UpdateSettings(ctx context.Context, userID uuid.UUID, req dto.SettingsDTO) (*dto.SettingsDTO, error) {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.UpdateSettings"),
+		slog.String("user_id", userID.String()),
+	)
+
+	settings := &domain.Settings{UserID: userID, MagicLinkEnabled: req.MagicLinkEnabled}
+
+	if err := s.settings.Save(ctx, settings); err != nil {
+		log.Error("failed to save auth settings", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("save auth settings", err)
+	}
+
+	s.recordAudit(ctx, &domain.AuditEvent{
+		Type:     domain.AuditSettingsChanged,
+		UserID:   userID,
+		Metadata: map[string]string{"magic_link_enabled": strconv.FormatBool(settings.MagicLinkEnabled)},
+	}, log)
+
+	log.Info("auth settings updated")
+
+	return &dto.SettingsDTO{MagicLinkEnabled: settings.MagicLinkEnabled}, nil
+}
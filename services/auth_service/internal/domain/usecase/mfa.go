@@ -0,0 +1,411 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/clients/user_api"
+	apperr "github.com/rockkley/pushpost/services/auth_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain/dto"
+	redisrepo "github.com/rockkley/pushpost/services/auth_service/internal/repository/redis"
+	"github.com/rockkley/pushpost/services/auth_service/internal/totp"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/ctxlog"
+)
+
+const (
+	mfaSetupTTL     = 10 * time.Minute
+	mfaChallengeTTL = 5 * time.Minute
+	maxMFAAttempts  = 5
+	// шаг TOTP плюс окно ±1 шаг — дольше код принять нельзя
+	totpReplayTTL = 2 * time.Minute
+)
+
+func (s *AuthUsecase) LoginMFA(ctx context.Context, req dto.LoginMFADTO) (*dto.TokenPairDTO, error) {
+	log := ctxlog.From(ctx).With(slog.String("op", "AuthUsecase.LoginMFA"))
+
+	tokenHash := hashChallengeToken(req.ChallengeToken)
+
+	challenge, err := s.mfaStore.GetChallenge(ctx, tokenHash)
+
+	if err != nil {
+		if errors.Is(err, redisrepo.ErrChallengeNotFound) {
+			return nil, apperr.MFAChallengeInvalid()
+		}
+
+		log.Error("failed to get mfa challenge", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("get mfa challenge", err)
+	}
+
+	log = log.With(slog.String("user_id", challenge.UserID.String()))
+
+	// попытка считается до проверки, чтобы параллельные запросы не обошли лимит
+	attempts, err := s.mfaStore.IncrChallengeAttempts(ctx, tokenHash, mfaChallengeTTL)
+
+	if err != nil {
+		log.Error("failed to increment mfa attempts", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("increment mfa attempts", err)
+	}
+
+	if attempts > maxMFAAttempts {
+		if delErr := s.mfaStore.DeleteChallenge(ctx, tokenHash); delErr != nil {
+			log.Error("failed to delete mfa challenge after max attempts", slog.Any("error", delErr))
+		}
+
+		return nil, apperr.TooManyMFAAttempts()
+	}
+
+	if err = s.verifySecondFactor(ctx, challenge.UserID, req.Code, req.RecoveryCode, log); err != nil {
+		var appErr commonapperr.AppError
+
+		if errors.As(err, &appErr) && appErr.Code() == apperr.CodeMFACodeInvalid {
+			event := deviceAudit(domain.AuditLoginFailed, challenge.UserID, challenge.Device)
+			event.Metadata = map[string]string{"reason": "invalid_second_factor"}
+			s.recordAudit(ctx, event, log)
+		}
+
+		return nil, err
+	}
+
+	if err = s.mfaStore.DeleteChallenge(ctx, tokenHash); err != nil {
+		log.Warn("failed to delete used mfa challenge", slog.Any("error", err))
+	}
+
+	user, err := s.userClient.GetUserByID(ctx, challenge.UserID)
+
+	if err != nil {
+		if errors.Is(err, user_api.ErrNotFound) {
+			return nil, apperr.AccountDeleted()
+		}
+
+		log.Error("failed to get user", slog.Any("error", err))
+
+		return nil, commonapperr.Service("get user", err)
+	}
+
+	if err = checkSignInStatus(user); err != nil {
+		return nil, err
+	}
+
+	// удаление отменяется только после второго фактора
+	if err = s.restoreIfPendingDeletion(ctx, user, log); err != nil {
+		return nil, err
+	}
+
+	sessionID := uuid.New()
+
+	if challenge.MagicLinkID != "" {
+		if err = s.bindMagicLinkSession(ctx, challenge.MagicLinkID, sessionID, challenge.MagicLinkExpiresAt, log); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.openSession(ctx, sessionID, user.ID, user.Role, challenge.Device, log)
+}
+
+// SetupMFA генерирует секрет и держит его в Redis до подтверждения кодом —
+// так 2FA не включится, если пользователь не успел добавить ключ в приложение.
+func (s *AuthUsecase) SetupMFA(ctx context.Context, userID uuid.UUID) (*dto.MFASetupDTO, error) {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.SetupMFA"),
+		slog.String("user_id", userID.String()),
+	)
+
+	enabled, err := s.mfaEnabled(ctx, userID, log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if enabled {
+		return nil, apperr.MFAAlreadyEnabled()
+	}
+
+	user, err := s.userClient.GetUserByID(ctx, userID)
+
+	if err != nil {
+		log.Error("failed to get user", slog.Any("error", err))
+
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+
+	if err != nil {
+		return nil, commonapperr.Internal("generate totp secret", err)
+	}
+
+	sealed, err := s.mfaSecrets.Seal([]byte(secret))
+
+	if err != nil {
+		return nil, commonapperr.Internal("encrypt totp secret", err)
+	}
+
+	if err = s.mfaStore.SavePendingSecret(ctx, userID, sealed, mfaSetupTTL); err != nil {
+		log.Error("failed to save pending mfa secret", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("save pending mfa secret", err)
+	}
+
+	log.Info("mfa setup started")
+
+	return &dto.MFASetupDTO{
+		Secret: secret,
+		URI:    totp.URI(s.mfaIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmMFA включает 2FA и возвращает коды восстановления — показываются один раз.
+func (s *AuthUsecase) ConfirmMFA(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.ConfirmMFA"),
+		slog.String("user_id", userID.String()),
+	)
+
+	sealed, err := s.mfaStore.GetPendingSecret(ctx, userID)
+
+	if err != nil {
+		if errors.Is(err, redisrepo.ErrMFASetupNotFound) {
+			return nil, apperr.MFASetupExpired()
+		}
+
+		log.Error("failed to get pending mfa secret", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("get pending mfa secret", err)
+	}
+
+	secret, err := s.mfaSecrets.Open(sealed)
+
+	if err != nil {
+		log.Error("failed to decrypt pending mfa secret", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("decrypt totp secret", err)
+	}
+
+	if err = s.checkTOTP(ctx, userID, string(secret), code, log); err != nil {
+		return nil, err
+	}
+
+	codes, err := totp.GenerateRecoveryCodes(totp.RecoveryCodeCount)
+
+	if err != nil {
+		return nil, commonapperr.Internal("generate recovery codes", err)
+	}
+
+	hashes := make([]string, len(codes))
+
+	for i, c := range codes {
+		hashes[i] = totp.HashRecoveryCode(c)
+	}
+
+	err = s.userClient.EnableMFA(ctx, userID, user_api.EnableMFARequest{
+		TOTPSecret:         sealed,
+		RecoveryCodeHashes: hashes,
+	})
+
+	if err != nil {
+		log.Error("failed to enable mfa", slog.Any("error", err))
+
+		return nil, err
+	}
+
+	if err = s.mfaStore.DeletePendingSecret(ctx, userID); err != nil {
+		log.Warn("failed to delete pending mfa secret", slog.Any("error", err))
+	}
+
+	s.recordAudit(ctx, &domain.AuditEvent{Type: domain.AuditMFAEnabled, UserID: userID}, log)
+
+	log.Info("mfa enabled")
+
+	return codes, nil
+}
+
+// DisableMFA требует и пароль, и второй фактор: украденной сессии недостаточно.
+// Остальные сессии завершаются, как и при смене пароля.
+func (s *AuthUsecase) DisableMFA(ctx context.Context, userID, sessionID uuid.UUID, password, code, recoveryCode string) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.DisableMFA"),
+		slog.String("user_id", userID.String()),
+	)
+
+	user, err := s.userClient.GetUserByID(ctx, userID)
+
+	if err != nil {
+		log.Error("failed to get user", slog.Any("error", err))
+
+		return err
+	}
+
+	if err = s.checkCurrentPassword(ctx, user, password, log); err != nil {
+		return err
+	}
+
+	if err = s.verifySecondFactor(ctx, userID, code, recoveryCode, log); err != nil {
+		return err
+	}
+
+	if err = s.userClient.DisableMFA(ctx, userID); err != nil {
+		if errors.Is(err, user_api.ErrNotFound) {
+			return apperr.MFANotEnabled()
+		}
+
+		log.Error("failed to disable mfa", slog.Any("error", err))
+
+		return err
+	}
+
+	if _, err = s.revokeOtherSessions(ctx, userID, sessionID, log); err != nil {
+		return err
+	}
+
+	s.recordAudit(ctx, &domain.AuditEvent{Type: domain.AuditMFADisabled, UserID: userID, SessionID: sessionID}, log)
+
+	log.Info("mfa disabled")
+
+	return nil
+}
+
+func (s *AuthUsecase) GetMFAStatus(ctx context.Context, userID uuid.UUID) (*dto.MFAStatusDTO, error) {
+	mfa, err := s.userClient.GetMFA(ctx, userID)
+
+	switch {
+	case err == nil:
+		return &dto.MFAStatusDTO{Enabled: true, RecoveryCodesLeft: mfa.RecoveryCodesLeft, EnabledAt: &mfa.EnabledAt}, nil
+	case errors.Is(err, user_api.ErrNotFound):
+		return &dto.MFAStatusDTO{}, nil
+	default:
+		ctxlog.From(ctx).Error("failed to get mfa status",
+			slog.String("op", "AuthUsecase.GetMFAStatus"),
+			slog.Any("error", err),
+		)
+
+		return nil, commonapperr.Service("get mfa status", err)
+	}
+}
+
+func (s *AuthUsecase) mfaEnabled(ctx context.Context, userID uuid.UUID, log *slog.Logger) (bool, error) {
+	_, err := s.userClient.GetMFA(ctx, userID)
+
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, user_api.ErrNotFound):
+		return false, nil
+	default:
+		log.Error("failed to get mfa settings", slog.Any("error", err))
+
+		return false, commonapperr.Service("get mfa settings", err)
+	}
+}
+
+func (s *AuthUsecase) startMFAChallenge(
+	ctx context.Context,
+	challenge *domain.MFAChallenge,
+	log *slog.Logger,
+) (*dto.LoginResultDTO, error) {
+	raw := make([]byte, 32)
+
+	if _, err := rand.Read(raw); err != nil {
+		return nil, commonapperr.Internal("generate challenge token", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	if err := s.mfaStore.SaveChallenge(ctx, hashChallengeToken(token), challenge, mfaChallengeTTL); err != nil {
+		log.Error("failed to save mfa challenge", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("save mfa challenge", err)
+	}
+
+	log.Info("password accepted, awaiting second factor", slog.String("user_id", challenge.UserID.String()))
+
+	return &dto.LoginResultDTO{MFARequired: true, ChallengeToken: token}, nil
+}
+
+// verifySecondFactor принимает либо TOTP-код, либо одноразовый код восстановления.
+func (s *AuthUsecase) verifySecondFactor(
+	ctx context.Context,
+	userID uuid.UUID,
+	code, recoveryCode string,
+	log *slog.Logger,
+) error {
+	if recoveryCode != "" {
+		err := s.userClient.ConsumeRecoveryCode(ctx, userID, totp.HashRecoveryCode(recoveryCode))
+
+		switch {
+		case err == nil:
+			log.Info("recovery code used")
+
+			return nil
+		case errors.Is(err, user_api.ErrNotFound):
+			return apperr.MFACodeInvalid()
+		default:
+			log.Error("failed to consume recovery code", slog.Any("error", err))
+
+			return commonapperr.Service("consume recovery code", err)
+		}
+	}
+
+	mfa, err := s.userClient.GetMFA(ctx, userID)
+
+	if err != nil {
+		if errors.Is(err, user_api.ErrNotFound) {
+			return apperr.MFANotEnabled()
+		}
+
+		log.Error("failed to get mfa settings", slog.Any("error", err))
+
+		return commonapperr.Service("get mfa settings", err)
+	}
+
+	secret, err := s.mfaSecrets.Open(mfa.TOTPSecret)
+
+	if err != nil {
+		log.Error("failed to decrypt totp secret", slog.Any("error", err))
+
+		return commonapperr.Internal("decrypt totp secret", err)
+	}
+
+	return s.checkTOTP(ctx, userID, string(secret), code, log)
+}
+
+// checkTOTP не даёт принять один и тот же код дважды в пределах окна.
+func (s *AuthUsecase) checkTOTP(ctx context.Context, userID uuid.UUID, secret, code string, log *slog.Logger) error {
+	step, ok := totp.Validate(secret, code, time.Now())
+
+	if !ok {
+		return apperr.MFACodeInvalid()
+	}
+
+	fresh, err := s.mfaStore.MarkTOTPUsed(ctx, userID, step, totpReplayTTL)
+
+	if err != nil {
+		log.Error("failed to mark totp code as used", slog.Any("error", err))
+
+		return commonapperr.Internal("mark totp used", err)
+	}
+
+	if !fresh {
+		log.Warn("totp code replay rejected")
+
+		return apperr.MFACodeInvalid()
+	}
+
+	return nil
+}
+
+func hashChallengeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
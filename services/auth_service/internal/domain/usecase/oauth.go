@@ -0,0 +1,510 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/clients/user_api"
+	apperr "github.com/rockkley/pushpost/services/auth_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain/dto"
+	"github.com/rockkley/pushpost/services/auth_service/internal/oauth"
+	redisrepo "github.com/rockkley/pushpost/services/auth_service/internal/repository/redis"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/ctxlog"
+	"github.com/rockkley/pushpost/services/common_service/reserved"
+)
+
+const (
+	oauthStateTTL = 10 * time.Minute
+	// попыток подобрать свободное имя для нового пользователя, прежде чем сдаться
+	maxUsernameAttempts = 5
+	minUsernameLength   = 3
+	maxUsernameBase     = 24
+	fallbackUsername    = "user"
+)
+
+// unusablePasswordHash — у пользователей, созданных через провайдера, пароля нет.
+// Такой хеш не совпадёт ни с одним паролем; задать пароль можно через сброс.
+const unusablePasswordHash = "!"
+
+func (s *AuthUsecase) StartOAuth(ctx context.Context, provider string, req dto.OAuthStartDTO) (*dto.OAuthAuthorizationDTO, error) {
+	deviceID := req.DeviceID
+
+	if deviceID == uuid.Nil {
+		deviceID = uuid.New()
+	}
+
+	return s.beginOAuth(ctx, provider, &domain.OAuthState{
+		Device: domain.Device{ID: deviceID, Name: req.DeviceName},
+	})
+}
+
+// StartOAuthLink начинает привязку провайдера к уже вошедшему пользователю. State
+// запоминает сессию: завершает привязку CompleteOAuthLink из неё же, а не анонимный callback.
+func (s *AuthUsecase) StartOAuthLink(ctx context.Context, userID, sessionID uuid.UUID, provider string) (*dto.OAuthAuthorizationDTO, error) {
+	return s.beginOAuth(ctx, provider, &domain.OAuthState{LinkUserID: userID, LinkSessionID: sessionID})
+}
+
+func (s *AuthUsecase) beginOAuth(ctx context.Context, provider string, state *domain.OAuthState) (*dto.OAuthAuthorizationDTO, error) {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.beginOAuth"),
+		slog.String("provider", provider),
+	)
+
+	p, ok := s.oauthProviders[provider]
+
+	if !ok {
+		return nil, apperr.OAuthProviderUnknown()
+	}
+
+	stateToken, err := oauth.NewVerifier()
+
+	if err != nil {
+		return nil, commonapperr.Internal("generate oauth state", err)
+	}
+
+	if state.CodeVerifier, err = oauth.NewVerifier(); err != nil {
+		return nil, commonapperr.Internal("generate pkce verifier", err)
+	}
+
+	state.Provider = provider
+
+	if err = s.oauthStates.Save(ctx, stateToken, state, oauthStateTTL); err != nil {
+		log.Error("failed to save oauth state", slog.Any("error", err))
+
+		return nil, commonapperr.Internal("save oauth state", err)
+	}
+
+	return &dto.OAuthAuthorizationDTO{
+		AuthorizationURL: p.AuthCodeURL(stateToken, oauth.Challenge(state.CodeVerifier)),
+	}, nil
+}
+
+// CompleteOAuth обрабатывает возврат от провайдера. Вход по уже привязанной личности
+// создаёт сессию так же, как Login; новая личность с подтверждённым email регистрирует
+// пользователя. Совпадение email с существующим аккаунтом не связывает их автоматически.
+// State привязки здесь не принимается: иначе чужой браузер мог бы завершить её за владельца.
+func (s *AuthUsecase) CompleteOAuth(
+	ctx context.Context,
+	provider string,
+	req dto.OAuthCallbackDTO,
+) (*dto.OAuthCallbackResultDTO, error) {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.CompleteOAuth"),
+		slog.String("provider", provider),
+	)
+
+	state, identity, err := s.exchangeOAuth(ctx, provider, req, uuid.Nil, uuid.Nil, log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := s.resolveOAuthUser(ctx, provider, identity, log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	log = log.With(slog.String("user_id", userID.String()))
+
+	user, err := s.userClient.GetUserByID(ctx, userID)
+
+	if err != nil {
+		log.Error("failed to get user", slog.Any("error", err))
+
+		return nil, commonapperr.Service("get user", err)
+	}
+
+	if err = checkSignInStatus(user); err != nil {
+		return nil, err
+	}
+
+	device := state.Device
+	device.UserAgent = req.UserAgent
+	device.IP = req.IP
+
+	mfaEnabled, err := s.mfaEnabled(ctx, user.ID, log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if mfaEnabled {
+		result, err := s.startMFAChallenge(ctx, &domain.MFAChallenge{UserID: user.ID, Device: device}, log)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &dto.OAuthCallbackResultDTO{LoginResultDTO: result}, nil
+	}
+
+	if err = s.restoreIfPendingDeletion(ctx, user, log); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.startSession(ctx, user.ID, user.Role, device, log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OAuthCallbackResultDTO{LoginResultDTO: &dto.LoginResultDTO{TokenPairDTO: tokens}}, nil
+}
+
+// CompleteOAuthLink завершает привязку, начатую StartOAuthLink, в той же сессии.
+func (s *AuthUsecase) CompleteOAuthLink(
+	ctx context.Context,
+	userID, sessionID uuid.UUID,
+	provider string,
+	req dto.OAuthCallbackDTO,
+) (*dto.OAuthCallbackResultDTO, error) {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.CompleteOAuthLink"),
+		slog.String("provider", provider),
+		slog.String("user_id", userID.String()),
+	)
+
+	_, identity, err := s.exchangeOAuth(ctx, provider, req, userID, sessionID, log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.linkIdentity(ctx, userID, provider, identity, log); err != nil {
+		return nil, err
+	}
+
+	return &dto.OAuthCallbackResultDTO{LinkedProvider: provider}, nil
+}
+
+// exchangeOAuth забирает state и меняет код на личность у провайдера. State должен быть
+// начат той же сессией (linkUserID, linkSessionID); для обычного входа оба нулевые.
+func (s *AuthUsecase) exchangeOAuth(
+	ctx context.Context,
+	provider string,
+	req dto.OAuthCallbackDTO,
+	linkUserID, linkSessionID uuid.UUID,
+	log *slog.Logger,
+) (*domain.OAuthState, *oauth.Identity, error) {
+	p, ok := s.oauthProviders[provider]
+
+	if !ok {
+		return nil, nil, apperr.OAuthProviderUnknown()
+	}
+
+	state, err := s.oauthStates.Take(ctx, req.State)
+
+	if err != nil {
+		if errors.Is(err, redisrepo.ErrOAuthStateNotFound) {
+			return nil, nil, apperr.OAuthStateInvalid()
+		}
+
+		log.Error("failed to get oauth state", slog.Any("error", err))
+
+		return nil, nil, commonapperr.Internal("get oauth state", err)
+	}
+
+	if state.Provider != provider || state.LinkUserID != linkUserID || state.LinkSessionID != linkSessionID {
+		log.Warn("oauth state does not match the callback")
+
+		return nil, nil, apperr.OAuthStateInvalid()
+	}
+
+	identity, err := p.Exchange(ctx, req.Code, state.CodeVerifier)
+
+	if err != nil {
+		log.Warn("oauth exchange failed", slog.Any("error", err))
+
+		return nil, nil, apperr.OAuthFailed()
+	}
+
+	return state, identity, nil
+}
+
+// resolveOAuthUser находит пользователя по привязанной личности или регистрирует нового.
+func (s *AuthUsecase) resolveOAuthUser(
+	ctx context.Context,
+	provider string,
+	identity *oauth.Identity,
+	log *slog.Logger,
+) (uuid.UUID, error) {
+	linked, err := s.identities.Get(ctx, provider, identity.Subject)
+
+	if err == nil {
+		return linked.UserID, nil
+	}
+
+	if !isAppErrorCode(err, apperr.CodeIdentityNotFound) {
+		log.Error("failed to get external identity", slog.Any("error", err))
+
+		return uuid.Nil, commonapperr.Internal("get external identity", err)
+	}
+
+	userEmail := strings.ToLower(strings.TrimSpace(identity.Email))
+
+	if userEmail == "" || !identity.EmailVerified {
+		return uuid.Nil, apperr.OAuthEmailUnverified()
+	}
+
+	_, err = s.userClient.GetUserByEmail(ctx, userEmail)
+
+	switch {
+	case err == nil:
+		return uuid.Nil, apperr.OAuthEmailTaken()
+	case !errors.Is(err, user_api.ErrNotFound):
+		log.Error("failed to check email", slog.Any("error", err))
+
+		return uuid.Nil, commonapperr.Service("check email", err)
+	}
+
+	user, err := s.provisionUser(ctx, identity, userEmail, log)
+
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if err = s.linkIdentity(ctx, user.ID, provider, identity, log); err != nil {
+		log.Error("user provisioned, but identity was not linked",
+			slog.String("user_id", user.ID.String()),
+			slog.Any("error", err),
+		)
+		s.discardProvisionedUser(ctx, user.ID, log)
+
+		return uuid.Nil, err
+	}
+
+	return user.ID, nil
+}
+
+// provisionUser создаёт пользователя без пароля с именем, выведенным из данных провайдера.
+// Email уже подтверждён провайдером, поэтому аккаунт сразу активируется.
+func (s *AuthUsecase) provisionUser(
+	ctx context.Context,
+	identity *oauth.Identity,
+	userEmail string,
+	log *slog.Logger,
+) (*user_api.UserResponse, error) {
+	base := identity.Username
+
+	if base == "" {
+		base, _, _ = strings.Cut(userEmail, "@")
+	}
+
+	base = usernameBase(base)
+
+	for attempt := range maxUsernameAttempts {
+		username := base
+
+		if attempt > 0 {
+			username = fmt.Sprintf("%s_%04d", base, rand.IntN(10000))
+		}
+
+		if reserved.IsReserved(username) {
+			continue
+		}
+
+		created, err := s.userClient.CreateUser(ctx, user_api.CreateUserRequest{
+			Username:     username,
+			Email:        userEmail,
+			PasswordHash: unusablePasswordHash,
+		})
+
+		if err != nil {
+			var appErr commonapperr.AppError
+
+			if errors.As(err, &appErr) && appErr.HTTPStatus() == http.StatusConflict {
+				if appErr.Field() == "username" {
+					continue
+				}
+
+				// тот же адрес успели зарегистрировать параллельно
+				return nil, apperr.OAuthEmailTaken()
+			}
+
+			log.Error("failed to create user", slog.Any("error", err))
+
+			return nil, commonapperr.Service("create user", err)
+		}
+
+		if err = s.userClient.ActivateUser(ctx, userEmail); err != nil {
+			log.Error("failed to activate provisioned user", slog.Any("error", err))
+			s.discardProvisionedUser(ctx, created.ID, log)
+
+			return nil, commonapperr.Service("activate user", err)
+		}
+
+		log.Info("user registered via oauth", slog.String("user_id", created.ID.String()))
+
+		return created, nil
+	}
+
+	return nil, commonapperr.Internal("generate username", errors.New("no free username after several attempts"))
+}
+
+// discardProvisionedUser откатывает создание пользователя, если вход через провайдера
+// не удалось довести до конца: аккаунт без пароля и личности недоступен, а его email
+// блокировал бы повторные попытки.
+func (s *AuthUsecase) discardProvisionedUser(ctx context.Context, userID uuid.UUID, log *slog.Logger) {
+	if err := s.userClient.DeleteUser(ctx, userID); err != nil {
+		log.Error("failed to roll back provisioned user",
+			slog.String("user_id", userID.String()),
+			slog.Any("error", err),
+		)
+
+		return
+	}
+
+	log.Info("provisioned user rolled back", slog.String("user_id", userID.String()))
+}
+
+func (s *AuthUsecase) linkIdentity(
+	ctx context.Context,
+	userID uuid.UUID,
+	provider string,
+	identity *oauth.Identity,
+	log *slog.Logger,
+) error {
+	err := s.identities.Create(ctx, &domain.ExternalIdentity{
+		Provider:  provider,
+		Subject:   identity.Subject,
+		UserID:    userID,
+		Email:     identity.Email,
+		CreatedAt: time.Now().UTC(),
+	})
+
+	if err != nil {
+		if isAppErrorCode(err, apperr.CodeIdentityAlreadyLinked) || isAppErrorCode(err, apperr.CodeProviderAlreadyLinked) {
+			return err
+		}
+
+		log.Error("failed to link external identity", slog.Any("error", err))
+
+		return commonapperr.Internal("link external identity", err)
+	}
+
+	s.recordAudit(ctx, &domain.AuditEvent{
+		Type:     domain.AuditIdentityLinked,
+		UserID:   userID,
+		Metadata: map[string]string{"provider": provider},
+	}, log)
+
+	return nil
+}
+
+func (s *AuthUsecase) ListIdentities(ctx context.Context, userID uuid.UUID) ([]*domain.ExternalIdentity, error) {
+	identities, err := s.identities.ListByUser(ctx, userID)
+
+	if err != nil {
+		ctxlog.From(ctx).Error("failed to list external identities",
+			slog.String("op", "AuthUsecase.ListIdentities"),
+			slog.Any("error", err),
+		)
+
+		return nil, commonapperr.Internal("list external identities", err)
+	}
+
+	return identities, nil
+}
+
+// UnlinkIdentity не даёт отвязать последнего провайдера у пользователя без пароля —
+// иначе он не сможет войти.
+func (s *AuthUsecase) UnlinkIdentity(ctx context.Context, userID uuid.UUID, provider string) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.UnlinkIdentity"),
+		slog.String("user_id", userID.String()),
+		slog.String("provider", provider),
+	)
+
+	identities, err := s.identities.ListByUser(ctx, userID)
+
+	if err != nil {
+		log.Error("failed to list external identities", slog.Any("error", err))
+
+		return commonapperr.Internal("list external identities", err)
+	}
+
+	found := false
+
+	for _, identity := range identities {
+		found = found || identity.Provider == provider
+	}
+
+	if !found {
+		return apperr.IdentityNotFound()
+	}
+
+	if len(identities) == 1 {
+		user, err := s.userClient.GetUserByID(ctx, userID)
+
+		if err != nil {
+			log.Error("failed to get user", slog.Any("error", err))
+
+			return commonapperr.Service("get user", err)
+		}
+
+		if user.PasswordHash == unusablePasswordHash {
+			return apperr.LastSignInMethod()
+		}
+	}
+
+	if err = s.identities.Delete(ctx, userID, provider); err != nil {
+		if isAppErrorCode(err, apperr.CodeIdentityNotFound) {
+			return err
+		}
+
+		log.Error("failed to unlink external identity", slog.Any("error", err))
+
+		return commonapperr.Internal("unlink external identity", err)
+	}
+
+	s.recordAudit(ctx, &domain.AuditEvent{
+		Type:     domain.AuditIdentityUnlinked,
+		UserID:   userID,
+		Metadata: map[string]string{"provider": provider},
+	}, log)
+
+	log.Info("external identity unlinked")
+
+	return nil
+}
+
+// usernameBase приводит имя от провайдера к правилам username: [a-z0-9_], от 3 символов.
+// Длина ограничена так, чтобы с суффиксом _NNNN имя оставалось в пределах 30.
+func usernameBase(raw string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(raw) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		case r == '-' || r == '.':
+			b.WriteRune('_')
+		}
+
+		if b.Len() == maxUsernameBase {
+			break
+		}
+	}
+
+	if b.Len() < minUsernameLength {
+		return fallbackUsername
+	}
+
+	return b.String()
+}
+
+func isAppErrorCode(err error, code string) bool {
+	var appErr commonapperr.AppError
+
+	return errors.As(err, &appErr) && appErr.Code() == code
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/clients/user_api"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain/dto"
+	"github.com/rockkley/pushpost/services/common_service/ctxlog"
+)
+
+// SuspendUser блокирует аккаунт в user_service и сразу завершает все его сессии:
+// без этого уже выданные токены работали бы до истечения срока.
+func (s *AuthUsecase) SuspendUser(ctx context.Context, userID uuid.UUID, req dto.SuspendUserDTO) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.SuspendUser"),
+		slog.String("user_id", userID.String()),
+	)
+
+	if _, err := s.moderationTarget(ctx, req.Actor, userID, log); err != nil {
+		return err
+	}
+
+	err := s.userClient.SuspendUser(ctx, userID, user_api.SuspendUserRequest{
+		Until:  req.Until,
+		Reason: req.Reason,
+	})
+
+	if err != nil {
+		log.Warn("failed to suspend user", slog.Any("error", err))
+
+		return err
+	}
+
+	if err = s.revokeAllSessions(ctx, userID, log); err != nil {
+		return err
+	}
+
+	metadata := actorMetadata(req.Actor)
+	metadata["reason"] = req.Reason
+
+	if req.Until != nil {
+		metadata["suspended_until"] = req.Until.UTC().Format(time.RFC3339)
+	}
+
+	s.recordAudit(ctx, &domain.AuditEvent{Type: domain.AuditAccountSuspended, UserID: userID, Metadata: metadata}, log)
+
+	log.Info("user suspended")
+
+	return nil
+}
+
+func (s *AuthUsecase) ReinstateUser(ctx context.Context, userID uuid.UUID, actor dto.Actor) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "AuthUsecase.ReinstateUser"),
+		slog.String("user_id", userID.String()),
+	)
+
+	if _, err := s.moderationTarget(ctx, actor, userID, log); err != nil {
+		return err
+	}
+
+	if err := s.userClient.ReinstateUser(ctx, userID); err != nil {
+		log.Warn("failed to reinstate user", slog.Any("error", err))
+
+		return err
+	}
+
+	s.recordAudit(ctx, &domain.AuditEvent{
+		Type:     domain.AuditAccountReinstated,
+		UserID:   userID,
+		Metadata: actorMetadata(actor),
+	}, log)
+
+	log.Info("user reinstated")
+
+	return nil
+}
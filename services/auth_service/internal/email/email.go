@@ -0,0 +1,54 @@
+package email
+
+import (
+	"context"
+	"time"
+)
+
+// Имена шаблонов: templates/<locale>/<name>.txt (тема и текст) и <name>.html.
+const (
+	TemplateVerifyEmail   = "verify_email"
+	TemplatePasswordReset = "password_reset"
+	TemplateEmailChange   = "email_change"
+	TemplateAccountLocked = "account_locked"
+	TemplateMagicLink     = "magic_link"
+)
+
+// Templates — шаблоны, которые обязаны быть в локали по умолчанию.
+var Templates = []string{
+	TemplateVerifyEmail,
+	TemplatePasswordReset,
+	TemplateEmailChange,
+	TemplateAccountLocked,
+	TemplateMagicLink,
+}
+
+// Data — параметры шаблона. AppName подставляется автоматически.
+type Data map[string]any
+
+type Sender interface {
+	Send(ctx context.Context, template, to string, data Data) error
+}
+
+// Transport доставляет уже собранное письмо: SMTP, файл или очередь поверх них.
+type Transport interface {
+	Deliver(ctx context.Context, msg *Message) error
+}
+
+type localeCtxKey struct{}
+
+// WithLocale задаёт язык письма; обычно берётся из Accept-Language запроса.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeCtxKey{}, locale)
+}
+
+func LocaleFrom(ctx context.Context) string {
+	locale, _ := ctx.Value(localeCtxKey{}).(string)
+
+	return locale
+}
+
+// Minutes — длительность для шаблонов, не меньше минуты.
+func Minutes(d time.Duration) int {
+	return max(int(d.Minutes()), 1)
+}
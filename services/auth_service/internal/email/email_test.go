@@ -0,0 +1,136 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRenderer(t *testing.T) *Renderer {
+	t.Helper()
+	r, err := NewRenderer("PushPost", "en")
+	require.NoError(t, err)
+	return r
+}
+
+func TestRenderer_AllTemplatesInAllLocales(t *testing.T) {
+	r := newTestRenderer(t)
+	data := Data{"Code": "123456", "TTLMinutes": 5, "LockedMinutes": 15, "Link": "https://pushpost.app/magic?token=x"}
+
+	for _, locale := range []string{"en", "ru"} {
+		for _, name := range Templates {
+			msg, err := r.Render(locale, name, data)
+			require.NoError(t, err, "%s/%s", locale, name)
+			require.NotEmpty(t, msg.Subject)
+			require.NotContains(t, msg.Text, "<no value>", "%s/%s", locale, name)
+			require.NotContains(t, msg.HTML, "<no value>", "%s/%s", locale, name)
+		}
+	}
+}
+
+func TestRenderer_LocaleFallback(t *testing.T) {
+	r := newTestRenderer(t)
+
+	ru, err := r.Render("ru-RU", TemplateVerifyEmail, Data{"Code": "111111", "TTLMinutes": 5})
+	require.NoError(t, err)
+	require.Contains(t, ru.Subject, "Подтвердите")
+
+	fallback, err := r.Render("de", TemplateVerifyEmail, Data{"Code": "111111", "TTLMinutes": 5})
+	require.NoError(t, err)
+	require.Equal(t, "Confirm your PushPost account", fallback.Subject)
+
+	_, err = r.Render("en", "no_such_template", nil)
+	require.Error(t, err)
+}
+
+func TestRenderer_EscapesHTML(t *testing.T) {
+	r := newTestRenderer(t)
+
+	msg, err := r.Render("en", TemplateVerifyEmail, Data{"Code": "<script>", "TTLMinutes": 5})
+	require.NoError(t, err)
+	require.Contains(t, msg.Text, "<script>")
+	require.NotContains(t, msg.HTML, "<script>")
+}
+
+func TestMessage_Bytes_IsParseable(t *testing.T) {
+	msg := &Message{From: "PushPost <no-reply@pushpost.dev>", To: "u@e.com", Subject: "Привет", Text: "text body", HTML: "<p>html body</p>"}
+
+	raw, err := msg.Bytes()
+	require.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	require.Equal(t, "Привет", subject)
+	require.Contains(t, parsed.Header.Get("Message-ID"), "@pushpost.dev>")
+
+	_, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+
+	parts := multipart.NewReader(parsed.Body, params["boundary"])
+	var bodies []string
+	for {
+		part, err := parts.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		b, err := io.ReadAll(part)
+		require.NoError(t, err)
+		bodies = append(bodies, string(b))
+	}
+	require.Equal(t, []string{"text body", "<p>html body</p>"}, bodies)
+}
+
+type flakyTransport struct {
+	mu        sync.Mutex
+	failUntil int
+	calls     int
+	delivered chan *Message
+}
+
+func (f *flakyTransport) Deliver(_ context.Context, msg *Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.calls <= f.failUntil {
+		return errors.New("smtp: connection refused")
+	}
+	f.delivered <- msg
+	return nil
+}
+
+func TestQueue_RetriesUntilDelivered(t *testing.T) {
+	transport := &flakyTransport{failUntil: 2, delivered: make(chan *Message, 1)}
+	q := NewQueue(transport, QueueConfig{Size: 1, Workers: 1, MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}, slog.Default())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, q.Deliver(ctx, &Message{To: "u@e.com"}))
+	require.ErrorIs(t, q.Deliver(ctx, &Message{To: "other@e.com"}), ErrQueueFull)
+
+	go q.Run(ctx)
+
+	select {
+	case msg := <-transport.delivered:
+		require.Equal(t, "u@e.com", msg.To)
+	case <-time.After(time.Second):
+		t.Fatal("message was not delivered")
+	}
+	transport.mu.Lock()
+	defer transport.mu.Unlock()
+	require.Equal(t, 3, transport.calls)
+}
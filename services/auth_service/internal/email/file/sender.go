@@ -0,0 +1,54 @@
+package file
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rockkley/pushpost/services/auth_service/internal/email"
+)
+
+// Sender складывает письма в каталог как .eml вместо отправки — для локальной разработки.
+// Файл сначала пишется во временный и переименовывается, чтобы читатель не увидел половину письма.
+type Sender struct {
+	dir string
+}
+
+func NewSender(dir string) (*Sender, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create mail dir: %w", err)
+	}
+
+	return &Sender{dir: dir}, nil
+}
+
+func (s *Sender) Deliver(_ context.Context, msg *email.Message) error {
+	body, err := msg.Bytes()
+
+	if err != nil {
+		return err
+	}
+
+	suffix := make([]byte, 4)
+
+	if _, err = rand.Read(suffix); err != nil {
+		return fmt.Errorf("generate file name: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), hex.EncodeToString(suffix))
+	tmp := filepath.Join(s.dir, "."+name+".tmp")
+
+	if err = os.WriteFile(tmp, body, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", tmp, err)
+	}
+
+	if err = os.Rename(tmp, filepath.Join(s.dir, name)); err != nil {
+		return fmt.Errorf("rename %s: %w", tmp, err)
+	}
+
+	return nil
+}
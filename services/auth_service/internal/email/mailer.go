@@ -0,0 +1,33 @@
+package email
+
+import (
+	"context"
+	"fmt"
+)
+
+// Mailer рендерит шаблон на языке из контекста и передаёт письмо транспорту.
+type Mailer struct {
+	renderer  *Renderer
+	transport Transport
+	from      string
+}
+
+func NewMailer(renderer *Renderer, transport Transport, from string) *Mailer {
+	return &Mailer{renderer: renderer, transport: transport, from: from}
+}
+
+func (m *Mailer) Send(ctx context.Context, template, to string, data Data) error {
+	msg, err := m.renderer.Render(LocaleFrom(ctx), template, data)
+
+	if err != nil {
+		return err
+	}
+
+	msg.From, msg.To = m.from, to
+
+	if err = m.transport.Deliver(ctx, msg); err != nil {
+		return fmt.Errorf("deliver %s to %s: %w", template, to, err)
+	}
+
+	return nil
+}
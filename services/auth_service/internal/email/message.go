@@ -0,0 +1,84 @@
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Bytes собирает письмо в формате RFC 5322 с text/plain и text/html частями.
+func (m *Message) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+
+	mw := multipart.NewWriter(&buf)
+
+	id := make([]byte, 16)
+
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("generate message id: %w", err)
+	}
+
+	domain := "localhost"
+
+	if at := strings.LastIndex(m.From, "@"); at >= 0 {
+		domain = strings.TrimRight(m.From[at+1:], ">")
+	}
+
+	header := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMessage-ID: <%s@%s>\r\nMIME-Version: 1.0\r\n"+
+			"Content-Type: multipart/alternative; boundary=%q\r\n\r\n",
+		m.From, m.To, mime.QEncoding.Encode("utf-8", m.Subject),
+		time.Now().Format(time.RFC1123Z), hex.EncodeToString(id), domain, mw.Boundary(),
+	)
+
+	// multipart.Writer ничего не пишет до первой части, так что заголовки идут первыми
+	buf.WriteString(header)
+
+	if err := writePart(mw, "text/plain; charset=UTF-8", m.Text); err != nil {
+		return nil, err
+	}
+
+	if err := writePart(mw, "text/html; charset=UTF-8", m.HTML); err != nil {
+		return nil, err
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("close multipart: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writePart(mw *multipart.Writer, contentType, body string) error {
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+
+	if err != nil {
+		return fmt.Errorf("create %s part: %w", contentType, err)
+	}
+
+	qp := quotedprintable.NewWriter(part)
+
+	if _, err = qp.Write([]byte(body)); err != nil {
+		return fmt.Errorf("write %s part: %w", contentType, err)
+	}
+
+	return qp.Close()
+}
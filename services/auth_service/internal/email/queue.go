@@ -0,0 +1,119 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var ErrQueueFull = errors.New("email queue is full")
+
+type QueueConfig struct {
+	Size        int
+	Workers     int
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+func DefaultQueueConfig() QueueConfig {
+	return QueueConfig{
+		Size:        256,
+		Workers:     2,
+		MaxAttempts: 5,
+		BaseDelay:   2 * time.Second,
+		MaxDelay:    time.Minute,
+	}
+}
+
+// Queue отправляет письма в фоне с повторами, чтобы медленный SMTP не держал запрос.
+// Очередь в памяти: при остановке неотправленные письма теряются, код можно запросить повторно.
+type Queue struct {
+	next Transport
+	cfg  QueueConfig
+	jobs chan *Message
+	log  *slog.Logger
+}
+
+func NewQueue(next Transport, cfg QueueConfig, log *slog.Logger) *Queue {
+	return &Queue{
+		next: next,
+		cfg:  cfg,
+		jobs: make(chan *Message, cfg.Size),
+		log:  log.With(slog.String("component", "email_queue")),
+	}
+}
+
+// Deliver не ждёт отправки; ошибка только если очередь переполнена.
+func (q *Queue) Deliver(_ context.Context, msg *Message) error {
+	select {
+	case q.jobs <- msg:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Run обрабатывает очередь до отмены ctx.
+func (q *Queue) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for range max(q.cfg.Workers, 1) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case msg := <-q.jobs:
+					q.deliver(ctx, msg)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (q *Queue) deliver(ctx context.Context, msg *Message) {
+	delay := q.cfg.BaseDelay
+
+	for attempt := 1; ; attempt++ {
+		err := q.next.Deliver(ctx, msg)
+
+		if err == nil {
+			return
+		}
+
+		if attempt >= q.cfg.MaxAttempts {
+			q.log.Error("email dropped after max attempts",
+				slog.String("to", msg.To),
+				slog.String("subject", msg.Subject),
+				slog.Int("attempts", attempt),
+				slog.Any("error", err),
+			)
+
+			return
+		}
+
+		q.log.Warn("email delivery failed, retrying",
+			slog.String("to", msg.To),
+			slog.Int("attempt", attempt),
+			slog.Duration("retry_in", delay),
+			slog.Any("error", err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay = min(delay*2, q.cfg.MaxDelay)
+	}
+}
@@ -4,7 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/smtp"
-	"strings"
+
+	"github.com/rockkley/pushpost/services/auth_service/internal/email"
 )
 
 type Config struct {
@@ -12,45 +13,30 @@ type Config struct {
 	Port     int
 	Username string
 	Password string
-	From     string
-	AppName  string
 }
 
 type Sender struct {
-	cfg  Config
 	auth smtp.Auth
 	addr string
 }
 
 func NewSender(cfg Config) *Sender {
 	return &Sender{
-		cfg:  cfg,
 		auth: smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host),
 		addr: fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
 	}
 }
 
-func (s *Sender) SendOTP(ctx context.Context, to, code string) error {
-	msg := s.buildMessage(to, code)
-	if err := smtp.SendMail(s.addr, s.auth, s.cfg.From, []string{to}, []byte(msg)); err != nil {
-		return fmt.Errorf("smtp send otp to %s: %w", to, err)
+func (s *Sender) Deliver(_ context.Context, msg *email.Message) error {
+	body, err := msg.Bytes()
+
+	if err != nil {
+		return err
 	}
 
-	return nil
-}
+	if err = smtp.SendMail(s.addr, s.auth, msg.From, []string{msg.To}, body); err != nil {
+		return fmt.Errorf("smtp send to %s: %w", msg.To, err)
+	}
 
-func (s *Sender) buildMessage(to, code string) string {
-	var b strings.Builder
-	b.WriteString(fmt.Sprintf("From: %s\r\n", s.cfg.From))
-	b.WriteString(fmt.Sprintf("To: %s\r\n", to))
-	b.WriteString(fmt.Sprintf("Subject: Confirm your %s account\r\n", s.cfg.AppName))
-	b.WriteString("MIME-Version: 1.0\r\n")
-	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
-	b.WriteString("\r\n")
-	b.WriteString(fmt.Sprintf(
-		"Your confirmation code: %s\n\nThe code is valid for 5 minutes.\n\nIf you did not register, ignore this email.",
-		code,
-	))
-
-	return b.String()
+	return nil
 }
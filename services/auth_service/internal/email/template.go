@@ -0,0 +1,142 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// Renderer хранит разобранные шаблоны всех локалей. В .txt-шаблоне блок
+// {{define "subject"}} задаёт тему, остальное — текстовая часть; .html — HTML-часть.
+type Renderer struct {
+	appName       string
+	defaultLocale string
+	text          map[string]*texttemplate.Template
+	html          map[string]*htmltemplate.Template
+}
+
+func NewRenderer(appName, defaultLocale string) (*Renderer, error) {
+	r := &Renderer{
+		appName:       appName,
+		defaultLocale: defaultLocale,
+		text:          make(map[string]*texttemplate.Template),
+		html:          make(map[string]*htmltemplate.Template),
+	}
+
+	err := fs.WalkDir(templateFS, "templates", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		locale := path.Base(path.Dir(p))
+		name := strings.TrimSuffix(path.Base(p), path.Ext(p))
+		key := locale + "/" + name
+
+		switch path.Ext(p) {
+		case ".txt":
+			t, err := texttemplate.ParseFS(templateFS, p)
+
+			if err != nil {
+				return fmt.Errorf("parse %s: %w", p, err)
+			}
+
+			if t.Lookup("subject") == nil {
+				return fmt.Errorf("template %s has no subject block", p)
+			}
+
+			r.text[key] = t
+		case ".html":
+			t, err := htmltemplate.ParseFS(templateFS, p)
+
+			if err != nil {
+				return fmt.Errorf("parse %s: %w", p, err)
+			}
+
+			r.html[key] = t
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range Templates {
+		key := defaultLocale + "/" + name
+
+		if r.text[key] == nil || r.html[key] == nil {
+			return nil, fmt.Errorf("template %q is missing text or html part for default locale %q", name, defaultLocale)
+		}
+	}
+
+	return r, nil
+}
+
+// Render выбирает локаль: точное совпадение, затем язык без региона ("ru-RU" → "ru"),
+// затем локаль по умолчанию.
+func (r *Renderer) Render(locale, name string, data Data) (*Message, error) {
+	key := r.resolve(strings.ToLower(locale), name)
+
+	if key == "" {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+
+	values := make(Data, len(data)+1)
+
+	for k, v := range data {
+		values[k] = v
+	}
+
+	values["AppName"] = r.appName
+
+	text := r.text[key]
+
+	var subject, body, html bytes.Buffer
+
+	if err := text.ExecuteTemplate(&subject, "subject", values); err != nil {
+		return nil, fmt.Errorf("render %s subject: %w", key, err)
+	}
+
+	if err := text.Execute(&body, values); err != nil {
+		return nil, fmt.Errorf("render %s text: %w", key, err)
+	}
+
+	if err := r.html[key].Execute(&html, values); err != nil {
+		return nil, fmt.Errorf("render %s html: %w", key, err)
+	}
+
+	return &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(body.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
+
+func (r *Renderer) resolve(locale, name string) string {
+	candidates := []string{locale}
+
+	if lang, _, ok := strings.Cut(locale, "-"); ok {
+		candidates = append(candidates, lang)
+	}
+
+	candidates = append(candidates, r.defaultLocale)
+
+	for _, l := range candidates {
+		key := l + "/" + name
+
+		if r.text[key] != nil && r.html[key] != nil {
+			return key
+		}
+	}
+
+	return ""
+}
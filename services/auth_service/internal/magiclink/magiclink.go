@@ -0,0 +1,124 @@
+package magiclink
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MinKeySize — ключ HMAC короче 32 байт не принимаем.
+const MinKeySize = 32
+
+const payloadSize = 16 + 16 + 8 // userID, tokenID, expires (unix)
+
+var (
+	ErrInvalid = errors.New("magic link token invalid")
+	ErrExpired = errors.New("magic link token expired")
+)
+
+type Claims struct {
+	TokenID   string
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+}
+
+// Issuer выпускает подписанные HMAC-SHA256 токены для входа по ссылке.
+// Подпись отсекает подделки и просроченные токены без похода в Redis;
+// одноразовость обеспечивает хранилище по TokenID.
+type Issuer struct {
+	key     []byte
+	ttl     time.Duration
+	baseURL string
+}
+
+func New(key []byte, ttl time.Duration, baseURL string) (*Issuer, error) {
+	if len(key) < MinKeySize {
+		return nil, fmt.Errorf("magic link key must be at least %d bytes", MinKeySize)
+	}
+
+	if _, err := url.Parse(baseURL); err != nil {
+		return nil, fmt.Errorf("invalid magic link base url: %w", err)
+	}
+
+	return &Issuer{key: key, ttl: ttl, baseURL: baseURL}, nil
+}
+
+func (i *Issuer) TTL() time.Duration {
+	return i.ttl
+}
+
+// Issue возвращает токен и готовую ссылку вида <baseURL>?token=<token>.
+func (i *Issuer) Issue(userID uuid.UUID, now time.Time) (token, link string, err error) {
+	payload := make([]byte, payloadSize)
+	copy(payload[:16], userID[:])
+
+	if _, err = rand.Read(payload[16:32]); err != nil {
+		return "", "", fmt.Errorf("generate token id: %w", err)
+	}
+
+	binary.BigEndian.PutUint64(payload[32:], uint64(now.Add(i.ttl).Unix()))
+
+	token = base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(i.sign(payload))
+
+	u, err := url.Parse(i.baseURL)
+
+	if err != nil {
+		return "", "", fmt.Errorf("parse magic link base url: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+
+	return token, u.String(), nil
+}
+
+func (i *Issuer) Verify(token string, now time.Time) (Claims, error) {
+	rawPayload, rawSig, ok := strings.Cut(token, ".")
+
+	if !ok {
+		return Claims{}, ErrInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(rawPayload)
+
+	if err != nil || len(payload) != payloadSize {
+		return Claims{}, ErrInvalid
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(rawSig)
+
+	if err != nil || !hmac.Equal(sig, i.sign(payload)) {
+		return Claims{}, ErrInvalid
+	}
+
+	claims := Claims{
+		UserID:    uuid.UUID(payload[:16]),
+		TokenID:   hex.EncodeToString(payload[16:32]),
+		ExpiresAt: time.Unix(int64(binary.BigEndian.Uint64(payload[32:])), 0),
+	}
+
+	if !now.Before(claims.ExpiresAt) {
+		return Claims{}, ErrExpired
+	}
+
+	return claims, nil
+}
+
+func (i *Issuer) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, i.key)
+	mac.Write([]byte("magic-link:v1:"))
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,81 @@
+package magiclink
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIssuer(t *testing.T) *Issuer {
+	t.Helper()
+	i, err := New([]byte(strings.Repeat("k", MinKeySize)), 15*time.Minute, "https://pushpost.app/auth/magic?src=email")
+	require.NoError(t, err)
+	return i
+}
+
+func TestIssuer_IssueAndVerify(t *testing.T) {
+	i := newTestIssuer(t)
+	userID := uuid.New()
+	now := time.Now()
+
+	token, link, err := i.Issue(userID, now)
+	require.NoError(t, err)
+
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	require.Equal(t, token, u.Query().Get("token"))
+	require.Equal(t, "email", u.Query().Get("src"), "existing query parameters must be kept")
+
+	claims, err := i.Verify(token, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, userID, claims.UserID)
+	require.Len(t, claims.TokenID, 32)
+
+	other, _, err := i.Issue(userID, now)
+	require.NoError(t, err)
+	otherClaims, err := i.Verify(other, now)
+	require.NoError(t, err)
+	require.NotEqual(t, claims.TokenID, otherClaims.TokenID)
+}
+
+func TestIssuer_Verify_Expired(t *testing.T) {
+	i := newTestIssuer(t)
+	now := time.Now()
+
+	token, _, err := i.Issue(uuid.New(), now)
+	require.NoError(t, err)
+
+	_, err = i.Verify(token, now.Add(16*time.Minute))
+	require.ErrorIs(t, err, ErrExpired)
+}
+
+func TestIssuer_Verify_RejectsTampering(t *testing.T) {
+	i := newTestIssuer(t)
+	token, _, err := i.Issue(uuid.New(), time.Now())
+	require.NoError(t, err)
+
+	payload, sig, _ := strings.Cut(token, ".")
+	forged := "A" + payload[1:]
+	if forged == payload {
+		forged = "B" + payload[1:]
+	}
+
+	for _, bad := range []string{"", "garbage", payload, forged + "." + sig, payload + "." + sig[1:]} {
+		_, err = i.Verify(bad, time.Now())
+		require.ErrorIs(t, err, ErrInvalid, bad)
+	}
+
+	otherKey, err := New([]byte(strings.Repeat("x", MinKeySize)), time.Minute, "https://pushpost.app")
+	require.NoError(t, err)
+	_, err = otherKey.Verify(token, time.Now())
+	require.ErrorIs(t, err, ErrInvalid)
+}
+
+func TestNew_RejectsShortKey(t *testing.T) {
+	_, err := New([]byte("short"), time.Minute, "https://pushpost.app")
+	require.Error(t, err)
+}
@@ -0,0 +1,149 @@
+// Package oauthtest — локальный OAuth2-провайдер для тестов.
+package oauthtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/rockkley/pushpost/services/auth_service/internal/oauth"
+)
+
+const (
+	ClientID     = "test-client"
+	ClientSecret = "test-secret"
+	RedirectURL  = "https://pushpost.test/oauth/callback"
+)
+
+type grant struct {
+	challenge string
+	claims    map[string]any
+}
+
+// Provider выдаёт code через Authorize, а на /token проверяет PKCE, client_id и redirect_uri.
+// Code и access-токены одноразовые, как у настоящих провайдеров.
+type Provider struct {
+	server *httptest.Server
+
+	mu     sync.Mutex
+	seq    int
+	codes  map[string]grant
+	tokens map[string]map[string]any
+}
+
+func NewProvider() *Provider {
+	p := &Provider{
+		codes:  make(map[string]grant),
+		tokens: make(map[string]map[string]any),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", p.token)
+	mux.HandleFunc("GET /userinfo", p.userInfo)
+	p.server = httptest.NewServer(mux)
+
+	return p
+}
+
+func (p *Provider) Close() {
+	p.server.Close()
+}
+
+func (p *Provider) Config(name string) oauth.Config {
+	return oauth.Config{
+		Name:         name,
+		ClientID:     ClientID,
+		ClientSecret: ClientSecret,
+		AuthURL:      p.server.URL + "/authorize",
+		TokenURL:     p.server.URL + "/token",
+		UserInfoURL:  p.server.URL + "/userinfo",
+		RedirectURL:  RedirectURL,
+		Scopes:       []string{"openid", "email"},
+	}
+}
+
+// Authorize имитирует согласие пользователя на странице провайдера и возвращает
+// code и state, с которыми провайдер перенаправил бы браузер на RedirectURL.
+func (p *Provider) Authorize(authURL string, claims map[string]any) (code, state string, err error) {
+	u, err := url.Parse(authURL)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	q := u.Query()
+
+	if q.Get("client_id") != ClientID || q.Get("redirect_uri") != RedirectURL {
+		return "", "", errors.New("unexpected client_id or redirect_uri")
+	}
+
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		return "", "", errors.New("pkce challenge is required")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.seq++
+	code = fmt.Sprintf("code-%d", p.seq)
+	p.codes[code] = grant{challenge: q.Get("code_challenge"), claims: claims}
+
+	return code, q.Get("state"), nil
+}
+
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	code := r.PostForm.Get("code")
+	g, ok := p.codes[code]
+	delete(p.codes, code)
+
+	switch {
+	case !ok:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+	case r.PostForm.Get("client_id") != ClientID || r.PostForm.Get("client_secret") != ClientSecret:
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+	case r.PostForm.Get("redirect_uri") != RedirectURL:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+	case oauth.Challenge(r.PostForm.Get("code_verifier")) != g.challenge:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+	default:
+		p.seq++
+		accessToken := fmt.Sprintf("token-%d", p.seq)
+		p.tokens[accessToken] = g.claims
+		writeJSON(w, http.StatusOK, map[string]string{"access_token": accessToken, "token_type": "Bearer"})
+	}
+}
+
+func (p *Provider) userInfo(w http.ResponseWriter, r *http.Request) {
+	accessToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	p.mu.Lock()
+	claims, ok := p.tokens[accessToken]
+	delete(p.tokens, accessToken)
+	p.mu.Unlock()
+
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, claims)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
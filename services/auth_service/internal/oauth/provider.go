@@ -0,0 +1,237 @@
+// Package oauth реализует authorization code flow с PKCE для внешних провайдеров
+// (OIDC и OAuth2 в стиле GitHub). Личность берётся из userinfo-эндпоинта по access-токену,
+// поэтому подпись id_token не проверяется.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const maxBodySize = 1 << 20
+
+var (
+	ErrExchange = errors.New("oauth code exchange failed")
+	ErrUserInfo = errors.New("oauth userinfo request failed")
+)
+
+type Config struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Identity — пользователь на стороне провайдера. Subject стабилен, email может меняться.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Username      string
+	Name          string
+}
+
+type Provider struct {
+	cfg    Config
+	client *http.Client
+}
+
+func NewProvider(cfg Config, client *http.Client) (*Provider, error) {
+	if cfg.Name == "" || cfg.ClientID == "" {
+		return nil, errors.New("oauth provider name and client id are required")
+	}
+
+	for _, raw := range []string{cfg.AuthURL, cfg.TokenURL, cfg.UserInfoURL, cfg.RedirectURL} {
+		if _, err := url.ParseRequestURI(raw); err != nil {
+			return nil, fmt.Errorf("oauth provider %s: invalid url %q: %w", cfg.Name, raw, err)
+		}
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Provider{cfg: cfg, client: client}, nil
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// AuthCodeURL — адрес, на который фронтенд перенаправляет пользователя.
+func (p *Provider) AuthCodeURL(state, codeChallenge string) string {
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"state":                 {state},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	if len(p.cfg.Scopes) > 0 {
+		q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	}
+
+	sep := "?"
+
+	if strings.Contains(p.cfg.AuthURL, "?") {
+		sep = "&"
+	}
+
+	return p.cfg.AuthURL + sep + q.Encode()
+}
+
+// Exchange обменивает code на access-токен и сразу запрашивает по нему личность.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (*Identity, error) {
+	accessToken, err := p.exchange(ctx, code, codeVerifier)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return p.userInfo(ctx, accessToken)
+}
+
+func (p *Provider) exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+
+	if p.cfg.ClientSecret != "" {
+		form.Set("client_secret", p.cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.TokenURL, strings.NewReader(form.Encode()))
+
+	if err != nil {
+		return "", fmt.Errorf("build token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// GitHub без этого заголовка отвечает form-urlencoded
+	req.Header.Set("Accept", "application/json")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}
+
+	if err = p.do(req, &token); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrExchange, err)
+	}
+
+	// GitHub возвращает ошибку обмена с кодом 200
+	if token.Error != "" || token.AccessToken == "" {
+		return "", fmt.Errorf("%w: %s", ErrExchange, token.Error)
+	}
+
+	return token.AccessToken, nil
+}
+
+func (p *Provider) userInfo(ctx context.Context, accessToken string) (*Identity, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.UserInfoURL, nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("build userinfo request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var claims map[string]any
+
+	if err = p.do(req, &claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUserInfo, err)
+	}
+
+	identity := parseIdentity(claims)
+
+	if identity.Subject == "" {
+		return nil, fmt.Errorf("%w: response has no subject", ErrUserInfo)
+	}
+
+	return identity, nil
+}
+
+func (p *Provider) do(req *http.Request, out any) error {
+	resp, err := p.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	if err = json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+// parseIdentity понимает стандартные claims OIDC и ответ GitHub /user, где вместо sub
+// числовой id, а вместо preferred_username — login. GitHub не сообщает email_verified
+// в /user, поэтому такой email считается неподтверждённым.
+func parseIdentity(claims map[string]any) *Identity {
+	identity := &Identity{
+		Email:    stringClaim(claims, "email"),
+		Username: stringClaim(claims, "preferred_username"),
+		Name:     stringClaim(claims, "name"),
+	}
+
+	identity.Subject = stringClaim(claims, "sub")
+
+	if identity.Subject == "" {
+		identity.Subject = stringClaim(claims, "id")
+	}
+
+	if identity.Username == "" {
+		identity.Username = stringClaim(claims, "login")
+	}
+
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = v
+	case string:
+		identity.EmailVerified, _ = strconv.ParseBool(v)
+	}
+
+	return identity
+}
+
+func stringClaim(claims map[string]any, name string) string {
+	switch v := claims[name].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
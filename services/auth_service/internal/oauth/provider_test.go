@@ -0,0 +1,77 @@
+package oauth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rockkley/pushpost/services/auth_service/internal/oauth"
+	"github.com/rockkley/pushpost/services/auth_service/internal/oauth/oauthtest"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProvider(t *testing.T) (*oauth.Provider, *oauthtest.Provider) {
+	t.Helper()
+	fake := oauthtest.NewProvider()
+	t.Cleanup(fake.Close)
+
+	p, err := oauth.NewProvider(fake.Config("fake"), nil)
+	require.NoError(t, err)
+	return p, fake
+}
+
+func TestProvider_ExchangeWithPKCE(t *testing.T) {
+	p, fake := newTestProvider(t)
+
+	verifier, err := oauth.NewVerifier()
+	require.NoError(t, err)
+
+	code, state, err := fake.Authorize(p.AuthCodeURL("state-1", oauth.Challenge(verifier)), map[string]any{
+		"sub":            "42",
+		"email":          "alice@example.com",
+		"email_verified": true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "state-1", state)
+
+	identity, err := p.Exchange(context.Background(), code, verifier)
+	require.NoError(t, err)
+	require.Equal(t, "42", identity.Subject)
+	require.Equal(t, "alice@example.com", identity.Email)
+	require.True(t, identity.EmailVerified)
+}
+
+func TestProvider_ExchangeRejectsWrongVerifier(t *testing.T) {
+	p, fake := newTestProvider(t)
+
+	verifier, err := oauth.NewVerifier()
+	require.NoError(t, err)
+	other, err := oauth.NewVerifier()
+	require.NoError(t, err)
+
+	code, _, err := fake.Authorize(p.AuthCodeURL("s", oauth.Challenge(verifier)), map[string]any{"sub": "42"})
+	require.NoError(t, err)
+
+	_, err = p.Exchange(context.Background(), code, other)
+	require.True(t, errors.Is(err, oauth.ErrExchange), err)
+}
+
+func TestProvider_GitHubStyleUserInfo(t *testing.T) {
+	p, fake := newTestProvider(t)
+
+	verifier, err := oauth.NewVerifier()
+	require.NoError(t, err)
+
+	code, _, err := fake.Authorize(p.AuthCodeURL("s", oauth.Challenge(verifier)), map[string]any{
+		"id":    float64(1234567),
+		"login": "octocat",
+		"email": "octo@example.com",
+	})
+	require.NoError(t, err)
+
+	identity, err := p.Exchange(context.Background(), code, verifier)
+	require.NoError(t, err)
+	require.Equal(t, "1234567", identity.Subject)
+	require.Equal(t, "octocat", identity.Username)
+	require.False(t, identity.EmailVerified)
+}
@@ -0,0 +1,160 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/rockkley/pushpost/services/auth_service/internal/repository"
+)
+
+const (
+	reasonDelay   = "delay"
+	reasonLockout = "lockout"
+)
+
+// Policy описывает, как реагировать на серию неудач по одному ключу.
+// Первые FreeAttempts проходят без задержки, дальше пауза между попытками
+// удваивается от BaseDelay до MaxDelay, а на LockoutAfter ключ блокируется на LockoutFor.
+type Policy struct {
+	Name         string
+	Window       time.Duration
+	FreeAttempts int64
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	LockoutAfter int64
+	LockoutFor   time.Duration
+}
+
+var (
+	LoginAccount = Policy{
+		Name:         "login_account",
+		Window:       15 * time.Minute,
+		FreeAttempts: 3,
+		BaseDelay:    time.Second,
+		MaxDelay:     30 * time.Second,
+		LockoutAfter: 10,
+		LockoutFor:   15 * time.Minute,
+	}
+	// LoginIP мягче: за одним NAT может сидеть много людей, но перебор по
+	// многим аккаунтам с одного адреса всё равно упрётся в лимит.
+	LoginIP = Policy{
+		Name:         "login_ip",
+		Window:       15 * time.Minute,
+		FreeAttempts: 10,
+		BaseDelay:    time.Second,
+		MaxDelay:     time.Minute,
+		LockoutAfter: 50,
+		LockoutFor:   15 * time.Minute,
+	}
+	// RegisterIP и ResendOTPIP считают все запросы, а не только неудачные.
+	RegisterIP = Policy{
+		Name:         "register_ip",
+		Window:       time.Hour,
+		LockoutAfter: 10,
+		LockoutFor:   time.Hour,
+	}
+	ResendOTPIP = Policy{
+		Name:         "resend_otp_ip",
+		Window:       time.Hour,
+		LockoutAfter: 20,
+		LockoutFor:   time.Hour,
+	}
+	// MagicLinkEmail ограничивает письма на один адрес, MagicLinkIP — рассылку с одного адреса.
+	MagicLinkEmail = Policy{
+		Name:         "magic_link_email",
+		Window:       time.Hour,
+		LockoutAfter: 5,
+		LockoutFor:   time.Hour,
+	}
+	MagicLinkIP = Policy{
+		Name:         "magic_link_ip",
+		Window:       time.Hour,
+		LockoutAfter: 20,
+		LockoutFor:   time.Hour,
+	}
+)
+
+// Status — RetryAfter > 0 означает, что следующую попытку нужно отложить.
+// Locked выставляется только тем вызовом Fail, который включил блокировку.
+type Status struct {
+	RetryAfter time.Duration
+	Lockout    bool
+	Locked     bool
+}
+
+type Limiter struct {
+	store repository.AttemptStore
+}
+
+func New(store repository.AttemptStore) *Limiter {
+	return &Limiter{store: store}
+}
+
+func (l *Limiter) Check(ctx context.Context, p Policy, subject string) (Status, error) {
+	ttl, reason, err := l.store.Blocked(ctx, key(p, subject))
+
+	if err != nil {
+		return Status{}, err
+	}
+
+	return Status{RetryAfter: ttl, Lockout: reason == reasonLockout}, nil
+}
+
+func (l *Limiter) Fail(ctx context.Context, p Policy, subject string) (Status, error) {
+	k := key(p, subject)
+
+	failures, err := l.store.Fail(ctx, k, p.Window)
+
+	if err != nil {
+		return Status{}, err
+	}
+
+	delay, lockout := p.Delay(failures)
+
+	if delay <= 0 {
+		return Status{}, nil
+	}
+
+	reason := reasonDelay
+
+	if lockout {
+		reason = reasonLockout
+	}
+
+	if err = l.store.Block(ctx, k, reason, delay); err != nil {
+		return Status{}, err
+	}
+
+	return Status{RetryAfter: delay, Lockout: lockout, Locked: failures == p.LockoutAfter}, nil
+}
+
+func (l *Limiter) Reset(ctx context.Context, p Policy, subject string) error {
+	return l.store.Reset(ctx, key(p, subject))
+}
+
+// Delay — пауза после failures-й неудачи и признак полной блокировки.
+func (p Policy) Delay(failures int64) (time.Duration, bool) {
+	if p.LockoutAfter > 0 && failures >= p.LockoutAfter {
+		return p.LockoutFor, true
+	}
+
+	if p.BaseDelay <= 0 || failures <= p.FreeAttempts {
+		return 0, false
+	}
+
+	delay := p.BaseDelay
+
+	for i := p.FreeAttempts + 1; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return delay, false
+}
+
+func key(p Policy, subject string) string {
+	return p.Name + ":" + subject
+}
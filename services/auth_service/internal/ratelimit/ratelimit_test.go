@@ -0,0 +1,45 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Delay_Progressive(t *testing.T) {
+	p := Policy{FreeAttempts: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second, LockoutAfter: 10, LockoutFor: time.Hour}
+
+	cases := []struct {
+		failures int64
+		delay    time.Duration
+		lockout  bool
+	}{
+		{1, 0, false},
+		{3, 0, false},
+		{4, time.Second, false},
+		{5, 2 * time.Second, false},
+		{6, 4 * time.Second, false},
+		{7, 8 * time.Second, false},
+		{8, 10 * time.Second, false},
+		{9, 10 * time.Second, false},
+		{10, time.Hour, true},
+		{11, time.Hour, true},
+	}
+
+	for _, c := range cases {
+		delay, lockout := p.Delay(c.failures)
+		require.Equal(t, c.delay, delay, "failures=%d", c.failures)
+		require.Equal(t, c.lockout, lockout, "failures=%d", c.failures)
+	}
+}
+
+func TestPolicy_Delay_CountOnly(t *testing.T) {
+	delay, lockout := RegisterIP.Delay(RegisterIP.LockoutAfter - 1)
+	require.Zero(t, delay)
+	require.False(t, lockout)
+
+	delay, lockout = RegisterIP.Delay(RegisterIP.LockoutAfter)
+	require.Equal(t, RegisterIP.LockoutFor, delay)
+	require.True(t, lockout)
+}
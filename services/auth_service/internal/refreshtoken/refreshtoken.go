@@ -0,0 +1,52 @@
+package refreshtoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const secretBytes = 32
+
+var ErrMalformed = errors.New("malformed refresh token")
+
+// Generate возвращает непрозрачный токен вида "<sessionID>.<secret>".
+// ID сессии нужен, чтобы найти семейство токенов без отдельного индекса.
+func Generate(sessionID uuid.UUID) (string, error) {
+	secret := make([]byte, secretBytes)
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("generate refresh token: %w", err)
+	}
+
+	return sessionID.String() + "." + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func SessionID(token string) (uuid.UUID, error) {
+	rawID, secret, ok := strings.Cut(token, ".")
+
+	if !ok || secret == "" {
+		return uuid.Nil, ErrMalformed
+	}
+
+	sessionID, err := uuid.Parse(rawID)
+
+	if err != nil {
+		return uuid.Nil, ErrMalformed
+	}
+
+	return sessionID, nil
+}
+
+// Hash — в хранилище попадает только хеш, сам токен знает лишь клиент.
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
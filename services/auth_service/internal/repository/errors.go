@@ -0,0 +1,10 @@
+package repository
+
+import "errors"
+
+var (
+	// ErrRefreshTokenInvalid — токен не совпадает ни с текущим, ни с уже использованным.
+	ErrRefreshTokenInvalid = errors.New("refresh token invalid")
+	// ErrRefreshTokenReused — предъявлен токен, который уже был ротирован.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	"github.com/rockkley/pushpost/services/common_service/revocation"
 	"time"
 )
 
@@ -11,13 +12,91 @@ type SessionStore interface {
 	Save(ctx context.Context, session *domain.Session) error
 	Get(ctx context.Context, sessionID uuid.UUID) (*domain.Session, error)
 	Delete(ctx context.Context, sessionID uuid.UUID) error
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error)
+	// Touch обновляет LastSeenAt только у существующей сессии и не воскрешает удалённую.
+	Touch(ctx context.Context, sessionID uuid.UUID, lastSeenAt int64) error
+	SaveRefreshToken(ctx context.Context, session *domain.Session, tokenHash string) error
+	// RotateRefreshToken атомарно заменяет текущий хеш на nextHash.
+	// Возвращает ErrRefreshTokenReused, если presentedHash уже был ротирован.
+	RotateRefreshToken(ctx context.Context, sessionID uuid.UUID, presentedHash, nextHash string) error
 }
 
 type OTPStore interface {
 	Save(ctx context.Context, email, code string, ttl time.Duration) error
 	Get(ctx context.Context, email string) (string, error)
 	Delete(ctx context.Context, email string) error
+	// Consume атомарно сверяет и удаляет код: два параллельных запроса не погасят его дважды.
+	Consume(ctx context.Context, email, code string) (bool, error)
 	IncrAttempts(ctx context.Context, email string, ttl time.Duration) (int64, error)
 	SetCooldown(ctx context.Context, email string, ttl time.Duration) error
 	HasCooldown(ctx context.Context, email string) (bool, error)
 }
+
+type RevocationPublisher interface {
+	Publish(ctx context.Context, event revocation.Event) error
+}
+
+// MFAStore хранит короткоживущее состояние 2FA. Постоянные данные (секрет,
+// коды восстановления) лежат в user_service.
+type MFAStore interface {
+	SavePendingSecret(ctx context.Context, userID uuid.UUID, encryptedSecret string, ttl time.Duration) error
+	GetPendingSecret(ctx context.Context, userID uuid.UUID) (string, error)
+	DeletePendingSecret(ctx context.Context, userID uuid.UUID) error
+	SaveChallenge(ctx context.Context, tokenHash string, challenge *domain.MFAChallenge, ttl time.Duration) error
+	GetChallenge(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error)
+	DeleteChallenge(ctx context.Context, tokenHash string) error
+	IncrChallengeAttempts(ctx context.Context, tokenHash string, ttl time.Duration) (int64, error)
+	// MarkTOTPUsed возвращает false, если код этого шага уже был принят.
+	MarkTOTPUsed(ctx context.Context, userID uuid.UUID, step int64, ttl time.Duration) (bool, error)
+}
+
+// MagicLinkStore обеспечивает одноразовость ссылок для входа. Ключи живут до истечения токена.
+type MagicLinkStore interface {
+	// Consume помечает токен использованным; false, если его уже предъявляли.
+	Consume(ctx context.Context, tokenID string, ttl time.Duration) (bool, error)
+	// BindSession запоминает сессию до её выдачи, чтобы отозвать её при повторном предъявлении.
+	// ErrMagicLinkReused, если ссылку уже предъявили повторно: такую сессию выдавать нельзя.
+	BindSession(ctx context.Context, tokenID string, sessionID uuid.UUID, ttl time.Duration) error
+	// MarkReused фиксирует повторное предъявление и возвращает привязанную сессию; uuid.Nil,
+	// если её ещё нет (например, ждём 2FA) — тогда и последующий BindSession не пройдёт.
+	MarkReused(ctx context.Context, tokenID string) (uuid.UUID, error)
+}
+
+// SettingsRepository — пользовательские настройки входа. Отсутствие записи означает значения по умолчанию.
+type SettingsRepository interface {
+	Get(ctx context.Context, userID uuid.UUID) (*domain.Settings, error)
+	Save(ctx context.Context, settings *domain.Settings) error
+}
+
+// OAuthStateStore хранит state незавершённых входов через провайдеров. Take удаляет запись,
+// поэтому один state нельзя предъявить дважды.
+type OAuthStateStore interface {
+	Save(ctx context.Context, state string, data *domain.OAuthState, ttl time.Duration) error
+	Take(ctx context.Context, state string) (*domain.OAuthState, error)
+}
+
+// IdentityRepository — привязки внешних аккаунтов. У пользователя не больше одной привязки на провайдера.
+type IdentityRepository interface {
+	Get(ctx context.Context, provider, subject string) (*domain.ExternalIdentity, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.ExternalIdentity, error)
+	Create(ctx context.Context, identity *domain.ExternalIdentity) error
+	Delete(ctx context.Context, userID uuid.UUID, provider string) error
+}
+
+// AttemptStore — счётчики неудачных попыток и блокировки для ratelimit.
+type AttemptStore interface {
+	// Blocked возвращает оставшееся время блокировки и её причину; 0, если блокировки нет.
+	Blocked(ctx context.Context, key string) (time.Duration, string, error)
+	// Fail увеличивает счётчик; окно отсчитывается от первой неудачи.
+	Fail(ctx context.Context, key string, window time.Duration) (int64, error)
+	Block(ctx context.Context, key, reason string, ttl time.Duration) error
+	Reset(ctx context.Context, key string) error
+}
+
+// AuditLog — журнал событий безопасности; каждое событие также уходит в Kafka через outbox.
+type AuditLog interface {
+	Record(ctx context.Context, event *domain.AuditEvent) error
+	// ListByUser возвращает события новее-первыми, строго раньше курсора (before, beforeID):
+	// id разводит события с одинаковым created_at, чтобы они не терялись на границе страниц.
+	ListByUser(ctx context.Context, userID uuid.UUID, before time.Time, beforeID uuid.UUID, limit int) ([]*domain.AuditEvent, error)
+}
@@ -5,19 +5,24 @@ import (
 	"errors"
 	"github.com/google/uuid"
 	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	"github.com/rockkley/pushpost/services/auth_service/internal/repository"
 	"sync"
 )
 
 var ErrSessionNotFound = errors.New("session not found")
 
 type SessionStore struct {
-	mu   sync.RWMutex
-	data map[uuid.UUID]*domain.Session
+	mu      sync.RWMutex
+	data    map[uuid.UUID]*domain.Session
+	refresh map[uuid.UUID]string
+	rotated map[uuid.UUID]map[string]struct{}
 }
 
 func NewSessionStore() *SessionStore {
 	return &SessionStore{
-		data: make(map[uuid.UUID]*domain.Session),
+		data:    make(map[uuid.UUID]*domain.Session),
+		refresh: make(map[uuid.UUID]string),
+		rotated: make(map[uuid.UUID]map[string]struct{}),
 	}
 }
 
@@ -48,6 +53,76 @@ func (s *SessionStore) Delete(ctx context.Context, sessionID uuid.UUID) error {
 	defer s.mu.Unlock()
 
 	delete(s.data, sessionID)
+	delete(s.refresh, sessionID)
+	delete(s.rotated, sessionID)
+
+	return nil
+}
+
+func (s *SessionStore) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var sessions []*domain.Session
+
+	for _, session := range s.data {
+		if session.UserID == userID {
+			sessions = append(sessions, session)
+		}
+	}
+
+	return sessions, nil
+}
+
+func (s *SessionStore) Touch(ctx context.Context, sessionID uuid.UUID, lastSeenAt int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.data[sessionID]
+
+	if !ok {
+		return ErrSessionNotFound
+	}
+
+	session.LastSeenAt = lastSeenAt
+
+	return nil
+}
+
+func (s *SessionStore) SaveRefreshToken(ctx context.Context, session *domain.Session, tokenHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.refresh[session.SessionID] = tokenHash
+	delete(s.rotated, session.SessionID)
+
+	return nil
+}
+
+func (s *SessionStore) RotateRefreshToken(ctx context.Context, sessionID uuid.UUID, presentedHash, nextHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.refresh[sessionID]
+
+	if !ok {
+		return ErrSessionNotFound
+	}
+
+	if current != presentedHash {
+		if _, used := s.rotated[sessionID][presentedHash]; used {
+			return repository.ErrRefreshTokenReused
+		}
+
+		return repository.ErrRefreshTokenInvalid
+	}
+
+	if s.rotated[sessionID] == nil {
+		s.rotated[sessionID] = make(map[string]struct{})
+	}
+
+	s.rotated[sessionID][presentedHash] = struct{}{}
+	s.refresh[sessionID] = nextHash
 
 	return nil
 }
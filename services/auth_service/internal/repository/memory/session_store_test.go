@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	"github.com/rockkley/pushpost/services/auth_service/internal/repository"
 	"github.com/stretchr/testify/require"
 )
 
@@ -118,6 +119,48 @@ func TestSessionStore_MultipleSessionsIndependent(t *testing.T) {
 	}
 }
 
+func TestSessionStore_ListByUser(t *testing.T) {
+	store := NewSessionStore()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	require.NoError(t, store.Save(ctx, newSession(uuid.New(), userID)))
+	require.NoError(t, store.Save(ctx, newSession(uuid.New(), userID)))
+	require.NoError(t, store.Save(ctx, newSession(uuid.New(), uuid.New())))
+
+	sessions, err := store.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+}
+
+// ── Refresh Tokens ────────────────────────────────────────────────────────────
+
+func TestSessionStore_RotateRefreshToken(t *testing.T) {
+	store := NewSessionStore()
+	ctx := context.Background()
+	s := newSession(uuid.New(), uuid.New())
+
+	require.NoError(t, store.Save(ctx, s))
+	require.NoError(t, store.SaveRefreshToken(ctx, s, "h1"))
+
+	require.NoError(t, store.RotateRefreshToken(ctx, s.SessionID, "h1", "h2"))
+	require.ErrorIs(t, store.RotateRefreshToken(ctx, s.SessionID, "h1", "h3"), repository.ErrRefreshTokenReused)
+	require.ErrorIs(t, store.RotateRefreshToken(ctx, s.SessionID, "unknown", "h3"), repository.ErrRefreshTokenInvalid)
+	require.NoError(t, store.RotateRefreshToken(ctx, s.SessionID, "h2", "h3"))
+}
+
+func TestSessionStore_Delete_DropsRefreshState(t *testing.T) {
+	store := NewSessionStore()
+	ctx := context.Background()
+	s := newSession(uuid.New(), uuid.New())
+
+	require.NoError(t, store.Save(ctx, s))
+	require.NoError(t, store.SaveRefreshToken(ctx, s, "h1"))
+	require.NoError(t, store.Delete(ctx, s.SessionID))
+
+	require.ErrorIs(t, store.RotateRefreshToken(ctx, s.SessionID, "h1", "h2"), ErrSessionNotFound)
+}
+
 // ── Concurrency ───────────────────────────────────────────────────────────────
 
 func TestSessionStore_ConcurrentSaveGet(t *testing.T) {
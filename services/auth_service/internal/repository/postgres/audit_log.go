@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/outbox"
+	outboxpg "github.com/rockkley/pushpost/services/common_service/outbox/postgres"
+)
+
+type AuditLog struct {
+	db *sql.DB
+}
+
+func NewAuditLog(db *sql.DB) *AuditLog {
+	return &AuditLog{db: db}
+}
+
+// Record пишет событие в журнал и в outbox одной транзакцией, чтобы в Kafka
+// не ушло событие, которого нет в журнале, и наоборот.
+func (r *AuditLog) Record(ctx context.Context, event *domain.AuditEvent) (err error) {
+	metadata := []byte("{}")
+
+	if len(event.Metadata) > 0 {
+		if metadata, err = json.Marshal(event.Metadata); err != nil {
+			return fmt.Errorf("marshal audit metadata: %w", err)
+		}
+	}
+
+	payload, err := marshalEnvelope(event)
+
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	const query = `
+		INSERT INTO auth_events
+			(id, user_id, event_type, session_id, ip, user_agent, device_name, metadata, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err = tx.ExecContext(ctx, query,
+		event.ID, nullUUID(event.UserID), event.Type, nullUUID(event.SessionID),
+		event.IP, event.UserAgent, event.DeviceName, metadata, event.CreatedAt,
+	)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "insert auth event")
+	}
+
+	aggregateType, aggregateID := "user", event.UserID.String()
+
+	if event.UserID == uuid.Nil {
+		aggregateType, aggregateID = "auth", event.ID.String()
+	}
+
+	err = outboxpg.NewWriterRepository(tx).Insert(ctx, &outbox.OutboxEvent{
+		ID:            uuid.New(),
+		AggregateID:   aggregateID,
+		AggregateType: aggregateType,
+		EventType:     event.Type,
+		Payload:       payload,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AuditLog) ListByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	before time.Time,
+	beforeID uuid.UUID,
+	limit int,
+) ([]*domain.AuditEvent, error) {
+	const query = `
+		SELECT id, event_type, session_id, ip, user_agent, device_name, metadata, created_at
+		FROM   auth_events
+		WHERE  user_id = $1 AND (created_at, id) < ($2, $3)
+		ORDER  BY created_at DESC, id DESC
+		LIMIT  $4`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, before, beforeID, limit)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "list auth events")
+	}
+
+	defer rows.Close()
+
+	events := make([]*domain.AuditEvent, 0, limit)
+
+	for rows.Next() {
+		var (
+			e         = domain.AuditEvent{UserID: userID}
+			sessionID uuid.NullUUID
+			metadata  []byte
+		)
+
+		if err = rows.Scan(
+			&e.ID, &e.Type, &sessionID, &e.IP, &e.UserAgent, &e.DeviceName, &metadata, &e.CreatedAt,
+		); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan auth event")
+		}
+
+		e.SessionID = sessionID.UUID
+
+		if err = json.Unmarshal(metadata, &e.Metadata); err != nil {
+			return nil, fmt.Errorf("unmarshal audit metadata: %w", err)
+		}
+
+		events = append(events, &e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "list auth events")
+	}
+
+	return events, nil
+}
+
+func nullUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
+
+func marshalEnvelope(event *domain.AuditEvent) ([]byte, error) {
+	inner, err := json.Marshal(event)
+
+	if err != nil {
+		return nil, fmt.Errorf("marshal outbox payload: %w", err)
+	}
+
+	type envelope struct {
+		EventType string          `json:"event_type"`
+		Payload   json.RawMessage `json:"payload"`
+	}
+
+	wrapped, err := json.Marshal(envelope{EventType: event.Type, Payload: inner})
+
+	if err != nil {
+		return nil, fmt.Errorf("marshal outbox envelope: %w", err)
+	}
+
+	return wrapped, nil
+}
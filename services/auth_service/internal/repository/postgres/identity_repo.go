@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	apperr "github.com/rockkley/pushpost/services/auth_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+)
+
+type IdentityRepository struct {
+	db *sql.DB
+}
+
+func NewIdentityRepository(db *sql.DB) *IdentityRepository {
+	return &IdentityRepository{db: db}
+}
+
+func (r *IdentityRepository) Get(ctx context.Context, provider, subject string) (*domain.ExternalIdentity, error) {
+	const query = `
+		SELECT user_id, email, created_at
+		FROM   external_identities
+		WHERE  provider = $1 AND subject = $2`
+
+	identity := domain.ExternalIdentity{Provider: provider, Subject: subject}
+
+	err := r.db.QueryRowContext(ctx, query, provider, subject).Scan(&identity.UserID, &identity.Email, &identity.CreatedAt)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperr.IdentityNotFound()
+		}
+
+		return nil, commonapperr.MapPostgresError(err, "get external identity")
+	}
+
+	return &identity, nil
+}
+
+func (r *IdentityRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.ExternalIdentity, error) {
+	const query = `
+		SELECT provider, subject, email, created_at
+		FROM   external_identities
+		WHERE  user_id = $1
+		ORDER  BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "list external identities")
+	}
+
+	defer rows.Close()
+
+	var identities []*domain.ExternalIdentity
+
+	for rows.Next() {
+		identity := domain.ExternalIdentity{UserID: userID}
+
+		if err = rows.Scan(&identity.Provider, &identity.Subject, &identity.Email, &identity.CreatedAt); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan external identity")
+		}
+
+		identities = append(identities, &identity)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "list external identities")
+	}
+
+	return identities, nil
+}
+
+func (r *IdentityRepository) Create(ctx context.Context, identity *domain.ExternalIdentity) error {
+	const query = `
+		INSERT INTO external_identities (provider, subject, user_id, email, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		identity.Provider, identity.Subject, identity.UserID, identity.Email, identity.CreatedAt,
+	)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "create external identity", apperr.MapConstraint)
+	}
+
+	return nil
+}
+
+func (r *IdentityRepository) Delete(ctx context.Context, userID uuid.UUID, provider string) error {
+	const query = `DELETE FROM external_identities WHERE user_id = $1 AND provider = $2`
+
+	res, err := r.db.ExecContext(ctx, query, userID, provider)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "delete external identity")
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return apperr.IdentityNotFound()
+	}
+
+	return nil
+}
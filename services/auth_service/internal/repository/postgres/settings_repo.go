@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+)
+
+type SettingsRepository struct {
+	db *sql.DB
+}
+
+func NewSettingsRepository(db *sql.DB) *SettingsRepository {
+	return &SettingsRepository{db: db}
+}
+
+func (r *SettingsRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.Settings, error) {
+	const query = `SELECT magic_link_enabled FROM auth_settings WHERE user_id = $1`
+
+	settings := domain.DefaultSettings(userID)
+
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&settings.MagicLinkEnabled)
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, commonapperr.MapPostgresError(err, "get auth settings")
+	}
+
+	return settings, nil
+}
+
+func (r *SettingsRepository) Save(ctx context.Context, settings *domain.Settings) error {
+	const query = `
+		INSERT INTO auth_settings (user_id, magic_link_enabled)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET magic_link_enabled = EXCLUDED.magic_link_enabled, updated_at = NOW()`
+
+	if _, err := r.db.ExecContext(ctx, query, settings.UserID, settings.MagicLinkEnabled); err != nil {
+		return commonapperr.MapPostgresError(err, "save auth settings")
+	}
+
+	return nil
+}
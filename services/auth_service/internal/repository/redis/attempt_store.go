@@ -0,0 +1,83 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	attemptCountPrefix = "attempts:"
+	attemptBlockPrefix = "attempts_block:"
+)
+
+type AttemptStore struct {
+	rdb     *redis.Client
+	timeout time.Duration
+}
+
+func NewAttemptStore(rdb *redis.Client, timeout time.Duration) *AttemptStore {
+	return &AttemptStore{rdb: rdb, timeout: timeout}
+}
+
+func (s *AttemptStore) Blocked(ctx context.Context, key string) (time.Duration, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	pipe := s.rdb.Pipeline()
+	reasonCmd := pipe.Get(ctx, attemptBlockPrefix+key)
+	ttlCmd := pipe.PTTL(ctx, attemptBlockPrefix+key)
+
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return 0, "", fmt.Errorf("redis get attempt block: %w", err)
+	}
+
+	reason, err := reasonCmd.Result()
+
+	if errors.Is(err, redis.Nil) {
+		return 0, "", nil
+	}
+
+	// ключ мог истечь между GET и PTTL — тогда блокировки уже нет
+	ttl := ttlCmd.Val()
+
+	if ttl <= 0 {
+		return 0, "", nil
+	}
+
+	return ttl, reason, nil
+}
+
+func (s *AttemptStore) Fail(ctx context.Context, key string, window time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	cKey := attemptCountPrefix + key
+
+	pipe := s.rdb.TxPipeline()
+	incr := pipe.Incr(ctx, cKey)
+	pipe.ExpireNX(ctx, cKey, window)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("redis incr attempts: %w", err)
+	}
+
+	return incr.Val(), nil
+}
+
+func (s *AttemptStore) Block(ctx context.Context, key, reason string, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return s.rdb.Set(ctx, attemptBlockPrefix+key, reason, ttl).Err()
+}
+
+func (s *AttemptStore) Reset(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return s.rdb.Del(ctx, attemptCountPrefix+key, attemptBlockPrefix+key).Err()
+}
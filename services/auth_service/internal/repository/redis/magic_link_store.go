@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	magicLinkUsedPrefix = "magic_link_used:"
+	// magicLinkReused — значение ключа, если ссылку предъявили повторно до привязки сессии.
+	magicLinkReused = "reused"
+)
+
+var ErrMagicLinkReused = errors.New("magic link reused before session was bound")
+
+// Ключ ссылки: "" — использована, сессии ещё нет; id сессии — привязана; magicLinkReused —
+// повторное предъявление до привязки. Переходы только из "", поэтому делаются скриптами.
+var (
+	bindMagicLinkScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) ~= '' then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return 1
+`)
+	markMagicLinkReusedScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if current == '' then
+	redis.call('SET', KEYS[1], ARGV[1], 'KEEPTTL')
+end
+return current
+`)
+)
+
+type MagicLinkStore struct {
+	rdb     *redis.Client
+	timeout time.Duration
+}
+
+func NewMagicLinkStore(rdb *redis.Client, timeout time.Duration) *MagicLinkStore {
+	return &MagicLinkStore{rdb: rdb, timeout: timeout}
+}
+
+func (s *MagicLinkStore) Consume(ctx context.Context, tokenID string, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	fresh, err := s.rdb.SetNX(ctx, magicLinkUsedPrefix+tokenID, "", ttl).Result()
+
+	if err != nil {
+		return false, fmt.Errorf("redis consume magic link: %w", err)
+	}
+
+	return fresh, nil
+}
+
+func (s *MagicLinkStore) BindSession(ctx context.Context, tokenID string, sessionID uuid.UUID, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	bound, err := bindMagicLinkScript.Run(ctx, s.rdb, []string{magicLinkUsedPrefix + tokenID},
+		sessionID.String(), ttl.Milliseconds(),
+	).Int()
+
+	if err != nil {
+		return fmt.Errorf("redis bind magic link session: %w", err)
+	}
+
+	if bound == 0 {
+		return ErrMagicLinkReused
+	}
+
+	return nil
+}
+
+func (s *MagicLinkStore) MarkReused(ctx context.Context, tokenID string) (uuid.UUID, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	raw, err := markMagicLinkReusedScript.Run(ctx, s.rdb, []string{magicLinkUsedPrefix + tokenID}, magicLinkReused).Text()
+
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return uuid.Nil, nil
+		}
+
+		return uuid.Nil, fmt.Errorf("redis mark magic link reused: %w", err)
+	}
+
+	if raw == "" || raw == magicLinkReused {
+		return uuid.Nil, nil
+	}
+
+	return uuid.Parse(raw)
+}
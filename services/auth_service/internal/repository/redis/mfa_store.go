@@ -0,0 +1,141 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+)
+
+const (
+	mfaPendingPrefix   = "mfa_pending:"
+	mfaChallengePrefix = "mfa_challenge:"
+	mfaAttemptsPrefix  = "mfa_challenge_attempts:"
+	totpUsedPrefix     = "totp_used:"
+)
+
+var (
+	ErrMFASetupNotFound  = errors.New("mfa setup not found or expired")
+	ErrChallengeNotFound = errors.New("mfa challenge not found or expired")
+)
+
+type MFAStore struct {
+	rdb     *redis.Client
+	timeout time.Duration
+}
+
+func NewMFAStore(rdb *redis.Client, timeout time.Duration) *MFAStore {
+	return &MFAStore{rdb: rdb, timeout: timeout}
+}
+
+func (s *MFAStore) SavePendingSecret(ctx context.Context, userID uuid.UUID, encryptedSecret string, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return s.rdb.Set(ctx, mfaPendingPrefix+userID.String(), encryptedSecret, ttl).Err()
+}
+
+func (s *MFAStore) GetPendingSecret(ctx context.Context, userID uuid.UUID) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	secret, err := s.rdb.Get(ctx, mfaPendingPrefix+userID.String()).Result()
+
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", ErrMFASetupNotFound
+		}
+
+		return "", fmt.Errorf("redis get pending mfa secret: %w", err)
+	}
+
+	return secret, nil
+}
+
+func (s *MFAStore) DeletePendingSecret(ctx context.Context, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return s.rdb.Del(ctx, mfaPendingPrefix+userID.String()).Err()
+}
+
+func (s *MFAStore) SaveChallenge(ctx context.Context, tokenHash string, challenge *domain.MFAChallenge, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	data, err := json.Marshal(challenge)
+
+	if err != nil {
+		return fmt.Errorf("mfa challenge marshal: %w", err)
+	}
+
+	return s.rdb.Set(ctx, mfaChallengePrefix+tokenHash, data, ttl).Err()
+}
+
+func (s *MFAStore) GetChallenge(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	data, err := s.rdb.Get(ctx, mfaChallengePrefix+tokenHash).Bytes()
+
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrChallengeNotFound
+		}
+
+		return nil, fmt.Errorf("redis get mfa challenge: %w", err)
+	}
+
+	var challenge domain.MFAChallenge
+
+	if err = json.Unmarshal(data, &challenge); err != nil {
+		return nil, fmt.Errorf("mfa challenge unmarshal: %w", err)
+	}
+
+	return &challenge, nil
+}
+
+func (s *MFAStore) DeleteChallenge(ctx context.Context, tokenHash string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return s.rdb.Del(ctx, mfaChallengePrefix+tokenHash, mfaAttemptsPrefix+tokenHash).Err()
+}
+
+func (s *MFAStore) IncrChallengeAttempts(ctx context.Context, tokenHash string, ttl time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	aKey := mfaAttemptsPrefix + tokenHash
+	count, err := s.rdb.Incr(ctx, aKey).Result()
+
+	if err != nil {
+		return 0, fmt.Errorf("redis incr mfa attempts: %w", err)
+	}
+
+	if count == 1 {
+		s.rdb.Expire(ctx, aKey, ttl)
+	}
+
+	return count, nil
+}
+
+func (s *MFAStore) MarkTOTPUsed(ctx context.Context, userID uuid.UUID, step int64, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	key := totpUsedPrefix + userID.String() + ":" + strconv.FormatInt(step, 10)
+	fresh, err := s.rdb.SetNX(ctx, key, "1", ttl).Result()
+
+	if err != nil {
+		return false, fmt.Errorf("redis mark totp used: %w", err)
+	}
+
+	return fresh, nil
+}
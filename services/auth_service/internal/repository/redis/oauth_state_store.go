@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+)
+
+const oauthStatePrefix = "oauth_state:"
+
+var ErrOAuthStateNotFound = errors.New("oauth state not found or expired")
+
+type OAuthStateStore struct {
+	rdb     *redis.Client
+	timeout time.Duration
+}
+
+func NewOAuthStateStore(rdb *redis.Client, timeout time.Duration) *OAuthStateStore {
+	return &OAuthStateStore{rdb: rdb, timeout: timeout}
+}
+
+func (s *OAuthStateStore) Save(ctx context.Context, state string, data *domain.OAuthState, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	raw, err := json.Marshal(data)
+
+	if err != nil {
+		return fmt.Errorf("oauth state marshal: %w", err)
+	}
+
+	return s.rdb.Set(ctx, oauthStatePrefix+state, raw, ttl).Err()
+}
+
+func (s *OAuthStateStore) Take(ctx context.Context, state string) (*domain.OAuthState, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	raw, err := s.rdb.GetDel(ctx, oauthStatePrefix+state).Bytes()
+
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrOAuthStateNotFound
+		}
+
+		return nil, fmt.Errorf("redis take oauth state: %w", err)
+	}
+
+	var data domain.OAuthState
+
+	if err = json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("oauth state unmarshal: %w", err)
+	}
+
+	return &data, nil
+}
@@ -17,27 +17,47 @@ const (
 
 var ErrOTPNotFound = errors.New("OTP not found or expired")
 
+// consumeScript: -1 — кода нет, 0 — не совпал, 1 — совпал и удалён вместе со счётчиком попыток.
+var consumeScript = redis.NewScript(`
+local stored = redis.call('GET', KEYS[1])
+if not stored then
+	return -1
+end
+if stored ~= ARGV[1] then
+	return 0
+end
+redis.call('DEL', KEYS[1], KEYS[2])
+return 1
+`)
+
 type OTPStore struct {
 	rdb     *redis.Client
 	timeout time.Duration
+	scope   string
 }
 
 func NewOTPStore(rdb *redis.Client, timeout time.Duration) *OTPStore {
 	return &OTPStore{rdb: rdb, timeout: timeout}
 }
 
+// NewScopedOTPStore — отдельное пространство ключей, чтобы коды разных сценариев
+// (подтверждение почты, сброс пароля) не перетирали друг друга.
+func NewScopedOTPStore(rdb *redis.Client, timeout time.Duration, scope string) *OTPStore {
+	return &OTPStore{rdb: rdb, timeout: timeout, scope: scope + ":"}
+}
+
 func (s *OTPStore) Save(ctx context.Context, email, code string, ttl time.Duration) error {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	return s.rdb.Set(ctx, otpKey(email), code, ttl).Err()
+	return s.rdb.Set(ctx, s.otpKey(email), code, ttl).Err()
 }
 
 func (s *OTPStore) Get(ctx context.Context, email string) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	code, err := s.rdb.Get(ctx, otpKey(email)).Result()
+	code, err := s.rdb.Get(ctx, s.otpKey(email)).Result()
 
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
@@ -54,14 +74,31 @@ func (s *OTPStore) Delete(ctx context.Context, email string) error {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	return s.rdb.Del(ctx, otpKey(email), attemptsKey(email)).Err()
+	return s.rdb.Del(ctx, s.otpKey(email), s.attemptsKey(email)).Err()
+}
+
+func (s *OTPStore) Consume(ctx context.Context, email, code string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	res, err := consumeScript.Run(ctx, s.rdb, []string{s.otpKey(email), s.attemptsKey(email)}, code).Int()
+
+	if err != nil {
+		return false, fmt.Errorf("redis consume otp: %w", err)
+	}
+
+	if res < 0 {
+		return false, ErrOTPNotFound
+	}
+
+	return res == 1, nil
 }
 
 func (s *OTPStore) IncrAttempts(ctx context.Context, email string, ttl time.Duration) (int64, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	aKey := attemptsKey(email)
+	aKey := s.attemptsKey(email)
 	count, err := s.rdb.Incr(ctx, aKey).Result()
 
 	if err != nil {
@@ -79,14 +116,14 @@ func (s *OTPStore) SetCooldown(ctx context.Context, email string, ttl time.Durat
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	return s.rdb.Set(ctx, cooldownKey(email), "1", ttl).Err()
+	return s.rdb.Set(ctx, s.cooldownKey(email), "1", ttl).Err()
 }
 
 func (s *OTPStore) HasCooldown(ctx context.Context, email string) (bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	n, err := s.rdb.Exists(ctx, cooldownKey(email)).Result()
+	n, err := s.rdb.Exists(ctx, s.cooldownKey(email)).Result()
 
 	if err != nil {
 		return false, fmt.Errorf("redis check otp cooldown: %w", err)
@@ -95,6 +132,6 @@ func (s *OTPStore) HasCooldown(ctx context.Context, email string) (bool, error)
 	return n > 0, nil
 }
 
-func otpKey(email string) string      { return otpPrefix + email }
-func attemptsKey(email string) string { return attemptsPrefix + email }
-func cooldownKey(email string) string { return cooldownPrefix + email }
+func (s *OTPStore) otpKey(email string) string      { return otpPrefix + s.scope + email }
+func (s *OTPStore) attemptsKey(email string) string { return attemptsPrefix + s.scope + email }
+func (s *OTPStore) cooldownKey(email string) string { return cooldownPrefix + s.scope + email }
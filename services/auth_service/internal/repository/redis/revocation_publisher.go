@@ -0,0 +1,37 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rockkley/pushpost/services/common_service/revocation"
+)
+
+type RevocationPublisher struct {
+	redis   *redis.Client
+	timeout time.Duration
+}
+
+func NewRevocationPublisher(redis *redis.Client, timeout time.Duration) *RevocationPublisher {
+	return &RevocationPublisher{redis: redis, timeout: timeout}
+}
+
+func (p *RevocationPublisher) Publish(ctx context.Context, event revocation.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	data, err := json.Marshal(event)
+
+	if err != nil {
+		return fmt.Errorf("revocation marshal: %w", err)
+	}
+
+	if err = p.redis.Publish(ctx, revocation.Channel, data).Err(); err != nil {
+		return fmt.Errorf("redis publish revocation: %w", err)
+	}
+
+	return nil
+}
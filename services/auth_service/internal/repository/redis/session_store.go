@@ -8,10 +8,53 @@ import (
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	"github.com/rockkley/pushpost/services/auth_service/internal/repository"
 	"time"
 )
 
-const keyPrefix = "session:"
+const (
+	keyPrefix        = "session:"
+	userKeyPrefix    = "user_sessions:"
+	refreshKeyPrefix = "session_refresh:"
+	rotatedKeyPrefix = "session_refresh_rotated:"
+)
+
+// rotateScript сверяет предъявленный хеш с текущим и заменяет его одной операцией,
+// чтобы два параллельных refresh не получили по новому токену.
+// 1 — ротация прошла, 0 — refresh-состояния нет, -1 — повторное использование, -2 — чужой токен.
+var rotateScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if not current then
+	return 0
+end
+if current == ARGV[1] then
+	local ttl = redis.call('PTTL', KEYS[1])
+	if ttl <= 0 then
+		return 0
+	end
+	redis.call('SET', KEYS[1], ARGV[2], 'PX', ttl)
+	redis.call('SADD', KEYS[2], ARGV[1])
+	redis.call('PEXPIRE', KEYS[2], ttl)
+	return 1
+end
+if redis.call('SISMEMBER', KEYS[2], ARGV[1]) == 1 then
+	return -1
+end
+return -2
+`)
+
+// touchScript меняет LastSeenAt внутри записи сессии, сохраняя TTL; отсутствующую сессию
+// не создаёт, чтобы запоздавшее обновление не восстановило отозванную.
+var touchScript = redis.NewScript(`
+local data = redis.call('GET', KEYS[1])
+if not data then
+	return 0
+end
+local session = cjson.decode(data)
+session['LastSeenAt'] = tonumber(ARGV[1])
+redis.call('SET', KEYS[1], cjson.encode(session), 'KEEPTTL')
+return 1
+`)
 
 var ErrSessionNotFound = errors.New("session not found")
 
@@ -40,7 +83,14 @@ func (s *SessionStore) Save(ctx context.Context, session *domain.Session) error
 		return fmt.Errorf("session marshal: %w", err)
 	}
 
-	if err = s.redis.Set(ctx, key(session.SessionID), data, ttl).Err(); err != nil {
+	// индекс живёт не меньше самой долгой сессии пользователя
+	pipe := s.redis.TxPipeline()
+	pipe.Set(ctx, key(session.SessionID), data, ttl)
+	pipe.SAdd(ctx, userKey(session.UserID), session.SessionID.String())
+	pipe.ExpireNX(ctx, userKey(session.UserID), ttl)
+	pipe.ExpireGT(ctx, userKey(session.UserID), ttl)
+
+	if _, err = pipe.Exec(ctx); err != nil {
 		return fmt.Errorf("redis set session: %w", err)
 	}
 
@@ -71,14 +121,155 @@ func (s *SessionStore) Delete(ctx context.Context, sessionID uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	if err := s.redis.Del(ctx, key(sessionID)).Err(); err != nil {
+	data, err := s.redis.Get(ctx, key(sessionID)).Bytes()
+
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("redis get session: %w", err)
+	}
+
+	pipe := s.redis.TxPipeline()
+	pipe.Del(ctx, key(sessionID), refreshKey(sessionID), rotatedKey(sessionID))
+
+	var session domain.Session
+	if len(data) > 0 && json.Unmarshal(data, &session) == nil {
+		pipe.SRem(ctx, userKey(session.UserID), sessionID.String())
+	}
+
+	if _, err = pipe.Exec(ctx); err != nil {
 		return fmt.Errorf("redis delete session: %w", err)
 	}
 
 	return nil
 }
 
+func (s *SessionStore) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	ids, err := s.redis.SMembers(ctx, userKey(userID)).Result()
+
+	if err != nil {
+		return nil, fmt.Errorf("redis list user sessions: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = keyPrefix + id
+	}
+
+	values, err := s.redis.MGet(ctx, keys...).Result()
+
+	if err != nil {
+		return nil, fmt.Errorf("redis get user sessions: %w", err)
+	}
+
+	sessions := make([]*domain.Session, 0, len(values))
+	var stale []any
+
+	for i, v := range values {
+		raw, ok := v.(string)
+
+		if !ok {
+			// сессия истекла по TTL, а запись в индексе осталась
+			stale = append(stale, ids[i])
+			continue
+		}
+
+		var session domain.Session
+		if err = json.Unmarshal([]byte(raw), &session); err != nil {
+			return nil, fmt.Errorf("session unmarshal: %w", err)
+		}
+
+		sessions = append(sessions, &session)
+	}
+
+	// чистка индекса best-effort: не удалось сейчас — удалим при следующем запросе
+	if len(stale) > 0 {
+		s.redis.SRem(ctx, userKey(userID), stale...)
+	}
+
+	return sessions, nil
+}
+
+func (s *SessionStore) Touch(ctx context.Context, sessionID uuid.UUID, lastSeenAt int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	res, err := touchScript.Run(ctx, s.redis, []string{key(sessionID)}, lastSeenAt).Int()
+
+	if err != nil {
+		return fmt.Errorf("redis touch session: %w", err)
+	}
+
+	if res == 0 {
+		return fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
+	}
+
+	return nil
+}
+
+func (s *SessionStore) SaveRefreshToken(ctx context.Context, session *domain.Session, tokenHash string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	ttl := time.Until(time.Unix(session.Expires, 0))
+
+	if ttl <= 0 {
+		return fmt.Errorf("session already expired")
+	}
+
+	pipe := s.redis.TxPipeline()
+	pipe.Set(ctx, refreshKey(session.SessionID), tokenHash, ttl)
+	pipe.Del(ctx, rotatedKey(session.SessionID))
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis set refresh token: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionStore) RotateRefreshToken(ctx context.Context, sessionID uuid.UUID, presentedHash, nextHash string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	keys := []string{refreshKey(sessionID), rotatedKey(sessionID)}
+
+	res, err := rotateScript.Run(ctx, s.redis, keys, presentedHash, nextHash).Int()
+
+	if err != nil {
+		return fmt.Errorf("redis rotate refresh token: %w", err)
+	}
+
+	switch res {
+	case 1:
+		return nil
+	case 0:
+		return fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
+	case -1:
+		return repository.ErrRefreshTokenReused
+	default:
+		return repository.ErrRefreshTokenInvalid
+	}
+}
+
 func key(sessionID uuid.UUID) string {
 
 	return keyPrefix + sessionID.String()
 }
+
+func userKey(userID uuid.UUID) string {
+	return userKeyPrefix + userID.String()
+}
+
+func refreshKey(sessionID uuid.UUID) string {
+	return refreshKeyPrefix + sessionID.String()
+}
+
+func rotatedKey(sessionID uuid.UUID) string {
+	return rotatedKeyPrefix + sessionID.String()
+}
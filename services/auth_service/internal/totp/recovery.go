@@ -0,0 +1,61 @@
+package totp
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	RecoveryCodeCount  = 10
+	recoveryCodeLength = 10
+	// без 0/o, 1/l/i — чтобы код можно было переписать с бумаги
+	recoveryAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+// GenerateRecoveryCodes возвращает коды вида "abcde-fghjk".
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	maximum := big.NewInt(int64(len(recoveryAlphabet)))
+
+	for i := range codes {
+		var b strings.Builder
+
+		for j := 0; j < recoveryCodeLength; j++ {
+			if j == recoveryCodeLength/2 {
+				b.WriteByte('-')
+			}
+
+			idx, err := rand.Int(rand.Reader, maximum)
+
+			if err != nil {
+				return nil, fmt.Errorf("generate recovery code: %w", err)
+			}
+
+			b.WriteByte(recoveryAlphabet[idx.Int64()])
+		}
+
+		codes[i] = b.String()
+	}
+
+	return codes, nil
+}
+
+// HashRecoveryCode нормализует ввод (регистр, дефисы, пробелы) и хеширует его.
+// У кодов достаточно энтропии, поэтому медленный хеш не нужен.
+func HashRecoveryCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+
+	sum := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(sum[:])
+}
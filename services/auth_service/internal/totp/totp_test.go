@@ -0,0 +1,88 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// base32("12345678901234567890") — секрет из тестовых векторов RFC 6238.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// ── Validate ──────────────────────────────────────────────────────────────────
+
+func TestTOTP_Validate_RFC6238Vectors(t *testing.T) {
+	// Last six digits of the SHA1 vectors from RFC 6238, Appendix B.
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for ts, code := range vectors {
+		counter, ok := Validate(rfcSecret, code, time.Unix(ts, 0))
+		require.True(t, ok, "code %s at %d", code, ts)
+		require.Equal(t, ts/period, counter)
+	}
+}
+
+func TestTOTP_Validate_AllowsOneStepSkew(t *testing.T) {
+	_, ok := Validate(rfcSecret, "287082", time.Unix(59+period, 0))
+	require.True(t, ok)
+
+	_, ok = Validate(rfcSecret, "287082", time.Unix(59+3*period, 0))
+	require.False(t, ok)
+}
+
+func TestTOTP_Validate_RejectsMalformedInput(t *testing.T) {
+	_, ok := Validate(rfcSecret, "12345", time.Now())
+	require.False(t, ok)
+
+	_, ok = Validate("not base32!", "123456", time.Now())
+	require.False(t, ok)
+}
+
+func TestTOTP_GenerateSecret_RoundTrip(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	code, err := GenerateCode(secret, now)
+	require.NoError(t, err)
+
+	_, ok := Validate(secret, code, now)
+	require.True(t, ok)
+}
+
+func TestTOTP_URI(t *testing.T) {
+	uri := URI("PushPost", "alice@example.com", rfcSecret)
+
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/PushPost:alice@example.com?"))
+	require.Contains(t, uri, "secret="+rfcSecret)
+	require.Contains(t, uri, "issuer=PushPost")
+}
+
+// ── Recovery codes ────────────────────────────────────────────────────────────
+
+func TestRecoveryCodes_UniqueAndFormatted(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(RecoveryCodeCount)
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+
+	seen := make(map[string]bool)
+	for _, c := range codes {
+		require.Len(t, c, recoveryCodeLength+1)
+		require.Equal(t, byte('-'), c[recoveryCodeLength/2])
+		require.False(t, seen[c])
+		seen[c] = true
+	}
+}
+
+func TestRecoveryCodes_HashIsNormalized(t *testing.T) {
+	require.Equal(t, HashRecoveryCode("abcde-fghjk"), HashRecoveryCode(" ABCDE FGHJK "))
+	require.NotEqual(t, HashRecoveryCode("abcde-fghjk"), HashRecoveryCode("abcde-fghjm"))
+}
@@ -3,7 +3,10 @@ package http
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
 	"github.com/rockkley/pushpost/services/auth_service/internal/domain/dto"
@@ -11,6 +14,9 @@ import (
 	"github.com/rockkley/pushpost/services/auth_service/internal/transport/http/middleware"
 	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
 	"github.com/rockkley/pushpost/services/common_service/httperror"
+	commonmiddleware "github.com/rockkley/pushpost/services/common_service/middleware"
+	"github.com/rockkley/pushpost/services/common_service/roles"
+	"github.com/rockkley/pushpost/services/common_service/transport"
 )
 
 type AuthHandler struct {
@@ -59,13 +65,56 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) error {
 		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
 	}
 
-	token, err := h.authUseCase.Login(r.Context(), req)
+	req.UserAgent = r.UserAgent()
+	req.IP = middleware.ClientIP(r)
+
+	result, err := h.authUseCase.Login(r.Context(), req)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, result)
+}
+
+func (h *AuthHandler) LoginMFA(w http.ResponseWriter, r *http.Request) error {
+	var req dto.LoginMFADTO
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err := req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	tokens, err := h.authUseCase.LoginMFA(r.Context(), req)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, tokens)
+}
+
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) error {
+	var req dto.RefreshTokenDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err := req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	tokens, err := h.authUseCase.Refresh(r.Context(), req.RefreshToken)
 
 	if err != nil {
 		return err
 	}
 
-	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"token": token})
+	return httperror.WriteJSON(w, http.StatusOK, tokens)
 }
 
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) error {
@@ -119,3 +168,742 @@ func (h *AuthHandler) ResendOTP(w http.ResponseWriter, r *http.Request) error {
 		"message": "if this email is registered and unverified, you will receive a new code",
 	})
 }
+
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) error {
+	var req httpDto.ForgotPasswordRequestDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err := req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	if err := h.authUseCase.ForgotPassword(r.Context(), req.Email); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "if this email is registered, you will receive a password reset code",
+	})
+}
+
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) error {
+	var req httpDto.ResetPasswordRequestDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err := req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	if vErr := dto.ValidatePassword(req.NewPassword); vErr != nil {
+		return commonapperr.Validation(vErr.Code, "new_password", "invalid password")
+	}
+
+	if err := h.authUseCase.ResetPassword(r.Context(), req.Email, req.Code, req.NewPassword); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "password has been reset"})
+}
+
+func (h *AuthHandler) IntrospectSession(w http.ResponseWriter, r *http.Request) error {
+	sessionID, err := transport.ParsePathUUID(r, "sessionID")
+
+	if err != nil {
+		return err
+	}
+
+	session, err := h.authUseCase.IntrospectSession(r.Context(), sessionID)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, httpDto.SessionResponseDTO{
+		SessionID: session.SessionID,
+		UserID:    session.UserID,
+		DeviceID:  session.DeviceID,
+		ExpiresAt: time.Unix(session.Expires, 0).UTC(),
+	})
+}
+
+func (h *AuthHandler) ListSessions(w http.ResponseWriter, r *http.Request) error {
+	userID, ok := r.Context().Value(middleware.CtxUserIDKey).(uuid.UUID)
+
+	if !ok {
+		return commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "invalid session")
+	}
+
+	currentID, _ := r.Context().Value(middleware.CtxSessionIDKey).(uuid.UUID)
+
+	sessions, err := h.authUseCase.ListSessions(r.Context(), userID)
+
+	if err != nil {
+		return err
+	}
+
+	items := make([]httpDto.ActiveSessionDTO, 0, len(sessions))
+
+	for _, s := range sessions {
+		items = append(items, httpDto.ActiveSessionDTO{
+			SessionID:  s.SessionID,
+			DeviceID:   s.DeviceID,
+			DeviceName: s.DeviceName,
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  time.Unix(s.CreatedAt, 0).UTC(),
+			LastSeenAt: time.Unix(s.LastSeenAt, 0).UTC(),
+			Current:    s.SessionID == currentID,
+		})
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]any{
+		"sessions": items,
+		"count":    len(items),
+	})
+}
+
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) error {
+	userID, ok := r.Context().Value(middleware.CtxUserIDKey).(uuid.UUID)
+
+	if !ok {
+		return commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "invalid session")
+	}
+
+	sessionID, err := transport.ParsePathUUID(r, "sessionID")
+
+	if err != nil {
+		return err
+	}
+
+	if err = h.authUseCase.RevokeSession(r.Context(), userID, sessionID); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "session revoked"})
+}
+
+func (h *AuthHandler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) error {
+	userID, ok := r.Context().Value(middleware.CtxUserIDKey).(uuid.UUID)
+
+	if !ok {
+		return commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "invalid session")
+	}
+
+	sessionID, ok := r.Context().Value(middleware.CtxSessionIDKey).(uuid.UUID)
+
+	if !ok {
+		return commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "invalid session")
+	}
+
+	revoked, err := h.authUseCase.RevokeOtherSessions(r.Context(), userID, sessionID)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]int{"revoked": revoked})
+}
+
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) error {
+	userID, sessionID, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	var req httpDto.ChangePasswordRequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	if vErr := dto.ValidatePassword(req.NewPassword); vErr != nil {
+		return commonapperr.Validation(vErr.Code, "new_password", "invalid password")
+	}
+
+	if err = h.authUseCase.ChangePassword(r.Context(), userID, sessionID, req.CurrentPassword, req.NewPassword); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "password changed"})
+}
+
+func (h *AuthHandler) RequestEmailChange(w http.ResponseWriter, r *http.Request) error {
+	userID, _, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	var req httpDto.ChangeEmailRequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	if _, vErr := dto.ValidateEmail(req.NewEmail); vErr != nil {
+		return commonapperr.Validation(vErr.Code, "new_email", "invalid email")
+	}
+
+	if err = h.authUseCase.RequestEmailChange(r.Context(), userID, req.NewEmail); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "a confirmation code has been sent to the new email",
+	})
+}
+
+func (h *AuthHandler) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) error {
+	userID, sessionID, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	var req httpDto.ConfirmEmailChangeRequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	if err = h.authUseCase.ConfirmEmailChange(r.Context(), userID, sessionID, req.NewEmail, req.Code); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "email changed"})
+}
+
+// AuditLog — GET /auth/audit?limit=&before=&before_id=, before в RFC 3339.
+func (h *AuthHandler) AuditLog(w http.ResponseWriter, r *http.Request) error {
+	userID, _, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	var before time.Time
+
+	if raw := r.URL.Query().Get("before"); raw != "" {
+		if before, err = time.Parse(time.RFC3339Nano, raw); err != nil {
+			return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid before - must be an RFC 3339 timestamp")
+		}
+	}
+
+	var beforeID uuid.UUID
+
+	if raw := r.URL.Query().Get("before_id"); raw != "" {
+		if beforeID, err = uuid.Parse(raw); err != nil {
+			return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid before_id - must be a UUID")
+		}
+	}
+
+	var limit int
+
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		if limit, err = strconv.Atoi(raw); err != nil {
+			return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid limit - must be an integer")
+		}
+	}
+
+	events, err := h.authUseCase.ListAuditEvents(r.Context(), userID, before, beforeID, limit)
+
+	if err != nil {
+		return err
+	}
+
+	resp := httpDto.AuditLogResponseDTO{Events: make([]httpDto.AuditEventDTO, 0, len(events))}
+
+	for _, e := range events {
+		item := httpDto.AuditEventDTO{
+			ID:         e.ID,
+			Type:       e.Type,
+			IP:         e.IP,
+			UserAgent:  e.UserAgent,
+			DeviceName: e.DeviceName,
+			Metadata:   e.Metadata,
+			CreatedAt:  e.CreatedAt,
+		}
+
+		if e.SessionID != uuid.Nil {
+			item.SessionID = &e.SessionID
+		}
+
+		resp.Events = append(resp.Events, item)
+	}
+
+	if len(events) > 0 {
+		last := events[len(events)-1]
+		resp.NextBefore, resp.NextBeforeID = &last.CreatedAt, &last.ID
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *AuthHandler) GetMFAStatus(w http.ResponseWriter, r *http.Request) error {
+	userID, _, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	status, err := h.authUseCase.GetMFAStatus(r.Context(), userID)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, status)
+}
+
+func (h *AuthHandler) SetupMFA(w http.ResponseWriter, r *http.Request) error {
+	userID, _, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	setup, err := h.authUseCase.SetupMFA(r.Context(), userID)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, setup)
+}
+
+func (h *AuthHandler) ConfirmMFA(w http.ResponseWriter, r *http.Request) error {
+	userID, _, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	var req httpDto.ConfirmMFARequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	codes, err := h.authUseCase.ConfirmMFA(r.Context(), userID, req.Code)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string][]string{"recovery_codes": codes})
+}
+
+func (h *AuthHandler) DisableMFA(w http.ResponseWriter, r *http.Request) error {
+	userID, sessionID, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	var req httpDto.DisableMFARequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	err = h.authUseCase.DisableMFA(r.Context(), userID, sessionID, req.CurrentPassword, req.Code, req.RecoveryCode)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "two-factor authentication disabled"})
+}
+
+func sessionFromContext(r *http.Request) (uuid.UUID, uuid.UUID, error) {
+	userID, ok := r.Context().Value(middleware.CtxUserIDKey).(uuid.UUID)
+
+	if !ok {
+		return uuid.Nil, uuid.Nil, commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "invalid session")
+	}
+
+	sessionID, ok := r.Context().Value(middleware.CtxSessionIDKey).(uuid.UUID)
+
+	if !ok {
+		return uuid.Nil, uuid.Nil, commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "invalid session")
+	}
+
+	return userID, sessionID, nil
+}
+
+func (h *AuthHandler) RequestMagicLink(w http.ResponseWriter, r *http.Request) error {
+	var req httpDto.MagicLinkRequestDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err := req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	if err := h.authUseCase.RequestMagicLink(r.Context(), req.Email); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "if this email is registered, you will receive a sign-in link",
+	})
+}
+
+func (h *AuthHandler) ConsumeMagicLink(w http.ResponseWriter, r *http.Request) error {
+	var req dto.MagicLinkConsumeDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err := req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	req.UserAgent = r.UserAgent()
+	req.IP = middleware.ClientIP(r)
+
+	result, err := h.authUseCase.ConsumeMagicLink(r.Context(), req)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, result)
+}
+
+func (h *AuthHandler) GetSettings(w http.ResponseWriter, r *http.Request) error {
+	userID, _, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	settings, err := h.authUseCase.GetSettings(r.Context(), userID)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, settings)
+}
+
+func (h *AuthHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) error {
+	userID, _, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	var req httpDto.UpdateSettingsRequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	settings, err := h.authUseCase.UpdateSettings(r.Context(), userID, dto.SettingsDTO{
+		MagicLinkEnabled: *req.MagicLinkEnabled,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, settings)
+}
+
+func (h *AuthHandler) StartOAuth(w http.ResponseWriter, r *http.Request) error {
+	req := dto.OAuthStartDTO{DeviceName: r.URL.Query().Get("device_name")}
+
+	if raw := r.URL.Query().Get("device_id"); raw != "" {
+		deviceID, err := uuid.Parse(raw)
+
+		if err != nil {
+			return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "device_id must be a UUID")
+		}
+
+		req.DeviceID = deviceID
+	}
+
+	if err := req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	result, err := h.authUseCase.StartOAuth(r.Context(), chi.URLParam(r, "provider"), req)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, result)
+}
+
+func (h *AuthHandler) CompleteOAuth(w http.ResponseWriter, r *http.Request) error {
+	var req dto.OAuthCallbackDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err := req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	req.UserAgent = r.UserAgent()
+	req.IP = middleware.ClientIP(r)
+
+	result, err := h.authUseCase.CompleteOAuth(r.Context(), chi.URLParam(r, "provider"), req)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, result)
+}
+
+// CompleteOAuthLink — callback привязки; в отличие от CompleteOAuth требует сессию,
+// начавшую привязку.
+func (h *AuthHandler) CompleteOAuthLink(w http.ResponseWriter, r *http.Request) error {
+	userID, sessionID, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	var req dto.OAuthCallbackDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	result, err := h.authUseCase.CompleteOAuthLink(r.Context(), userID, sessionID, chi.URLParam(r, "provider"), req)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, result)
+}
+
+func (h *AuthHandler) ListIdentities(w http.ResponseWriter, r *http.Request) error {
+	userID, _, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	identities, err := h.authUseCase.ListIdentities(r.Context(), userID)
+
+	if err != nil {
+		return err
+	}
+
+	items := make([]httpDto.IdentityDTO, 0, len(identities))
+
+	for _, i := range identities {
+		items = append(items, httpDto.IdentityDTO{Provider: i.Provider, Email: i.Email, CreatedAt: i.CreatedAt})
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]any{"identities": items})
+}
+
+func (h *AuthHandler) LinkIdentity(w http.ResponseWriter, r *http.Request) error {
+	userID, sessionID, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	result, err := h.authUseCase.StartOAuthLink(r.Context(), userID, sessionID, chi.URLParam(r, "provider"))
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, result)
+}
+
+func (h *AuthHandler) UnlinkIdentity(w http.ResponseWriter, r *http.Request) error {
+	userID, _, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	if err = h.authUseCase.UnlinkIdentity(r.Context(), userID, chi.URLParam(r, "provider")); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "provider unlinked"})
+}
+
+func (h *AuthHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) error {
+	userID, _, err := sessionFromContext(r)
+
+	if err != nil {
+		return err
+	}
+
+	var req httpDto.DeleteAccountRequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	deletion, err := h.authUseCase.DeleteAccount(r.Context(), userID, req.Password)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusAccepted, deletion)
+}
+
+func (h *AuthHandler) SuspendUser(w http.ResponseWriter, r *http.Request) error {
+	userID, err := transport.ParsePathUUID(r, "userID")
+
+	if err != nil {
+		return err
+	}
+
+	actor := actorFromContext(r)
+
+	if actor == nil {
+		return commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "missing actor")
+	}
+
+	var req httpDto.SuspendUserRequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	err = h.authUseCase.SuspendUser(r.Context(), userID, dto.SuspendUserDTO{
+		Until:  req.Until,
+		Reason: req.Reason,
+		Actor:  *actor,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "user suspended"})
+}
+
+func (h *AuthHandler) ReinstateUser(w http.ResponseWriter, r *http.Request) error {
+	userID, err := transport.ParsePathUUID(r, "userID")
+
+	if err != nil {
+		return err
+	}
+
+	actor := actorFromContext(r)
+
+	if actor == nil {
+		return commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "missing actor")
+	}
+
+	if err = h.authUseCase.ReinstateUser(r.Context(), userID, *actor); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "user reinstated"})
+}
+
+func (h *AuthHandler) ChangeUserRole(w http.ResponseWriter, r *http.Request) error {
+	userID, err := transport.ParsePathUUID(r, "userID")
+
+	if err != nil {
+		return err
+	}
+
+	actor := actorFromContext(r)
+
+	if actor == nil {
+		return commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "missing actor")
+	}
+
+	var req httpDto.ChangeRoleRequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	err = h.authUseCase.ChangeUserRole(r.Context(), userID, dto.ChangeRoleDTO{
+		Role:  roles.Role(req.Role),
+		Actor: *actor,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "role changed"})
+}
+
+// actorFromContext возвращает nil, если запрос пришёл без подписанных gateway id и роли.
+func actorFromContext(r *http.Request) *dto.Actor {
+	userID, ok := commonmiddleware.UserIDFromContext(r.Context())
+
+	if !ok {
+		return nil
+	}
+
+	role, ok := commonmiddleware.RoleFromContext(r.Context())
+
+	if !ok {
+		return nil
+	}
+
+	return &dto.Actor{UserID: userID, Role: role}
+}
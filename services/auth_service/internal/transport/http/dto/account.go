@@ -0,0 +1,15 @@
+package dto
+
+import "errors"
+
+type DeleteAccountRequestDTO struct {
+	Password string `json:"password"`
+}
+
+func (d *DeleteAccountRequestDTO) Validate() error {
+	if d.Password == "" {
+		return errors.New("password is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditEventDTO struct {
+	ID         uuid.UUID         `json:"id"`
+	Type       string            `json:"event_type"`
+	SessionID  *uuid.UUID        `json:"session_id,omitempty"`
+	IP         string            `json:"ip"`
+	UserAgent  string            `json:"user_agent"`
+	DeviceName string            `json:"device_name"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+type AuditLogResponseDTO struct {
+	Events []AuditEventDTO `json:"events"`
+	// NextBefore и NextBeforeID передаются в ?before=&before_id= для следующей страницы
+	NextBefore   *time.Time `json:"next_before,omitempty"`
+	NextBeforeID *uuid.UUID `json:"next_before_id,omitempty"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+)
+
+type ChangeEmailRequestDTO struct {
+	NewEmail string `json:"new_email"`
+}
+
+func (d *ChangeEmailRequestDTO) Validate() error {
+	d.NewEmail = strings.ToLower(strings.TrimSpace(d.NewEmail))
+
+	if d.NewEmail == "" {
+		return errors.New("new_email is required")
+	}
+
+	return nil
+}
+
+type ConfirmEmailChangeRequestDTO struct {
+	NewEmail string `json:"new_email"`
+	Code     string `json:"code"`
+}
+
+func (d *ConfirmEmailChangeRequestDTO) Validate() error {
+	d.NewEmail = strings.ToLower(strings.TrimSpace(d.NewEmail))
+	d.Code = strings.TrimSpace(d.Code)
+
+	if d.NewEmail == "" {
+		return errors.New("new_email is required")
+	}
+
+	if d.Code == "" {
+		return errors.New("code is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,9 @@
+package dto
+
+import "time"
+
+type IdentityDTO struct {
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
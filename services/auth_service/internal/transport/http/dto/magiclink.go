@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+)
+
+type MagicLinkRequestDTO struct {
+	Email string `json:"email"`
+}
+
+func (d *MagicLinkRequestDTO) Validate() error {
+	d.Email = strings.ToLower(strings.TrimSpace(d.Email))
+
+	if d.Email == "" {
+		return errors.New("email is required")
+	}
+
+	return nil
+}
+
+// UpdateSettingsRequestDTO — указатель, чтобы отсутствующее поле не выключало вход по ссылке.
+type UpdateSettingsRequestDTO struct {
+	MagicLinkEnabled *bool `json:"magic_link_enabled"`
+}
+
+func (d *UpdateSettingsRequestDTO) Validate() error {
+	if d.MagicLinkEnabled == nil {
+		return errors.New("magic_link_enabled is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+)
+
+type ConfirmMFARequestDTO struct {
+	Code string `json:"code"`
+}
+
+func (d *ConfirmMFARequestDTO) Validate() error {
+	d.Code = strings.TrimSpace(d.Code)
+
+	if d.Code == "" {
+		return errors.New("code is required")
+	}
+
+	return nil
+}
+
+type DisableMFARequestDTO struct {
+	CurrentPassword string `json:"current_password"`
+	Code            string `json:"code"`
+	RecoveryCode    string `json:"recovery_code"`
+}
+
+func (d *DisableMFARequestDTO) Validate() error {
+	d.Code = strings.TrimSpace(d.Code)
+
+	if d.CurrentPassword == "" {
+		return errors.New("current_password is required")
+	}
+
+	if d.Code == "" && d.RecoveryCode == "" {
+		return errors.New("code or recovery_code is required")
+	}
+
+	return nil
+}
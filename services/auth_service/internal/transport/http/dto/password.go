@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+)
+
+type ForgotPasswordRequestDTO struct {
+	Email string `json:"email"`
+}
+
+func (d *ForgotPasswordRequestDTO) Validate() error {
+	d.Email = strings.ToLower(strings.TrimSpace(d.Email))
+
+	if d.Email == "" {
+		return errors.New("email is required")
+	}
+
+	return nil
+}
+
+type ResetPasswordRequestDTO struct {
+	Email       string `json:"email"`
+	Code        string `json:"code"`
+	NewPassword string `json:"new_password"`
+}
+
+func (d *ResetPasswordRequestDTO) Validate() error {
+	d.Email = strings.ToLower(strings.TrimSpace(d.Email))
+	d.Code = strings.TrimSpace(d.Code)
+
+	if d.Email == "" {
+		return errors.New("email is required")
+	}
+
+	if d.Code == "" {
+		return errors.New("code is required")
+	}
+
+	if d.NewPassword == "" {
+		return errors.New("new_password is required")
+	}
+
+	return nil
+}
+
+type ChangePasswordRequestDTO struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+func (d *ChangePasswordRequestDTO) Validate() error {
+	if d.CurrentPassword == "" {
+		return errors.New("current_password is required")
+	}
+
+	if d.NewPassword == "" {
+		return errors.New("new_password is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rockkley/pushpost/services/common_service/roles"
+)
+
+type SuspendUserRequestDTO struct {
+	Until  *time.Time `json:"until"`
+	Reason string     `json:"reason"`
+}
+
+func (d *SuspendUserRequestDTO) Validate() error {
+	d.Reason = strings.TrimSpace(d.Reason)
+
+	if d.Reason == "" {
+		return errors.New("reason is required")
+	}
+
+	if d.Until != nil && !d.Until.After(time.Now()) {
+		return errors.New("until must be in the future")
+	}
+
+	return nil
+}
+
+type ChangeRoleRequestDTO struct {
+	Role string `json:"role"`
+}
+
+func (d *ChangeRoleRequestDTO) Validate() error {
+	if _, ok := roles.Parse(d.Role); !ok {
+		return errors.New("role must be one of user, moderator, admin")
+	}
+
+	return nil
+}
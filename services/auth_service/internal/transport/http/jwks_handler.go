@@ -0,0 +1,26 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/rockkley/pushpost/services/common_service/httperror"
+	"github.com/rockkley/pushpost/services/common_service/jwt"
+)
+
+// jwksMaxAge меньше периода ротации ключей на порядки, но снимает нагрузку
+// с auth_service, если верификаторов много.
+const jwksMaxAge = "public, max-age=300"
+
+type JWKSHandler struct {
+	keys jwt.JWKS
+}
+
+func NewJWKSHandler(keys jwt.JWKS) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+func (h *JWKSHandler) Keys(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Cache-Control", jwksMaxAge)
+
+	return httperror.WriteJSON(w, http.StatusOK, h.keys)
+}
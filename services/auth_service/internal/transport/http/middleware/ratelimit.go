@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	apperr "github.com/rockkley/pushpost/services/auth_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/auth_service/internal/domain"
+	"github.com/rockkley/pushpost/services/auth_service/internal/email"
+	"github.com/rockkley/pushpost/services/auth_service/internal/ratelimit"
+	"github.com/rockkley/pushpost/services/common_service/ctxlog"
+	"github.com/rockkley/pushpost/services/common_service/httperror"
+)
+
+type RateLimitMiddleware struct {
+	limiter *ratelimit.Limiter
+}
+
+func NewRateLimitMiddleware(limiter *ratelimit.Limiter) *RateLimitMiddleware {
+	return &RateLimitMiddleware{limiter: limiter}
+}
+
+// PerIP считает каждый запрос с адреса клиента; при недоступном Redis пропускает запрос.
+func (m *RateLimitMiddleware) PerIP(policy ratelimit.Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			log := ctxlog.From(r.Context()).With(slog.String("policy", policy.Name))
+			ip := ClientIP(r)
+
+			status, err := m.limiter.Check(r.Context(), policy, ip)
+
+			if err != nil {
+				log.Error("rate limit check failed", slog.Any("error", err))
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			if status.RetryAfter > 0 {
+				httperror.HandleError(w, r, apperr.RateLimited(status.RetryAfter))
+
+				return
+			}
+
+			if _, err = m.limiter.Fail(r.Context(), policy, ip); err != nil {
+				log.Error("rate limit count failed", slog.Any("error", err))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ClientInfo кладёт в контекст адрес и user-agent клиента (их записывает журнал безопасности)
+// и предпочитаемый язык для писем.
+func ClientInfo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := domain.WithClient(r.Context(), domain.Client{IP: ClientIP(r), UserAgent: r.UserAgent()})
+		ctx = email.WithLocale(ctx, preferredLanguage(r.Header.Get("Accept-Language")))
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// preferredLanguage берёт первый язык из Accept-Language: "ru-RU,ru;q=0.9" → "ru-ru".
+// Веса не учитываем — браузеры и так ставят основной язык первым.
+func preferredLanguage(header string) string {
+	first, _, _ := strings.Cut(header, ",")
+	tag, _, _ := strings.Cut(first, ";")
+
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// ClientIP берёт адрес, который проставил gateway; входящие X-Forwarded-For он не пропускает.
+func ClientIP(r *http.Request) string {
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		parts := strings.Split(xff, ",")
+
+		return strings.TrimSpace(parts[len(parts)-1])
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
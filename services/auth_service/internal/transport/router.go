@@ -3,10 +3,13 @@ package transport
 import (
 	handlerhttp "github.com/rockkley/pushpost/services/common_service/http"
 	"github.com/rockkley/pushpost/services/common_service/httplog"
+	commonmiddleware "github.com/rockkley/pushpost/services/common_service/middleware"
+	"github.com/rockkley/pushpost/services/common_service/roles"
 	"log/slog"
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/rockkley/pushpost/services/auth_service/internal/ratelimit"
 	myHTTP "github.com/rockkley/pushpost/services/auth_service/internal/transport/http"
 	authmiddleware "github.com/rockkley/pushpost/services/auth_service/internal/transport/http/middleware"
 	"github.com/rockkley/pushpost/services/common_service/metrics"
@@ -15,7 +18,10 @@ import (
 func NewRouter(
 	log *slog.Logger,
 	authMW *authmiddleware.AuthMiddleware,
+	rateLimitMW *authmiddleware.RateLimitMiddleware,
 	authHandler *myHTTP.AuthHandler,
+	jwksHandler *myHTTP.JWKSHandler,
+	identitySecret []byte,
 ) *chi.Mux {
 	r := chi.NewRouter()
 
@@ -24,19 +30,67 @@ func NewRouter(
 	r.Use(chimiddleware.Recoverer)
 	r.Use(metrics.Middleware("auth-service"))
 	r.Use(chimiddleware.URLFormat)
+	r.Use(authmiddleware.ClientInfo)
 	r.Handle("/metrics", metrics.Handler())
+	// URLFormat срезает расширение, так что этот маршрут отвечает на /.well-known/jwks.json
+	r.Get("/.well-known/jwks", handlerhttp.MakeHandler(jwksHandler.Keys))
 
 	r.Route("/auth", func(r chi.Router) {
-		r.Post("/register", handlerhttp.MakeHandler(authHandler.Register))
+		r.With(rateLimitMW.PerIP(ratelimit.RegisterIP)).
+			Post("/register", handlerhttp.MakeHandler(authHandler.Register))
 		r.Post("/login", handlerhttp.MakeHandler(authHandler.Login))
+		r.Post("/login/2fa", handlerhttp.MakeHandler(authHandler.LoginMFA))
+		r.Post("/refresh", handlerhttp.MakeHandler(authHandler.Refresh))
 		r.Post("/verify-email", handlerhttp.MakeHandler(authHandler.VerifyEmail))
-		r.Post("/resend-otp", handlerhttp.MakeHandler(authHandler.ResendOTP))
+		r.With(rateLimitMW.PerIP(ratelimit.ResendOTPIP)).
+			Post("/resend-otp", handlerhttp.MakeHandler(authHandler.ResendOTP))
+		r.Post("/password/forgot", handlerhttp.MakeHandler(authHandler.ForgotPassword))
+		r.Post("/password/reset", handlerhttp.MakeHandler(authHandler.ResetPassword))
+		r.With(rateLimitMW.PerIP(ratelimit.MagicLinkIP)).
+			Post("/magic-link", handlerhttp.MakeHandler(authHandler.RequestMagicLink))
+		r.Post("/magic-link/consume", handlerhttp.MakeHandler(authHandler.ConsumeMagicLink))
+		r.Get("/oauth/{provider}/start", handlerhttp.MakeHandler(authHandler.StartOAuth))
+		r.Post("/oauth/{provider}/callback", handlerhttp.MakeHandler(authHandler.CompleteOAuth))
 
 		r.Group(func(r chi.Router) {
 			r.Use(authMW.RequireAuth)
 			r.Post("/logout", handlerhttp.MakeHandler(authHandler.Logout))
+			r.Get("/sessions", handlerhttp.MakeHandler(authHandler.ListSessions))
+			r.Delete("/sessions/{sessionID}", handlerhttp.MakeHandler(authHandler.RevokeSession))
+			r.Post("/sessions/revoke-others", handlerhttp.MakeHandler(authHandler.RevokeOtherSessions))
+			r.Post("/password/change", handlerhttp.MakeHandler(authHandler.ChangePassword))
+			r.Post("/email/change", handlerhttp.MakeHandler(authHandler.RequestEmailChange))
+			r.Post("/email/change/confirm", handlerhttp.MakeHandler(authHandler.ConfirmEmailChange))
+			r.Get("/audit", handlerhttp.MakeHandler(authHandler.AuditLog))
+			r.Get("/2fa", handlerhttp.MakeHandler(authHandler.GetMFAStatus))
+			r.Post("/2fa/setup", handlerhttp.MakeHandler(authHandler.SetupMFA))
+			r.Post("/2fa/confirm", handlerhttp.MakeHandler(authHandler.ConfirmMFA))
+			r.Post("/2fa/disable", handlerhttp.MakeHandler(authHandler.DisableMFA))
+			r.Get("/settings", handlerhttp.MakeHandler(authHandler.GetSettings))
+			r.Put("/settings", handlerhttp.MakeHandler(authHandler.UpdateSettings))
+			r.Get("/identities", handlerhttp.MakeHandler(authHandler.ListIdentities))
+			r.Post("/identities/{provider}", handlerhttp.MakeHandler(authHandler.LinkIdentity))
+			r.Post("/identities/{provider}/callback", handlerhttp.MakeHandler(authHandler.CompleteOAuthLink))
+			r.Delete("/identities/{provider}", handlerhttp.MakeHandler(authHandler.UnlinkIdentity))
 		})
 	})
 
+	// gateway направляет сюда DELETE /users/me: удаление требует пароль и отзывает сессии
+	r.With(authMW.RequireAuth).Delete("/users/me", handlerhttp.MakeHandler(authHandler.DeleteAccount))
+
+	// gateway пускает сюда moderator и выше; роль перепроверяется по подписи gateway
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(commonmiddleware.RequireRole(identitySecret, roles.Moderator))
+		r.Put("/users/{userID}/suspension", handlerhttp.MakeHandler(authHandler.SuspendUser))
+		r.Delete("/users/{userID}/suspension", handlerhttp.MakeHandler(authHandler.ReinstateUser))
+		r.With(commonmiddleware.RequireRole(identitySecret, roles.Admin)).
+			Put("/users/{userID}/role", handlerhttp.MakeHandler(authHandler.ChangeUserRole))
+	})
+
+	// internal API, not exposed through the gateway
+	r.Route("/internal", func(r chi.Router) {
+		r.Get("/sessions/{sessionID}", handlerhttp.MakeHandler(authHandler.IntrospectSession))
+	})
+
 	return r
 }
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE auth_events
+(
+    id          UUID        PRIMARY KEY,
+    user_id     UUID,
+    event_type  TEXT        NOT NULL,
+    session_id  UUID,
+    ip          TEXT        NOT NULL DEFAULT '',
+    user_agent  TEXT        NOT NULL DEFAULT '',
+    device_name TEXT        NOT NULL DEFAULT '',
+    metadata    JSONB       NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_auth_events_user_created ON auth_events (user_id, created_at DESC)
+    WHERE user_id IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS auth_events;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE auth_settings
+(
+    user_id            UUID        PRIMARY KEY,
+    magic_link_enabled BOOLEAN     NOT NULL DEFAULT TRUE,
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS auth_settings;
+-- +goose StatementEnd
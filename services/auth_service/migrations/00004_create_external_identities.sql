@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE external_identities
+(
+    provider   VARCHAR(32)  NOT NULL,
+    subject    VARCHAR(255) NOT NULL,
+    user_id    UUID         NOT NULL,
+    email      VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT external_identities_pkey PRIMARY KEY (provider, subject),
+    CONSTRAINT external_identities_user_provider_key UNIQUE (user_id, provider)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS external_identities;
+-- +goose StatementEnd
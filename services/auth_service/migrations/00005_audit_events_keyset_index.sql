@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- id в индексе нужен для курсора (created_at, id): события с одинаковым временем
+-- иначе терялись бы на границе страниц.
+DROP INDEX IF EXISTS idx_auth_events_user_created;
+
+CREATE INDEX idx_auth_events_user_created ON auth_events (user_id, created_at DESC, id DESC)
+    WHERE user_id IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_auth_events_user_created;
+
+CREATE INDEX idx_auth_events_user_created ON auth_events (user_id, created_at DESC)
+    WHERE user_id IS NOT NULL;
+-- +goose StatementEnd
@@ -2,6 +2,7 @@ package apperror
 
 import (
 	"fmt"
+	"time"
 )
 
 type AppError interface {
@@ -13,6 +14,11 @@ type AppError interface {
 	Unwrap() error
 }
 
+// RetryAfterError реализуют ошибки, после которых клиенту нужно подождать.
+type RetryAfterError interface {
+	RetryAfter() time.Duration
+}
+
 type appError struct {
 	httpStatus int
 	code       string
@@ -20,6 +26,7 @@ type appError struct {
 	fields     map[string]string
 	message    string
 	cause      error
+	retryAfter time.Duration
 }
 
 func (e *appError) Error() string {
@@ -34,3 +41,6 @@ func (e *appError) Code() string              { return e.code }
 func (e *appError) Field() string             { return e.field }
 func (e *appError) Unwrap() error             { return e.cause }
 func (e *appError) Fields() map[string]string { return e.fields }
+
+// RetryAfter — через сколько клиенту имеет смысл повторить запрос (только для 429).
+func (e *appError) RetryAfter() time.Duration { return e.retryAfter }
@@ -1,6 +1,9 @@
 package apperror
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
 const (
 	CodeInternalError = "internal_error"
@@ -18,6 +21,8 @@ const (
 	// generic errors
 	CodeAlreadyExists = "already_exists"
 	CodeUnauthorized  = "unauthorized"
+	CodeForbidden     = "forbidden"
+	CodeRateLimited   = "rate_limited"
 )
 
 // 4xx ----------------
@@ -33,6 +38,11 @@ func Forbidden(code, message string) AppError {
 	return &appError{httpStatus: http.StatusForbidden, code: code, message: message}
 }
 
+// ForbiddenFields — отказ с подробностями, которые клиент может показать пользователю.
+func ForbiddenFields(code, message string, fields map[string]string) AppError {
+	return &appError{httpStatus: http.StatusForbidden, code: code, fields: fields, message: message}
+}
+
 func NotFound(code, message string) AppError {
 	return &appError{httpStatus: http.StatusNotFound, code: code, message: message}
 }
@@ -50,6 +60,15 @@ func Validation(code, field, message string) AppError {
 	}
 }
 
+func TooManyRequests(code, message string, retryAfter time.Duration) AppError {
+	return &appError{
+		httpStatus: http.StatusTooManyRequests,
+		code:       code,
+		message:    message,
+		retryAfter: retryAfter,
+	}
+}
+
 func ValidationFields(fields map[string]string) AppError {
 	return &appError{
 		httpStatus: http.StatusUnprocessableEntity,
@@ -0,0 +1,67 @@
+// Package export — общий формат выгрузки персональных данных. Каждый сервис отдаёт
+// по GET /internal/export/{userID} свой Section, user_service собирает их в один архив.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/httperror"
+)
+
+const ContentTypeJSON = "application/json"
+
+// File — файл внутри архива. Name — путь относительно каталога сервиса, например "comments.json".
+type File struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"data"`
+}
+
+type Section struct {
+	Files []File `json:"files"`
+}
+
+func (s *Section) Add(name, contentType string, data []byte) {
+	s.Files = append(s.Files, File{Name: name, ContentType: contentType, Data: data})
+}
+
+// AddJSON добавляет v как отформатированный JSON: архив читают люди.
+func (s *Section) AddJSON(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", name, err)
+	}
+
+	s.Add(name, ContentTypeJSON, data)
+
+	return nil
+}
+
+// CollectFunc собирает всё, что сервис хранит о пользователе.
+type CollectFunc func(ctx context.Context, userID uuid.UUID) (*Section, error)
+
+// Handler — обработчик GET /internal/export/{userID} для handlerhttp.MakeHandler.
+func Handler(collect CollectFunc) func(w http.ResponseWriter, r *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+
+		if err != nil {
+			return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid user id")
+		}
+
+		section, err := collect(r.Context(), userID)
+
+		if err != nil {
+			return err
+		}
+
+		return httperror.WriteJSON(w, http.StatusOK, section)
+	}
+}
@@ -5,16 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/rockkley/pushpost/services/common_service/apperror"
 	"github.com/rockkley/pushpost/services/common_service/ctxlog"
 )
 
 type ErrorResponse struct {
-	Code   string            `json:"code"`
-	Field  string            `json:"field,omitempty"`
-	Fields map[string]string `json:"fields,omitempty"`
+	Code       string            `json:"code"`
+	Field      string            `json:"field,omitempty"`
+	Fields     map[string]string `json:"fields,omitempty"`
+	RetryAfter int64             `json:"retry_after,omitempty"`
 }
 
 // HandleError maps an error to an HTTP response. If the error is an AppError, it uses its HTTP status and code;
@@ -57,6 +60,13 @@ func HandleError(w http.ResponseWriter, r *http.Request, err error) error {
 		Field:  appErr.Field(),
 		Fields: appErr.Fields(),
 	}
+
+	var retryErr apperror.RetryAfterError
+	if errors.As(err, &retryErr) && retryErr.RetryAfter() > 0 {
+		// округляем вверх: клиент, подождавший ровно Retry-After, не должен упереться в лимит
+		resp.RetryAfter = int64(math.Ceil(retryErr.RetryAfter().Seconds()))
+		w.Header().Set("Retry-After", strconv.FormatInt(resp.RetryAfter, 10))
+	}
 	if err = WriteJSON(w, status, resp); err != nil {
 		log.Error("failed to write error response",
 			slog.Int("status", status),
@@ -0,0 +1,54 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+)
+
+// JWK — RFC 8037, ключ типа OKP/Ed25519.
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func newJWK(kid string, key ed25519.PublicKey) JWK {
+	return JWK{
+		Kty: "OKP",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(key),
+		Kid: kid,
+		Alg: "EdDSA",
+		Use: "sig",
+	}
+}
+
+// PublicKeys пропускает ключи чужих типов: в наборе могут появиться ключи
+// для других алгоритмов, и это не должно ломать проверку наших токенов.
+func (s JWKS) PublicKeys() (map[string]ed25519.PublicKey, error) {
+	keys := make(map[string]ed25519.PublicKey, len(s.Keys))
+
+	for _, k := range s.Keys {
+		if k.Kty != "OKP" || k.Crv != "Ed25519" || k.Kid == "" {
+			continue
+		}
+
+		raw, err := base64.RawURLEncoding.DecodeString(k.X)
+
+		if err != nil || len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("jwk %q: invalid public key", k.Kid)
+		}
+
+		keys[k.Kid] = ed25519.PublicKey(raw)
+	}
+
+	return keys, nil
+}
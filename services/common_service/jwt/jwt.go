@@ -1,46 +1,95 @@
 package jwt
 
 import (
+	"crypto/ed25519"
 	"errors"
+	"fmt"
+	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
-	"time"
 )
 
+var (
+	ErrUnknownKey   = errors.New("unknown signing key")
+	ErrCannotSign   = errors.New("manager has no signing key")
+	errInvalidToken = errors.New("invalid token")
+)
+
+// KeyProvider отдаёт публичный ключ по kid из заголовка токена.
+type KeyProvider interface {
+	PublicKey(kid string) (ed25519.PublicKey, error)
+}
+
+// Manager подписывает токены EdDSA. Приватные ключи есть только у auth_service
+// (NewSigner); остальные сервисы проверяют токены по JWKS (NewVerifier).
 type Manager struct {
-	secret []byte
+	active *SigningKey
+	keys   KeyProvider
 	ttl    time.Duration
 }
 
-func NewManager(secret string, ttl *time.Duration) *Manager {
+// NewSigner подписывает активным ключом и принимает токены любого ключа из набора,
+// чтобы во время ротации старые токены доживали свой срок.
+func NewSigner(keys []SigningKey, activeKID string, ttl *time.Duration) (*Manager, error) {
+	set := &StaticKeySet{keys: make(map[string]SigningKey, len(keys))}
+
+	for _, k := range keys {
+		set.keys[k.ID] = k
+	}
+
+	active, ok := set.keys[activeKID]
+
+	if !ok {
+		return nil, fmt.Errorf("active signing key %q is not configured", activeKID)
+	}
+
 	t := 24 * time.Hour
 	if ttl != nil {
 		t = *ttl
 	}
-	return &Manager{secret: []byte(secret), ttl: t}
+
+	return &Manager{active: &active, keys: set, ttl: t}, nil
+}
+
+func NewVerifier(keys KeyProvider) *Manager {
+	return &Manager{keys: keys}
 }
 
-func (m *Manager) Generate(userID, deviceID, sessionID uuid.UUID) (string, error) {
+// Generate выпускает access-токен. Роль в claim "rol" актуальна на момент выпуска:
+// изменение роли доходит до токена при следующем refresh.
+func (m *Manager) Generate(userID, deviceID, sessionID uuid.UUID, role string) (string, error) {
+	if m.active == nil {
+		return "", ErrCannotSign
+	}
+
 	claims := jwt.MapClaims{
 		"sub": userID.String(),
 		"did": deviceID.String(),
 		"sid": sessionID.String(),
+		"rol": role,
 		"exp": time.Now().Add(m.ttl).Unix(),
 	}
-	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = m.active.ID
+
+	return token.SignedString(m.active.Private)
 }
 
 func (m *Manager) Parse(tokenStr string) (jwt.MapClaims, error) {
 	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("invalid signing method")
+		kid, _ := t.Header["kid"].(string)
+
+		if kid == "" {
+			return nil, errors.New("token missing kid")
 		}
 
-		return m.secret, nil
-	})
+		return m.keys.PublicKey(kid)
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}))
 
 	if err != nil || !token.Valid {
-		return nil, errors.New("invalid token")
+		return nil, errInvalidToken
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
@@ -52,6 +101,17 @@ func (m *Manager) Parse(tokenStr string) (jwt.MapClaims, error) {
 	return claims, nil
 }
 
+// JWKS — публичная часть ключей подписи; пуст у verify-only менеджера.
+func (m *Manager) JWKS() JWKS {
+	set, ok := m.keys.(*StaticKeySet)
+
+	if !ok {
+		return JWKS{Keys: []JWK{}}
+	}
+
+	return set.JWKS()
+}
+
 func (m *Manager) TTL() time.Duration {
 	return m.ttl
 }
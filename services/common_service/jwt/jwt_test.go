@@ -1,35 +1,58 @@
 package jwt
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	jwtlib "github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
 const (
-	validSecret = "a-valid-secret-that-is-32-chars!!"
-	otherSecret = "a-different-secret-32-characters!!"
+	validKey = "key-1"
+	otherKey = "key-2"
 )
 
-func newManager(secret string) *Manager {
-	return NewManager(secret, nil)
+func newSigningKey(t testing.TB, kid string) SigningKey {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return SigningKey{ID: kid, Private: priv}
+}
+
+func newManager(t testing.TB, kid string) *Manager {
+	return newManagerWithTTL(t, kid, nil)
+}
+
+func newManagerWithTTL(t testing.TB, kid string, ttl *time.Duration) *Manager {
+	t.Helper()
+	m, err := NewSigner([]SigningKey{newSigningKey(t, kid)}, kid, ttl)
+	require.NoError(t, err)
+	return m
 }
 
 // ── Generate ──────────────────────────────────────────────────────────────────
 
 func TestJWT_Generate_ReturnsNonEmptyToken(t *testing.T) {
-	m := newManager(validSecret)
-	token, err := m.Generate(uuid.New(), uuid.New(), uuid.New())
+	m := newManager(t, validKey)
+	token, err := m.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
 
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 }
 
 func TestJWT_Generate_TokenHasThreeParts(t *testing.T) {
-	m := newManager(validSecret)
-	token, err := m.Generate(uuid.New(), uuid.New(), uuid.New())
+	m := newManager(t, validKey)
+	token, err := m.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
 
 	require.NoError(t, err)
 	count := 0
@@ -42,9 +65,9 @@ func TestJWT_Generate_TokenHasThreeParts(t *testing.T) {
 }
 
 func TestJWT_Generate_DifferentTokensForDifferentInputs(t *testing.T) {
-	m := newManager(validSecret)
-	t1, _ := m.Generate(uuid.New(), uuid.New(), uuid.New())
-	t2, _ := m.Generate(uuid.New(), uuid.New(), uuid.New())
+	m := newManager(t, validKey)
+	t1, _ := m.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
+	t2, _ := m.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
 
 	require.NotEqual(t, t1, t2)
 }
@@ -52,12 +75,12 @@ func TestJWT_Generate_DifferentTokensForDifferentInputs(t *testing.T) {
 // ── Parse ─────────────────────────────────────────────────────────────────────
 
 func TestJWT_Parse_ValidToken(t *testing.T) {
-	m := newManager(validSecret)
+	m := newManager(t, validKey)
 	userID := uuid.New()
 	deviceID := uuid.New()
 	sessionID := uuid.New()
 
-	token, err := m.Generate(userID, deviceID, sessionID)
+	token, err := m.Generate(userID, deviceID, sessionID, "moderator")
 	require.NoError(t, err)
 
 	claims, err := m.Parse(token)
@@ -66,11 +89,12 @@ func TestJWT_Parse_ValidToken(t *testing.T) {
 	require.Equal(t, userID.String(), claims["sub"])
 	require.Equal(t, deviceID.String(), claims["did"])
 	require.Equal(t, sessionID.String(), claims["sid"])
+	require.Equal(t, "moderator", claims["rol"])
 }
 
 func TestJWT_Parse_ClaimsContainExpiry(t *testing.T) {
-	m := newManager(validSecret)
-	token, err := m.Generate(uuid.New(), uuid.New(), uuid.New())
+	m := newManager(t, validKey)
+	token, err := m.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
 	require.NoError(t, err)
 
 	claims, err := m.Parse(token)
@@ -86,14 +110,14 @@ func TestJWT_Parse_ClaimsContainExpiry(t *testing.T) {
 }
 
 func TestJWT_Parse_EmptyString(t *testing.T) {
-	m := newManager(validSecret)
+	m := newManager(t, validKey)
 	_, err := m.Parse("")
 
 	require.Error(t, err)
 }
 
 func TestJWT_Parse_MalformedToken(t *testing.T) {
-	m := newManager(validSecret)
+	m := newManager(t, validKey)
 
 	malformed := []string{
 		"not-a-jwt",
@@ -109,10 +133,10 @@ func TestJWT_Parse_MalformedToken(t *testing.T) {
 }
 
 func TestJWT_Parse_WrongSigningKey(t *testing.T) {
-	signer := newManager(validSecret)
-	verifier := newManager(otherSecret)
+	signer := newManager(t, validKey)
+	verifier := newManager(t, validKey) // тот же kid, другой ключ
 
-	token, err := signer.Generate(uuid.New(), uuid.New(), uuid.New())
+	token, err := signer.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
 	require.NoError(t, err)
 
 	_, err = verifier.Parse(token)
@@ -120,8 +144,8 @@ func TestJWT_Parse_WrongSigningKey(t *testing.T) {
 }
 
 func TestJWT_Parse_TamperedPayload(t *testing.T) {
-	m := newManager(validSecret)
-	token, err := m.Generate(uuid.New(), uuid.New(), uuid.New())
+	m := newManager(t, validKey)
+	token, err := m.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
 	require.NoError(t, err)
 
 	// Replace the payload section with a different base64 blob.
@@ -133,14 +157,121 @@ func TestJWT_Parse_TamperedPayload(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestJWT_Parse_RejectsHMACToken(t *testing.T) {
+	m := newManager(t, validKey)
+
+	token := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, jwtlib.MapClaims{
+		"sub": uuid.NewString(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = validKey
+	signed, err := token.SignedString([]byte("a-valid-secret-that-is-32-chars!!"))
+	require.NoError(t, err)
+
+	_, err = m.Parse(signed)
+	require.Error(t, err)
+}
+
+// ── Key rotation ──────────────────────────────────────────────────────────────
+
+func TestJWT_Generate_SetsKidHeader(t *testing.T) {
+	m := newManager(t, validKey)
+	token, err := m.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
+	require.NoError(t, err)
+
+	parsed, _, err := jwtlib.NewParser().ParseUnverified(token, jwtlib.MapClaims{})
+	require.NoError(t, err)
+	require.Equal(t, validKey, parsed.Header["kid"])
+	require.Equal(t, "EdDSA", parsed.Header["alg"])
+}
+
+func TestJWT_Rotation_OldKeyStillVerifies(t *testing.T) {
+	oldKey := newSigningKey(t, validKey)
+	newKey := newSigningKey(t, otherKey)
+
+	before, err := NewSigner([]SigningKey{oldKey}, validKey, nil)
+	require.NoError(t, err)
+	oldToken, err := before.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
+	require.NoError(t, err)
+
+	after, err := NewSigner([]SigningKey{oldKey, newKey}, otherKey, nil)
+	require.NoError(t, err)
+
+	_, err = after.Parse(oldToken)
+	require.NoError(t, err, "tokens signed by the previous key must verify during overlap")
+	require.Len(t, after.JWKS().Keys, 2)
+}
+
+func TestJWT_NewSigner_UnknownActiveKey(t *testing.T) {
+	_, err := NewSigner([]SigningKey{newSigningKey(t, validKey)}, otherKey, nil)
+	require.Error(t, err)
+}
+
+func TestJWT_Verifier_CannotSign(t *testing.T) {
+	v := NewVerifier(&StaticKeySet{})
+	_, err := v.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
+	require.ErrorIs(t, err, ErrCannotSign)
+}
+
+// ── Remote JWKS ───────────────────────────────────────────────────────────────
+
+func TestJWT_RemoteKeySet_FetchesUnknownKid(t *testing.T) {
+	signer := newManager(t, validKey)
+	var hits atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		_ = json.NewEncoder(w).Encode(signer.JWKS())
+	}))
+	defer srv.Close()
+
+	keys := NewRemoteKeySet(srv.URL, srv.Client(), time.Hour, slog.Default())
+	verifier := NewVerifier(keys)
+
+	token, err := signer.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
+	require.NoError(t, err)
+
+	_, err = verifier.Parse(token)
+	require.NoError(t, err)
+
+	_, err = verifier.Parse(token)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), hits.Load(), "known kid must be served from cache")
+}
+
+func TestJWT_RemoteKeySet_UnknownKidRefreshIsRateLimited(t *testing.T) {
+	signer := newManager(t, validKey)
+	stranger := newManager(t, otherKey)
+	var hits atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		_ = json.NewEncoder(w).Encode(signer.JWKS())
+	}))
+	defer srv.Close()
+
+	keys := NewRemoteKeySet(srv.URL, srv.Client(), time.Hour, slog.Default())
+	require.NoError(t, keys.Refresh(context.Background()))
+	verifier := NewVerifier(keys)
+
+	token, err := stranger.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = verifier.Parse(token)
+		require.Error(t, err)
+	}
+	require.Equal(t, int32(1), hits.Load())
+}
+
 // ── TTL ───────────────────────────────────────────────────────────────────────
 
 func TestJWT_CustomTTL_UsedInGenerate(t *testing.T) {
 	// BUG REGRESSION: jwt.Manager.Generate must use m.ttl, not hardcode 24h.
 	ttl := 2 * time.Hour
-	m := NewManager(validSecret, &ttl)
+	m := newManagerWithTTL(t, validKey, &ttl)
 
-	token, err := m.Generate(uuid.New(), uuid.New(), uuid.New())
+	token, err := m.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
 	require.NoError(t, err)
 
 	claims, err := m.Parse(token)
@@ -156,9 +287,9 @@ func TestJWT_CustomTTL_UsedInGenerate(t *testing.T) {
 }
 
 func TestJWT_DefaultTTL_Is24Hours(t *testing.T) {
-	m := NewManager(validSecret, nil)
+	m := newManager(t, validKey)
 
-	token, err := m.Generate(uuid.New(), uuid.New(), uuid.New())
+	token, err := m.Generate(uuid.New(), uuid.New(), uuid.New(), "user")
 	require.NoError(t, err)
 
 	claims, err := m.Parse(token)
@@ -0,0 +1,61 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"sort"
+)
+
+type SigningKey struct {
+	ID      string
+	Private ed25519.PrivateKey
+}
+
+// ParseSigningKeys принимает kid → base64 seed (32 байта), как в JWT_SIGNING_KEYS.
+func ParseSigningKeys(seeds map[string]string) ([]SigningKey, error) {
+	keys := make([]SigningKey, 0, len(seeds))
+
+	for kid, encoded := range seeds {
+		seed, err := base64.StdEncoding.DecodeString(encoded)
+
+		if err != nil {
+			return nil, fmt.Errorf("signing key %q: invalid base64: %w", kid, err)
+		}
+
+		if len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("signing key %q: seed must be %d bytes, got %d", kid, ed25519.SeedSize, len(seed))
+		}
+
+		keys = append(keys, SigningKey{ID: kid, Private: ed25519.NewKeyFromSeed(seed)})
+	}
+
+	return keys, nil
+}
+
+// StaticKeySet — ключи, известные процессу заранее (auth_service).
+type StaticKeySet struct {
+	keys map[string]SigningKey
+}
+
+func (s *StaticKeySet) PublicKey(kid string) (ed25519.PublicKey, error) {
+	k, ok := s.keys[kid]
+
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	return k.Private.Public().(ed25519.PublicKey), nil
+}
+
+func (s *StaticKeySet) JWKS() JWKS {
+	set := JWKS{Keys: make([]JWK, 0, len(s.keys))}
+
+	for kid, k := range s.keys {
+		set.Keys = append(set.Keys, newJWK(kid, k.Private.Public().(ed25519.PublicKey)))
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+
+	return set
+}
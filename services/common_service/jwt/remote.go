@@ -0,0 +1,153 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	maxJWKSSize = 1 << 20
+	// не чаще раза в minRefreshGap дёргаем JWKS из-за незнакомого kid,
+	// иначе поток мусорных токенов превратится в поток запросов к auth_service
+	minRefreshGap = 30 * time.Second
+)
+
+// RemoteKeySet кэширует JWKS и периодически его обновляет. Незнакомый kid
+// (только что ротированный ключ) вызывает внеочередное обновление.
+type RemoteKeySet struct {
+	url      string
+	client   *http.Client
+	interval time.Duration
+	log      *slog.Logger
+
+	mu          sync.RWMutex
+	keys        map[string]ed25519.PublicKey
+	refreshMu   sync.Mutex
+	lastAttempt time.Time
+}
+
+func NewRemoteKeySet(url string, client *http.Client, interval time.Duration, log *slog.Logger) *RemoteKeySet {
+	return &RemoteKeySet{
+		url:      url,
+		client:   client,
+		interval: interval,
+		log:      log,
+		keys:     make(map[string]ed25519.PublicKey),
+	}
+}
+
+func (s *RemoteKeySet) PublicKey(kid string) (ed25519.PublicKey, error) {
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+
+	if err := s.refreshIfStale(context.Background()); err != nil {
+		s.log.Warn("jwks refresh for unknown kid failed", slog.String("kid", kid), slog.Any("error", err))
+	}
+
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+
+	return nil, ErrUnknownKey
+}
+
+// Refresh заменяет набор ключей целиком: ключ, убранный из JWKS, перестаёт приниматься.
+func (s *RemoteKeySet) Refresh(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	return s.fetch(ctx)
+}
+
+// Run обновляет ключи каждые interval до отмены ctx.
+func (s *RemoteKeySet) Run(ctx context.Context) {
+	if err := s.Refresh(ctx); err != nil {
+		s.log.Warn("initial jwks fetch failed, will retry", slog.Any("error", err))
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				s.log.Warn("jwks refresh failed, keeping cached keys", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+func (s *RemoteKeySet) lookup(kid string) (ed25519.PublicKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.keys[kid]
+
+	return key, ok
+}
+
+func (s *RemoteKeySet) refreshIfStale(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	if time.Since(s.lastAttempt) < minRefreshGap {
+		return nil
+	}
+
+	return s.fetch(ctx)
+}
+
+// fetch вызывается под refreshMu.
+func (s *RemoteKeySet) fetch(ctx context.Context) error {
+	s.lastAttempt = time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+
+	if err != nil {
+		return fmt.Errorf("build jwks request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+
+	if err != nil {
+		return fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	var set JWKS
+
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxJWKSSize)).Decode(&set); err != nil {
+		return fmt.Errorf("decode jwks: %w", err)
+	}
+
+	keys, err := set.PublicKeys()
+
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		return fmt.Errorf("jwks contains no usable keys")
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/httperror"
+	"github.com/rockkley/pushpost/services/common_service/roles"
+)
+
+const (
+	HeaderUserRole          = "X-User-Role"
+	HeaderIdentitySignature = "X-Identity-Signature"
+
+	// identityMaxAge ограничивает повтор перехваченных заголовков внутри сети.
+	identityMaxAge = time.Minute
+)
+
+var ErrInvalidIdentitySignature = errors.New("invalid identity signature")
+
+type roleKey struct{}
+
+// SignIdentity подписывает пару user_id и роль общим с сервисами секретом.
+// Gateway ставит результат в X-Identity-Signature: "<unix>.<base64url hmac-sha256>".
+func SignIdentity(secret []byte, userID uuid.UUID, role roles.Role, now time.Time) string {
+	ts := strconv.FormatInt(now.Unix(), 10)
+
+	return ts + "." + identityMAC(secret, userID, role, ts)
+}
+
+func VerifyIdentity(secret []byte, userID uuid.UUID, role roles.Role, signature string, now time.Time) error {
+	ts, mac, ok := strings.Cut(signature, ".")
+
+	if !ok {
+		return ErrInvalidIdentitySignature
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+
+	if err != nil {
+		return ErrInvalidIdentitySignature
+	}
+
+	age := now.Sub(time.Unix(unix, 0))
+
+	if age > identityMaxAge || age < -identityMaxAge {
+		return ErrInvalidIdentitySignature
+	}
+
+	if !hmac.Equal([]byte(mac), []byte(identityMAC(secret, userID, role, ts))) {
+		return ErrInvalidIdentitySignature
+	}
+
+	return nil
+}
+
+func identityMAC(secret []byte, userID uuid.UUID, role roles.Role, ts string) string {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(userID.String() + "|" + string(role) + "|" + ts))
+
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// RequireRole пропускает запрос, только если gateway подписал роль не ниже min.
+// Заменяет RequireUserID: кладёт в контекст и пользователя, и роль.
+func RequireRole(secret []byte, min roles.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, err := uuid.Parse(r.Header.Get(HeaderUserID))
+			if err != nil || userID == uuid.Nil {
+				httperror.HandleError(w, r, apperror.Unauthorized(
+					apperror.CodeUnauthorized, "missing or invalid X-User-ID header",
+				))
+				return
+			}
+
+			role := roles.Role(r.Header.Get(HeaderUserRole))
+			signature := r.Header.Get(HeaderIdentitySignature)
+
+			if err = VerifyIdentity(secret, userID, role, signature, time.Now()); err != nil {
+				httperror.HandleError(w, r, apperror.Unauthorized(
+					apperror.CodeUnauthorized, "invalid identity signature",
+				))
+				return
+			}
+
+			if !role.AtLeast(min) {
+				httperror.HandleError(w, r, apperror.Forbidden(
+					apperror.CodeForbidden, "insufficient role",
+				))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userIDKey{}, userID)
+			ctx = context.WithValue(ctx, roleKey{}, role)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func RoleFromContext(ctx context.Context) (roles.Role, bool) {
+	role, ok := ctx.Value(roleKey{}).(roles.Role)
+	return role, ok && role.Valid()
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/roles"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func TestVerifyIdentity_Valid(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+	sig := SignIdentity(testSecret, userID, roles.Admin, now)
+
+	require.NoError(t, VerifyIdentity(testSecret, userID, roles.Admin, sig, now))
+}
+
+func TestVerifyIdentity_TamperedRole(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+	sig := SignIdentity(testSecret, userID, roles.User, now)
+
+	require.ErrorIs(t, VerifyIdentity(testSecret, userID, roles.Admin, sig, now), ErrInvalidIdentitySignature)
+}
+
+func TestVerifyIdentity_Expired(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+	sig := SignIdentity(testSecret, userID, roles.Admin, now.Add(-2*time.Minute))
+
+	require.ErrorIs(t, VerifyIdentity(testSecret, userID, roles.Admin, sig, now), ErrInvalidIdentitySignature)
+}
+
+func TestRequireRole(t *testing.T) {
+	userID := uuid.New()
+
+	cases := []struct {
+		name   string
+		role   roles.Role
+		sign   bool
+		status int
+	}{
+		{"admin passes moderator check", roles.Admin, true, http.StatusOK},
+		{"user is forbidden", roles.User, true, http.StatusForbidden},
+		{"unsigned role is rejected", roles.Admin, false, http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotRole roles.Role
+
+			h := RequireRole(testSecret, roles.Moderator)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotRole, _ = RoleFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			req.Header.Set(HeaderUserID, userID.String())
+			req.Header.Set(HeaderUserRole, string(tc.role))
+
+			if tc.sign {
+				req.Header.Set(HeaderIdentitySignature, SignIdentity(testSecret, userID, tc.role, time.Now()))
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.status, rec.Code)
+
+			if tc.status == http.StatusOK {
+				require.Equal(t, tc.role, gotRole)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package password
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -14,30 +15,22 @@ func TestPassword_Hash_ReturnsNonEmptyString(t *testing.T) {
 	require.NotEmpty(t, hash)
 }
 
-func TestPassword_Hash_Is60Chars(t *testing.T) {
+func TestPassword_Hash_IsArgon2idPHC(t *testing.T) {
 	hash, err := Hash("SomePassword1")
 
 	require.NoError(t, err)
-	require.Len(t, hash, 60, "bcrypt hash at DefaultCost must be exactly 60 chars")
-}
-
-func TestPassword_Hash_IsValidBcrypt(t *testing.T) {
-	const plain = "ValidPass99"
-	hash, err := Hash(plain)
-	require.NoError(t, err)
-
-	err = bcrypt.CompareHashAndPassword([]byte(hash), []byte(plain))
-	require.NoError(t, err, "produced hash must be verifiable by bcrypt directly")
+	require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=2$"), hash)
+	require.Len(t, strings.Split(hash, "$"), 6)
 }
 
 func TestPassword_Hash_DifferentHashesForSameInput(t *testing.T) {
-	// bcrypt includes a random salt, so two hashes of the same input differ.
+	// argon2id uses a random salt, so two hashes of the same input differ.
 	h1, err1 := Hash("SamePassword1")
 	h2, err2 := Hash("SamePassword1")
 
 	require.NoError(t, err1)
 	require.NoError(t, err2)
-	require.NotEqual(t, h1, h2, "bcrypt must produce different hashes due to random salt")
+	require.NotEqual(t, h1, h2, "hashes must differ due to random salt")
 }
 
 func TestPassword_Hash_DoesNotStoreOrReturnPlaintext(t *testing.T) {
@@ -62,7 +55,7 @@ func TestPassword_Compare_WrongPassword(t *testing.T) {
 	require.NoError(t, err)
 
 	err = Compare("WrongPass1", hash)
-	require.Error(t, err)
+	require.ErrorIs(t, err, ErrMismatch)
 }
 
 func TestPassword_Compare_EmptyPassword(t *testing.T) {
@@ -89,7 +82,7 @@ func TestPassword_Compare_HashFromExternalBcrypt(t *testing.T) {
 }
 
 func TestPassword_Compare_LongPassword(t *testing.T) {
-	// bcrypt silently truncates passwords > 72 bytes. Verify no panic occurs.
+	// argon2id has no 72-byte limit, unlike bcrypt.
 	longPass := string(make([]byte, 100))
 	for i := range longPass {
 		longPass = longPass[:i] + "A" + longPass[i+1:]
@@ -100,3 +93,41 @@ func TestPassword_Compare_LongPassword(t *testing.T) {
 	err = Compare(longPass, hash)
 	require.NoError(t, err)
 }
+
+func TestPassword_Compare_MalformedHash(t *testing.T) {
+	for _, hash := range []string{"plain", "$argon2id$v=19$m=1,t=1$x$y", "$argon2i$v=19$m=8,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5"} {
+		require.ErrorIs(t, Compare("SomePass1", hash), ErrInvalidHash, hash)
+	}
+}
+
+func newCheapHasher(t *testing.T) *Hasher {
+	t.Helper()
+	h, err := NewHasher(Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	require.NoError(t, err)
+	return h
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	h := newCheapHasher(t)
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("Legacy123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.True(t, h.NeedsRehash(string(legacy)), "bcrypt hashes must be upgraded")
+
+	current, err := h.Hash("Current123")
+	require.NoError(t, err)
+	require.False(t, h.NeedsRehash(current))
+
+	stronger, err := NewHasher(Params{Memory: 2048, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	require.NoError(t, err)
+	require.True(t, stronger.NeedsRehash(current), "hash with old parameters must be upgraded")
+	require.NoError(t, stronger.Compare("Current123", current), "old parameters must still verify")
+}
+
+func TestNewHasher_RejectsWeakParams(t *testing.T) {
+	_, err := NewHasher(Params{Memory: 0, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	require.Error(t, err)
+
+	_, err = NewHasher(Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 4, KeyLength: 32})
+	require.Error(t, err)
+}
@@ -0,0 +1,84 @@
+package reserved
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// confusables — выборка из таблицы UTS #39 (confusables.txt) для символов, похожих
+// на строчную латиницу и цифры. Имена регистронезависимы, поэтому сопоставление идёт
+// после приведения к нижнему регистру; полноширинные и математические варианты
+// латиницы снимает NFKD. Строже стандарта: i, l и 1 — один класс, иначе "adm1n"
+// не совпадёт с "admin".
+var confusables = map[rune]rune{
+	// ASCII
+	'0': 'o',
+	'1': 'l',
+	'i': 'l',
+	'|': 'l',
+	// латиница
+	'ı': 'l',
+	'ɑ': 'a',
+	'ɡ': 'g',
+	'ɩ': 'l',
+	'ʟ': 'l',
+	// кириллица
+	'а': 'a',
+	'с': 'c',
+	'ԁ': 'd',
+	'е': 'e',
+	'һ': 'h',
+	'і': 'l',
+	'ј': 'j',
+	'ӏ': 'l',
+	'о': 'o',
+	'р': 'p',
+	'ԛ': 'q',
+	'ѕ': 's',
+	'у': 'y',
+	'ԝ': 'w',
+	'х': 'x',
+	// греческий
+	'α': 'a',
+	'ι': 'l',
+	'κ': 'k',
+	'ν': 'v',
+	'ο': 'o',
+	'ρ': 'p',
+	'υ': 'u',
+	'χ': 'x',
+}
+
+// Буквосочетания, которые в большинстве шрифтов неотличимы от одной буквы.
+var sequences = strings.NewReplacer("rn", "m", "vv", "w")
+
+// Skeleton возвращает скелет имени в духе UTS #39: имена с одинаковым скелетом
+// выглядят одинаково, например "adm1n", "аdmin" с кириллической "а" и "_admin_".
+// В отличие от стандарта скелет не зависит от регистра и не учитывает разделители.
+//
+// Для имён из [a-zA-Z0-9_] результат совпадает с SQL-выражением, которым user_service
+// заполнил скелеты существующих имён (00009_add_username_skeleton.sql): при изменении
+// правил скелеты нужно пересчитать.
+func Skeleton(username string) string {
+	var b strings.Builder
+
+	for _, r := range norm.NFKD.String(strings.TrimSpace(username)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+
+		r = unicode.ToLower(r)
+
+		if proto, ok := confusables[r]; ok {
+			r = proto
+		}
+
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+
+	return sequences.Replace(b.String())
+}
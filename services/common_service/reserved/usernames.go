@@ -0,0 +1,103 @@
+package reserved
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrReserved  = errors.New("username is reserved")
+	ErrForbidden = errors.New("username contains a forbidden word")
+)
+
+// usernames - зарезервированные имена пользователей.
+// Защищают URL-пространство от захвата пользователями.
+// Список обновляется при добавлении новых маршрутов в gateway.
+//
+// Общий для user_service, который проверяет имена при регистрации,
+// и auth_service, который генерирует имена для входа через внешних провайдеров.
+var usernames = map[string]struct{}{
+	// gateway
+	"auth":          {},
+	"users":         {},
+	"friends":       {},
+	"follows":       {},
+	"blocks":        {},
+	"messages":      {},
+	"posts":         {},
+	"profiles":      {},
+	"notifications": {},
+	"health":        {},
+	"metrics":       {},
+	"api":           {},
+	// common
+	"admin":    {},
+	"me":       {},
+	"settings": {},
+	"profile":  {},
+	"login":    {},
+	"logout":   {},
+	"register": {},
+	"static":   {},
+	"favicon":  {},
+	// product
+	"support": {},
+	"help":    {},
+	"about":   {},
+	"terms":   {},
+	"privacy": {},
+}
+
+// skeletons — скелеты зарезервированных имён: "adm1n" и "_admin_" заняты так же, как "admin".
+var skeletons = func() map[string]struct{} {
+	m := make(map[string]struct{}, len(usernames))
+
+	for name := range usernames {
+		m[Skeleton(name)] = struct{}{}
+	}
+
+	return m
+}()
+
+// IsReserved возвращает true, если имя похоже на зарезервированное.
+func IsReserved(username string) bool {
+	_, ok := skeletons[Skeleton(username)]
+
+	return ok
+}
+
+// Policy дополняет зарезервированные имена настраиваемым списком запрещённых слов
+// (брань, имитация бренда и персонала). Слово из списка запрещено в любой части имени,
+// поэтому список не должен содержать коротких и общеупотребительных слов.
+type Policy struct {
+	denylist []string
+}
+
+func NewPolicy(denylist []string) *Policy {
+	p := &Policy{}
+
+	for _, word := range denylist {
+		if s := Skeleton(word); s != "" {
+			p.denylist = append(p.denylist, s)
+		}
+	}
+
+	return p
+}
+
+// Check возвращает ErrReserved или ErrForbidden, если имя нельзя занять.
+func (p *Policy) Check(username string) error {
+	skeleton := Skeleton(username)
+
+	if _, ok := skeletons[skeleton]; ok {
+		return ErrReserved
+	}
+
+	for _, word := range p.denylist {
+		if strings.Contains(skeleton, word) {
+			return ErrForbidden
+		}
+	}
+
+	return nil
+}
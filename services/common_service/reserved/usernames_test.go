@@ -0,0 +1,45 @@
+package reserved
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkeleton_Confusables(t *testing.T) {
+	for _, username := range []string{
+		"admin",
+		"ADMIN",
+		"adm1n",
+		"_admin_",
+		"\u0430dmin",                     // кириллическая "а"
+		"\u0430\u0501min",                // кириллические "а" и "ԁ"
+		"\uff41\uff44\uff4d\uff49\uff4e", // полноширинные
+		"admi\u0301n",                    // комбинируемое ударение
+	} {
+		assert.Equal(t, Skeleton("admin"), Skeleton(username), username)
+	}
+}
+
+func TestSkeleton_Sequences(t *testing.T) {
+	assert.Equal(t, Skeleton("modern"), Skeleton("rnodern"))
+	assert.Equal(t, Skeleton("wave"), Skeleton("vvave"))
+}
+
+func TestIsReserved(t *testing.T) {
+	assert.True(t, IsReserved("Adm1n"))
+	assert.True(t, IsReserved("\u0430dmin"))
+	assert.True(t, IsReserved("__support"))
+	assert.True(t, IsReserved("follows"))
+	assert.True(t, IsReserved("Blocks"))
+	assert.False(t, IsReserved("admiral"))
+}
+
+func TestPolicy_Check(t *testing.T) {
+	p := NewPolicy([]string{"pushpost", " "})
+
+	assert.ErrorIs(t, p.Check("_me_"), ErrReserved)
+	assert.ErrorIs(t, p.Check("official_pushp0st"), ErrForbidden)
+	assert.ErrorIs(t, p.Check("\u0440ushpost_team"), ErrForbidden)
+	assert.NoError(t, p.Check("alice"))
+}
@@ -0,0 +1,16 @@
+package revocation
+
+import "github.com/google/uuid"
+
+// Channel is the Redis pub/sub channel auth_service publishes to whenever a
+// session stops being valid before its token expires.
+const Channel = "session.revoked"
+
+// Event describes a revoked session. A nil SessionID means that every session
+// of UserID has been revoked.
+type Event struct {
+	UserID    uuid.UUID `json:"user_id"`
+	SessionID uuid.UUID `json:"session_id"`
+}
+
+func (e Event) AllSessions() bool { return e.SessionID == uuid.Nil }
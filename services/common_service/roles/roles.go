@@ -0,0 +1,39 @@
+// Package roles — роли пользователей. Роли упорядочены: каждая следующая
+// включает права предыдущей (admin может всё, что moderator).
+package roles
+
+type Role string
+
+const (
+	User      Role = "user"
+	Moderator Role = "moderator"
+	Admin     Role = "admin"
+)
+
+var rank = map[Role]int{
+	User:      1,
+	Moderator: 2,
+	Admin:     3,
+}
+
+// Parse возвращает false для неизвестной роли.
+func Parse(s string) (Role, bool) {
+	r := Role(s)
+
+	return r, r.Valid()
+}
+
+func (r Role) Valid() bool {
+	_, ok := rank[r]
+
+	return ok
+}
+
+// AtLeast сообщает, даёт ли роль права роли min. Неизвестная роль не даёт никаких прав.
+func (r Role) AtLeast(min Role) bool {
+	return r.Valid() && rank[r] >= rank[min]
+}
+
+func (r Role) String() string {
+	return string(r)
+}
@@ -13,15 +13,19 @@ import (
 	"syscall"
 
 	"github.com/joho/godotenv"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/rockkley/pushpost/clients/profile_grpc"
 	"github.com/rockkley/pushpost/services/common_service/database"
 	"github.com/rockkley/pushpost/services/common_service/logger"
 	"github.com/rockkley/pushpost/services/common_service/outbox"
 	"github.com/rockkley/pushpost/services/common_service/outbox/kafka"
 	outboxpg "github.com/rockkley/pushpost/services/common_service/outbox/postgres"
 	"github.com/rockkley/pushpost/services/friendship_service/gen/friendshipv1"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/clients/profile"
 	"github.com/rockkley/pushpost/services/friendship_service/internal/config"
 	"github.com/rockkley/pushpost/services/friendship_service/internal/domain/usecase"
 	repopg "github.com/rockkley/pushpost/services/friendship_service/internal/repository/postgres"
+	redisrepo "github.com/rockkley/pushpost/services/friendship_service/internal/repository/redis"
 	"github.com/rockkley/pushpost/services/friendship_service/internal/transport"
 	grpctransport "github.com/rockkley/pushpost/services/friendship_service/internal/transport/grpc"
 	friendhttp "github.com/rockkley/pushpost/services/friendship_service/internal/transport/http"
@@ -61,8 +65,34 @@ func main() {
 	}
 	defer db.Close()
 
+	// Redis: кэш рекомендаций друзей
+	rdb := goredis.NewClient(&goredis.Options{
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+
+	if err = rdb.Ping(context.Background()).Err(); err != nil {
+		appLog.Error("failed to connect to redis", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	defer rdb.Close()
+
+	// Profile gRPC: закрытые профили принимают подписчиков только по заявке и прячут счётчики
+	profileClient, err := profile_grpc.NewClient(cfg.Services.ProfileServiceGRPC)
+
+	if err != nil {
+		appLog.Error("failed to create profile grpc client", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	defer profileClient.Close()
+
 	uow := repopg.NewUnitOfWork(db)
-	friendUseCase := usecase.NewFriendshipUseCase(uow)
+	privacy := profile.NewPrivacyClient(profileClient)
+	friendUseCase := usecase.NewFriendshipUseCase(uow, redisrepo.NewSuggestionCache(rdb, cfg.Redis.SuggestionsTTL), privacy)
+	followUseCase := usecase.NewFollowUseCase(uow, privacy)
 
 	// Kafka outbox worker
 	kafkaPublisher := kafka.NewPublisher(cfg.Kafka.Brokers(), appLog)
@@ -88,7 +118,9 @@ func main() {
 
 	// HTTP server
 	httpHandler := friendhttp.NewFriendshipHandler(friendUseCase)
-	mux := transport.NewRouter(appLog, httpHandler)
+	followHandler := friendhttp.NewFollowHandler(followUseCase)
+	exportHandler := friendhttp.NewExportHandler(repopg.NewExportRepository(db))
+	mux := transport.NewRouter(appLog, httpHandler, followHandler, exportHandler)
 
 	httpSrv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.HTTP.Port),
@@ -101,7 +133,7 @@ func main() {
 	grpcSrv := grpc.NewServer()
 	friendshipv1.RegisterFriendshipServiceServer(
 		grpcSrv,
-		grpctransport.NewFriendshipServer(friendUseCase, appLog),
+		grpctransport.NewFriendshipServer(friendUseCase, followUseCase, appLog),
 	)
 
 	grpcLis, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPC.Port))
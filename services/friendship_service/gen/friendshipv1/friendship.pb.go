@@ -205,6 +205,794 @@ func (x *GetFriendIDsResponse) GetFriendIds() []string {
 	return nil
 }
 
+type ListFriendIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFriendIDsRequest) Reset() {
+	*x = ListFriendIDsRequest{}
+	mi := &file_friendship_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFriendIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFriendIDsRequest) ProtoMessage() {}
+
+func (x *ListFriendIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFriendIDsRequest.ProtoReflect.Descriptor instead.
+func (*ListFriendIDsRequest) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListFriendIDsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListFriendIDsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFriendIDsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListFriendIDsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FriendIds     []string               `protobuf:"bytes,1,rep,name=friend_ids,json=friendIds,proto3" json:"friend_ids,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFriendIDsResponse) Reset() {
+	*x = ListFriendIDsResponse{}
+	mi := &file_friendship_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFriendIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFriendIDsResponse) ProtoMessage() {}
+
+func (x *ListFriendIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFriendIDsResponse.ProtoReflect.Descriptor instead.
+func (*ListFriendIDsResponse) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListFriendIDsResponse) GetFriendIds() []string {
+	if x != nil {
+		return x.FriendIds
+	}
+	return nil
+}
+
+func (x *ListFriendIDsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type Relationship struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	TargetId               string                 `protobuf:"bytes,1,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	AreFriends             bool                   `protobuf:"varint,2,opt,name=are_friends,json=areFriends,proto3" json:"are_friends,omitempty"`
+	PendingRequestSent     bool                   `protobuf:"varint,3,opt,name=pending_request_sent,json=pendingRequestSent,proto3" json:"pending_request_sent,omitempty"`
+	PendingRequestReceived bool                   `protobuf:"varint,4,opt,name=pending_request_received,json=pendingRequestReceived,proto3" json:"pending_request_received,omitempty"`
+	Blocked                bool                   `protobuf:"varint,5,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	BlockedBy              bool                   `protobuf:"varint,6,opt,name=blocked_by,json=blockedBy,proto3" json:"blocked_by,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Relationship) Reset() {
+	*x = Relationship{}
+	mi := &file_friendship_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Relationship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Relationship) ProtoMessage() {}
+
+func (x *Relationship) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Relationship.ProtoReflect.Descriptor instead.
+func (*Relationship) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Relationship) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *Relationship) GetAreFriends() bool {
+	if x != nil {
+		return x.AreFriends
+	}
+	return false
+}
+
+func (x *Relationship) GetPendingRequestSent() bool {
+	if x != nil {
+		return x.PendingRequestSent
+	}
+	return false
+}
+
+func (x *Relationship) GetPendingRequestReceived() bool {
+	if x != nil {
+		return x.PendingRequestReceived
+	}
+	return false
+}
+
+func (x *Relationship) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+func (x *Relationship) GetBlockedBy() bool {
+	if x != nil {
+		return x.BlockedBy
+	}
+	return false
+}
+
+type GetRelationshipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ViewerId      string                 `protobuf:"bytes,1,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	TargetId      string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelationshipRequest) Reset() {
+	*x = GetRelationshipRequest{}
+	mi := &file_friendship_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelationshipRequest) ProtoMessage() {}
+
+func (x *GetRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*GetRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetRelationshipRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+func (x *GetRelationshipRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+type GetRelationshipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relationship  *Relationship          `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelationshipResponse) Reset() {
+	*x = GetRelationshipResponse{}
+	mi := &file_friendship_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelationshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelationshipResponse) ProtoMessage() {}
+
+func (x *GetRelationshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelationshipResponse.ProtoReflect.Descriptor instead.
+func (*GetRelationshipResponse) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetRelationshipResponse) GetRelationship() *Relationship {
+	if x != nil {
+		return x.Relationship
+	}
+	return nil
+}
+
+type GetRelationshipsBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ViewerId      string                 `protobuf:"bytes,1,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	TargetIds     []string               `protobuf:"bytes,2,rep,name=target_ids,json=targetIds,proto3" json:"target_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelationshipsBatchRequest) Reset() {
+	*x = GetRelationshipsBatchRequest{}
+	mi := &file_friendship_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelationshipsBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelationshipsBatchRequest) ProtoMessage() {}
+
+func (x *GetRelationshipsBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelationshipsBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetRelationshipsBatchRequest) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetRelationshipsBatchRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+func (x *GetRelationshipsBatchRequest) GetTargetIds() []string {
+	if x != nil {
+		return x.TargetIds
+	}
+	return nil
+}
+
+type GetRelationshipsBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relationships []*Relationship        `protobuf:"bytes,1,rep,name=relationships,proto3" json:"relationships,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelationshipsBatchResponse) Reset() {
+	*x = GetRelationshipsBatchResponse{}
+	mi := &file_friendship_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelationshipsBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelationshipsBatchResponse) ProtoMessage() {}
+
+func (x *GetRelationshipsBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelationshipsBatchResponse.ProtoReflect.Descriptor instead.
+func (*GetRelationshipsBatchResponse) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetRelationshipsBatchResponse) GetRelationships() []*Relationship {
+	if x != nil {
+		return x.Relationships
+	}
+	return nil
+}
+
+type AreBlockedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User1Id       string                 `protobuf:"bytes,1,opt,name=user1_id,json=user1Id,proto3" json:"user1_id,omitempty"`
+	User2Id       string                 `protobuf:"bytes,2,opt,name=user2_id,json=user2Id,proto3" json:"user2_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AreBlockedRequest) Reset() {
+	*x = AreBlockedRequest{}
+	mi := &file_friendship_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AreBlockedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AreBlockedRequest) ProtoMessage() {}
+
+func (x *AreBlockedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AreBlockedRequest.ProtoReflect.Descriptor instead.
+func (*AreBlockedRequest) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AreBlockedRequest) GetUser1Id() string {
+	if x != nil {
+		return x.User1Id
+	}
+	return ""
+}
+
+func (x *AreBlockedRequest) GetUser2Id() string {
+	if x != nil {
+		return x.User2Id
+	}
+	return ""
+}
+
+type AreBlockedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocked       bool                   `protobuf:"varint,1,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AreBlockedResponse) Reset() {
+	*x = AreBlockedResponse{}
+	mi := &file_friendship_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AreBlockedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AreBlockedResponse) ProtoMessage() {}
+
+func (x *AreBlockedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AreBlockedResponse.ProtoReflect.Descriptor instead.
+func (*AreBlockedResponse) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AreBlockedResponse) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+type GetBlockedUserIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockedUserIDsRequest) Reset() {
+	*x = GetBlockedUserIDsRequest{}
+	mi := &file_friendship_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockedUserIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockedUserIDsRequest) ProtoMessage() {}
+
+func (x *GetBlockedUserIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockedUserIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockedUserIDsRequest) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetBlockedUserIDsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetBlockedUserIDsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockedIds    []string               `protobuf:"bytes,1,rep,name=blocked_ids,json=blockedIds,proto3" json:"blocked_ids,omitempty"`
+	BlockedByIds  []string               `protobuf:"bytes,2,rep,name=blocked_by_ids,json=blockedByIds,proto3" json:"blocked_by_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockedUserIDsResponse) Reset() {
+	*x = GetBlockedUserIDsResponse{}
+	mi := &file_friendship_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockedUserIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockedUserIDsResponse) ProtoMessage() {}
+
+func (x *GetBlockedUserIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockedUserIDsResponse.ProtoReflect.Descriptor instead.
+func (*GetBlockedUserIDsResponse) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetBlockedUserIDsResponse) GetBlockedIds() []string {
+	if x != nil {
+		return x.BlockedIds
+	}
+	return nil
+}
+
+func (x *GetBlockedUserIDsResponse) GetBlockedByIds() []string {
+	if x != nil {
+		return x.BlockedByIds
+	}
+	return nil
+}
+
+type ListFollowerIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFollowerIDsRequest) Reset() {
+	*x = ListFollowerIDsRequest{}
+	mi := &file_friendship_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowerIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowerIDsRequest) ProtoMessage() {}
+
+func (x *ListFollowerIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFollowerIDsRequest.ProtoReflect.Descriptor instead.
+func (*ListFollowerIDsRequest) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListFollowerIDsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListFollowerIDsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFollowerIDsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListFollowerIDsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FollowerIds   []string               `protobuf:"bytes,1,rep,name=follower_ids,json=followerIds,proto3" json:"follower_ids,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFollowerIDsResponse) Reset() {
+	*x = ListFollowerIDsResponse{}
+	mi := &file_friendship_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowerIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowerIDsResponse) ProtoMessage() {}
+
+func (x *ListFollowerIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFollowerIDsResponse.ProtoReflect.Descriptor instead.
+func (*ListFollowerIDsResponse) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListFollowerIDsResponse) GetFollowerIds() []string {
+	if x != nil {
+		return x.FollowerIds
+	}
+	return nil
+}
+
+func (x *ListFollowerIDsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type IsFollowingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FollowerId    string                 `protobuf:"bytes,1,opt,name=follower_id,json=followerId,proto3" json:"follower_id,omitempty"`
+	FolloweeId    string                 `protobuf:"bytes,2,opt,name=followee_id,json=followeeId,proto3" json:"followee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsFollowingRequest) Reset() {
+	*x = IsFollowingRequest{}
+	mi := &file_friendship_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsFollowingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsFollowingRequest) ProtoMessage() {}
+
+func (x *IsFollowingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsFollowingRequest.ProtoReflect.Descriptor instead.
+func (*IsFollowingRequest) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *IsFollowingRequest) GetFollowerId() string {
+	if x != nil {
+		return x.FollowerId
+	}
+	return ""
+}
+
+func (x *IsFollowingRequest) GetFolloweeId() string {
+	if x != nil {
+		return x.FolloweeId
+	}
+	return ""
+}
+
+type IsFollowingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Following     bool                   `protobuf:"varint,1,opt,name=following,proto3" json:"following,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsFollowingResponse) Reset() {
+	*x = IsFollowingResponse{}
+	mi := &file_friendship_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsFollowingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsFollowingResponse) ProtoMessage() {}
+
+func (x *IsFollowingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_friendship_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsFollowingResponse.ProtoReflect.Descriptor instead.
+func (*IsFollowingResponse) Descriptor() ([]byte, []int) {
+	return file_friendship_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *IsFollowingResponse) GetFollowing() bool {
+	if x != nil {
+		return x.Following
+	}
+	return false
+}
+
 var File_friendship_proto protoreflect.FileDescriptor
 
 const file_friendship_proto_rawDesc = "" +
@@ -220,11 +1008,74 @@ const file_friendship_proto_rawDesc = "" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"5\n" +
 	"\x14GetFriendIDsResponse\x12\x1d\n" +
 	"\n" +
-	"friend_ids\x18\x01 \x03(\tR\tfriendIds2\xbf\x01\n" +
+	"friend_ids\x18\x01 \x03(\tR\tfriendIds\"k\n" +
+	"\x14ListFriendIDsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"^\n" +
+	"\x15ListFriendIDsResponse\x12\x1d\n" +
+	"\n" +
+	"friend_ids\x18\x01 \x03(\tR\tfriendIds\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xf1\x01\n" +
+	"\fRelationship\x12\x1b\n" +
+	"\ttarget_id\x18\x01 \x01(\tR\btargetId\x12\x1f\n" +
+	"\vare_friends\x18\x02 \x01(\bR\n" +
+	"areFriends\x120\n" +
+	"\x14pending_request_sent\x18\x03 \x01(\bR\x12pendingRequestSent\x128\n" +
+	"\x18pending_request_received\x18\x04 \x01(\bR\x16pendingRequestReceived\x12\x18\n" +
+	"\ablocked\x18\x05 \x01(\bR\ablocked\x12\x1d\n" +
+	"\n" +
+	"blocked_by\x18\x06 \x01(\bR\tblockedBy\"R\n" +
+	"\x16GetRelationshipRequest\x12\x1b\n" +
+	"\tviewer_id\x18\x01 \x01(\tR\bviewerId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId\"Z\n" +
+	"\x17GetRelationshipResponse\x12?\n" +
+	"\frelationship\x18\x01 \x01(\v2\x1b.friendship.v1.RelationshipR\frelationship\"Z\n" +
+	"\x1cGetRelationshipsBatchRequest\x12\x1b\n" +
+	"\tviewer_id\x18\x01 \x01(\tR\bviewerId\x12\x1d\n" +
+	"\n" +
+	"target_ids\x18\x02 \x03(\tR\ttargetIds\"b\n" +
+	"\x1dGetRelationshipsBatchResponse\x12A\n" +
+	"\rrelationships\x18\x01 \x03(\v2\x1b.friendship.v1.RelationshipR\rrelationships\"I\n" +
+	"\x11AreBlockedRequest\x12\x19\n" +
+	"\buser1_id\x18\x01 \x01(\tR\auser1Id\x12\x19\n" +
+	"\buser2_id\x18\x02 \x01(\tR\auser2Id\".\n" +
+	"\x12AreBlockedResponse\x12\x18\n" +
+	"\ablocked\x18\x01 \x01(\bR\ablocked\"3\n" +
+	"\x18GetBlockedUserIDsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"b\n" +
+	"\x19GetBlockedUserIDsResponse\x12\x1f\n" +
+	"\vblocked_ids\x18\x01 \x03(\tR\n" +
+	"blockedIds\x12$\n" +
+	"\x0eblocked_by_ids\x18\x02 \x03(\tR\fblockedByIds\"m\n" +
+	"\x16ListFollowerIDsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"d\n" +
+	"\x17ListFollowerIDsResponse\x12!\n" +
+	"\ffollower_ids\x18\x01 \x03(\tR\vfollowerIds\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"V\n" +
+	"\x12IsFollowingRequest\x12\x1f\n" +
+	"\vfollower_id\x18\x01 \x01(\tR\n" +
+	"followerId\x12\x1f\n" +
+	"\vfollowee_id\x18\x02 \x01(\tR\n" +
+	"followeeId\"3\n" +
+	"\x13IsFollowingResponse\x12\x1c\n" +
+	"\tfollowing\x18\x01 \x01(\bR\tfollowing2\xe4\x06\n" +
 	"\x11FriendshipService\x12Q\n" +
 	"\n" +
 	"AreFriends\x12 .friendship.v1.AreFriendsRequest\x1a!.friendship.v1.AreFriendsResponse\x12W\n" +
-	"\fGetFriendIDs\x12\".friendship.v1.GetFriendIDsRequest\x1a#.friendship.v1.GetFriendIDsResponseBYZWgithub.com/rockkley/pushpost/services/friendship_service/gen/friendship/v1;friendshipv1b\x06proto3"
+	"\fGetFriendIDs\x12\".friendship.v1.GetFriendIDsRequest\x1a#.friendship.v1.GetFriendIDsResponse\x12Z\n" +
+	"\rListFriendIDs\x12#.friendship.v1.ListFriendIDsRequest\x1a$.friendship.v1.ListFriendIDsResponse\x12`\n" +
+	"\x0fGetRelationship\x12%.friendship.v1.GetRelationshipRequest\x1a&.friendship.v1.GetRelationshipResponse\x12r\n" +
+	"\x15GetRelationshipsBatch\x12+.friendship.v1.GetRelationshipsBatchRequest\x1a,.friendship.v1.GetRelationshipsBatchResponse\x12Q\n" +
+	"\n" +
+	"AreBlocked\x12 .friendship.v1.AreBlockedRequest\x1a!.friendship.v1.AreBlockedResponse\x12f\n" +
+	"\x11GetBlockedUserIDs\x12'.friendship.v1.GetBlockedUserIDsRequest\x1a(.friendship.v1.GetBlockedUserIDsResponse\x12`\n" +
+	"\x0fListFollowerIDs\x12%.friendship.v1.ListFollowerIDsRequest\x1a&.friendship.v1.ListFollowerIDsResponse\x12T\n" +
+	"\vIsFollowing\x12!.friendship.v1.IsFollowingRequest\x1a\".friendship.v1.IsFollowingResponseBYZWgithub.com/rockkley/pushpost/services/friendship_service/gen/friendship/v1;friendshipv1b\x06proto3"
 
 var (
 	file_friendship_proto_rawDescOnce sync.Once
@@ -238,23 +1089,54 @@ func file_friendship_proto_rawDescGZIP() []byte {
 	return file_friendship_proto_rawDescData
 }
 
-var file_friendship_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_friendship_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_friendship_proto_goTypes = []any{
-	(*AreFriendsRequest)(nil),    // 0: friendship.v1.AreFriendsRequest
-	(*AreFriendsResponse)(nil),   // 1: friendship.v1.AreFriendsResponse
-	(*GetFriendIDsRequest)(nil),  // 2: friendship.v1.GetFriendIDsRequest
-	(*GetFriendIDsResponse)(nil), // 3: friendship.v1.GetFriendIDsResponse
+	(*AreFriendsRequest)(nil),             // 0: friendship.v1.AreFriendsRequest
+	(*AreFriendsResponse)(nil),            // 1: friendship.v1.AreFriendsResponse
+	(*GetFriendIDsRequest)(nil),           // 2: friendship.v1.GetFriendIDsRequest
+	(*GetFriendIDsResponse)(nil),          // 3: friendship.v1.GetFriendIDsResponse
+	(*ListFriendIDsRequest)(nil),          // 4: friendship.v1.ListFriendIDsRequest
+	(*ListFriendIDsResponse)(nil),         // 5: friendship.v1.ListFriendIDsResponse
+	(*Relationship)(nil),                  // 6: friendship.v1.Relationship
+	(*GetRelationshipRequest)(nil),        // 7: friendship.v1.GetRelationshipRequest
+	(*GetRelationshipResponse)(nil),       // 8: friendship.v1.GetRelationshipResponse
+	(*GetRelationshipsBatchRequest)(nil),  // 9: friendship.v1.GetRelationshipsBatchRequest
+	(*GetRelationshipsBatchResponse)(nil), // 10: friendship.v1.GetRelationshipsBatchResponse
+	(*AreBlockedRequest)(nil),             // 11: friendship.v1.AreBlockedRequest
+	(*AreBlockedResponse)(nil),            // 12: friendship.v1.AreBlockedResponse
+	(*GetBlockedUserIDsRequest)(nil),      // 13: friendship.v1.GetBlockedUserIDsRequest
+	(*GetBlockedUserIDsResponse)(nil),     // 14: friendship.v1.GetBlockedUserIDsResponse
+	(*ListFollowerIDsRequest)(nil),        // 15: friendship.v1.ListFollowerIDsRequest
+	(*ListFollowerIDsResponse)(nil),       // 16: friendship.v1.ListFollowerIDsResponse
+	(*IsFollowingRequest)(nil),            // 17: friendship.v1.IsFollowingRequest
+	(*IsFollowingResponse)(nil),           // 18: friendship.v1.IsFollowingResponse
 }
 var file_friendship_proto_depIdxs = []int32{
-	0, // 0: friendship.v1.FriendshipService.AreFriends:input_type -> friendship.v1.AreFriendsRequest
-	2, // 1: friendship.v1.FriendshipService.GetFriendIDs:input_type -> friendship.v1.GetFriendIDsRequest
-	1, // 2: friendship.v1.FriendshipService.AreFriends:output_type -> friendship.v1.AreFriendsResponse
-	3, // 3: friendship.v1.FriendshipService.GetFriendIDs:output_type -> friendship.v1.GetFriendIDsResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	6,  // 0: friendship.v1.GetRelationshipResponse.relationship:type_name -> friendship.v1.Relationship
+	6,  // 1: friendship.v1.GetRelationshipsBatchResponse.relationships:type_name -> friendship.v1.Relationship
+	0,  // 2: friendship.v1.FriendshipService.AreFriends:input_type -> friendship.v1.AreFriendsRequest
+	2,  // 3: friendship.v1.FriendshipService.GetFriendIDs:input_type -> friendship.v1.GetFriendIDsRequest
+	4,  // 4: friendship.v1.FriendshipService.ListFriendIDs:input_type -> friendship.v1.ListFriendIDsRequest
+	7,  // 5: friendship.v1.FriendshipService.GetRelationship:input_type -> friendship.v1.GetRelationshipRequest
+	9,  // 6: friendship.v1.FriendshipService.GetRelationshipsBatch:input_type -> friendship.v1.GetRelationshipsBatchRequest
+	11, // 7: friendship.v1.FriendshipService.AreBlocked:input_type -> friendship.v1.AreBlockedRequest
+	13, // 8: friendship.v1.FriendshipService.GetBlockedUserIDs:input_type -> friendship.v1.GetBlockedUserIDsRequest
+	15, // 9: friendship.v1.FriendshipService.ListFollowerIDs:input_type -> friendship.v1.ListFollowerIDsRequest
+	17, // 10: friendship.v1.FriendshipService.IsFollowing:input_type -> friendship.v1.IsFollowingRequest
+	1,  // 11: friendship.v1.FriendshipService.AreFriends:output_type -> friendship.v1.AreFriendsResponse
+	3,  // 12: friendship.v1.FriendshipService.GetFriendIDs:output_type -> friendship.v1.GetFriendIDsResponse
+	5,  // 13: friendship.v1.FriendshipService.ListFriendIDs:output_type -> friendship.v1.ListFriendIDsResponse
+	8,  // 14: friendship.v1.FriendshipService.GetRelationship:output_type -> friendship.v1.GetRelationshipResponse
+	10, // 15: friendship.v1.FriendshipService.GetRelationshipsBatch:output_type -> friendship.v1.GetRelationshipsBatchResponse
+	12, // 16: friendship.v1.FriendshipService.AreBlocked:output_type -> friendship.v1.AreBlockedResponse
+	14, // 17: friendship.v1.FriendshipService.GetBlockedUserIDs:output_type -> friendship.v1.GetBlockedUserIDsResponse
+	16, // 18: friendship.v1.FriendshipService.ListFollowerIDs:output_type -> friendship.v1.ListFollowerIDsResponse
+	18, // 19: friendship.v1.FriendshipService.IsFollowing:output_type -> friendship.v1.IsFollowingResponse
+	11, // [11:20] is the sub-list for method output_type
+	2,  // [2:11] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_friendship_proto_init() }
@@ -268,7 +1150,7 @@ func file_friendship_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_friendship_proto_rawDesc), len(file_friendship_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
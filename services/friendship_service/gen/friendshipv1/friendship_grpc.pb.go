@@ -19,8 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	FriendshipService_AreFriends_FullMethodName   = "/friendship.v1.FriendshipService/AreFriends"
-	FriendshipService_GetFriendIDs_FullMethodName = "/friendship.v1.FriendshipService/GetFriendIDs"
+	FriendshipService_AreFriends_FullMethodName            = "/friendship.v1.FriendshipService/AreFriends"
+	FriendshipService_GetFriendIDs_FullMethodName          = "/friendship.v1.FriendshipService/GetFriendIDs"
+	FriendshipService_ListFriendIDs_FullMethodName         = "/friendship.v1.FriendshipService/ListFriendIDs"
+	FriendshipService_GetRelationship_FullMethodName       = "/friendship.v1.FriendshipService/GetRelationship"
+	FriendshipService_GetRelationshipsBatch_FullMethodName = "/friendship.v1.FriendshipService/GetRelationshipsBatch"
+	FriendshipService_AreBlocked_FullMethodName            = "/friendship.v1.FriendshipService/AreBlocked"
+	FriendshipService_GetBlockedUserIDs_FullMethodName     = "/friendship.v1.FriendshipService/GetBlockedUserIDs"
+	FriendshipService_ListFollowerIDs_FullMethodName       = "/friendship.v1.FriendshipService/ListFollowerIDs"
+	FriendshipService_IsFollowing_FullMethodName           = "/friendship.v1.FriendshipService/IsFollowing"
 )
 
 // FriendshipServiceClient is the client API for FriendshipService service.
@@ -29,6 +36,13 @@ const (
 type FriendshipServiceClient interface {
 	AreFriends(ctx context.Context, in *AreFriendsRequest, opts ...grpc.CallOption) (*AreFriendsResponse, error)
 	GetFriendIDs(ctx context.Context, in *GetFriendIDsRequest, opts ...grpc.CallOption) (*GetFriendIDsResponse, error)
+	ListFriendIDs(ctx context.Context, in *ListFriendIDsRequest, opts ...grpc.CallOption) (*ListFriendIDsResponse, error)
+	GetRelationship(ctx context.Context, in *GetRelationshipRequest, opts ...grpc.CallOption) (*GetRelationshipResponse, error)
+	GetRelationshipsBatch(ctx context.Context, in *GetRelationshipsBatchRequest, opts ...grpc.CallOption) (*GetRelationshipsBatchResponse, error)
+	AreBlocked(ctx context.Context, in *AreBlockedRequest, opts ...grpc.CallOption) (*AreBlockedResponse, error)
+	GetBlockedUserIDs(ctx context.Context, in *GetBlockedUserIDsRequest, opts ...grpc.CallOption) (*GetBlockedUserIDsResponse, error)
+	ListFollowerIDs(ctx context.Context, in *ListFollowerIDsRequest, opts ...grpc.CallOption) (*ListFollowerIDsResponse, error)
+	IsFollowing(ctx context.Context, in *IsFollowingRequest, opts ...grpc.CallOption) (*IsFollowingResponse, error)
 }
 
 type friendshipServiceClient struct {
@@ -59,12 +73,89 @@ func (c *friendshipServiceClient) GetFriendIDs(ctx context.Context, in *GetFrien
 	return out, nil
 }
 
+func (c *friendshipServiceClient) ListFriendIDs(ctx context.Context, in *ListFriendIDsRequest, opts ...grpc.CallOption) (*ListFriendIDsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFriendIDsResponse)
+	err := c.cc.Invoke(ctx, FriendshipService_ListFriendIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendshipServiceClient) GetRelationship(ctx context.Context, in *GetRelationshipRequest, opts ...grpc.CallOption) (*GetRelationshipResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelationshipResponse)
+	err := c.cc.Invoke(ctx, FriendshipService_GetRelationship_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendshipServiceClient) GetRelationshipsBatch(ctx context.Context, in *GetRelationshipsBatchRequest, opts ...grpc.CallOption) (*GetRelationshipsBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelationshipsBatchResponse)
+	err := c.cc.Invoke(ctx, FriendshipService_GetRelationshipsBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendshipServiceClient) AreBlocked(ctx context.Context, in *AreBlockedRequest, opts ...grpc.CallOption) (*AreBlockedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AreBlockedResponse)
+	err := c.cc.Invoke(ctx, FriendshipService_AreBlocked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendshipServiceClient) GetBlockedUserIDs(ctx context.Context, in *GetBlockedUserIDsRequest, opts ...grpc.CallOption) (*GetBlockedUserIDsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBlockedUserIDsResponse)
+	err := c.cc.Invoke(ctx, FriendshipService_GetBlockedUserIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendshipServiceClient) ListFollowerIDs(ctx context.Context, in *ListFollowerIDsRequest, opts ...grpc.CallOption) (*ListFollowerIDsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFollowerIDsResponse)
+	err := c.cc.Invoke(ctx, FriendshipService_ListFollowerIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendshipServiceClient) IsFollowing(ctx context.Context, in *IsFollowingRequest, opts ...grpc.CallOption) (*IsFollowingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IsFollowingResponse)
+	err := c.cc.Invoke(ctx, FriendshipService_IsFollowing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FriendshipServiceServer is the server API for FriendshipService service.
 // All implementations must embed UnimplementedFriendshipServiceServer
 // for forward compatibility.
 type FriendshipServiceServer interface {
 	AreFriends(context.Context, *AreFriendsRequest) (*AreFriendsResponse, error)
 	GetFriendIDs(context.Context, *GetFriendIDsRequest) (*GetFriendIDsResponse, error)
+	ListFriendIDs(context.Context, *ListFriendIDsRequest) (*ListFriendIDsResponse, error)
+	GetRelationship(context.Context, *GetRelationshipRequest) (*GetRelationshipResponse, error)
+	GetRelationshipsBatch(context.Context, *GetRelationshipsBatchRequest) (*GetRelationshipsBatchResponse, error)
+	AreBlocked(context.Context, *AreBlockedRequest) (*AreBlockedResponse, error)
+	GetBlockedUserIDs(context.Context, *GetBlockedUserIDsRequest) (*GetBlockedUserIDsResponse, error)
+	ListFollowerIDs(context.Context, *ListFollowerIDsRequest) (*ListFollowerIDsResponse, error)
+	IsFollowing(context.Context, *IsFollowingRequest) (*IsFollowingResponse, error)
 	mustEmbedUnimplementedFriendshipServiceServer()
 }
 
@@ -81,6 +172,27 @@ func (UnimplementedFriendshipServiceServer) AreFriends(context.Context, *AreFrie
 func (UnimplementedFriendshipServiceServer) GetFriendIDs(context.Context, *GetFriendIDsRequest) (*GetFriendIDsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetFriendIDs not implemented")
 }
+func (UnimplementedFriendshipServiceServer) ListFriendIDs(context.Context, *ListFriendIDsRequest) (*ListFriendIDsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFriendIDs not implemented")
+}
+func (UnimplementedFriendshipServiceServer) GetRelationship(context.Context, *GetRelationshipRequest) (*GetRelationshipResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRelationship not implemented")
+}
+func (UnimplementedFriendshipServiceServer) GetRelationshipsBatch(context.Context, *GetRelationshipsBatchRequest) (*GetRelationshipsBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRelationshipsBatch not implemented")
+}
+func (UnimplementedFriendshipServiceServer) AreBlocked(context.Context, *AreBlockedRequest) (*AreBlockedResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AreBlocked not implemented")
+}
+func (UnimplementedFriendshipServiceServer) GetBlockedUserIDs(context.Context, *GetBlockedUserIDsRequest) (*GetBlockedUserIDsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBlockedUserIDs not implemented")
+}
+func (UnimplementedFriendshipServiceServer) ListFollowerIDs(context.Context, *ListFollowerIDsRequest) (*ListFollowerIDsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFollowerIDs not implemented")
+}
+func (UnimplementedFriendshipServiceServer) IsFollowing(context.Context, *IsFollowingRequest) (*IsFollowingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method IsFollowing not implemented")
+}
 func (UnimplementedFriendshipServiceServer) mustEmbedUnimplementedFriendshipServiceServer() {}
 func (UnimplementedFriendshipServiceServer) testEmbeddedByValue()                           {}
 
@@ -138,6 +250,132 @@ func _FriendshipService_GetFriendIDs_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FriendshipService_ListFriendIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFriendIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendshipServiceServer).ListFriendIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendshipService_ListFriendIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendshipServiceServer).ListFriendIDs(ctx, req.(*ListFriendIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendshipService_GetRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendshipServiceServer).GetRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendshipService_GetRelationship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendshipServiceServer).GetRelationship(ctx, req.(*GetRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendshipService_GetRelationshipsBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelationshipsBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendshipServiceServer).GetRelationshipsBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendshipService_GetRelationshipsBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendshipServiceServer).GetRelationshipsBatch(ctx, req.(*GetRelationshipsBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendshipService_AreBlocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AreBlockedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendshipServiceServer).AreBlocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendshipService_AreBlocked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendshipServiceServer).AreBlocked(ctx, req.(*AreBlockedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendshipService_GetBlockedUserIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockedUserIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendshipServiceServer).GetBlockedUserIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendshipService_GetBlockedUserIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendshipServiceServer).GetBlockedUserIDs(ctx, req.(*GetBlockedUserIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendshipService_ListFollowerIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFollowerIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendshipServiceServer).ListFollowerIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendshipService_ListFollowerIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendshipServiceServer).ListFollowerIDs(ctx, req.(*ListFollowerIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendshipService_IsFollowing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsFollowingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendshipServiceServer).IsFollowing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendshipService_IsFollowing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendshipServiceServer).IsFollowing(ctx, req.(*IsFollowingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FriendshipService_ServiceDesc is the grpc.ServiceDesc for FriendshipService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +391,34 @@ var FriendshipService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFriendIDs",
 			Handler:    _FriendshipService_GetFriendIDs_Handler,
 		},
+		{
+			MethodName: "ListFriendIDs",
+			Handler:    _FriendshipService_ListFriendIDs_Handler,
+		},
+		{
+			MethodName: "GetRelationship",
+			Handler:    _FriendshipService_GetRelationship_Handler,
+		},
+		{
+			MethodName: "GetRelationshipsBatch",
+			Handler:    _FriendshipService_GetRelationshipsBatch_Handler,
+		},
+		{
+			MethodName: "AreBlocked",
+			Handler:    _FriendshipService_AreBlocked_Handler,
+		},
+		{
+			MethodName: "GetBlockedUserIDs",
+			Handler:    _FriendshipService_GetBlockedUserIDs_Handler,
+		},
+		{
+			MethodName: "ListFollowerIDs",
+			Handler:    _FriendshipService_ListFollowerIDs_Handler,
+		},
+		{
+			MethodName: "IsFollowing",
+			Handler:    _FriendshipService_IsFollowing_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "friendship.proto",
@@ -12,4 +12,11 @@ const (
 	CodeAlreadyBlocked          = "already_blocked"
 	CodeBlockNotFound           = "block_not_found"
 	CodeUserBlocked             = "user_blocked"
+	CodeUserNotFound            = "user_not_found"
+	CodeCannotFollowSelf        = "cannot_follow_self"
+	CodeAlreadyFollowing        = "already_following"
+	CodeNotFollowing            = "not_following"
+	CodeFollowRequestExists     = "follow_request_exists"
+	CodeFollowRequestNotFound   = "follow_request_not_found"
+	CodeFollowListPrivate       = "follow_list_private"
 )
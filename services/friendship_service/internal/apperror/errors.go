@@ -50,6 +50,34 @@ func UserBlocked() apperror.AppError {
 	return apperror.BadRequest(CodeUserBlocked, "you cannot perform this action because the user has blocked you")
 }
 
+func UserNotFound() apperror.AppError {
+	return apperror.NotFound(CodeUserNotFound, "user not found")
+}
+
+func CannotFollowSelf() apperror.AppError {
+	return apperror.BadRequest(CodeCannotFollowSelf, "cannot follow yourself")
+}
+
+func AlreadyFollowing() apperror.AppError {
+	return apperror.BadRequest(CodeAlreadyFollowing, "you already follow this user")
+}
+
+func NotFollowing() apperror.AppError {
+	return apperror.NotFound(CodeNotFollowing, "you do not follow this user")
+}
+
+func FollowRequestExists() apperror.AppError {
+	return apperror.BadRequest(CodeFollowRequestExists, "follow request already exists")
+}
+
+func FollowRequestNotFound() apperror.AppError {
+	return apperror.NotFound(CodeFollowRequestNotFound, "follow request not found")
+}
+
+func FollowListPrivate() apperror.AppError {
+	return apperror.Forbidden(CodeFollowListPrivate, "this account is private")
+}
+
 // -- Postgres constraint mapper
 
 func MapConstraint(constraintName string) apperror.AppError {
@@ -62,10 +90,18 @@ func MapConstraint(constraintName string) apperror.AppError {
 		return FriendRequestExists()
 	case "friendships_ordered_users":
 		return apperror.Internal("friendship ordering constraint violated", nil)
+	case "friendship_counters_non_negative":
+		return apperror.Internal("friendship counter went negative", nil)
 	case "chk_block_not_self":
 		return CannotBlockSelf()
 	case "blocks_pkey":
 		return AlreadyBlocked()
+	case "chk_follow_not_self", "chk_follow_request_not_self":
+		return CannotFollowSelf()
+	case "follows_pkey":
+		return AlreadyFollowing()
+	case "follow_requests_pkey":
+		return FollowRequestExists()
 
 	default:
 		return nil // return nil = pass control to generic mapper
@@ -0,0 +1,37 @@
+package profile
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/clients/profile_grpc"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	apperr "github.com/rockkley/pushpost/services/friendship_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/domain"
+)
+
+var _ domain.ProfilePrivacy = (*PrivacyClient)(nil)
+
+// PrivacyClient отдаёт приватность профиля из profile_service в терминах ошибок friendship_service.
+type PrivacyClient struct {
+	client *profile_grpc.Client
+}
+
+func NewPrivacyClient(client *profile_grpc.Client) *PrivacyClient {
+	return &PrivacyClient{client: client}
+}
+
+func (c *PrivacyClient) IsPrivate(ctx context.Context, userID uuid.UUID) (bool, error) {
+	isPrivate, err := c.client.IsPrivate(ctx, userID)
+
+	if err != nil {
+		if errors.Is(err, profile_grpc.ErrNotFound) {
+			return false, apperr.UserNotFound()
+		}
+
+		return false, commonapperr.Service("failed to check profile privacy", err)
+	}
+
+	return isPrivate, nil
+}
@@ -13,6 +13,12 @@ type Config struct {
 	GRPC     GRPCConfig
 	Database DatabaseConfig
 	Kafka    KafkaConfig
+	Redis    RedisConfig
+	Services ServicesConfig
+}
+
+type ServicesConfig struct {
+	ProfileServiceGRPC string `env:"PROFILE_SERVICE_GRPC_ADDR" env-default:"profile-service:9083"`
 }
 
 type HTTPConfig struct {
@@ -36,8 +42,12 @@ type KafkaConfig struct {
 	BrokersRaw string `env:"KAFKA_BROKERS" env-default:"kafka:9092"`
 }
 
-type JWTConfig struct {
-	Secret string `env:"JWT_SECRET" env-required:"true"`
+type RedisConfig struct {
+	Addr     string `env:"REDIS_ADDR"     env-default:"redis:6379"`
+	Password string `env:"REDIS_PASSWORD" env-default:""`
+	DB       int    `env:"REDIS_DB"       env-default:"3"`
+	// SuggestionsTTL — сколько живёт кэш рекомендаций; изменения у друзей друзей видны не раньше.
+	SuggestionsTTL time.Duration `env:"FRIEND_SUGGESTIONS_TTL" env-default:"15m"`
 }
 
 func Load() (*Config, error) {
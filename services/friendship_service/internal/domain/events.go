@@ -6,6 +6,10 @@ const (
 	EventFriendRequestCancelled = "friendship_request.cancelled"
 	EventFriendshipCreated      = "friendship.created"
 	EventFriendshipDeleted      = "friendship.deleted"
+	EventBlockCreated           = "block.created"
+	EventBlockRemoved           = "block.removed"
+	EventFollowCreated          = "follow.created"
+	EventFollowDeleted          = "follow.deleted"
 )
 
 // EventFriendRequestSent
@@ -39,3 +43,15 @@ type FriendshipDeletedPayload struct {
 	UserID   string `json:"user_id"`
 	FriendID string `json:"friend_id"`
 }
+
+// EventBlockCreated, EventBlockRemoved — UserID блокирует TargetID.
+type BlockPayload struct {
+	UserID   string `json:"user_id"`
+	TargetID string `json:"target_id"`
+}
+
+// EventFollowCreated, EventFollowDeleted — FollowerID подписался на FolloweeID или отписался от него.
+type FollowPayload struct {
+	FollowerID string `json:"follower_id"`
+	FolloweeID string `json:"followee_id"`
+}
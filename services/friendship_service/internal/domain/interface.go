@@ -15,14 +15,38 @@ type FriendshipUseCase interface {
 	CancelRequest(ctx context.Context, senderID, receiverID uuid.UUID) error
 	DeleteFriendship(ctx context.Context, userID, friendID uuid.UUID) error
 	GetFriendIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	ListFriends(ctx context.Context, userID uuid.UUID, after *entity.Friend, limit int) ([]*entity.Friend, bool, error)
 	AreFriends(ctx context.Context, user1, user2 uuid.UUID) (bool, error)
 	GetFriendshipStatus(ctx context.Context, viewerID, targetID uuid.UUID) (*entity.FriendshipStatus, error)
+	GetFriendshipStatuses(ctx context.Context, viewerID uuid.UUID, targetIDs []uuid.UUID) (map[uuid.UUID]*entity.FriendshipStatus, error)
 	GetIncomingRequests(ctx context.Context, userID uuid.UUID) ([]*entity.FriendshipRequest, error)
 	GetOutgoingRequests(ctx context.Context, userID uuid.UUID) ([]*entity.FriendshipRequest, error)
 	BlockUser(ctx context.Context, userID, targetID uuid.UUID) error
 	UnblockUser(ctx context.Context, userID, targetID uuid.UUID) error
 	AreBlocked(ctx context.Context, userID, targetID uuid.UUID) (bool, error)
+	IsBlockedBetween(ctx context.Context, user1, user2 uuid.UUID) (bool, error)
 	GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	GetBlockedByUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	GetSuggestions(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Suggestion, error)
+	GetMutualFriends(ctx context.Context, viewerID, targetID uuid.UUID, limit, offset int) ([]uuid.UUID, int, error)
+	GetRelationshipSummary(ctx context.Context, viewerID, targetID uuid.UUID) (*entity.RelationshipSummary, error)
+}
+
+type FollowUseCase interface {
+	Follow(ctx context.Context, followerID, followeeID uuid.UUID) (entity.FollowState, error)
+	Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) error
+	AcceptFollowRequest(ctx context.Context, followeeID, followerID uuid.UUID) error
+	RejectFollowRequest(ctx context.Context, followeeID, followerID uuid.UUID) error
+	GetIncomingFollowRequests(ctx context.Context, userID uuid.UUID) ([]*entity.FollowRequest, error)
+	GetFollowers(ctx context.Context, viewerID, userID uuid.UUID, limit, offset int) ([]*entity.Follow, error)
+	GetFollowing(ctx context.Context, viewerID, userID uuid.UUID, limit, offset int) ([]*entity.Follow, error)
+	ListFollowerIDs(ctx context.Context, userID, afterID uuid.UUID, limit int) ([]uuid.UUID, bool, error)
+	IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error)
+}
+
+// ProfilePrivacy сообщает, закрыт ли профиль; для несуществующего профиля возвращает apperror.UserNotFound.
+type ProfilePrivacy interface {
+	IsPrivate(ctx context.Context, userID uuid.UUID) (bool, error)
 }
 
 type Tx interface {
@@ -30,6 +54,8 @@ type Tx interface {
 	Friendships() repository.FriendshipRepository
 	Outbox() outbox.WriterInterface
 	Blocks() repository.BlockRepository
+	Counters() repository.CounterRepository
+	Follows() repository.FollowRepository
 }
 
 type UnitOfWork interface {
@@ -37,4 +63,6 @@ type UnitOfWork interface {
 	Requests() repository.FriendshipRequestRepository
 	Friendships() repository.FriendshipRepository
 	Blocks() repository.BlockRepository
+	Counters() repository.CounterRepository
+	Follows() repository.FollowRepository
 }
@@ -0,0 +1,512 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/outbox"
+	apperr "github.com/rockkley/pushpost/services/friendship_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/domain"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/entity"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/repository"
+)
+
+// memStore — состояние friendship_service в памяти; транзакции не откатываются,
+// поэтому тесты проверяют только успешные пути и ошибки до первой записи.
+type memStore struct {
+	requests       []*entity.FriendshipRequest
+	friendships    map[[2]uuid.UUID]time.Time
+	blocks         map[[2]uuid.UUID]time.Time
+	follows        map[[2]uuid.UUID]time.Time
+	followRequests map[[2]uuid.UUID]time.Time
+	counters       map[uuid.UUID]*entity.FriendshipCounters
+	events         []*outbox.OutboxEvent
+
+	// suggest подменяет SQL-расчёт рекомендаций; rejectedSince запоминает окно отказов
+	suggest       func(userID uuid.UUID) []*entity.Suggestion
+	suggestCalls  int
+	rejectedSince time.Time
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		friendships:    make(map[[2]uuid.UUID]time.Time),
+		blocks:         make(map[[2]uuid.UUID]time.Time),
+		follows:        make(map[[2]uuid.UUID]time.Time),
+		followRequests: make(map[[2]uuid.UUID]time.Time),
+		counters:       make(map[uuid.UUID]*entity.FriendshipCounters),
+	}
+}
+
+func pairKey(a, b uuid.UUID) [2]uuid.UUID {
+	if a.String() < b.String() {
+		return [2]uuid.UUID{a, b}
+	}
+	return [2]uuid.UUID{b, a}
+}
+
+func (s *memStore) counter(id uuid.UUID) *entity.FriendshipCounters {
+	c, ok := s.counters[id]
+	if !ok {
+		c = &entity.FriendshipCounters{UserID: id}
+		s.counters[id] = c
+	}
+	return c
+}
+
+// befriend и block раскладывают исходное состояние в обход usecase, без событий в outbox.
+func (s *memStore) befriend(a, b uuid.UUID) {
+	s.friendships[pairKey(a, b)] = time.Now()
+	s.counter(a).FriendsCount++
+	s.counter(b).FriendsCount++
+}
+
+func (s *memStore) block(userID, targetID uuid.UUID) {
+	s.blocks[[2]uuid.UUID{userID, targetID}] = time.Now()
+}
+
+func (s *memStore) eventTypes() []string {
+	types := make([]string, len(s.events))
+	for i, e := range s.events {
+		types[i] = e.EventType
+	}
+	return types
+}
+
+// ── Unit of work ──────────────────────────────────────────────────────────────
+
+type memUoW struct{ s *memStore }
+
+var (
+	_ domain.UnitOfWork = memUoW{}
+	_ domain.Tx         = memUoW{}
+)
+
+func (u memUoW) Do(_ context.Context, fn func(tx domain.Tx) error) error { return fn(u) }
+
+func (u memUoW) Requests() repository.FriendshipRequestRepository { return memRequests{u.s} }
+func (u memUoW) Friendships() repository.FriendshipRepository     { return memFriendships{u.s} }
+func (u memUoW) Blocks() repository.BlockRepository               { return memBlocks{u.s} }
+func (u memUoW) Counters() repository.CounterRepository           { return memCounters{u.s} }
+func (u memUoW) Follows() repository.FollowRepository             { return memFollows{u.s} }
+func (u memUoW) Outbox() outbox.WriterInterface                   { return memOutbox{u.s} }
+
+type memOutbox struct{ s *memStore }
+
+func (o memOutbox) Insert(_ context.Context, event *outbox.OutboxEvent) error {
+	o.s.events = append(o.s.events, event)
+	return nil
+}
+
+// ── Requests ──────────────────────────────────────────────────────────────────
+
+type memRequests struct{ s *memStore }
+
+func (r memRequests) Create(_ context.Context, req *entity.FriendshipRequest) error {
+	req.CreatedAt, req.UpdatedAt = time.Now(), time.Now()
+	r.s.requests = append(r.s.requests, req)
+	return nil
+}
+
+func (r memRequests) FindPending(_ context.Context, senderID, receiverID uuid.UUID) (*entity.FriendshipRequest, error) {
+	for _, req := range r.s.requests {
+		if req.Status == entity.ReqStatusPending && req.SenderID == senderID && req.ReceiverID == receiverID {
+			return req, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r memRequests) FindPendingBetween(ctx context.Context, user1, user2 uuid.UUID) (*entity.FriendshipRequest, error) {
+	if req, _ := r.FindPending(ctx, user1, user2); req != nil {
+		return req, nil
+	}
+	return r.FindPending(ctx, user2, user1)
+}
+
+func (r memRequests) UpdateStatus(ctx context.Context, senderID, receiverID uuid.UUID, status entity.FriendshipReqStatus) error {
+	req, _ := r.FindPending(ctx, senderID, receiverID)
+	if req == nil {
+		return apperr.FriendRequestNotFound()
+	}
+	req.Status, req.UpdatedAt = status, time.Now()
+	return nil
+}
+
+func (r memRequests) HasRecentRejected(_ context.Context, senderID, receiverID uuid.UUID, since time.Time) (bool, error) {
+	for _, req := range r.s.requests {
+		if req.Status == entity.ReqStatusRejected && req.SenderID == senderID && req.ReceiverID == receiverID && req.UpdatedAt.After(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r memRequests) GetIncoming(_ context.Context, receiverID uuid.UUID) ([]*entity.FriendshipRequest, error) {
+	var out []*entity.FriendshipRequest
+	for _, req := range r.s.requests {
+		if req.Status == entity.ReqStatusPending && req.ReceiverID == receiverID {
+			out = append(out, req)
+		}
+	}
+	return out, nil
+}
+
+func (r memRequests) GetOutgoing(_ context.Context, senderID uuid.UUID) ([]*entity.FriendshipRequest, error) {
+	var out []*entity.FriendshipRequest
+	for _, req := range r.s.requests {
+		if req.Status == entity.ReqStatusPending && req.SenderID == senderID {
+			out = append(out, req)
+		}
+	}
+	return out, nil
+}
+
+func (r memRequests) FindPendingWith(ctx context.Context, userID uuid.UUID, otherIDs []uuid.UUID) ([]*entity.FriendshipRequest, error) {
+	var out []*entity.FriendshipRequest
+	for _, id := range otherIDs {
+		if req, _ := r.FindPendingBetween(ctx, userID, id); req != nil {
+			out = append(out, req)
+		}
+	}
+	return out, nil
+}
+
+// ── Friendships ───────────────────────────────────────────────────────────────
+
+type memFriendships struct{ s *memStore }
+
+func (r memFriendships) Create(_ context.Context, f *entity.Friendship) error {
+	key := pairKey(f.User1ID, f.User2ID)
+	if _, ok := r.s.friendships[key]; ok {
+		return apperr.AlreadyFriends()
+	}
+	r.s.friendships[key] = time.Now()
+	return nil
+}
+
+func (r memFriendships) Exists(_ context.Context, user1, user2 uuid.UUID) (bool, error) {
+	_, ok := r.s.friendships[pairKey(user1, user2)]
+	return ok, nil
+}
+
+func (r memFriendships) Delete(_ context.Context, userID, friendID uuid.UUID) error {
+	key := pairKey(userID, friendID)
+	if _, ok := r.s.friendships[key]; !ok {
+		return apperr.NotFriends()
+	}
+	delete(r.s.friendships, key)
+	return nil
+}
+
+func (r memFriendships) GetFriendIDs(_ context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for key := range r.s.friendships {
+		switch userID {
+		case key[0]:
+			ids = append(ids, key[1])
+		case key[1]:
+			ids = append(ids, key[0])
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	return ids, nil
+}
+
+func (r memFriendships) ListFriends(ctx context.Context, userID uuid.UUID, _ *entity.Friend, limit int) ([]*entity.Friend, error) {
+	ids, _ := r.GetFriendIDs(ctx, userID)
+	var out []*entity.Friend
+	for _, id := range ids[:min(limit, len(ids))] {
+		out = append(out, &entity.Friend{ID: id, Since: r.s.friendships[pairKey(userID, id)]})
+	}
+	return out, nil
+}
+
+func (r memFriendships) FilterFriends(ctx context.Context, userID uuid.UUID, otherIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var out []uuid.UUID
+	for _, id := range otherIDs {
+		if ok, _ := r.Exists(ctx, userID, id); ok {
+			out = append(out, id)
+		}
+	}
+	return out, nil
+}
+
+func (r memFriendships) SuggestFriends(_ context.Context, userID uuid.UUID, rejectedSince time.Time, limit int) ([]*entity.Suggestion, error) {
+	r.s.suggestCalls++
+	r.s.rejectedSince = rejectedSince
+	if r.s.suggest == nil {
+		return nil, nil
+	}
+	suggestions := r.s.suggest(userID)
+	return suggestions[:min(limit, len(suggestions))], nil
+}
+
+func (r memFriendships) mutual(ctx context.Context, user1, user2 uuid.UUID) []uuid.UUID {
+	a, _ := r.GetFriendIDs(ctx, user1)
+	var out []uuid.UUID
+	for _, id := range a {
+		if ok, _ := r.Exists(ctx, user2, id); ok {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func (r memFriendships) GetMutualFriendIDs(ctx context.Context, user1, user2 uuid.UUID, limit, offset int) ([]uuid.UUID, error) {
+	ids := r.mutual(ctx, user1, user2)
+	if offset >= len(ids) {
+		return nil, nil
+	}
+	return ids[offset:min(offset+limit, len(ids))], nil
+}
+
+func (r memFriendships) CountMutualFriends(ctx context.Context, user1, user2 uuid.UUID) (int, error) {
+	return len(r.mutual(ctx, user1, user2)), nil
+}
+
+// ── Blocks ────────────────────────────────────────────────────────────────────
+
+type memBlocks struct{ s *memStore }
+
+func (r memBlocks) Create(_ context.Context, b *entity.Block) error {
+	key := [2]uuid.UUID{b.UserID, b.TargetID}
+	if _, ok := r.s.blocks[key]; ok {
+		return apperr.AlreadyBlocked()
+	}
+	r.s.blocks[key] = time.Now()
+	return nil
+}
+
+func (r memBlocks) Delete(_ context.Context, userID, targetID uuid.UUID) error {
+	key := [2]uuid.UUID{userID, targetID}
+	if _, ok := r.s.blocks[key]; !ok {
+		return apperr.BlockNotFound()
+	}
+	delete(r.s.blocks, key)
+	return nil
+}
+
+func (r memBlocks) Exists(_ context.Context, userID, targetID uuid.UUID) (bool, error) {
+	_, ok := r.s.blocks[[2]uuid.UUID{userID, targetID}]
+	return ok, nil
+}
+
+func (r memBlocks) GetBlockedUserIDs(_ context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var out []uuid.UUID
+	for key := range r.s.blocks {
+		if key[0] == userID {
+			out = append(out, key[1])
+		}
+	}
+	return out, nil
+}
+
+func (r memBlocks) GetBlockedByUserIDs(_ context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var out []uuid.UUID
+	for key := range r.s.blocks {
+		if key[1] == userID {
+			out = append(out, key[0])
+		}
+	}
+	return out, nil
+}
+
+func (r memBlocks) ExistsBetween(ctx context.Context, user1, user2 uuid.UUID) (bool, error) {
+	if ok, _ := r.Exists(ctx, user1, user2); ok {
+		return true, nil
+	}
+	return r.Exists(ctx, user2, user1)
+}
+
+func (r memBlocks) FindBetween(_ context.Context, userID uuid.UUID, otherIDs []uuid.UUID) ([]*entity.Block, error) {
+	var out []*entity.Block
+	for _, id := range otherIDs {
+		for _, key := range [][2]uuid.UUID{{userID, id}, {id, userID}} {
+			if at, ok := r.s.blocks[key]; ok {
+				out = append(out, &entity.Block{UserID: key[0], TargetID: key[1], CreatedAt: at})
+			}
+		}
+	}
+	return out, nil
+}
+
+// ── Counters ──────────────────────────────────────────────────────────────────
+
+// memCounters, как и CHECK в таблице, не даёт счётчику уйти в минус.
+type memCounters struct{ s *memStore }
+
+func (r memCounters) add(fields ...*int) func(delta int) error {
+	return func(delta int) error {
+		for _, f := range fields {
+			if *f+delta < 0 {
+				return commonapperr.Internal("friendship counter went negative", nil)
+			}
+		}
+		for _, f := range fields {
+			*f += delta
+		}
+		return nil
+	}
+}
+
+func (r memCounters) AddFriends(_ context.Context, user1, user2 uuid.UUID, delta int) error {
+	return r.add(&r.s.counter(user1).FriendsCount, &r.s.counter(user2).FriendsCount)(delta)
+}
+
+func (r memCounters) AddRequests(_ context.Context, senderID, receiverID uuid.UUID, delta int) error {
+	return r.add(&r.s.counter(senderID).OutgoingRequests, &r.s.counter(receiverID).IncomingRequests)(delta)
+}
+
+func (r memCounters) AddFollows(_ context.Context, followerID, followeeID uuid.UUID, delta int) error {
+	return r.add(&r.s.counter(followerID).FollowingCount, &r.s.counter(followeeID).FollowersCount)(delta)
+}
+
+func (r memCounters) Get(_ context.Context, userID uuid.UUID) (*entity.FriendshipCounters, error) {
+	c := *r.s.counter(userID)
+	return &c, nil
+}
+
+// ── Follows ───────────────────────────────────────────────────────────────────
+
+type memFollows struct{ s *memStore }
+
+func (r memFollows) Create(_ context.Context, f *entity.Follow) error {
+	key := [2]uuid.UUID{f.FollowerID, f.FolloweeID}
+	if _, ok := r.s.follows[key]; ok {
+		return apperr.AlreadyFollowing()
+	}
+	r.s.follows[key] = time.Now()
+	return nil
+}
+
+func (r memFollows) Delete(_ context.Context, followerID, followeeID uuid.UUID) error {
+	key := [2]uuid.UUID{followerID, followeeID}
+	if _, ok := r.s.follows[key]; !ok {
+		return apperr.NotFollowing()
+	}
+	delete(r.s.follows, key)
+	return nil
+}
+
+func (r memFollows) Exists(_ context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	_, ok := r.s.follows[[2]uuid.UUID{followerID, followeeID}]
+	return ok, nil
+}
+
+func (r memFollows) list(match func(key [2]uuid.UUID) bool, limit, offset int) []*entity.Follow {
+	var out []*entity.Follow
+	for key, at := range r.s.follows {
+		if match(key) {
+			out = append(out, &entity.Follow{FollowerID: key[0], FolloweeID: key[1], CreatedAt: at})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].FollowerID.String()+out[i].FolloweeID.String() < out[j].FollowerID.String()+out[j].FolloweeID.String()
+	})
+	if offset >= len(out) {
+		return nil
+	}
+	return out[offset:min(offset+limit, len(out))]
+}
+
+func (r memFollows) GetFollowers(_ context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Follow, error) {
+	return r.list(func(key [2]uuid.UUID) bool { return key[1] == userID }, limit, offset), nil
+}
+
+func (r memFollows) GetFollowing(_ context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Follow, error) {
+	return r.list(func(key [2]uuid.UUID) bool { return key[0] == userID }, limit, offset), nil
+}
+
+func (r memFollows) ListFollowerIDs(_ context.Context, userID, afterID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for key := range r.s.follows {
+		if key[1] == userID && key[0].String() > afterID.String() {
+			ids = append(ids, key[0])
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	return ids[:min(limit, len(ids))], nil
+}
+
+func (r memFollows) CreateRequest(_ context.Context, req *entity.FollowRequest) error {
+	key := [2]uuid.UUID{req.FollowerID, req.FolloweeID}
+	if _, ok := r.s.followRequests[key]; ok {
+		return apperr.FollowRequestExists()
+	}
+	r.s.followRequests[key] = time.Now()
+	return nil
+}
+
+func (r memFollows) DeleteRequest(_ context.Context, followerID, followeeID uuid.UUID) error {
+	key := [2]uuid.UUID{followerID, followeeID}
+	if _, ok := r.s.followRequests[key]; !ok {
+		return apperr.FollowRequestNotFound()
+	}
+	delete(r.s.followRequests, key)
+	return nil
+}
+
+func (r memFollows) DeleteRequestsBetween(_ context.Context, user1, user2 uuid.UUID) error {
+	delete(r.s.followRequests, [2]uuid.UUID{user1, user2})
+	delete(r.s.followRequests, [2]uuid.UUID{user2, user1})
+	return nil
+}
+
+func (r memFollows) RequestExists(_ context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	_, ok := r.s.followRequests[[2]uuid.UUID{followerID, followeeID}]
+	return ok, nil
+}
+
+func (r memFollows) GetIncomingRequests(_ context.Context, followeeID uuid.UUID) ([]*entity.FollowRequest, error) {
+	var out []*entity.FollowRequest
+	for key, at := range r.s.followRequests {
+		if key[1] == followeeID {
+			out = append(out, &entity.FollowRequest{FollowerID: key[0], FolloweeID: key[1], CreatedAt: at})
+		}
+	}
+	return out, nil
+}
+
+// ── Suggestion cache ──────────────────────────────────────────────────────────
+
+type memSuggestionCache struct {
+	cached      map[uuid.UUID][]*entity.Suggestion
+	invalidated map[uuid.UUID]bool
+}
+
+func newMemSuggestionCache() *memSuggestionCache {
+	return &memSuggestionCache{
+		cached:      make(map[uuid.UUID][]*entity.Suggestion),
+		invalidated: make(map[uuid.UUID]bool),
+	}
+}
+
+func (c *memSuggestionCache) Get(_ context.Context, userID uuid.UUID) ([]*entity.Suggestion, bool, error) {
+	s, ok := c.cached[userID]
+	return s, ok, nil
+}
+
+func (c *memSuggestionCache) Set(_ context.Context, userID uuid.UUID, suggestions []*entity.Suggestion) error {
+	c.cached[userID] = suggestions
+	return nil
+}
+
+func (c *memSuggestionCache) Invalidate(_ context.Context, userIDs ...uuid.UUID) error {
+	for _, id := range userIDs {
+		delete(c.cached, id)
+		c.invalidated[id] = true
+	}
+	return nil
+}
+
+// ── Profile privacy ───────────────────────────────────────────────────────────
+
+type memPrivacy struct{ private map[uuid.UUID]bool }
+
+func (p memPrivacy) IsPrivate(_ context.Context, userID uuid.UUID) (bool, error) {
+	return p.private[userID], nil
+}
@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/ctxlog"
+	apperr "github.com/rockkley/pushpost/services/friendship_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/domain"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/entity"
+)
+
+const (
+	defaultFollowLimit = 20
+	maxFollowLimit     = 100
+)
+
+// FollowUseCase — односторонние подписки. На открытый профиль подписка оформляется сразу,
+// к закрытому сначала создаётся заявка, которую владелец принимает или отклоняет.
+type FollowUseCase struct {
+	uow     domain.UnitOfWork
+	privacy domain.ProfilePrivacy
+}
+
+func NewFollowUseCase(uow domain.UnitOfWork, privacy domain.ProfilePrivacy) *FollowUseCase {
+	return &FollowUseCase{uow: uow, privacy: privacy}
+}
+
+func (uc *FollowUseCase) Follow(ctx context.Context, followerID, followeeID uuid.UUID) (entity.FollowState, error) {
+	if followerID == followeeID {
+		return "", apperr.CannotFollowSelf()
+	}
+
+	if blocked, err := uc.uow.Blocks().ExistsBetween(ctx, followerID, followeeID); err != nil {
+		return "", err
+	} else if blocked {
+		return "", apperr.UserBlocked()
+	}
+
+	isPrivate, err := uc.privacy.IsPrivate(ctx, followeeID)
+
+	if err != nil {
+		return "", err
+	}
+
+	err = uc.uow.Do(ctx, func(tx domain.Tx) error {
+		following, err := tx.Follows().Exists(ctx, followerID, followeeID)
+
+		if err != nil {
+			return err
+		}
+
+		if following {
+			return apperr.AlreadyFollowing()
+		}
+
+		if isPrivate {
+			return tx.Follows().CreateRequest(ctx, &entity.FollowRequest{FollowerID: followerID, FolloweeID: followeeID})
+		}
+
+		return createFollow(ctx, tx, followerID, followeeID)
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	state := entity.FollowStateFollowing
+
+	if isPrivate {
+		state = entity.FollowStateRequested
+	}
+
+	ctxlog.From(ctx).With(
+		slog.String("op", "FollowUseCase.Follow"),
+		slog.String("follower_id", followerID.String()),
+		slog.String("followee_id", followeeID.String()),
+		slog.String("state", string(state)),
+	).Info("follow requested")
+
+	return state, nil
+}
+
+// Unfollow снимает подписку, а если её ещё нет — отзывает заявку на подписку.
+func (uc *FollowUseCase) Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	err := uc.uow.Do(ctx, func(tx domain.Tx) error {
+		following, err := tx.Follows().Exists(ctx, followerID, followeeID)
+
+		if err != nil {
+			return err
+		}
+
+		if following {
+			return removeFollow(ctx, tx, followerID, followeeID)
+		}
+
+		requested, err := tx.Follows().RequestExists(ctx, followerID, followeeID)
+
+		if err != nil {
+			return err
+		}
+
+		if !requested {
+			return apperr.NotFollowing()
+		}
+
+		return tx.Follows().DeleteRequest(ctx, followerID, followeeID)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	ctxlog.From(ctx).With(
+		slog.String("op", "FollowUseCase.Unfollow"),
+		slog.String("follower_id", followerID.String()),
+		slog.String("followee_id", followeeID.String()),
+	).Info("unfollowed")
+
+	return nil
+}
+
+func (uc *FollowUseCase) AcceptFollowRequest(ctx context.Context, followeeID, followerID uuid.UUID) error {
+	err := uc.uow.Do(ctx, func(tx domain.Tx) error {
+		if err := tx.Follows().DeleteRequest(ctx, followerID, followeeID); err != nil {
+			return err
+		}
+
+		return createFollow(ctx, tx, followerID, followeeID)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	ctxlog.From(ctx).With(
+		slog.String("op", "FollowUseCase.AcceptFollowRequest"),
+		slog.String("follower_id", followerID.String()),
+		slog.String("followee_id", followeeID.String()),
+	).Info("follow request accepted")
+
+	return nil
+}
+
+func (uc *FollowUseCase) RejectFollowRequest(ctx context.Context, followeeID, followerID uuid.UUID) error {
+	if err := uc.uow.Follows().DeleteRequest(ctx, followerID, followeeID); err != nil {
+		return err
+	}
+
+	ctxlog.From(ctx).With(
+		slog.String("op", "FollowUseCase.RejectFollowRequest"),
+		slog.String("follower_id", followerID.String()),
+		slog.String("followee_id", followeeID.String()),
+	).Info("follow request rejected")
+
+	return nil
+}
+
+func (uc *FollowUseCase) GetIncomingFollowRequests(ctx context.Context, userID uuid.UUID) ([]*entity.FollowRequest, error) {
+	return uc.uow.Follows().GetIncomingRequests(ctx, userID)
+}
+
+func (uc *FollowUseCase) GetFollowers(ctx context.Context, viewerID, userID uuid.UUID, limit, offset int) ([]*entity.Follow, error) {
+	if err := checkRelationsAccess(ctx, uc.uow, uc.privacy, viewerID, userID); err != nil {
+		return nil, err
+	}
+
+	limit, offset = normalizeFollowPage(limit, offset)
+
+	return uc.uow.Follows().GetFollowers(ctx, userID, limit, offset)
+}
+
+func (uc *FollowUseCase) GetFollowing(ctx context.Context, viewerID, userID uuid.UUID, limit, offset int) ([]*entity.Follow, error) {
+	if err := checkRelationsAccess(ctx, uc.uow, uc.privacy, viewerID, userID); err != nil {
+		return nil, err
+	}
+
+	limit, offset = normalizeFollowPage(limit, offset)
+
+	return uc.uow.Follows().GetFollowing(ctx, userID, limit, offset)
+}
+
+// ListFollowerIDs — постраничный список подписчиков для внутренних сервисов, без проверки приватности.
+// limit вне (0, max] заменяется на значение по умолчанию.
+func (uc *FollowUseCase) ListFollowerIDs(ctx context.Context, userID, afterID uuid.UUID, limit int) ([]uuid.UUID, bool, error) {
+	if limit <= 0 || limit > maxFriendPageSize {
+		limit = defaultFriendPageSize
+	}
+
+	ids, err := uc.uow.Follows().ListFollowerIDs(ctx, userID, afterID, limit+1)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(ids) > limit {
+		return ids[:limit], true, nil
+	}
+
+	return ids, false, nil
+}
+
+func (uc *FollowUseCase) IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	return uc.uow.Follows().Exists(ctx, followerID, followeeID)
+}
+
+// checkRelationsAccess — подписки и счётчики закрытого профиля видны только владельцу
+// и его подписчикам; между заблокированными пользователями не видны совсем.
+func checkRelationsAccess(
+	ctx context.Context,
+	uow domain.UnitOfWork,
+	privacy domain.ProfilePrivacy,
+	viewerID, userID uuid.UUID,
+) error {
+	if viewerID == userID {
+		return nil
+	}
+
+	if blocked, err := uow.Blocks().ExistsBetween(ctx, viewerID, userID); err != nil {
+		return err
+	} else if blocked {
+		return apperr.UserBlocked()
+	}
+
+	isPrivate, err := privacy.IsPrivate(ctx, userID)
+
+	if err != nil {
+		return err
+	}
+
+	if !isPrivate {
+		return nil
+	}
+
+	following, err := uow.Follows().Exists(ctx, viewerID, userID)
+
+	if err != nil {
+		return err
+	}
+
+	if !following {
+		return apperr.FollowListPrivate()
+	}
+
+	return nil
+}
+
+func normalizeFollowPage(limit, offset int) (int, int) {
+	if limit <= 0 || limit > maxFollowLimit {
+		limit = defaultFollowLimit
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	return limit, offset
+}
+
+func createFollow(ctx context.Context, tx domain.Tx, followerID, followeeID uuid.UUID) error {
+	if err := tx.Follows().Create(ctx, &entity.Follow{FollowerID: followerID, FolloweeID: followeeID}); err != nil {
+		return err
+	}
+
+	if err := tx.Counters().AddFollows(ctx, followerID, followeeID, 1); err != nil {
+		return err
+	}
+
+	return insertOutboxEvent(ctx, tx, followerID.String(), "follow",
+		domain.EventFollowCreated,
+		domain.FollowPayload{FollowerID: followerID.String(), FolloweeID: followeeID.String()},
+	)
+}
+
+func removeFollow(ctx context.Context, tx domain.Tx, followerID, followeeID uuid.UUID) error {
+	if err := tx.Follows().Delete(ctx, followerID, followeeID); err != nil {
+		return err
+	}
+
+	if err := tx.Counters().AddFollows(ctx, followerID, followeeID, -1); err != nil {
+		return err
+	}
+
+	return insertOutboxEvent(ctx, tx, followerID.String(), "follow",
+		domain.EventFollowDeleted,
+		domain.FollowPayload{FollowerID: followerID.String(), FolloweeID: followeeID.String()},
+	)
+}
+
+// removeFollowsBetween снимает подписки и заявки на подписку между пользователями в обе стороны.
+func removeFollowsBetween(ctx context.Context, tx domain.Tx, user1, user2 uuid.UUID) error {
+	for _, pair := range [][2]uuid.UUID{{user1, user2}, {user2, user1}} {
+		following, err := tx.Follows().Exists(ctx, pair[0], pair[1])
+
+		if err != nil {
+			return err
+		}
+
+		if !following {
+			continue
+		}
+
+		if err = removeFollow(ctx, tx, pair[0], pair[1]); err != nil {
+			return err
+		}
+	}
+
+	return tx.Follows().DeleteRequestsBetween(ctx, user1, user2)
+}
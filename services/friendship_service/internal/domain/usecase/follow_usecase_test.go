@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	apperr "github.com/rockkley/pushpost/services/friendship_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/domain"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFollowUseCase(private ...uuid.UUID) (*FollowUseCase, *memStore) {
+	store := newMemStore()
+	privacy := memPrivacy{private: make(map[uuid.UUID]bool)}
+	for _, id := range private {
+		privacy.private[id] = true
+	}
+
+	return NewFollowUseCase(memUoW{store}, privacy), store
+}
+
+// ── Follow / unfollow ─────────────────────────────────────────────────────────
+
+func TestFollowUseCase_Follow_PublicProfile(t *testing.T) {
+	uc, store := newTestFollowUseCase()
+	follower, followee := uuid.New(), uuid.New()
+	ctx := context.Background()
+
+	state, err := uc.Follow(ctx, follower, followee)
+	require.NoError(t, err)
+	require.Equal(t, entity.FollowStateFollowing, state)
+	require.Equal(t, []string{domain.EventFollowCreated}, store.eventTypes())
+	requireCounters(t, store, follower, entity.FriendshipCounters{FollowingCount: 1})
+	requireCounters(t, store, followee, entity.FriendshipCounters{FollowersCount: 1})
+
+	_, err = uc.Follow(ctx, follower, followee)
+	requireAppErrorCode(t, err, apperr.CodeAlreadyFollowing)
+
+	require.NoError(t, uc.Unfollow(ctx, follower, followee))
+	require.Equal(t, []string{domain.EventFollowCreated, domain.EventFollowDeleted}, store.eventTypes())
+	requireCounters(t, store, follower, entity.FriendshipCounters{})
+	requireCounters(t, store, followee, entity.FriendshipCounters{})
+
+	requireAppErrorCode(t, uc.Unfollow(ctx, follower, followee), apperr.CodeNotFollowing)
+}
+
+func TestFollowUseCase_Follow_PrivateProfileAccept(t *testing.T) {
+	owner, follower := uuid.New(), uuid.New()
+	uc, store := newTestFollowUseCase(owner)
+	ctx := context.Background()
+
+	state, err := uc.Follow(ctx, follower, owner)
+	require.NoError(t, err)
+	require.Equal(t, entity.FollowStateRequested, state)
+	require.Empty(t, store.events, "a follow request is not a follow yet")
+	requireCounters(t, store, owner, entity.FriendshipCounters{})
+
+	_, err = uc.Follow(ctx, follower, owner)
+	requireAppErrorCode(t, err, apperr.CodeFollowRequestExists)
+
+	incoming, err := uc.GetIncomingFollowRequests(ctx, owner)
+	require.NoError(t, err)
+	require.Len(t, incoming, 1)
+	require.Equal(t, follower, incoming[0].FollowerID)
+
+	require.NoError(t, uc.AcceptFollowRequest(ctx, owner, follower))
+	following, err := uc.IsFollowing(ctx, follower, owner)
+	require.NoError(t, err)
+	require.True(t, following)
+	require.Empty(t, store.followRequests)
+	require.Equal(t, []string{domain.EventFollowCreated}, store.eventTypes())
+	requireCounters(t, store, owner, entity.FriendshipCounters{FollowersCount: 1})
+
+	requireAppErrorCode(t, uc.AcceptFollowRequest(ctx, owner, follower), apperr.CodeFollowRequestNotFound)
+}
+
+func TestFollowUseCase_Follow_PrivateProfileRejectAndWithdraw(t *testing.T) {
+	owner, rejected, withdrawn := uuid.New(), uuid.New(), uuid.New()
+	uc, store := newTestFollowUseCase(owner)
+	ctx := context.Background()
+
+	_, err := uc.Follow(ctx, rejected, owner)
+	require.NoError(t, err)
+	_, err = uc.Follow(ctx, withdrawn, owner)
+	require.NoError(t, err)
+
+	require.NoError(t, uc.RejectFollowRequest(ctx, owner, rejected))
+	require.NoError(t, uc.Unfollow(ctx, withdrawn, owner), "unfollow withdraws a pending request")
+
+	require.Empty(t, store.followRequests)
+	require.Empty(t, store.follows)
+	require.Empty(t, store.events)
+	requireCounters(t, store, owner, entity.FriendshipCounters{})
+}
+
+func TestFollowUseCase_Follow_Rejected(t *testing.T) {
+	uc, store := newTestFollowUseCase()
+	user, blocked, blocker := uuid.New(), uuid.New(), uuid.New()
+	store.block(user, blocked)
+	store.block(blocker, user)
+	ctx := context.Background()
+
+	_, err := uc.Follow(ctx, user, user)
+	requireAppErrorCode(t, err, apperr.CodeCannotFollowSelf)
+
+	_, err = uc.Follow(ctx, user, blocked)
+	requireAppErrorCode(t, err, apperr.CodeUserBlocked)
+
+	_, err = uc.Follow(ctx, user, blocker)
+	requireAppErrorCode(t, err, apperr.CodeUserBlocked)
+
+	require.Empty(t, store.follows)
+	require.Empty(t, store.followRequests)
+}
+
+// ── Lists ─────────────────────────────────────────────────────────────────────
+
+func TestFollowUseCase_GetFollowers_PrivateProfile(t *testing.T) {
+	owner, follower, requester, stranger := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	uc, _ := newTestFollowUseCase(owner)
+	ctx := context.Background()
+
+	_, err := uc.Follow(ctx, follower, owner)
+	require.NoError(t, err)
+	require.NoError(t, uc.AcceptFollowRequest(ctx, owner, follower))
+	_, err = uc.Follow(ctx, requester, owner)
+	require.NoError(t, err)
+
+	for _, viewer := range []uuid.UUID{stranger, requester} {
+		_, err = uc.GetFollowers(ctx, viewer, owner, 0, 0)
+		requireAppErrorCode(t, err, apperr.CodeFollowListPrivate)
+		_, err = uc.GetFollowing(ctx, viewer, owner, 0, 0)
+		requireAppErrorCode(t, err, apperr.CodeFollowListPrivate)
+	}
+
+	for _, viewer := range []uuid.UUID{owner, follower} {
+		followers, err := uc.GetFollowers(ctx, viewer, owner, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, followers, 1)
+		require.Equal(t, follower, followers[0].FollowerID)
+	}
+}
+
+// ── Fan-out ───────────────────────────────────────────────────────────────────
+
+func TestFollowUseCase_ListFollowerIDs_Pages(t *testing.T) {
+	uc, _ := newTestFollowUseCase()
+	author := uuid.New()
+	ctx := context.Background()
+
+	want := make([]uuid.UUID, 5)
+	for i := range want {
+		want[i] = uuid.New()
+		_, err := uc.Follow(ctx, want[i], author)
+		require.NoError(t, err)
+	}
+	sort.Slice(want, func(i, j int) bool { return want[i].String() < want[j].String() })
+
+	var got []uuid.UUID
+	after := uuid.Nil
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3, "five followers must fit into three pages of two")
+
+		ids, hasMore, err := uc.ListFollowerIDs(ctx, author, after, 2)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(ids), 2)
+		got = append(got, ids...)
+
+		if !hasMore {
+			break
+		}
+		after = ids[len(ids)-1]
+	}
+
+	require.Equal(t, want, got, "every follower is returned exactly once, in id order")
+}
+
+func TestFollowUseCase_ListFollowerIDs_SkipsPendingRequests(t *testing.T) {
+	author, follower, requester := uuid.New(), uuid.New(), uuid.New()
+	uc, _ := newTestFollowUseCase(author)
+	ctx := context.Background()
+
+	_, err := uc.Follow(ctx, follower, author)
+	require.NoError(t, err)
+	require.NoError(t, uc.AcceptFollowRequest(ctx, author, follower))
+	_, err = uc.Follow(ctx, requester, author)
+	require.NoError(t, err)
+
+	ids, hasMore, err := uc.ListFollowerIDs(ctx, author, uuid.Nil, 0)
+	require.NoError(t, err)
+	require.False(t, hasMore)
+	require.Equal(t, []uuid.UUID{follower}, ids, "only accepted followers receive the author's posts")
+}
@@ -10,18 +10,39 @@ import (
 	apperr "github.com/rockkley/pushpost/services/friendship_service/internal/apperror"
 	"github.com/rockkley/pushpost/services/friendship_service/internal/domain"
 	"github.com/rockkley/pushpost/services/friendship_service/internal/entity"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/repository"
 	"log/slog"
 	"time"
 )
 
-const cooldownDuration = 24 * time.Hour
+const (
+	cooldownDuration = 24 * time.Hour
+
+	defaultFriendPageSize = 1000
+	maxFriendPageSize     = 5000
+
+	defaultSuggestionLimit = 20
+	maxSuggestionLimit     = 50
+
+	defaultMutualLimit = 20
+	maxMutualLimit     = 100
+
+	// maxSuggestions — сколько рекомендаций рассчитывается и кэшируется за раз; страницы режутся из них.
+	maxSuggestions = 200
+)
 
 type FriendshipUseCase struct {
-	uow domain.UnitOfWork
+	uow         domain.UnitOfWork
+	suggestions repository.SuggestionCache
+	privacy     domain.ProfilePrivacy
 }
 
-func NewFriendshipUseCase(uow domain.UnitOfWork) *FriendshipUseCase {
-	return &FriendshipUseCase{uow: uow}
+func NewFriendshipUseCase(
+	uow domain.UnitOfWork,
+	suggestions repository.SuggestionCache,
+	privacy domain.ProfilePrivacy,
+) *FriendshipUseCase {
+	return &FriendshipUseCase{uow: uow, suggestions: suggestions, privacy: privacy}
 }
 
 func (uc *FriendshipUseCase) SendRequest(ctx context.Context, senderID, receiverID uuid.UUID) error {
@@ -81,6 +102,10 @@ func (uc *FriendshipUseCase) SendRequest(ctx context.Context, senderID, receiver
 			return err
 		}
 
+		if err = tx.Counters().AddRequests(ctx, senderID, receiverID, 1); err != nil {
+			return err
+		}
+
 		return insertOutboxEvent(ctx, tx, req.ID.String(), "friendship_request",
 			domain.EventFriendRequestSent,
 			domain.FriendRequestSentPayload{
@@ -95,6 +120,8 @@ func (uc *FriendshipUseCase) SendRequest(ctx context.Context, senderID, receiver
 		return err
 	}
 
+	uc.invalidateSuggestions(ctx, senderID, receiverID)
+
 	ctxlog.From(ctx).With(
 		slog.String("op", "FriendshipUseCase.SendRequest"),
 		slog.String("sender_id", senderID.String()),
@@ -105,30 +132,67 @@ func (uc *FriendshipUseCase) SendRequest(ctx context.Context, senderID, receiver
 }
 
 func (uc *FriendshipUseCase) GetFriendshipStatus(ctx context.Context, viewerID, targetID uuid.UUID) (*entity.FriendshipStatus, error) {
-	areFriends, err := uc.uow.Friendships().Exists(ctx, viewerID, targetID)
+	statuses, err := uc.GetFriendshipStatuses(ctx, viewerID, []uuid.UUID{targetID})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses[targetID], nil
+}
+
+// GetFriendshipStatuses возвращает статус для каждого из targetIDs тремя запросами,
+// независимо от их количества.
+func (uc *FriendshipUseCase) GetFriendshipStatuses(
+	ctx context.Context,
+	viewerID uuid.UUID,
+	targetIDs []uuid.UUID,
+) (map[uuid.UUID]*entity.FriendshipStatus, error) {
+	statuses := make(map[uuid.UUID]*entity.FriendshipStatus, len(targetIDs))
+
+	for _, id := range targetIDs {
+		statuses[id] = &entity.FriendshipStatus{}
+	}
+
+	friendIDs, err := uc.uow.Friendships().FilterFriends(ctx, viewerID, targetIDs)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if areFriends {
-		return &entity.FriendshipStatus{AreFriends: true}, nil
+	for _, id := range friendIDs {
+		statuses[id].AreFriends = true
 	}
 
-	req, err := uc.uow.Requests().FindPendingBetween(ctx, viewerID, targetID)
+	requests, err := uc.uow.Requests().FindPendingWith(ctx, viewerID, targetIDs)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if req == nil {
-		return nil, nil
+	for _, req := range requests {
+		if req.SenderID == viewerID {
+			statuses[req.ReceiverID].PendingRequestSent = true
+		} else {
+			statuses[req.SenderID].PendingRequestReceived = true
+		}
 	}
 
-	return &entity.FriendshipStatus{
-		PendingRequestSent:     req.SenderID == viewerID,
-		PendingRequestReceived: req.ReceiverID == viewerID,
-	}, nil
+	blocks, err := uc.uow.Blocks().FindBetween(ctx, viewerID, targetIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range blocks {
+		if b.UserID == viewerID {
+			statuses[b.TargetID].Blocked = true
+		} else {
+			statuses[b.UserID].BlockedBy = true
+		}
+	}
+
+	return statuses, nil
 }
 
 func (uc *FriendshipUseCase) AcceptRequest(ctx context.Context, receiverID, senderID uuid.UUID) error {
@@ -138,6 +202,10 @@ func (uc *FriendshipUseCase) AcceptRequest(ctx context.Context, receiverID, send
 			return err
 		}
 
+		if err := tx.Counters().AddRequests(ctx, senderID, receiverID, -1); err != nil {
+			return err
+		}
+
 		friendship := entity.Friendship{
 			ID:      uuid.New(),
 			User1ID: senderID,
@@ -148,6 +216,10 @@ func (uc *FriendshipUseCase) AcceptRequest(ctx context.Context, receiverID, send
 			return err
 		}
 
+		if err := tx.Counters().AddFriends(ctx, senderID, receiverID, 1); err != nil {
+			return err
+		}
+
 		return insertOutboxEvent(ctx, tx, friendship.ID.String(), "friendship",
 			domain.EventFriendshipCreated,
 			domain.FriendshipCreatedPayload{
@@ -163,6 +235,8 @@ func (uc *FriendshipUseCase) AcceptRequest(ctx context.Context, receiverID, send
 		return err
 	}
 
+	uc.invalidateFriendGraph(ctx, senderID, receiverID)
+
 	ctxlog.From(ctx).With(
 		slog.String("op", "FriendshipUseCase.AcceptRequest"),
 		slog.String("sender_id", senderID.String()),
@@ -178,6 +252,10 @@ func (uc *FriendshipUseCase) RejectRequest(ctx context.Context, receiverID, send
 			return err
 		}
 
+		if err := tx.Counters().AddRequests(ctx, senderID, receiverID, -1); err != nil {
+			return err
+		}
+
 		return insertOutboxEvent(ctx, tx, senderID.String(), "friendship_status",
 			domain.EventFriendRequestRejected,
 			domain.FriendRequestRejectedPayload{
@@ -191,6 +269,8 @@ func (uc *FriendshipUseCase) RejectRequest(ctx context.Context, receiverID, send
 		return err
 	}
 
+	uc.invalidateSuggestions(ctx, senderID, receiverID)
+
 	ctxlog.From(ctx).With(
 		slog.String("op", "FriendshipUseCase.RejectRequest"),
 		slog.String("sender_id", senderID.String()),
@@ -206,6 +286,10 @@ func (uc *FriendshipUseCase) CancelRequest(ctx context.Context, senderID, receiv
 			return err
 		}
 
+		if err := tx.Counters().AddRequests(ctx, senderID, receiverID, -1); err != nil {
+			return err
+		}
+
 		return insertOutboxEvent(ctx, tx, senderID.String(), "friendship_request",
 			domain.EventFriendRequestCancelled,
 			domain.FriendRequestCancelledPayload{
@@ -219,6 +303,8 @@ func (uc *FriendshipUseCase) CancelRequest(ctx context.Context, senderID, receiv
 		return err
 	}
 
+	uc.invalidateSuggestions(ctx, senderID, receiverID)
+
 	ctxlog.From(ctx).With(
 		slog.String("op", "FriendshipUseCase.CancelRequest"),
 		slog.String("sender_id", senderID.String()),
@@ -234,6 +320,10 @@ func (uc *FriendshipUseCase) DeleteFriendship(ctx context.Context, userID, frien
 			return err
 		}
 
+		if err := tx.Counters().AddFriends(ctx, userID, friendID, -1); err != nil {
+			return err
+		}
+
 		return insertOutboxEvent(ctx, tx, userID.String(), "friendship",
 			domain.EventFriendshipDeleted,
 			domain.FriendshipDeletedPayload{
@@ -247,6 +337,8 @@ func (uc *FriendshipUseCase) DeleteFriendship(ctx context.Context, userID, frien
 		return err
 	}
 
+	uc.invalidateFriendGraph(ctx, userID, friendID)
+
 	ctxlog.From(ctx).With(
 		slog.String("op", "FriendshipUseCase.DeleteFriendship"),
 		slog.String("user_id", userID.String()),
@@ -261,6 +353,26 @@ func (uc *FriendshipUseCase) GetFriendIDs(ctx context.Context, userID uuid.UUID)
 
 }
 
+// ListFriends — постраничный список друзей для больших списков; второе значение сообщает,
+// есть ли следующая страница. limit вне (0, max] заменяется на значение по умолчанию.
+func (uc *FriendshipUseCase) ListFriends(ctx context.Context, userID uuid.UUID, after *entity.Friend, limit int) ([]*entity.Friend, bool, error) {
+	if limit <= 0 || limit > maxFriendPageSize {
+		limit = defaultFriendPageSize
+	}
+
+	friends, err := uc.uow.Friendships().ListFriends(ctx, userID, after, limit+1)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(friends) > limit {
+		return friends[:limit], true, nil
+	}
+
+	return friends, false, nil
+}
+
 func (uc *FriendshipUseCase) AreFriends(ctx context.Context, user1, user2 uuid.UUID) (bool, error) {
 	return uc.uow.Friendships().Exists(ctx, user1, user2)
 }
@@ -278,6 +390,8 @@ func (uc *FriendshipUseCase) BlockUser(ctx context.Context, userID, targetID uui
 		return apperr.CannotBlockSelf()
 	}
 
+	var wereFriends bool
+
 	err := uc.uow.Do(ctx, func(tx domain.Tx) error {
 		block := &entity.Block{UserID: userID, TargetID: targetID}
 
@@ -295,15 +409,78 @@ func (uc *FriendshipUseCase) BlockUser(ctx context.Context, userID, targetID uui
 			if err = tx.Friendships().Delete(ctx, userID, targetID); err != nil {
 				return err
 			}
+
+			wereFriends = true
+
+			if err = tx.Counters().AddFriends(ctx, userID, targetID, -1); err != nil {
+				return err
+			}
+
+			// без события ленты и переписка в других сервисах продолжали бы считать их друзьями
+			err = insertOutboxEvent(ctx, tx, userID.String(), "friendship",
+				domain.EventFriendshipDeleted,
+				domain.FriendshipDeletedPayload{
+					UserID:   userID.String(),
+					FriendID: targetID.String(),
+				},
+			)
+
+			if err != nil {
+				return err
+			}
 		}
 
-		return nil
+		pending, err := tx.Requests().FindPendingBetween(ctx, userID, targetID)
+
+		if err != nil {
+			return err
+		}
+
+		if pending != nil {
+			err = tx.Requests().UpdateStatus(ctx, pending.SenderID, pending.ReceiverID, entity.ReqStatusCancelled)
+
+			if err != nil {
+				return err
+			}
+
+			if err = tx.Counters().AddRequests(ctx, pending.SenderID, pending.ReceiverID, -1); err != nil {
+				return err
+			}
+
+			err = insertOutboxEvent(ctx, tx, pending.SenderID.String(), "friendship_request",
+				domain.EventFriendRequestCancelled,
+				domain.FriendRequestCancelledPayload{
+					SenderID:   pending.SenderID.String(),
+					ReceiverID: pending.ReceiverID.String(),
+				},
+			)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		// подписки тоже не переживают блокировку, иначе посты продолжали бы попадать в ленту
+		if err = removeFollowsBetween(ctx, tx, userID, targetID); err != nil {
+			return err
+		}
+
+		return insertOutboxEvent(ctx, tx, userID.String(), "block",
+			domain.EventBlockCreated,
+			domain.BlockPayload{UserID: userID.String(), TargetID: targetID.String()},
+		)
 	})
 
 	if err != nil {
 		return err
 	}
 
+	if wereFriends {
+		uc.invalidateFriendGraph(ctx, userID, targetID)
+	} else {
+		uc.invalidateSuggestions(ctx, userID, targetID)
+	}
+
 	ctxlog.From(ctx).With(
 		slog.String("op", "FriendshipUseCase.BlockUser"),
 		slog.String("user_id", userID.String()),
@@ -314,12 +491,23 @@ func (uc *FriendshipUseCase) BlockUser(ctx context.Context, userID, targetID uui
 }
 
 func (uc *FriendshipUseCase) UnblockUser(ctx context.Context, userID, targetID uuid.UUID) error {
-	err := uc.uow.Blocks().Delete(ctx, userID, targetID)
+	err := uc.uow.Do(ctx, func(tx domain.Tx) error {
+		if err := tx.Blocks().Delete(ctx, userID, targetID); err != nil {
+			return err
+		}
+
+		return insertOutboxEvent(ctx, tx, userID.String(), "block",
+			domain.EventBlockRemoved,
+			domain.BlockPayload{UserID: userID.String(), TargetID: targetID.String()},
+		)
+	})
 
 	if err != nil {
 		return err
 	}
 
+	uc.invalidateSuggestions(ctx, userID, targetID)
+
 	ctxlog.From(ctx).With(
 		slog.String("op", "FriendshipUseCase.UnblockUser"),
 		slog.String("user_id", userID.String()),
@@ -333,10 +521,153 @@ func (uc *FriendshipUseCase) AreBlocked(ctx context.Context, userID, targetID uu
 	return uc.uow.Blocks().Exists(ctx, userID, targetID)
 }
 
+func (uc *FriendshipUseCase) IsBlockedBetween(ctx context.Context, user1, user2 uuid.UUID) (bool, error) {
+	return uc.uow.Blocks().ExistsBetween(ctx, user1, user2)
+}
+
 func (uc *FriendshipUseCase) GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
 	return uc.uow.Blocks().GetBlockedUserIDs(ctx, userID)
 }
 
+func (uc *FriendshipUseCase) GetBlockedByUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return uc.uow.Blocks().GetBlockedByUserIDs(ctx, userID)
+}
+
+// GetMutualFriends — страница общих друзей viewer и target и их общее число.
+func (uc *FriendshipUseCase) GetMutualFriends(
+	ctx context.Context,
+	viewerID, targetID uuid.UUID,
+	limit, offset int,
+) ([]uuid.UUID, int, error) {
+	if limit <= 0 || limit > maxMutualLimit {
+		limit = defaultMutualLimit
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	total, err := uc.uow.Friendships().CountMutualFriends(ctx, viewerID, targetID)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if total == 0 || offset >= total {
+		return nil, total, nil
+	}
+
+	ids, err := uc.uow.Friendships().GetMutualFriendIDs(ctx, viewerID, targetID, limit, offset)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ids, total, nil
+}
+
+// GetRelationshipSummary — счётчики target глазами viewer. Доступ такой же, как к спискам подписок;
+// счётчики заявок видны только самому пользователю.
+func (uc *FriendshipUseCase) GetRelationshipSummary(ctx context.Context, viewerID, targetID uuid.UUID) (*entity.RelationshipSummary, error) {
+	if err := checkRelationsAccess(ctx, uc.uow, uc.privacy, viewerID, targetID); err != nil {
+		return nil, err
+	}
+
+	counters, err := uc.uow.Counters().Get(ctx, targetID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &entity.RelationshipSummary{FriendshipCounters: *counters}
+
+	if viewerID == targetID {
+		return summary, nil
+	}
+
+	summary.IncomingRequests, summary.OutgoingRequests = 0, 0
+
+	if summary.MutualFriends, err = uc.uow.Friendships().CountMutualFriends(ctx, viewerID, targetID); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// GetSuggestions — страница рекомендаций из кэша; при промахе список рассчитывается заново.
+func (uc *FriendshipUseCase) GetSuggestions(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Suggestion, error) {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "FriendshipUseCase.GetSuggestions"),
+		slog.String("user_id", userID.String()),
+	)
+
+	if limit <= 0 || limit > maxSuggestionLimit {
+		limit = defaultSuggestionLimit
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	suggestions, ok, err := uc.suggestions.Get(ctx, userID)
+
+	if err != nil {
+		log.Warn("failed to read cached suggestions", slog.Any("error", err))
+	}
+
+	if !ok {
+		suggestions, err = uc.uow.Friendships().SuggestFriends(ctx, userID, time.Now().Add(-cooldownDuration), maxSuggestions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = uc.suggestions.Set(ctx, userID, suggestions); err != nil {
+			log.Warn("failed to cache suggestions", slog.Any("error", err))
+		}
+	}
+
+	if offset >= len(suggestions) {
+		return nil, nil
+	}
+
+	return suggestions[offset:min(offset+limit, len(suggestions))], nil
+}
+
+// invalidateFriendGraph — после появления или разрыва дружбы меняются друзья друзей не только
+// у самой пары, но и у всех их друзей, поэтому сбрасывается и их кэш рекомендаций.
+func (uc *FriendshipUseCase) invalidateFriendGraph(ctx context.Context, user1, user2 uuid.UUID) {
+	affected := []uuid.UUID{user1, user2}
+
+	for _, id := range []uuid.UUID{user1, user2} {
+		friendIDs, err := uc.uow.Friendships().GetFriendIDs(ctx, id)
+
+		if err != nil {
+			ctxlog.From(ctx).Warn("failed to load friends for suggestion invalidation",
+				slog.String("user_id", id.String()),
+				slog.Any("error", err),
+			)
+
+			continue
+		}
+
+		affected = append(affected, friendIDs...)
+	}
+
+	uc.invalidateSuggestions(ctx, affected...)
+}
+
+// invalidateSuggestions сбрасывает кэш рекомендаций после изменения отношений.
+// Ошибка не прерывает операцию: устаревший кэш доживёт только до TTL.
+func (uc *FriendshipUseCase) invalidateSuggestions(ctx context.Context, userIDs ...uuid.UUID) {
+	if err := uc.suggestions.Invalidate(ctx, userIDs...); err != nil {
+		ctxlog.From(ctx).Warn("failed to invalidate friend suggestions",
+			slog.Any("user_ids", userIDs),
+			slog.Any("error", err),
+		)
+	}
+}
+
 func marshalEnvelope(eventType string, payload any) ([]byte, error) {
 	inner, err := json.Marshal(payload)
 
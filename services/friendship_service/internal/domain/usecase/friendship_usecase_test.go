@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/apperror"
+	apperr "github.com/rockkley/pushpost/services/friendship_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFriendshipUseCase() (*FriendshipUseCase, *memStore, *memSuggestionCache) {
+	store := newMemStore()
+	cache := newMemSuggestionCache()
+
+	return NewFriendshipUseCase(memUoW{store}, cache, memPrivacy{}), store, cache
+}
+
+// ── Suggestions ───────────────────────────────────────────────────────────────
+
+func TestFriendshipUseCase_GetSuggestions_CachesAndPages(t *testing.T) {
+	uc, store, cache := newTestFriendshipUseCase()
+	userID := uuid.New()
+	ranked := []*entity.Suggestion{
+		{UserID: uuid.New(), MutualFriends: 3},
+		{UserID: uuid.New(), MutualFriends: 2},
+		{UserID: uuid.New(), MutualFriends: 1},
+	}
+	store.suggest = func(uuid.UUID) []*entity.Suggestion { return ranked }
+	ctx := context.Background()
+
+	page, err := uc.GetSuggestions(ctx, userID, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, ranked[:2], page)
+	require.Equal(t, ranked, cache.cached[userID], "the whole ranking must be cached, not the page")
+	require.WithinDuration(t, time.Now().Add(-cooldownDuration), store.rejectedSince, time.Minute,
+		"recently rejected candidates are excluded for the same window as the request cooldown")
+
+	page, err = uc.GetSuggestions(ctx, userID, 2, 2)
+	require.NoError(t, err)
+	require.Equal(t, ranked[2:], page)
+	require.Equal(t, 1, store.suggestCalls, "second page must come from the cache")
+
+	page, err = uc.GetSuggestions(ctx, userID, 2, 10)
+	require.NoError(t, err)
+	require.Empty(t, page)
+}
+
+func TestFriendshipUseCase_AcceptRequest_InvalidatesFriendsOfPair(t *testing.T) {
+	uc, store, cache := newTestFriendshipUseCase()
+	sender, receiver := uuid.New(), uuid.New()
+	senderFriend, receiverFriend, stranger := uuid.New(), uuid.New(), uuid.New()
+	store.befriend(sender, senderFriend)
+	store.befriend(receiver, receiverFriend)
+	ctx := context.Background()
+
+	require.NoError(t, uc.SendRequest(ctx, sender, receiver))
+	cache.invalidated = map[uuid.UUID]bool{}
+
+	require.NoError(t, uc.AcceptRequest(ctx, receiver, sender))
+
+	for _, id := range []uuid.UUID{sender, receiver, senderFriend, receiverFriend} {
+		require.True(t, cache.invalidated[id], "friends of friends changed for %s", id)
+	}
+	require.False(t, cache.invalidated[stranger])
+}
+
+func TestFriendshipUseCase_DeleteFriendship_InvalidatesFriendsOfPair(t *testing.T) {
+	uc, store, cache := newTestFriendshipUseCase()
+	user, friend, mutual := uuid.New(), uuid.New(), uuid.New()
+	store.befriend(user, friend)
+	store.befriend(user, mutual)
+	store.befriend(friend, mutual)
+
+	require.NoError(t, uc.DeleteFriendship(context.Background(), user, friend))
+
+	require.True(t, cache.invalidated[user])
+	require.True(t, cache.invalidated[friend])
+	require.True(t, cache.invalidated[mutual])
+}
+
+func TestFriendshipUseCase_BlockFriend_InvalidatesFriendsOfPair(t *testing.T) {
+	uc, store, cache := newTestFriendshipUseCase()
+	user, target, targetFriend := uuid.New(), uuid.New(), uuid.New()
+	store.befriend(user, target)
+	store.befriend(target, targetFriend)
+
+	require.NoError(t, uc.BlockUser(context.Background(), user, target))
+
+	require.True(t, cache.invalidated[user])
+	require.True(t, cache.invalidated[target])
+	require.True(t, cache.invalidated[targetFriend])
+}
+
+func TestFriendshipUseCase_RequestLifecycle_InvalidatesPair(t *testing.T) {
+	ctx := context.Background()
+
+	cases := map[string]func(uc *FriendshipUseCase, sender, receiver uuid.UUID) error{
+		"reject": func(uc *FriendshipUseCase, sender, receiver uuid.UUID) error {
+			return uc.RejectRequest(ctx, receiver, sender)
+		},
+		"cancel": func(uc *FriendshipUseCase, sender, receiver uuid.UUID) error {
+			return uc.CancelRequest(ctx, sender, receiver)
+		},
+	}
+
+	for name, act := range cases {
+		t.Run(name, func(t *testing.T) {
+			uc, _, cache := newTestFriendshipUseCase()
+			sender, receiver := uuid.New(), uuid.New()
+			require.NoError(t, uc.SendRequest(ctx, sender, receiver))
+			cache.invalidated = map[uuid.UUID]bool{}
+
+			require.NoError(t, act(uc, sender, receiver))
+			require.True(t, cache.invalidated[sender])
+			require.True(t, cache.invalidated[receiver])
+		})
+	}
+}
+
+func TestFriendshipUseCase_UnblockUser_InvalidatesPair(t *testing.T) {
+	uc, store, cache := newTestFriendshipUseCase()
+	user, target := uuid.New(), uuid.New()
+	store.block(user, target)
+
+	require.NoError(t, uc.UnblockUser(context.Background(), user, target))
+	require.True(t, cache.invalidated[user])
+	require.True(t, cache.invalidated[target])
+}
+
+// ── Counters ──────────────────────────────────────────────────────────────────
+
+func requireCounters(t *testing.T, store *memStore, userID uuid.UUID, want entity.FriendshipCounters) {
+	t.Helper()
+	want.UserID = userID
+	require.Equal(t, want, *store.counter(userID))
+}
+
+func TestFriendshipUseCase_Counters_SendAndAccept(t *testing.T) {
+	uc, store, _ := newTestFriendshipUseCase()
+	sender, receiver := uuid.New(), uuid.New()
+	ctx := context.Background()
+
+	require.NoError(t, uc.SendRequest(ctx, sender, receiver))
+	requireCounters(t, store, sender, entity.FriendshipCounters{OutgoingRequests: 1})
+	requireCounters(t, store, receiver, entity.FriendshipCounters{IncomingRequests: 1})
+
+	require.NoError(t, uc.AcceptRequest(ctx, receiver, sender))
+	requireCounters(t, store, sender, entity.FriendshipCounters{FriendsCount: 1})
+	requireCounters(t, store, receiver, entity.FriendshipCounters{FriendsCount: 1})
+
+	require.NoError(t, uc.DeleteFriendship(ctx, receiver, sender))
+	requireCounters(t, store, sender, entity.FriendshipCounters{})
+	requireCounters(t, store, receiver, entity.FriendshipCounters{})
+}
+
+func TestFriendshipUseCase_Counters_RejectAndCancel(t *testing.T) {
+	uc, store, _ := newTestFriendshipUseCase()
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	ctx := context.Background()
+
+	require.NoError(t, uc.SendRequest(ctx, a, b))
+	require.NoError(t, uc.SendRequest(ctx, a, c))
+	requireCounters(t, store, a, entity.FriendshipCounters{OutgoingRequests: 2})
+
+	require.NoError(t, uc.RejectRequest(ctx, b, a))
+	require.NoError(t, uc.CancelRequest(ctx, a, c))
+
+	for _, id := range []uuid.UUID{a, b, c} {
+		requireCounters(t, store, id, entity.FriendshipCounters{})
+	}
+}
+
+func TestFriendshipUseCase_Counters_CrossingRequests(t *testing.T) {
+	uc, store, _ := newTestFriendshipUseCase()
+	a, b := uuid.New(), uuid.New()
+	ctx := context.Background()
+
+	require.NoError(t, uc.SendRequest(ctx, a, b))
+	require.Error(t, uc.SendRequest(ctx, b, a), "a pending request in either direction blocks a second one")
+
+	requireCounters(t, store, a, entity.FriendshipCounters{OutgoingRequests: 1})
+	requireCounters(t, store, b, entity.FriendshipCounters{IncomingRequests: 1})
+}
+
+func TestFriendshipUseCase_Counters_BlockClearsRelationship(t *testing.T) {
+	uc, store, _ := newTestFriendshipUseCase()
+	user, friend, requester := uuid.New(), uuid.New(), uuid.New()
+	ctx := context.Background()
+
+	require.NoError(t, uc.SendRequest(ctx, user, friend))
+	require.NoError(t, uc.AcceptRequest(ctx, friend, user))
+	require.NoError(t, uc.SendRequest(ctx, requester, user))
+	require.NoError(t, createFollow(ctx, memUoW{store}, friend, user))
+	require.NoError(t, createFollow(ctx, memUoW{store}, user, friend))
+
+	require.NoError(t, uc.BlockUser(ctx, user, friend))
+	requireCounters(t, store, user, entity.FriendshipCounters{IncomingRequests: 1})
+	requireCounters(t, store, friend, entity.FriendshipCounters{})
+
+	require.NoError(t, uc.BlockUser(ctx, user, requester))
+	requireCounters(t, store, user, entity.FriendshipCounters{})
+	requireCounters(t, store, requester, entity.FriendshipCounters{})
+}
+
+// ── Relationship summary ──────────────────────────────────────────────────────
+
+func requireAppErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, code, appErr.Code())
+}
+
+func TestFriendshipUseCase_GetRelationshipSummary_RequestCountsOnlyForOwner(t *testing.T) {
+	uc, store, _ := newTestFriendshipUseCase()
+	owner, viewer, mutual, requester := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	store.befriend(owner, mutual)
+	store.befriend(viewer, mutual)
+	ctx := context.Background()
+	require.NoError(t, uc.SendRequest(ctx, requester, owner))
+
+	own, err := uc.GetRelationshipSummary(ctx, owner, owner)
+	require.NoError(t, err)
+	require.Equal(t, 1, own.FriendsCount)
+	require.Equal(t, 1, own.IncomingRequests)
+
+	seen, err := uc.GetRelationshipSummary(ctx, viewer, owner)
+	require.NoError(t, err)
+	require.Equal(t, 1, seen.FriendsCount)
+	require.Equal(t, 1, seen.MutualFriends)
+	require.Zero(t, seen.IncomingRequests)
+	require.Zero(t, seen.OutgoingRequests)
+}
+
+func TestFriendshipUseCase_GetRelationshipSummary_BlockedEitherWay(t *testing.T) {
+	uc, store, _ := newTestFriendshipUseCase()
+	owner, blocked, blocker := uuid.New(), uuid.New(), uuid.New()
+	store.block(owner, blocked)
+	store.block(blocker, owner)
+	ctx := context.Background()
+
+	_, err := uc.GetRelationshipSummary(ctx, blocked, owner)
+	requireAppErrorCode(t, err, apperr.CodeUserBlocked)
+
+	_, err = uc.GetRelationshipSummary(ctx, blocker, owner)
+	requireAppErrorCode(t, err, apperr.CodeUserBlocked)
+}
+
+func TestFriendshipUseCase_GetRelationshipSummary_PrivateProfile(t *testing.T) {
+	store := newMemStore()
+	owner, follower, stranger := uuid.New(), uuid.New(), uuid.New()
+	uc := NewFriendshipUseCase(memUoW{store}, newMemSuggestionCache(), memPrivacy{private: map[uuid.UUID]bool{owner: true}})
+	require.NoError(t, createFollow(context.Background(), memUoW{store}, follower, owner))
+	ctx := context.Background()
+
+	_, err := uc.GetRelationshipSummary(ctx, stranger, owner)
+	requireAppErrorCode(t, err, apperr.CodeFollowListPrivate)
+
+	summary, err := uc.GetRelationshipSummary(ctx, follower, owner)
+	require.NoError(t, err)
+	require.Equal(t, 1, summary.FollowersCount)
+
+	_, err = uc.GetRelationshipSummary(ctx, owner, owner)
+	require.NoError(t, err)
+}
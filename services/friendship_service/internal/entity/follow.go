@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Follow — FollowerID подписан на FolloweeID; подписка односторонняя и не требует дружбы.
+type Follow struct {
+	FollowerID uuid.UUID
+	FolloweeID uuid.UUID
+	CreatedAt  time.Time
+}
+
+// FollowRequest — заявка на подписку к закрытому профилю, ждёт решения FolloweeID.
+type FollowRequest struct {
+	FollowerID uuid.UUID
+	FolloweeID uuid.UUID
+	CreatedAt  time.Time
+}
+
+// FollowState — результат попытки подписаться.
+type FollowState string
+
+const (
+	FollowStateFollowing FollowState = "following"
+	FollowStateRequested FollowState = "requested"
+)
@@ -0,0 +1,12 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Friend — друг пользователя и момент, с которого они дружат.
+type Friend struct {
+	ID    uuid.UUID
+	Since time.Time
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "github.com/google/uuid"
+
+// FriendshipCounters — счётчики пользователя; у пользователя без строки в таблице все нули.
+type FriendshipCounters struct {
+	UserID           uuid.UUID
+	FriendsCount     int
+	IncomingRequests int
+	OutgoingRequests int
+	FollowersCount   int
+	FollowingCount   int
+}
+
+// RelationshipSummary — счётчики пользователя глазами viewer; для самого себя MutualFriends равен нулю.
+type RelationshipSummary struct {
+	FriendshipCounters
+	MutualFriends int
+}
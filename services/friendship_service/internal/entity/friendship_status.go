@@ -1,7 +1,11 @@
 package entity
 
+// FriendshipStatus — отношение viewer к target.
+// Blocked: viewer заблокировал target; BlockedBy: target заблокировал viewer.
 type FriendshipStatus struct {
 	AreFriends             bool
 	PendingRequestSent     bool
 	PendingRequestReceived bool
+	Blocked                bool
+	BlockedBy              bool
 }
@@ -0,0 +1,9 @@
+package entity
+
+import "github.com/google/uuid"
+
+// Suggestion — кандидат в друзья и число общих друзей с пользователем.
+type Suggestion struct {
+	UserID        uuid.UUID
+	MutualFriends int
+}
@@ -15,6 +15,7 @@ type FriendshipRequestRepository interface {
 	HasRecentRejected(ctx context.Context, senderID, receiverID uuid.UUID, since time.Time) (bool, error)
 	GetIncoming(ctx context.Context, receiverID uuid.UUID) ([]*entity.FriendshipRequest, error)
 	GetOutgoing(ctx context.Context, senderID uuid.UUID) ([]*entity.FriendshipRequest, error)
+	FindPendingWith(ctx context.Context, userID uuid.UUID, otherIDs []uuid.UUID) ([]*entity.FriendshipRequest, error)
 }
 
 type FriendshipRepository interface {
@@ -22,6 +23,20 @@ type FriendshipRepository interface {
 	Exists(ctx context.Context, user1, user2 uuid.UUID) (bool, error)
 	Delete(ctx context.Context, userID, friendID uuid.UUID) error
 	GetFriendIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	ListFriends(ctx context.Context, userID uuid.UUID, after *entity.Friend, limit int) ([]*entity.Friend, error)
+	FilterFriends(ctx context.Context, userID uuid.UUID, otherIDs []uuid.UUID) ([]uuid.UUID, error)
+	SuggestFriends(ctx context.Context, userID uuid.UUID, rejectedSince time.Time, limit int) ([]*entity.Suggestion, error)
+	GetMutualFriendIDs(ctx context.Context, user1, user2 uuid.UUID, limit, offset int) ([]uuid.UUID, error)
+	CountMutualFriends(ctx context.Context, user1, user2 uuid.UUID) (int, error)
+}
+
+// CounterRepository — денормализованные счётчики друзей, заявок и подписок; меняется в тех же транзакциях,
+// что и сами дружбы, заявки и подписки.
+type CounterRepository interface {
+	AddFriends(ctx context.Context, user1, user2 uuid.UUID, delta int) error
+	AddRequests(ctx context.Context, senderID, receiverID uuid.UUID, delta int) error
+	AddFollows(ctx context.Context, followerID, followeeID uuid.UUID, delta int) error
+	Get(ctx context.Context, userID uuid.UUID) (*entity.FriendshipCounters, error)
 }
 
 type BlockRepository interface {
@@ -29,4 +44,36 @@ type BlockRepository interface {
 	Delete(ctx context.Context, userID, targetID uuid.UUID) error
 	Exists(ctx context.Context, userID, targetID uuid.UUID) (bool, error)
 	GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	GetBlockedByUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	ExistsBetween(ctx context.Context, user1, user2 uuid.UUID) (bool, error)
+	FindBetween(ctx context.Context, userID uuid.UUID, otherIDs []uuid.UUID) ([]*entity.Block, error)
+}
+
+// FollowRepository — подписки и заявки на подписку к закрытым профилям.
+type FollowRepository interface {
+	Create(ctx context.Context, follow *entity.Follow) error
+	Delete(ctx context.Context, followerID, followeeID uuid.UUID) error
+	Exists(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error)
+	GetFollowers(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Follow, error)
+	GetFollowing(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Follow, error)
+	ListFollowerIDs(ctx context.Context, userID, afterID uuid.UUID, limit int) ([]uuid.UUID, error)
+	CreateRequest(ctx context.Context, req *entity.FollowRequest) error
+	DeleteRequest(ctx context.Context, followerID, followeeID uuid.UUID) error
+	DeleteRequestsBetween(ctx context.Context, user1, user2 uuid.UUID) error
+	RequestExists(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error)
+	GetIncomingRequests(ctx context.Context, followeeID uuid.UUID) ([]*entity.FollowRequest, error)
+}
+
+type ExportRepository interface {
+	Friendships(ctx context.Context, userID uuid.UUID) ([]*entity.Friendship, error)
+	Requests(ctx context.Context, userID uuid.UUID) ([]*entity.FriendshipRequest, error)
+	Blocks(ctx context.Context, userID uuid.UUID) ([]*entity.Block, error)
+	Follows(ctx context.Context, userID uuid.UUID) ([]*entity.Follow, error)
+}
+
+// SuggestionCache — кэш рекомендаций друзей по пользователю.
+type SuggestionCache interface {
+	Get(ctx context.Context, userID uuid.UUID) ([]*entity.Suggestion, bool, error)
+	Set(ctx context.Context, userID uuid.UUID, suggestions []*entity.Suggestion) error
+	Invalidate(ctx context.Context, userIDs ...uuid.UUID) error
 }
@@ -90,3 +90,85 @@ func (r *blockRepo) GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]
 
 	return blockedIDs, nil
 }
+
+func (r *blockRepo) GetBlockedByUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	query := `SELECT user_id FROM blocks WHERE target_id = $1`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "get blocked by user ids")
+	}
+
+	defer rows.Close()
+
+	var blockerIDs []uuid.UUID
+
+	for rows.Next() {
+		var blockerID uuid.UUID
+		if err = rows.Scan(&blockerID); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan blocker id")
+		}
+		blockerIDs = append(blockerIDs, blockerID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "iterate blocker ids")
+	}
+
+	return blockerIDs, nil
+}
+
+// ExistsBetween — есть ли блокировка в любую сторону.
+func (r *blockRepo) ExistsBetween(ctx context.Context, user1, user2 uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM blocks
+			WHERE (user_id = $1 AND target_id = $2) OR (user_id = $2 AND target_id = $1)
+		)`
+
+	var exists bool
+
+	if err := r.exec.QueryRowContext(ctx, query, user1, user2).Scan(&exists); err != nil {
+		return false, commonapperr.MapPostgresError(err, "check block between users")
+	}
+
+	return exists, nil
+}
+
+// FindBetween возвращает блокировки между userID и любым из otherIDs в обе стороны.
+func (r *blockRepo) FindBetween(ctx context.Context, userID uuid.UUID, otherIDs []uuid.UUID) ([]*entity.Block, error) {
+	if len(otherIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT user_id, target_id, created_at
+		FROM   blocks
+		WHERE  (user_id = $1 AND target_id = ANY($2::uuid[]))
+		   OR  (target_id = $1 AND user_id = ANY($2::uuid[]))`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID, otherIDs)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "find blocks between users")
+	}
+
+	defer rows.Close()
+
+	var blocks []*entity.Block
+
+	for rows.Next() {
+		var b entity.Block
+		if err = rows.Scan(&b.UserID, &b.TargetID, &b.CreatedAt); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan block")
+		}
+		blocks = append(blocks, &b)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "iterate blocks")
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/database"
+	apperr "github.com/rockkley/pushpost/services/friendship_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/entity"
+)
+
+type counterRepo struct {
+	exec database.Executor
+}
+
+func NewCounterRepository(exec database.Executor) *counterRepo {
+	return &counterRepo{exec: exec}
+}
+
+// AddFriends меняет friends_count обоим пользователям на delta.
+func (r *counterRepo) AddFriends(ctx context.Context, user1, user2 uuid.UUID, delta int) error {
+	first, second := orderUUIDs(user1, user2)
+
+	query := `
+		INSERT INTO friendship_counters (user_id, friends_count)
+		VALUES ($1, $3), ($2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET friends_count = friendship_counters.friends_count + EXCLUDED.friends_count,
+		    updated_at    = NOW()`
+
+	if _, err := r.exec.ExecContext(ctx, query, first, second, delta); err != nil {
+		return commonapperr.MapPostgresError(err, "update friends counters", apperr.MapConstraint)
+	}
+
+	return nil
+}
+
+// AddRequests меняет счётчики заявки: исходящие у отправителя и входящие у получателя.
+func (r *counterRepo) AddRequests(ctx context.Context, senderID, receiverID uuid.UUID, delta int) error {
+	query := `
+		INSERT INTO friendship_counters (user_id, outgoing_requests_count, incoming_requests_count)
+		VALUES ($1, $2, $3), ($4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE
+		SET outgoing_requests_count = friendship_counters.outgoing_requests_count + EXCLUDED.outgoing_requests_count,
+		    incoming_requests_count = friendship_counters.incoming_requests_count + EXCLUDED.incoming_requests_count,
+		    updated_at              = NOW()`
+
+	args := pairDeltas(senderID, receiverID, delta)
+
+	if _, err := r.exec.ExecContext(ctx, query, args...); err != nil {
+		return commonapperr.MapPostgresError(err, "update request counters", apperr.MapConstraint)
+	}
+
+	return nil
+}
+
+// AddFollows меняет счётчики подписки: following у подписчика и followers у того, на кого подписались.
+func (r *counterRepo) AddFollows(ctx context.Context, followerID, followeeID uuid.UUID, delta int) error {
+	query := `
+		INSERT INTO friendship_counters (user_id, following_count, followers_count)
+		VALUES ($1, $2, $3), ($4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE
+		SET following_count = friendship_counters.following_count + EXCLUDED.following_count,
+		    followers_count = friendship_counters.followers_count + EXCLUDED.followers_count,
+		    updated_at      = NOW()`
+
+	args := pairDeltas(followerID, followeeID, delta)
+
+	if _, err := r.exec.ExecContext(ctx, query, args...); err != nil {
+		return commonapperr.MapPostgresError(err, "update follow counters", apperr.MapConstraint)
+	}
+
+	return nil
+}
+
+// pairDeltas раскладывает направленное изменение на две строки (id, исходящий, входящий),
+// упорядоченные по id: встречные операции A→B и B→A блокируют строки в одном порядке
+// и не ловят deadlock.
+func pairDeltas(fromID, toID uuid.UUID, delta int) []any {
+	from := []any{fromID, delta, 0}
+	to := []any{toID, 0, delta}
+
+	if first, _ := orderUUIDs(fromID, toID); first != fromID {
+		from, to = to, from
+	}
+
+	return append(from, to...)
+}
+
+func (r *counterRepo) Get(ctx context.Context, userID uuid.UUID) (*entity.FriendshipCounters, error) {
+	query := `
+		SELECT friends_count, incoming_requests_count, outgoing_requests_count,
+		       followers_count, following_count
+		FROM   friendship_counters
+		WHERE  user_id = $1`
+
+	c := &entity.FriendshipCounters{UserID: userID}
+
+	err := r.exec.QueryRowContext(ctx, query, userID).Scan(
+		&c.FriendsCount, &c.IncomingRequests, &c.OutgoingRequests,
+		&c.FollowersCount, &c.FollowingCount,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c, nil
+		}
+
+		return nil, commonapperr.MapPostgresError(err, "get friendship counters")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCounterRepo_CrossingUpdatesDoNotDeadlock — встречные подписки A→B и B→A в параллельных
+// транзакциях: без упорядочивания строк одна из них падала бы с deadlock detected.
+func TestCounterRepo_CrossingUpdatesDoNotDeadlock(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	a, b := uuid.New(), uuid.New()
+	require.NoError(t, NewCounterRepository(db).AddFollows(ctx, a, b, 0))
+
+	const rounds = 50
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*rounds)
+
+	for _, pair := range [][2]uuid.UUID{{a, b}, {b, a}} {
+		wg.Add(1)
+
+		go func(from, to uuid.UUID) {
+			defer wg.Done()
+
+			for range rounds {
+				errs <- inTx(ctx, db, func(tx *sql.Tx) error {
+					counters := NewCounterRepository(tx)
+
+					if err := counters.AddFollows(ctx, from, to, 1); err != nil {
+						return err
+					}
+
+					return counters.AddRequests(ctx, from, to, 1)
+				})
+			}
+		}(pair[0], pair[1])
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	got, err := NewCounterRepository(db).Get(ctx, a)
+	require.NoError(t, err)
+	require.Equal(t, rounds, got.FollowersCount)
+	require.Equal(t, rounds, got.FollowingCount)
+	require.Equal(t, rounds, got.IncomingRequests)
+	require.Equal(t, rounds, got.OutgoingRequests)
+}
+
+func TestCounterRepo_NegativeCounterFails(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	a, b := uuid.New(), uuid.New()
+	counters := NewCounterRepository(db)
+
+	require.NoError(t, counters.AddFriends(ctx, a, b, 1))
+	require.NoError(t, counters.AddFriends(ctx, a, b, -1))
+
+	err := counters.AddFriends(ctx, a, b, -1)
+
+	var appErr apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, 500, appErr.HTTPStatus())
+
+	got, err := counters.Get(ctx, a)
+	require.NoError(t, err)
+	require.Zero(t, got.FriendsCount, "a failed update must not clamp or change the counter")
+}
+
+func inTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
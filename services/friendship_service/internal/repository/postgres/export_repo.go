@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/database"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/entity"
+)
+
+// ExportRepository читает связи пользователя для выгрузки данных.
+// Блокировки отдаются только исходящие: кто заблокировал пользователя — чужие данные.
+type ExportRepository struct{ exec database.Executor }
+
+func NewExportRepository(exec database.Executor) *ExportRepository {
+	return &ExportRepository{exec: exec}
+}
+
+func (r *ExportRepository) Friendships(ctx context.Context, userID uuid.UUID) ([]*entity.Friendship, error) {
+	const query = `
+		SELECT id, user1_id, user2_id, created_at
+		FROM   friendships
+		WHERE  user1_id = $1 OR user2_id = $1
+		ORDER  BY created_at`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "export friendships")
+	}
+
+	defer rows.Close()
+
+	var friendships []*entity.Friendship
+
+	for rows.Next() {
+		var f entity.Friendship
+
+		if err = rows.Scan(&f.ID, &f.User1ID, &f.User2ID, &f.CreatedAt); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan exported friendship")
+		}
+
+		friendships = append(friendships, &f)
+	}
+
+	return friendships, rows.Err()
+}
+
+func (r *ExportRepository) Requests(ctx context.Context, userID uuid.UUID) ([]*entity.FriendshipRequest, error) {
+	const query = `
+		SELECT id, sender_id, receiver_id, status, created_at, updated_at
+		FROM   friendship_requests
+		WHERE  sender_id = $1 OR receiver_id = $1
+		ORDER  BY created_at`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "export friendship requests")
+	}
+
+	defer rows.Close()
+
+	var requests []*entity.FriendshipRequest
+
+	for rows.Next() {
+		var req entity.FriendshipRequest
+
+		if err = rows.Scan(
+			&req.ID, &req.SenderID, &req.ReceiverID, &req.Status, &req.CreatedAt, &req.UpdatedAt,
+		); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan exported friendship request")
+		}
+
+		requests = append(requests, &req)
+	}
+
+	return requests, rows.Err()
+}
+
+func (r *ExportRepository) Blocks(ctx context.Context, userID uuid.UUID) ([]*entity.Block, error) {
+	const query = `
+		SELECT user_id, target_id, created_at
+		FROM   blocks
+		WHERE  user_id = $1
+		ORDER  BY created_at`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "export blocks")
+	}
+
+	defer rows.Close()
+
+	var blocks []*entity.Block
+
+	for rows.Next() {
+		var b entity.Block
+
+		if err = rows.Scan(&b.UserID, &b.TargetID, &b.CreatedAt); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan exported block")
+		}
+
+		blocks = append(blocks, &b)
+	}
+
+	return blocks, rows.Err()
+}
+
+// Follows — подписки пользователя в обе стороны: и его собственные, и на него.
+func (r *ExportRepository) Follows(ctx context.Context, userID uuid.UUID) ([]*entity.Follow, error) {
+	const query = `
+		SELECT follower_id, followee_id, created_at
+		FROM   follows
+		WHERE  follower_id = $1 OR followee_id = $1
+		ORDER  BY created_at`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "export follows")
+	}
+
+	defer rows.Close()
+
+	var follows []*entity.Follow
+
+	for rows.Next() {
+		var f entity.Follow
+
+		if err = rows.Scan(&f.FollowerID, &f.FolloweeID, &f.CreatedAt); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan exported follow")
+		}
+
+		follows = append(follows, &f)
+	}
+
+	return follows, rows.Err()
+}
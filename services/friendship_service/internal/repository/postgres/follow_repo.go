@@ -0,0 +1,245 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/database"
+	apperr "github.com/rockkley/pushpost/services/friendship_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/entity"
+)
+
+type followRepo struct {
+	exec database.Executor
+}
+
+func NewFollowRepository(exec database.Executor) *followRepo {
+	return &followRepo{exec: exec}
+}
+
+func (r *followRepo) Create(ctx context.Context, follow *entity.Follow) error {
+	query := `
+		INSERT INTO follows (follower_id, followee_id)
+		VALUES ($1, $2) RETURNING created_at`
+
+	err := r.exec.QueryRowContext(ctx, query, follow.FollowerID, follow.FolloweeID).Scan(&follow.CreatedAt)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "create follow", apperr.MapConstraint)
+	}
+
+	return nil
+}
+
+func (r *followRepo) Delete(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	query := `DELETE FROM follows WHERE follower_id = $1 AND followee_id = $2`
+
+	result, err := r.exec.ExecContext(ctx, query, followerID, followeeID)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "delete follow")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "delete follow rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFollowing()
+	}
+
+	return nil
+}
+
+func (r *followRepo) Exists(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM follows WHERE follower_id = $1 AND followee_id = $2)`
+
+	var exists bool
+
+	if err := r.exec.QueryRowContext(ctx, query, followerID, followeeID).Scan(&exists); err != nil {
+		return false, commonapperr.MapPostgresError(err, "check follow existence")
+	}
+
+	return exists, nil
+}
+
+// GetFollowers — подписчики userID, новые первыми.
+func (r *followRepo) GetFollowers(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Follow, error) {
+	query := `
+		SELECT follower_id, followee_id, created_at
+		FROM   follows
+		WHERE  followee_id = $1
+		ORDER  BY created_at DESC, follower_id
+		LIMIT  $2 OFFSET $3`
+
+	return r.queryFollows(ctx, "get followers", query, userID, limit, offset)
+}
+
+// GetFollowing — на кого подписан userID, новые подписки первыми.
+func (r *followRepo) GetFollowing(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Follow, error) {
+	query := `
+		SELECT follower_id, followee_id, created_at
+		FROM   follows
+		WHERE  follower_id = $1
+		ORDER  BY created_at DESC, followee_id
+		LIMIT  $2 OFFSET $3`
+
+	return r.queryFollows(ctx, "get following", query, userID, limit, offset)
+}
+
+func (r *followRepo) queryFollows(ctx context.Context, op, query string, args ...any) ([]*entity.Follow, error) {
+	rows, err := r.exec.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, op)
+	}
+
+	defer rows.Close()
+
+	var follows []*entity.Follow
+
+	for rows.Next() {
+		var f entity.Follow
+		if err = rows.Scan(&f.FollowerID, &f.FolloweeID, &f.CreatedAt); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan follow")
+		}
+		follows = append(follows, &f)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "iterate follows")
+	}
+
+	return follows, nil
+}
+
+// ListFollowerIDs — страница подписчиков userID по возрастанию id, начиная после afterID;
+// uuid.Nil означает первую страницу.
+func (r *followRepo) ListFollowerIDs(ctx context.Context, userID, afterID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT follower_id
+		FROM   follows
+		WHERE  followee_id = $1 AND follower_id > $2
+		ORDER  BY follower_id
+		LIMIT  $3`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID, afterID, limit)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "list follower ids")
+	}
+
+	defer rows.Close()
+
+	var ids []uuid.UUID
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan follower id")
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "iterate follower ids")
+	}
+
+	return ids, nil
+}
+
+func (r *followRepo) CreateRequest(ctx context.Context, req *entity.FollowRequest) error {
+	query := `
+		INSERT INTO follow_requests (follower_id, followee_id)
+		VALUES ($1, $2) RETURNING created_at`
+
+	err := r.exec.QueryRowContext(ctx, query, req.FollowerID, req.FolloweeID).Scan(&req.CreatedAt)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "create follow request", apperr.MapConstraint)
+	}
+
+	return nil
+}
+
+func (r *followRepo) DeleteRequest(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	query := `DELETE FROM follow_requests WHERE follower_id = $1 AND followee_id = $2`
+
+	result, err := r.exec.ExecContext(ctx, query, followerID, followeeID)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "delete follow request")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "delete follow request rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return apperr.FollowRequestNotFound()
+	}
+
+	return nil
+}
+
+// DeleteRequestsBetween снимает заявки на подписку между пользователями в обе стороны.
+func (r *followRepo) DeleteRequestsBetween(ctx context.Context, user1, user2 uuid.UUID) error {
+	query := `
+		DELETE FROM follow_requests
+		WHERE (follower_id = $1 AND followee_id = $2) OR (follower_id = $2 AND followee_id = $1)`
+
+	if _, err := r.exec.ExecContext(ctx, query, user1, user2); err != nil {
+		return commonapperr.MapPostgresError(err, "delete follow requests between users")
+	}
+
+	return nil
+}
+
+func (r *followRepo) RequestExists(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM follow_requests WHERE follower_id = $1 AND followee_id = $2)`
+
+	var exists bool
+
+	if err := r.exec.QueryRowContext(ctx, query, followerID, followeeID).Scan(&exists); err != nil {
+		return false, commonapperr.MapPostgresError(err, "check follow request existence")
+	}
+
+	return exists, nil
+}
+
+func (r *followRepo) GetIncomingRequests(ctx context.Context, followeeID uuid.UUID) ([]*entity.FollowRequest, error) {
+	query := `
+		SELECT follower_id, followee_id, created_at
+		FROM   follow_requests
+		WHERE  followee_id = $1
+		ORDER  BY created_at DESC`
+
+	rows, err := r.exec.QueryContext(ctx, query, followeeID)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "get incoming follow requests")
+	}
+
+	defer rows.Close()
+
+	var requests []*entity.FollowRequest
+
+	for rows.Next() {
+		var req entity.FollowRequest
+		if err = rows.Scan(&req.FollowerID, &req.FolloweeID, &req.CreatedAt); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan follow request")
+		}
+		requests = append(requests, &req)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "iterate follow requests")
+	}
+
+	return requests, nil
+}
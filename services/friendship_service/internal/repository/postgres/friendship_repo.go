@@ -12,6 +12,7 @@ import (
 	"github.com/rockkley/pushpost/services/friendship_service/internal/entity"
 	"github.com/rockkley/pushpost/services/friendship_service/internal/repository"
 	"log/slog"
+	"time"
 )
 
 type friendshipRepo struct {
@@ -123,6 +124,220 @@ func (r *friendshipRepo) GetFriendIDs(ctx context.Context, userID uuid.UUID) ([]
 	return ids, nil
 }
 
+// ListFriends — страница друзей от новых к старым; after — последний элемент предыдущей страницы.
+func (r *friendshipRepo) ListFriends(
+	ctx context.Context,
+	userID uuid.UUID,
+	after *entity.Friend,
+	limit int,
+) ([]*entity.Friend, error) {
+	query := `
+		SELECT friend_id, created_at
+		FROM (
+			SELECT CASE WHEN user1_id = $1 THEN user2_id ELSE user1_id END AS friend_id, created_at
+			FROM   friendships
+			WHERE  user1_id = $1 OR user2_id = $1
+		) f
+		WHERE  $2::timestamptz IS NULL OR (created_at, friend_id) < ($2, $3)
+		ORDER BY created_at DESC, friend_id DESC
+		LIMIT  $4`
+
+	var (
+		afterSince *time.Time
+		afterID    uuid.UUID
+	)
+
+	if after != nil {
+		afterSince, afterID = &after.Since, after.ID
+	}
+
+	rows, err := r.exec.QueryContext(ctx, query, userID, afterSince, afterID, limit)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "list friends")
+	}
+
+	defer rows.Close()
+
+	friends := make([]*entity.Friend, 0, limit)
+
+	for rows.Next() {
+		var f entity.Friend
+
+		if err = rows.Scan(&f.ID, &f.Since); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan friend")
+		}
+
+		friends = append(friends, &f)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "iterate friends")
+	}
+
+	return friends, nil
+}
+
+// FilterFriends возвращает те из otherIDs, с кем userID дружит.
+func (r *friendshipRepo) FilterFriends(ctx context.Context, userID uuid.UUID, otherIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(otherIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT CASE WHEN user1_id = $1 THEN user2_id ELSE user1_id END
+		FROM   friendships
+		WHERE  (user1_id = $1 AND user2_id = ANY($2::uuid[]))
+		   OR  (user2_id = $1 AND user1_id = ANY($2::uuid[]))`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID, otherIDs)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "filter friends")
+	}
+
+	defer rows.Close()
+
+	var ids []uuid.UUID
+
+	for rows.Next() {
+		var id uuid.UUID
+
+		if err = rows.Scan(&id); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan friend id")
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "iterate friend ids")
+	}
+
+	return ids, nil
+}
+
+// SuggestFriends — друзья друзей по убыванию числа общих друзей. Исключаются текущие друзья,
+// ожидающие заявки и блокировки в любую сторону, а также заявки, отклонённые после rejectedSince.
+func (r *friendshipRepo) SuggestFriends(
+	ctx context.Context,
+	userID uuid.UUID,
+	rejectedSince time.Time,
+	limit int,
+) ([]*entity.Suggestion, error) {
+	query := `
+		WITH my_friends AS (
+			SELECT CASE WHEN user1_id = $1 THEN user2_id ELSE user1_id END AS id
+			FROM   friendships
+			WHERE  user1_id = $1 OR user2_id = $1
+		), candidates AS (
+			SELECT CASE WHEN f.user1_id = mf.id THEN f.user2_id ELSE f.user1_id END AS id
+			FROM   friendships f
+			JOIN   my_friends mf ON f.user1_id = mf.id OR f.user2_id = mf.id
+		)
+		SELECT c.id, COUNT(*) AS mutual_friends
+		FROM   candidates c
+		WHERE  c.id <> $1
+		  AND  c.id NOT IN (SELECT id FROM my_friends)
+		  AND  NOT EXISTS (
+				SELECT 1 FROM friendship_requests fr
+				WHERE  ((fr.sender_id = $1 AND fr.receiver_id = c.id)
+				    OR  (fr.sender_id = c.id AND fr.receiver_id = $1))
+				  AND  (fr.status = 'pending' OR (fr.status = 'rejected' AND fr.updated_at > $2))
+		  )
+		  AND  NOT EXISTS (
+				SELECT 1 FROM blocks b
+				WHERE  (b.user_id = $1 AND b.target_id = c.id)
+				   OR  (b.user_id = c.id AND b.target_id = $1)
+		  )
+		GROUP BY c.id
+		ORDER BY mutual_friends DESC, c.id
+		LIMIT  $3`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID, rejectedSince, limit)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "suggest friends")
+	}
+
+	defer rows.Close()
+
+	var suggestions []*entity.Suggestion
+
+	for rows.Next() {
+		var s entity.Suggestion
+
+		if err = rows.Scan(&s.UserID, &s.MutualFriends); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan suggestion")
+		}
+
+		suggestions = append(suggestions, &s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "iterate suggestions")
+	}
+
+	return suggestions, nil
+}
+
+const mutualFriendsCTE = `
+		WITH a AS (
+			SELECT CASE WHEN user1_id = $1 THEN user2_id ELSE user1_id END AS id
+			FROM   friendships
+			WHERE  user1_id = $1 OR user2_id = $1
+		), b AS (
+			SELECT CASE WHEN user1_id = $2 THEN user2_id ELSE user1_id END AS id
+			FROM   friendships
+			WHERE  user1_id = $2 OR user2_id = $2
+		)`
+
+func (r *friendshipRepo) GetMutualFriendIDs(ctx context.Context, user1, user2 uuid.UUID, limit, offset int) ([]uuid.UUID, error) {
+	query := mutualFriendsCTE + `
+		SELECT a.id FROM a JOIN b ON a.id = b.id
+		ORDER BY a.id
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.exec.QueryContext(ctx, query, user1, user2, limit, offset)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "get mutual friend ids")
+	}
+
+	defer rows.Close()
+
+	var ids []uuid.UUID
+
+	for rows.Next() {
+		var id uuid.UUID
+
+		if err = rows.Scan(&id); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan mutual friend id")
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "iterate mutual friend ids")
+	}
+
+	return ids, nil
+}
+
+func (r *friendshipRepo) CountMutualFriends(ctx context.Context, user1, user2 uuid.UUID) (int, error) {
+	query := mutualFriendsCTE + `
+		SELECT COUNT(*) FROM a JOIN b ON a.id = b.id`
+
+	var count int
+
+	if err := r.exec.QueryRowContext(ctx, query, user1, user2).Scan(&count); err != nil {
+		return 0, commonapperr.MapPostgresError(err, "count mutual friends")
+	}
+
+	return count, nil
+}
+
 func (r *friendshipRepo) AreFriends(ctx context.Context, user1, user2 uuid.UUID) (bool, error) {
 	u1, u2 := orderUUIDs(user1, user2)
 
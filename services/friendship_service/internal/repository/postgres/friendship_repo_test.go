@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func befriend(t *testing.T, db *sql.DB, a, b uuid.UUID) {
+	t.Helper()
+	require.NoError(t, NewFriendshipRepository(db).Create(context.Background(), &entity.Friendship{ID: uuid.New(), User1ID: a, User2ID: b}))
+}
+
+func insertRequest(t *testing.T, db *sql.DB, sender, receiver uuid.UUID, status entity.FriendshipReqStatus, updatedAt time.Time) {
+	t.Helper()
+	_, err := db.ExecContext(context.Background(),
+		`INSERT INTO friendship_requests (id, sender_id, receiver_id, status, updated_at) VALUES ($1, $2, $3, $4, $5)`,
+		uuid.New(), sender, receiver, status, updatedAt)
+	require.NoError(t, err)
+}
+
+func suggestedIDs(suggestions []*entity.Suggestion) []uuid.UUID {
+	ids := make([]uuid.UUID, len(suggestions))
+	for i, s := range suggestions {
+		ids[i] = s.UserID
+	}
+	return ids
+}
+
+func TestFriendshipRepo_SuggestFriends_RanksByMutualFriends(t *testing.T) {
+	db := openTestDB(t)
+	user, f1, f2, f3 := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	twoMutual, oneMutual := uuid.New(), uuid.New()
+
+	for _, f := range []uuid.UUID{f1, f2, f3} {
+		befriend(t, db, user, f)
+	}
+	befriend(t, db, f1, twoMutual)
+	befriend(t, db, f2, twoMutual)
+	befriend(t, db, f3, oneMutual)
+	// f1 и f2 дружат между собой, но уже в друзьях у user и в рекомендации не попадают
+	befriend(t, db, f1, f2)
+
+	suggestions, err := NewFriendshipRepository(db).SuggestFriends(context.Background(), user, time.Now().Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{twoMutual, oneMutual}, suggestedIDs(suggestions))
+	require.Equal(t, 2, suggestions[0].MutualFriends)
+	require.Equal(t, 1, suggestions[1].MutualFriends)
+}
+
+func TestFriendshipRepo_SuggestFriends_Exclusions(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	user, friend := uuid.New(), uuid.New()
+	befriend(t, db, user, friend)
+
+	candidate := func() uuid.UUID {
+		id := uuid.New()
+		befriend(t, db, friend, id)
+		return id
+	}
+
+	pendingOut, pendingIn := candidate(), candidate()
+	blocked, blockedBy := candidate(), candidate()
+	recentlyRejected, rejectedLongAgo, cancelled := candidate(), candidate(), candidate()
+
+	now := time.Now()
+	insertRequest(t, db, user, pendingOut, entity.ReqStatusPending, now)
+	insertRequest(t, db, pendingIn, user, entity.ReqStatusPending, now)
+	insertRequest(t, db, user, recentlyRejected, entity.ReqStatusRejected, now.Add(-time.Hour))
+	insertRequest(t, db, user, rejectedLongAgo, entity.ReqStatusRejected, now.Add(-48*time.Hour))
+	insertRequest(t, db, cancelled, user, entity.ReqStatusCancelled, now)
+
+	blocks := NewBlockRepository(db)
+	require.NoError(t, blocks.Create(ctx, &entity.Block{UserID: user, TargetID: blocked}))
+	require.NoError(t, blocks.Create(ctx, &entity.Block{UserID: blockedBy, TargetID: user}))
+
+	suggestions, err := NewFriendshipRepository(db).SuggestFriends(ctx, user, now.Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uuid.UUID{rejectedLongAgo, cancelled}, suggestedIDs(suggestions))
+}
@@ -215,3 +215,49 @@ func (r *friendshipRequestRepository) GetOutgoing(
 	return result, nil
 
 }
+
+// FindPendingWith возвращает ожидающие заявки между userID и любым из otherIDs в обе стороны.
+func (r *friendshipRequestRepository) FindPendingWith(
+	ctx context.Context,
+	userID uuid.UUID,
+	otherIDs []uuid.UUID,
+) ([]*entity.FriendshipRequest, error) {
+	if len(otherIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT id, sender_id, receiver_id, status, created_at, updated_at
+		FROM   friendship_requests
+		WHERE  status = 'pending'
+		  AND  ((sender_id = $1 AND receiver_id = ANY($2::uuid[]))
+		    OR  (receiver_id = $1 AND sender_id = ANY($2::uuid[])))`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID, otherIDs)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "find pending requests with users")
+	}
+
+	defer rows.Close()
+
+	var result []*entity.FriendshipRequest
+
+	for rows.Next() {
+		var req entity.FriendshipRequest
+		if err = rows.Scan(
+			&req.ID, &req.SenderID, &req.ReceiverID,
+			&req.Status, &req.CreatedAt, &req.UpdatedAt,
+		); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan pending request")
+		}
+
+		result = append(result, &req)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "iterate pending requests")
+	}
+
+	return result, nil
+}
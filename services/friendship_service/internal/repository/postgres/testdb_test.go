@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/require"
+)
+
+// testDatabaseEnv — URL пустой базы Postgres; без него тесты репозиториев пропускаются.
+const testDatabaseEnv = "FRIENDSHIP_TEST_DATABASE_URL"
+
+// openTestDB создаёт отдельную схему, прогоняет в ней Up-части миграций и удаляет её после теста.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	url := os.Getenv(testDatabaseEnv)
+
+	if url == "" {
+		t.Skipf("%s is not set", testDatabaseEnv)
+	}
+
+	ctx := context.Background()
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	admin, err := sql.Open("pgx", url)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = admin.Close() })
+
+	_, err = admin.ExecContext(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+	t.Cleanup(func() { _, _ = admin.ExecContext(ctx, "DROP SCHEMA "+schema+" CASCADE") })
+
+	sep := "?"
+	if strings.Contains(url, "?") {
+		sep = "&"
+	}
+
+	db, err := sql.Open("pgx", url+sep+"search_path="+schema)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "migrations", "*.sql"))
+	require.NoError(t, err)
+	sort.Strings(files)
+
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		up, _, _ := strings.Cut(string(raw), "-- +goose Down")
+
+		_, err = db.ExecContext(ctx, up)
+		require.NoError(t, err, fmt.Sprintf("apply %s", filepath.Base(file)))
+	}
+
+	return db
+}
@@ -17,6 +17,8 @@ type uowTx struct {
 	requests    repository.FriendshipRequestRepository
 	friendships repository.FriendshipRepository
 	blocks      repository.BlockRepository
+	counters    repository.CounterRepository
+	follows     repository.FollowRepository
 	outbox      outbox.WriterInterface
 }
 
@@ -26,6 +28,10 @@ func (u *uowTx) Friendships() repository.FriendshipRepository { return u.friends
 
 func (u *uowTx) Blocks() repository.BlockRepository { return u.blocks }
 
+func (u *uowTx) Counters() repository.CounterRepository { return u.counters }
+
+func (u *uowTx) Follows() repository.FollowRepository { return u.follows }
+
 func (u *uowTx) Outbox() outbox.WriterInterface { return u.outbox }
 
 type UnitOfWork struct {
@@ -50,6 +56,8 @@ func (u *UnitOfWork) Do(ctx context.Context, fn func(domain.Tx) error) error {
 		requests:    NewFriendshipRequestRepository(sqlTx),
 		friendships: NewFriendshipRepository(sqlTx),
 		blocks:      NewBlockRepository(sqlTx),
+		counters:    NewCounterRepository(sqlTx),
+		follows:     NewFollowRepository(sqlTx),
 		outbox:      outboxpg.NewWriterRepository(sqlTx),
 	}
 
@@ -78,3 +86,11 @@ func (u *UnitOfWork) Friendships() repository.FriendshipRepository {
 func (u *UnitOfWork) Blocks() repository.BlockRepository {
 	return NewBlockRepository(u.db)
 }
+
+func (u *UnitOfWork) Counters() repository.CounterRepository {
+	return NewCounterRepository(u.db)
+}
+
+func (u *UnitOfWork) Follows() repository.FollowRepository {
+	return NewFollowRepository(u.db)
+}
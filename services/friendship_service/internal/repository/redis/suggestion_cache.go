@@ -0,0 +1,102 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/entity"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/repository"
+)
+
+const (
+	suggestionsPrefix   = "friend_suggestions:"
+	invalidateBatchSize = 500
+)
+
+type cachedSuggestion struct {
+	UserID        uuid.UUID `json:"user_id"`
+	MutualFriends int       `json:"mutual_friends"`
+}
+
+// SuggestionCache хранит рассчитанный список рекомендаций целиком; страницы режутся из него.
+type SuggestionCache struct {
+	rdb *goredis.Client
+	ttl time.Duration
+}
+
+func NewSuggestionCache(rdb *goredis.Client, ttl time.Duration) repository.SuggestionCache {
+	return &SuggestionCache{rdb: rdb, ttl: ttl}
+}
+
+func (c *SuggestionCache) Get(ctx context.Context, userID uuid.UUID) ([]*entity.Suggestion, bool, error) {
+	raw, err := c.rdb.Get(ctx, suggestionsPrefix+userID.String()).Bytes()
+
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, false, nil
+		}
+
+		return nil, false, fmt.Errorf("redis get suggestions: %w", err)
+	}
+
+	var cached []cachedSuggestion
+
+	if err = json.Unmarshal(raw, &cached); err != nil {
+		return nil, false, fmt.Errorf("decode cached suggestions: %w", err)
+	}
+
+	out := make([]*entity.Suggestion, len(cached))
+
+	for i, s := range cached {
+		out[i] = &entity.Suggestion{UserID: s.UserID, MutualFriends: s.MutualFriends}
+	}
+
+	return out, true, nil
+}
+
+func (c *SuggestionCache) Set(ctx context.Context, userID uuid.UUID, suggestions []*entity.Suggestion) error {
+	cached := make([]cachedSuggestion, len(suggestions))
+
+	for i, s := range suggestions {
+		cached[i] = cachedSuggestion{UserID: s.UserID, MutualFriends: s.MutualFriends}
+	}
+
+	raw, err := json.Marshal(cached)
+
+	if err != nil {
+		return fmt.Errorf("encode suggestions: %w", err)
+	}
+
+	if err = c.rdb.Set(ctx, suggestionsPrefix+userID.String(), raw, c.ttl).Err(); err != nil {
+		return fmt.Errorf("redis set suggestions: %w", err)
+	}
+
+	return nil
+}
+
+func (c *SuggestionCache) Invalidate(ctx context.Context, userIDs ...uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	// у популярного пользователя друзей тысячи: удаляем пачками, а не одной огромной командой
+	for start := 0; start < len(userIDs); start += invalidateBatchSize {
+		batch := userIDs[start:min(start+invalidateBatchSize, len(userIDs))]
+		keys := make([]string, len(batch))
+
+		for i, id := range batch {
+			keys[i] = suggestionsPrefix + id.String()
+		}
+
+		if err := c.rdb.Del(ctx, keys...).Err(); err != nil {
+			return fmt.Errorf("redis del suggestions: %w", err)
+		}
+	}
+
+	return nil
+}
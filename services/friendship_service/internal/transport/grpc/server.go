@@ -2,7 +2,12 @@ package grpc
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
@@ -10,16 +15,21 @@ import (
 
 	friendshipv1 "github.com/rockkley/pushpost/services/friendship_service/gen/friendshipv1"
 	"github.com/rockkley/pushpost/services/friendship_service/internal/domain"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/entity"
 )
 
+// maxRelationshipBatch — сколько target_ids принимает один GetRelationshipsBatch.
+const maxRelationshipBatch = 100
+
 type FriendshipServer struct {
 	friendshipv1.UnimplementedFriendshipServiceServer
-	uc  domain.FriendshipUseCase
-	log *slog.Logger
+	uc       domain.FriendshipUseCase
+	followUC domain.FollowUseCase
+	log      *slog.Logger
 }
 
-func NewFriendshipServer(uc domain.FriendshipUseCase, log *slog.Logger) *FriendshipServer {
-	return &FriendshipServer{uc: uc, log: log}
+func NewFriendshipServer(uc domain.FriendshipUseCase, followUC domain.FollowUseCase, log *slog.Logger) *FriendshipServer {
+	return &FriendshipServer{uc: uc, followUC: followUC, log: log}
 }
 
 func (s *FriendshipServer) AreFriends(
@@ -66,3 +76,264 @@ func (s *FriendshipServer) GetFriendIDs(
 
 	return &friendshipv1.GetFriendIDsResponse{FriendIds: strIDs}, nil
 }
+
+func (s *FriendshipServer) ListFriendIDs(
+	ctx context.Context,
+	req *friendshipv1.ListFriendIDsRequest,
+) (*friendshipv1.ListFriendIDsResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+
+	var after *entity.Friend
+	if req.PageToken != "" {
+		if after, err = decodePageToken(req.PageToken); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %v", err)
+		}
+	}
+
+	friends, hasMore, err := s.uc.ListFriends(ctx, userID, after, int(req.PageSize))
+	if err != nil {
+		s.log.Error("ListFriendIDs failed", slog.Any("error", err))
+		return nil, status.Errorf(codes.Internal, "internal error")
+	}
+
+	resp := &friendshipv1.ListFriendIDsResponse{FriendIds: make([]string, len(friends))}
+	for i, f := range friends {
+		resp.FriendIds[i] = f.ID.String()
+	}
+
+	if hasMore {
+		resp.NextPageToken = encodePageToken(friends[len(friends)-1])
+	}
+
+	return resp, nil
+}
+
+func (s *FriendshipServer) GetRelationship(
+	ctx context.Context,
+	req *friendshipv1.GetRelationshipRequest,
+) (*friendshipv1.GetRelationshipResponse, error) {
+	viewerID, err := uuid.Parse(req.ViewerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid viewer_id: %v", err)
+	}
+	targetID, err := uuid.Parse(req.TargetId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid target_id: %v", err)
+	}
+
+	rel, err := s.uc.GetFriendshipStatus(ctx, viewerID, targetID)
+	if err != nil {
+		s.log.Error("GetRelationship failed", slog.Any("error", err))
+		return nil, status.Errorf(codes.Internal, "internal error")
+	}
+
+	return &friendshipv1.GetRelationshipResponse{Relationship: toProtoRelationship(targetID, rel)}, nil
+}
+
+func (s *FriendshipServer) GetRelationshipsBatch(
+	ctx context.Context,
+	req *friendshipv1.GetRelationshipsBatchRequest,
+) (*friendshipv1.GetRelationshipsBatchResponse, error) {
+	viewerID, err := uuid.Parse(req.ViewerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid viewer_id: %v", err)
+	}
+
+	if len(req.TargetIds) > maxRelationshipBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "too many target_ids: max %d", maxRelationshipBatch)
+	}
+
+	targetIDs := make([]uuid.UUID, len(req.TargetIds))
+	for i, raw := range req.TargetIds {
+		if targetIDs[i], err = uuid.Parse(raw); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid target_id %q: %v", raw, err)
+		}
+	}
+
+	statuses, err := s.uc.GetFriendshipStatuses(ctx, viewerID, targetIDs)
+	if err != nil {
+		s.log.Error("GetRelationshipsBatch failed", slog.Any("error", err))
+		return nil, status.Errorf(codes.Internal, "internal error")
+	}
+
+	resp := &friendshipv1.GetRelationshipsBatchResponse{
+		Relationships: make([]*friendshipv1.Relationship, len(targetIDs)),
+	}
+	for i, id := range targetIDs {
+		resp.Relationships[i] = toProtoRelationship(id, statuses[id])
+	}
+
+	return resp, nil
+}
+
+func (s *FriendshipServer) AreBlocked(
+	ctx context.Context,
+	req *friendshipv1.AreBlockedRequest,
+) (*friendshipv1.AreBlockedResponse, error) {
+	user1, err := uuid.Parse(req.User1Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user1_id: %v", err)
+	}
+	user2, err := uuid.Parse(req.User2Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user2_id: %v", err)
+	}
+
+	blocked, err := s.uc.IsBlockedBetween(ctx, user1, user2)
+	if err != nil {
+		s.log.Error("AreBlocked failed", slog.Any("error", err))
+		return nil, status.Errorf(codes.Internal, "internal error")
+	}
+
+	return &friendshipv1.AreBlockedResponse{Blocked: blocked}, nil
+}
+
+func (s *FriendshipServer) GetBlockedUserIDs(
+	ctx context.Context,
+	req *friendshipv1.GetBlockedUserIDsRequest,
+) (*friendshipv1.GetBlockedUserIDsResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+
+	blocked, err := s.uc.GetBlockedUserIDs(ctx, userID)
+	if err != nil {
+		s.log.Error("GetBlockedUserIDs failed", slog.Any("error", err))
+		return nil, status.Errorf(codes.Internal, "internal error")
+	}
+
+	blockedBy, err := s.uc.GetBlockedByUserIDs(ctx, userID)
+	if err != nil {
+		s.log.Error("GetBlockedUserIDs failed", slog.Any("error", err))
+		return nil, status.Errorf(codes.Internal, "internal error")
+	}
+
+	return &friendshipv1.GetBlockedUserIDsResponse{
+		BlockedIds:   uuidStrings(blocked),
+		BlockedByIds: uuidStrings(blockedBy),
+	}, nil
+}
+
+func toProtoRelationship(targetID uuid.UUID, rel *entity.FriendshipStatus) *friendshipv1.Relationship {
+	out := &friendshipv1.Relationship{TargetId: targetID.String()}
+	if rel == nil {
+		return out
+	}
+
+	out.AreFriends = rel.AreFriends
+	out.PendingRequestSent = rel.PendingRequestSent
+	out.PendingRequestReceived = rel.PendingRequestReceived
+	out.Blocked = rel.Blocked
+	out.BlockedBy = rel.BlockedBy
+
+	return out
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+
+	return out
+}
+
+// page token — позиция последнего друга на странице: "<unix nano>:<friend id>" в base64.
+func (s *FriendshipServer) ListFollowerIDs(
+	ctx context.Context,
+	req *friendshipv1.ListFollowerIDsRequest,
+) (*friendshipv1.ListFollowerIDsResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+
+	after := uuid.Nil
+	if req.PageToken != "" {
+		if after, err = decodeFollowerPageToken(req.PageToken); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %v", err)
+		}
+	}
+
+	ids, hasMore, err := s.followUC.ListFollowerIDs(ctx, userID, after, int(req.PageSize))
+	if err != nil {
+		s.log.Error("ListFollowerIDs failed", slog.Any("error", err))
+		return nil, status.Errorf(codes.Internal, "internal error")
+	}
+
+	resp := &friendshipv1.ListFollowerIDsResponse{FollowerIds: make([]string, len(ids))}
+	for i, id := range ids {
+		resp.FollowerIds[i] = id.String()
+	}
+
+	if hasMore {
+		resp.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(ids[len(ids)-1].String()))
+	}
+
+	return resp, nil
+}
+
+func (s *FriendshipServer) IsFollowing(
+	ctx context.Context,
+	req *friendshipv1.IsFollowingRequest,
+) (*friendshipv1.IsFollowingResponse, error) {
+	followerID, err := uuid.Parse(req.FollowerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid follower_id: %v", err)
+	}
+	followeeID, err := uuid.Parse(req.FolloweeId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid followee_id: %v", err)
+	}
+
+	following, err := s.followUC.IsFollowing(ctx, followerID, followeeID)
+	if err != nil {
+		s.log.Error("IsFollowing failed", slog.Any("error", err))
+		return nil, status.Errorf(codes.Internal, "internal error")
+	}
+
+	return &friendshipv1.IsFollowingResponse{Following: following}, nil
+}
+
+func encodePageToken(f *entity.Friend) string {
+	raw := strconv.FormatInt(f.Since.UnixNano(), 10) + ":" + f.ID.String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePageToken(token string) (*entity.Friend, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, fmt.Errorf("malformed token")
+	}
+
+	ns, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse position: %w", err)
+	}
+
+	friendID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("parse friend id: %w", err)
+	}
+
+	return &entity.Friend{ID: friendID, Since: time.Unix(0, ns).UTC()}, nil
+}
+
+func decodeFollowerPageToken(token string) (uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("decode: %w", err)
+	}
+
+	return uuid.Parse(string(raw))
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/export"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/repository"
+)
+
+type ExportHandler struct {
+	repo repository.ExportRepository
+}
+
+func NewExportHandler(repo repository.ExportRepository) *ExportHandler {
+	return &ExportHandler{repo: repo}
+}
+
+// Collect отдаёт дружбы, заявки, блокировки и подписки пользователя для выгрузки персональных данных.
+func (h *ExportHandler) Collect(ctx context.Context, userID uuid.UUID) (*export.Section, error) {
+	friendships, err := h.repo.Friendships(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := h.repo.Requests(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := h.repo.Blocks(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	follows, err := h.repo.Follows(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	section := &export.Section{}
+
+	for _, f := range []struct {
+		name string
+		data any
+	}{
+		{"friendships.json", friendships},
+		{"friendship_requests.json", requests},
+		{"blocks.json", blocks},
+		{"follows.json", follows},
+	} {
+		if err = section.AddJSON(f.name, f.data); err != nil {
+			return nil, err
+		}
+	}
+
+	return section, nil
+}
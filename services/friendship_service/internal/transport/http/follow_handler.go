@@ -0,0 +1,205 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/httperror"
+	commontransport "github.com/rockkley/pushpost/services/common_service/transport"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/domain"
+	"github.com/rockkley/pushpost/services/friendship_service/internal/entity"
+)
+
+type FollowHandler struct {
+	uc domain.FollowUseCase
+}
+
+func NewFollowHandler(uc domain.FollowUseCase) *FollowHandler {
+	return &FollowHandler{uc: uc}
+}
+
+// Follow — подписка на открытый профиль создаётся сразу (201), к закрытому уходит заявка (202).
+func (h *FollowHandler) Follow(w http.ResponseWriter, r *http.Request) error {
+	followerID, err := commontransport.RequireUserID(r)
+
+	if err != nil {
+		return err
+	}
+
+	followeeID, err := commontransport.ParsePathUUID(r, "userID")
+
+	if err != nil {
+		return err
+	}
+
+	state, err := h.uc.Follow(r.Context(), followerID, followeeID)
+
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusCreated
+
+	if state == entity.FollowStateRequested {
+		code = http.StatusAccepted
+	}
+
+	return httperror.WriteJSON(w, code, map[string]string{"status": string(state)})
+}
+
+func (h *FollowHandler) Unfollow(w http.ResponseWriter, r *http.Request) error {
+	followerID, err := commontransport.RequireUserID(r)
+
+	if err != nil {
+		return err
+	}
+
+	followeeID, err := commontransport.ParsePathUUID(r, "userID")
+
+	if err != nil {
+		return err
+	}
+
+	if err = h.uc.Unfollow(r.Context(), followerID, followeeID); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "unfollowed"})
+}
+
+func (h *FollowHandler) AcceptRequest(w http.ResponseWriter, r *http.Request) error {
+	followeeID, err := commontransport.RequireUserID(r)
+
+	if err != nil {
+		return err
+	}
+
+	followerID, err := commontransport.ParsePathUUID(r, "followerID")
+
+	if err != nil {
+		return err
+	}
+
+	if err = h.uc.AcceptFollowRequest(r.Context(), followeeID, followerID); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "follow request accepted"})
+}
+
+func (h *FollowHandler) RejectRequest(w http.ResponseWriter, r *http.Request) error {
+	followeeID, err := commontransport.RequireUserID(r)
+
+	if err != nil {
+		return err
+	}
+
+	followerID, err := commontransport.ParsePathUUID(r, "followerID")
+
+	if err != nil {
+		return err
+	}
+
+	if err = h.uc.RejectFollowRequest(r.Context(), followeeID, followerID); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "follow request rejected"})
+}
+
+func (h *FollowHandler) GetIncomingRequests(w http.ResponseWriter, r *http.Request) error {
+	userID, err := commontransport.RequireUserID(r)
+
+	if err != nil {
+		return err
+	}
+
+	reqs, err := h.uc.GetIncomingFollowRequests(r.Context(), userID)
+
+	if err != nil {
+		return err
+	}
+
+	type item struct {
+		FollowerID string `json:"follower_id"`
+		CreatedAt  string `json:"created_at"`
+	}
+
+	items := make([]item, 0, len(reqs))
+
+	for _, req := range reqs {
+		items = append(items, item{
+			FollowerID: req.FollowerID.String(),
+			CreatedAt:  req.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]any{
+		"requests": items,
+		"count":    len(items),
+	})
+}
+
+func (h *FollowHandler) GetFollowers(w http.ResponseWriter, r *http.Request) error {
+	return writeFollowList(w, r, "followers", h.uc.GetFollowers, func(f *entity.Follow) uuid.UUID { return f.FollowerID })
+}
+
+func (h *FollowHandler) GetFollowing(w http.ResponseWriter, r *http.Request) error {
+	return writeFollowList(w, r, "following", h.uc.GetFollowing, func(f *entity.Follow) uuid.UUID { return f.FolloweeID })
+}
+
+type listFollowsFunc func(ctx context.Context, viewerID, userID uuid.UUID, limit, offset int) ([]*entity.Follow, error)
+
+// writeFollowList — общий ответ для списков подписчиков и подписок; other выбирает вторую сторону подписки.
+func writeFollowList(
+	w http.ResponseWriter,
+	r *http.Request,
+	key string,
+	list listFollowsFunc,
+	other func(*entity.Follow) uuid.UUID,
+) error {
+	viewerID, err := commontransport.RequireUserID(r)
+
+	if err != nil {
+		return err
+	}
+
+	userID, err := commontransport.ParsePathUUID(r, "userID")
+
+	if err != nil {
+		return err
+	}
+
+	limit, offset, err := commontransport.ParsePagination(r)
+
+	if err != nil {
+		return err
+	}
+
+	follows, err := list(r.Context(), viewerID, userID, limit, offset)
+
+	if err != nil {
+		return err
+	}
+
+	type item struct {
+		UserID    string `json:"user_id"`
+		CreatedAt string `json:"created_at"`
+	}
+
+	items := make([]item, 0, len(follows))
+
+	for _, f := range follows {
+		items = append(items, item{
+			UserID:    other(f).String(),
+			CreatedAt: f.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]any{
+		key:     items,
+		"count": len(items),
+	})
+}
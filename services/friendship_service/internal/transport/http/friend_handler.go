@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
 	"github.com/rockkley/pushpost/services/common_service/httperror"
@@ -163,6 +164,126 @@ func (h *FriendshipHandler) GetFriendIDs(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+func (h *FriendshipHandler) GetSuggestions(w http.ResponseWriter, r *http.Request) error {
+	userID, err := commontransport.RequireUserID(r)
+
+	if err != nil {
+		return err
+	}
+
+	limit, offset, err := commontransport.ParsePagination(r)
+
+	if err != nil {
+		return err
+	}
+
+	suggestions, err := h.uc.GetSuggestions(r.Context(), userID, limit, offset)
+
+	if err != nil {
+		return err
+	}
+
+	type item struct {
+		UserID        string `json:"user_id"`
+		MutualFriends int    `json:"mutual_friends"`
+	}
+
+	items := make([]item, 0, len(suggestions))
+
+	for _, s := range suggestions {
+		items = append(items, item{UserID: s.UserID.String(), MutualFriends: s.MutualFriends})
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]any{
+		"suggestions": items,
+		"count":       len(items),
+	})
+}
+
+func (h *FriendshipHandler) GetMutualFriends(w http.ResponseWriter, r *http.Request) error {
+	viewerID, err := commontransport.RequireUserID(r)
+
+	if err != nil {
+		return err
+	}
+
+	targetID, err := commontransport.ParsePathUUID(r, "userID")
+
+	if err != nil {
+		return err
+	}
+
+	limit, offset, err := commontransport.ParsePagination(r)
+
+	if err != nil {
+		return err
+	}
+
+	ids, total, err := h.uc.GetMutualFriends(r.Context(), viewerID, targetID, limit, offset)
+
+	if err != nil {
+		return err
+	}
+
+	if ids == nil {
+		ids = []uuid.UUID{}
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]any{
+		"mutual_friend_ids": ids,
+		"count":             len(ids),
+		"total":             total,
+	})
+}
+
+// GetSummary — счётчики пользователя из пути или, без userID, самого viewer.
+// Счётчики заявок видны только владельцу.
+func (h *FriendshipHandler) GetSummary(w http.ResponseWriter, r *http.Request) error {
+	viewerID, err := commontransport.RequireUserID(r)
+
+	if err != nil {
+		return err
+	}
+
+	targetID := viewerID
+
+	if chi.URLParam(r, "userID") != "" {
+		if targetID, err = commontransport.ParsePathUUID(r, "userID"); err != nil {
+			return err
+		}
+	}
+
+	summary, err := h.uc.GetRelationshipSummary(r.Context(), viewerID, targetID)
+
+	if err != nil {
+		return err
+	}
+
+	resp := struct {
+		UserID           uuid.UUID `json:"user_id"`
+		FriendsCount     int       `json:"friends_count"`
+		FollowersCount   int       `json:"followers_count"`
+		FollowingCount   int       `json:"following_count"`
+		MutualCount      *int      `json:"mutual_count,omitempty"`
+		IncomingRequests *int      `json:"incoming_requests_count,omitempty"`
+		OutgoingRequests *int      `json:"outgoing_requests_count,omitempty"`
+	}{
+		UserID:         targetID,
+		FriendsCount:   summary.FriendsCount,
+		FollowersCount: summary.FollowersCount,
+		FollowingCount: summary.FollowingCount,
+	}
+
+	if viewerID == targetID {
+		resp.IncomingRequests = &summary.IncomingRequests
+		resp.OutgoingRequests = &summary.OutgoingRequests
+	} else {
+		resp.MutualCount = &summary.MutualFriends
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, resp)
+}
+
 func (h *FriendshipHandler) AreFriends(w http.ResponseWriter, r *http.Request) error {
 	userID, err := commontransport.RequireUserID(r)
 	if err != nil {
@@ -6,36 +6,60 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/rockkley/pushpost/services/common_service/export"
 	handlerhttp "github.com/rockkley/pushpost/services/common_service/http"
 	"github.com/rockkley/pushpost/services/common_service/httplog"
 	"github.com/rockkley/pushpost/services/common_service/metrics"
 	myHTTP "github.com/rockkley/pushpost/services/friendship_service/internal/transport/http"
 )
 
-func NewRouter(log *slog.Logger, h *myHTTP.FriendshipHandler) *chi.Mux {
+func NewRouter(
+	log *slog.Logger,
+	h *myHTTP.FriendshipHandler,
+	followHandler *myHTTP.FollowHandler,
+	exportHandler *myHTTP.ExportHandler,
+) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(chimiddleware.RequestID)
 	r.Use(httplog.Logger(log))
 	r.Use(metrics.Middleware("friendship-service"))
 	r.Use(chimiddleware.Recoverer)
-	r.Use(commonmiddleware.RequireUserID)
 
 	r.Handle("/metrics", metrics.Handler())
 
-	r.Post("/friends/requests", handlerhttp.MakeHandler(h.SendRequest))
-	r.Get("/friends/requests/incoming", handlerhttp.MakeHandler(h.GetIncomingRequests))
-	r.Post("/friends/requests/{senderID}/accept", handlerhttp.MakeHandler(h.AcceptRequest))
-	r.Post("/friends/requests/{senderID}/reject", handlerhttp.MakeHandler(h.RejectRequest))
-	r.Delete("/friends/requests/{receiverID}", handlerhttp.MakeHandler(h.CancelRequest))
-	r.Delete("/friends/{userID}", handlerhttp.MakeHandler(h.DeleteFriendship))
-	r.Get("/friends", handlerhttp.MakeHandler(h.GetFriendIDs))
-	r.Get("/friends/{userID}/status", handlerhttp.MakeHandler(h.AreFriends))
-	r.Get("/friends/{userID}/relationship", handlerhttp.MakeHandler(h.GetRelationship))
-	r.Post("/blocks/{userID}", handlerhttp.MakeHandler(h.BlockUser))
-	r.Delete("/blocks/{userID}", handlerhttp.MakeHandler(h.UnblockUser))
-	r.Get("/blocks", handlerhttp.MakeHandler(h.GetBlockedUsers))
-	r.Post("/blocks/{userID}/check", handlerhttp.MakeHandler(h.AreBlocked))
+	// internal API, not exposed through the gateway
+	r.Get("/internal/export/{userID}", handlerhttp.MakeHandler(export.Handler(exportHandler.Collect)))
+
+	r.Group(func(r chi.Router) {
+		r.Use(commonmiddleware.RequireUserID)
+
+		r.Post("/friends/requests", handlerhttp.MakeHandler(h.SendRequest))
+		r.Get("/friends/requests/incoming", handlerhttp.MakeHandler(h.GetIncomingRequests))
+		r.Post("/friends/requests/{senderID}/accept", handlerhttp.MakeHandler(h.AcceptRequest))
+		r.Post("/friends/requests/{senderID}/reject", handlerhttp.MakeHandler(h.RejectRequest))
+		r.Delete("/friends/requests/{receiverID}", handlerhttp.MakeHandler(h.CancelRequest))
+		r.Delete("/friends/{userID}", handlerhttp.MakeHandler(h.DeleteFriendship))
+		r.Get("/friends", handlerhttp.MakeHandler(h.GetFriendIDs))
+		r.Get("/friends/suggestions", handlerhttp.MakeHandler(h.GetSuggestions))
+		r.Get("/friends/summary", handlerhttp.MakeHandler(h.GetSummary))
+		r.Get("/friends/{userID}/summary", handlerhttp.MakeHandler(h.GetSummary))
+		r.Get("/friends/{userID}/mutual", handlerhttp.MakeHandler(h.GetMutualFriends))
+		r.Get("/friends/{userID}/status", handlerhttp.MakeHandler(h.AreFriends))
+		r.Get("/friends/{userID}/relationship", handlerhttp.MakeHandler(h.GetRelationship))
+		r.Post("/blocks/{userID}", handlerhttp.MakeHandler(h.BlockUser))
+		r.Delete("/blocks/{userID}", handlerhttp.MakeHandler(h.UnblockUser))
+		r.Get("/blocks", handlerhttp.MakeHandler(h.GetBlockedUsers))
+		r.Post("/blocks/{userID}/check", handlerhttp.MakeHandler(h.AreBlocked))
+
+		r.Get("/follows/requests", handlerhttp.MakeHandler(followHandler.GetIncomingRequests))
+		r.Post("/follows/requests/{followerID}/accept", handlerhttp.MakeHandler(followHandler.AcceptRequest))
+		r.Post("/follows/requests/{followerID}/reject", handlerhttp.MakeHandler(followHandler.RejectRequest))
+		r.Post("/follows/{userID}", handlerhttp.MakeHandler(followHandler.Follow))
+		r.Delete("/follows/{userID}", handlerhttp.MakeHandler(followHandler.Unfollow))
+		r.Get("/follows/{userID}/followers", handlerhttp.MakeHandler(followHandler.GetFollowers))
+		r.Get("/follows/{userID}/following", handlerhttp.MakeHandler(followHandler.GetFollowing))
+	})
 
 	return r
 }
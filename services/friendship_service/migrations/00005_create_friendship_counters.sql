@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- счётчики ведёт FriendshipUseCase в тех же транзакциях, что меняют friendships и friendship_requests
+CREATE TABLE friendship_counters
+(
+    user_id                 UUID        PRIMARY KEY,
+    friends_count           INT         NOT NULL DEFAULT 0,
+    incoming_requests_count INT         NOT NULL DEFAULT 0,
+    outgoing_requests_count INT         NOT NULL DEFAULT 0,
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO friendship_counters (user_id, friends_count, incoming_requests_count, outgoing_requests_count)
+SELECT user_id,
+       SUM(friends)::int,
+       SUM(incoming)::int,
+       SUM(outgoing)::int
+FROM (
+    SELECT user1_id AS user_id, 1 AS friends, 0 AS incoming, 0 AS outgoing FROM friendships
+    UNION ALL
+    SELECT user2_id, 1, 0, 0 FROM friendships
+    UNION ALL
+    SELECT receiver_id, 0, 1, 0 FROM friendship_requests WHERE status = 'pending'
+    UNION ALL
+    SELECT sender_id, 0, 0, 1 FROM friendship_requests WHERE status = 'pending'
+) c
+GROUP BY user_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS friendship_counters;
+-- +goose StatementEnd
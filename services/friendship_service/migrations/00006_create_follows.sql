@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+-- односторонние подписки; к закрытому профилю подписка сначала попадает в follow_requests
+CREATE TABLE IF NOT EXISTS follows
+(
+    follower_id UUID                     NOT NULL,
+    followee_id UUID                     NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_id, followee_id),
+    CONSTRAINT chk_follow_not_self CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_follows_followee_created
+    ON follows (followee_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_follows_follower_created
+    ON follows (follower_id, created_at DESC);
+
+-- для постраничной выдачи подписчиков по gRPC (keyset по follower_id)
+CREATE INDEX IF NOT EXISTS idx_follows_followee_follower
+    ON follows (followee_id, follower_id);
+
+CREATE TABLE IF NOT EXISTS follow_requests
+(
+    follower_id UUID                     NOT NULL,
+    followee_id UUID                     NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_id, followee_id),
+    CONSTRAINT chk_follow_request_not_self CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_follow_requests_followee
+    ON follow_requests (followee_id, created_at DESC);
+
+ALTER TABLE friendship_counters
+    ADD COLUMN followers_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN following_count INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE friendship_counters
+    DROP COLUMN IF EXISTS followers_count,
+    DROP COLUMN IF EXISTS following_count;
+
+DROP TABLE IF EXISTS follow_requests;
+DROP TABLE IF EXISTS follows;
+-- +goose StatementEnd
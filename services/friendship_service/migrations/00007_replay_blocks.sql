@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- post, message и notification держат проекцию user_blocks, которую наполняют только
+-- block.created/block.removed; блокировки, созданные до их появления, переотправляем
+-- через outbox тем же конвертом, что и insertOutboxEvent. Потребители пишут
+-- ON CONFLICT DO NOTHING, так что повтор для уже известных блокировок безопасен.
+INSERT INTO outbox_events (id, aggregate_id, aggregate_type, event_type, payload)
+SELECT gen_random_uuid(),
+       user_id::text,
+       'block',
+       'block.created',
+       jsonb_build_object(
+           'event_type', 'block.created',
+           'payload', jsonb_build_object('user_id', user_id::text, 'target_id', target_id::text)
+       )
+FROM blocks
+ORDER BY created_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- уже отправленные события не отзываются; проекции остаются заполненными
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- отрицательный счётчик означает ошибку в учёте: пусть запись падает, а не обрезается до нуля
+ALTER TABLE friendship_counters
+    ADD CONSTRAINT friendship_counters_non_negative CHECK (
+        friends_count >= 0
+        AND incoming_requests_count >= 0
+        AND outgoing_requests_count >= 0
+        AND followers_count >= 0
+        AND following_count >= 0
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE friendship_counters DROP CONSTRAINT IF EXISTS friendship_counters_non_negative;
+-- +goose StatementEnd
@@ -20,6 +20,7 @@ import (
 	outboxpg "github.com/rockkley/pushpost/services/common_service/outbox/postgres"
 	"github.com/rockkley/pushpost/services/message_service/internal/config"
 	"github.com/rockkley/pushpost/services/message_service/internal/domain/usecase"
+	msgkafka "github.com/rockkley/pushpost/services/message_service/internal/kafka"
 	"github.com/rockkley/pushpost/services/message_service/internal/repository/postgres"
 	"github.com/rockkley/pushpost/services/message_service/internal/transport"
 	myHTTP "github.com/rockkley/pushpost/services/message_service/internal/transport/http"
@@ -61,7 +62,8 @@ func main() {
 	uow := postgres.NewUnitOfWork(db)
 	uc := usecase.NewMessageUseCase(uow)
 	handler := myHTTP.NewMessageHandler(uc)
-	mux := transport.NewRouter(appLog, handler)
+	exportHandler := myHTTP.NewExportHandler(postgres.NewExportRepository(db))
+	mux := transport.NewRouter(appLog, handler, exportHandler)
 
 	kafkaPublisher := kafka.NewPublisher(cfg.Kafka.Brokers(), appLog)
 
@@ -84,6 +86,15 @@ func main() {
 
 	go outboxWorker.Run(ctx)
 
+	// локальная проекция блокировок из friendship_service
+	consumer := msgkafka.NewConsumer(
+		cfg.Kafka.Brokers(),
+		cfg.Kafka.GroupID,
+		msgkafka.ConsumedTopics,
+		msgkafka.NewRouter(msgkafka.NewHandlers(postgres.NewBlockRepository(db), appLog), appLog),
+		appLog,
+	)
+
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.HTTP.Port),
 		Handler:      mux,
@@ -91,7 +102,13 @@ func main() {
 		WriteTimeout: cfg.HTTP.WriteTimeout,
 	}
 
-	serverErr := make(chan error, 1)
+	serverErr := make(chan error, 2)
+
+	go func() {
+		if runErr := consumer.Run(ctx); runErr != nil {
+			serverErr <- fmt.Errorf("kafka consumer: %w", runErr)
+		}
+	}()
 
 	go func() {
 		appLog.Info("message service started", slog.String("port", cfg.HTTP.Port))
@@ -113,6 +130,7 @@ func main() {
 	}
 
 	cancel()
+	_ = consumer.Close()
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
 
@@ -6,4 +6,5 @@ const (
 	CodeMessageTooLong    = "message_too_long"
 	CodeMessageEmpty      = "message_empty"
 	CodeNotReceiver       = "not_message_receiver"
+	CodeUserBlocked       = "user_blocked"
 )
@@ -21,3 +21,7 @@ func MessageEmpty() apperror.AppError {
 func NotReceiver() apperror.AppError {
 	return apperror.Forbidden(CodeNotReceiver, "you are not the receiver of this message")
 }
+
+func UserBlocked() apperror.AppError {
+	return apperror.Forbidden(CodeUserBlocked, "you cannot send messages to this user")
+}
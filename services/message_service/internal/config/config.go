@@ -28,7 +28,8 @@ type DatabaseConfig struct {
 }
 
 type KafkaConfig struct {
-	BrokersRaw string `env:"KAFKA_BROKERS" env-required:"true" env-default:"kafka:9092"`
+	BrokersRaw string `env:"KAFKA_BROKERS"  env-required:"true" env-default:"kafka:9092"`
+	GroupID    string `env:"KAFKA_GROUP_ID" env-default:"message_service"`
 }
 
 func (k KafkaConfig) Brokers() []string {
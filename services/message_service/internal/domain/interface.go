@@ -27,4 +27,5 @@ type Tx interface {
 type UnitOfWork interface {
 	Do(ctx context.Context, fn func(tx Tx) error) error
 	Reader() repository.MessageRepository
+	Blocks() repository.BlockRepository
 }
@@ -49,6 +49,21 @@ func (uc *MessageUseCase) SendMessage(ctx context.Context, req dto.SendMessageDT
 		}
 	}
 
+	blocked, err := uc.uow.Blocks().ExistsBetween(ctx, req.SenderID, req.ReceiverID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if blocked {
+		log.Info("message rejected: users are blocked",
+			slog.String("sender_id", req.SenderID.String()),
+			slog.String("receiver_id", req.ReceiverID.String()),
+		)
+
+		return nil, apperr.UserBlocked()
+	}
+
 	msg := &entity.Message{
 		ID:         uuid.New(),
 		SenderID:   req.SenderID,
@@ -59,7 +74,7 @@ func (uc *MessageUseCase) SendMessage(ctx context.Context, req dto.SendMessageDT
 
 	var created *entity.Message
 
-	err := uc.uow.Do(ctx, func(tx domain.Tx) error {
+	err = uc.uow.Do(ctx, func(tx domain.Tx) error {
 		var txErr error
 		created, txErr = tx.Messages().Create(ctx, msg)
 
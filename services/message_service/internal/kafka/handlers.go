@@ -0,0 +1,70 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/message_service/internal/repository"
+)
+
+type blockPayload struct {
+	UserID   string `json:"user_id"`
+	TargetID string `json:"target_id"`
+}
+
+type Handlers struct {
+	blocks repository.BlockRepository
+	log    *slog.Logger
+}
+
+func NewHandlers(blocks repository.BlockRepository, log *slog.Logger) *Handlers {
+	return &Handlers{blocks: blocks, log: log.With("component", "message_handlers")}
+}
+
+func (h *Handlers) HandleBlockCreated(ctx context.Context, payload json.RawMessage) error {
+	userID, targetID, ok, err := h.parseBlock(TopicBlockCreated, payload)
+
+	if err != nil || !ok {
+		return err
+	}
+
+	return h.blocks.Add(ctx, userID, targetID)
+}
+
+func (h *Handlers) HandleBlockRemoved(ctx context.Context, payload json.RawMessage) error {
+	userID, targetID, ok, err := h.parseBlock(TopicBlockRemoved, payload)
+
+	if err != nil || !ok {
+		return err
+	}
+
+	return h.blocks.Remove(ctx, userID, targetID)
+}
+
+// parseBlock возвращает ok=false для событий с битыми id — их пропускаем, а не ретраим.
+func (h *Handlers) parseBlock(topic string, payload json.RawMessage) (uuid.UUID, uuid.UUID, bool, error) {
+	var p blockPayload
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return uuid.Nil, uuid.Nil, false, fmt.Errorf("decode %s: %w", topic, err)
+	}
+
+	userID, err := uuid.Parse(p.UserID)
+
+	if err != nil {
+		h.log.Warn("invalid user_id in "+topic+", skipping", slog.String("user_id", p.UserID))
+		return uuid.Nil, uuid.Nil, false, nil
+	}
+
+	targetID, err := uuid.Parse(p.TargetID)
+
+	if err != nil {
+		h.log.Warn("invalid target_id in "+topic+", skipping", slog.String("target_id", p.TargetID))
+		return uuid.Nil, uuid.Nil, false, nil
+	}
+
+	return userID, targetID, true, nil
+}
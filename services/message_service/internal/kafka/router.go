@@ -0,0 +1,34 @@
+package kafka
+
+import (
+	"context"
+	"log/slog"
+)
+
+type EventRouter struct {
+	handlers *Handlers
+	log      *slog.Logger
+}
+
+func NewRouter(handlers *Handlers, log *slog.Logger) *EventRouter {
+	return &EventRouter{handlers: handlers, log: log.With("component", "message_event_router")}
+}
+
+func (r *EventRouter) Route(ctx context.Context, topic string, env Envelope) error {
+	eventType := env.EventType
+	if eventType == "" {
+		eventType = topic
+	}
+
+	switch eventType {
+	case TopicBlockCreated:
+		return r.handlers.HandleBlockCreated(ctx, env.Payload)
+	case TopicBlockRemoved:
+		return r.handlers.HandleBlockRemoved(ctx, env.Payload)
+	default:
+		r.log.Debug("unhandled event type, skipping",
+			slog.String("event_type", eventType),
+			slog.String("topic", topic))
+		return nil
+	}
+}
@@ -0,0 +1,11 @@
+package kafka
+
+const (
+	TopicBlockCreated = "block.created"
+	TopicBlockRemoved = "block.removed"
+)
+
+var ConsumedTopics = []string{
+	TopicBlockCreated,
+	TopicBlockRemoved,
+}
@@ -15,3 +15,13 @@ type MessageRepository interface {
 	GetUnreadCount(ctx context.Context, userID uuid.UUID) (int, error)
 	GetUnreadMessages(ctx context.Context, userID uuid.UUID) ([]*entity.Message, error)
 }
+
+type ExportRepository interface {
+	Messages(ctx context.Context, userID uuid.UUID) ([]*entity.Message, error)
+}
+
+type BlockRepository interface {
+	Add(ctx context.Context, userID, targetID uuid.UUID) error
+	Remove(ctx context.Context, userID, targetID uuid.UUID) error
+	ExistsBetween(ctx context.Context, user1, user2 uuid.UUID) (bool, error)
+}
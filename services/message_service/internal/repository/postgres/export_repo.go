@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/database"
+	"github.com/rockkley/pushpost/services/message_service/internal/entity"
+)
+
+// ExportRepository читает переписку пользователя для выгрузки данных.
+type ExportRepository struct{ exec database.Executor }
+
+func NewExportRepository(exec database.Executor) *ExportRepository {
+	return &ExportRepository{exec: exec}
+}
+
+// Messages возвращает и отправленные, и полученные сообщения в порядке создания.
+func (r *ExportRepository) Messages(ctx context.Context, userID uuid.UUID) ([]*entity.Message, error) {
+	const query = `
+		SELECT id, sender_id, receiver_id, content, created_at, read_at
+		FROM   messages
+		WHERE  sender_id = $1 OR receiver_id = $1
+		ORDER  BY created_at`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "export messages")
+	}
+
+	defer rows.Close()
+
+	var messages []*entity.Message
+
+	for rows.Next() {
+		var m entity.Message
+
+		if err = rows.Scan(&m.ID, &m.SenderID, &m.ReceiverID, &m.Content, &m.CreatedAt, &m.ReadAt); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan exported message")
+		}
+
+		messages = append(messages, &m)
+	}
+
+	return messages, rows.Err()
+}
@@ -35,6 +35,10 @@ func (u *UnitOfWork) Reader() repository.MessageRepository {
 	return NewMessageRepository(u.db)
 }
 
+func (u *UnitOfWork) Blocks() repository.BlockRepository {
+	return NewBlockRepository(u.db)
+}
+
 func (u *UnitOfWork) Do(ctx context.Context, fn func(domain.Tx) error) error {
 	sqlTx, err := u.db.BeginTx(ctx, nil)
 
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/export"
+	"github.com/rockkley/pushpost/services/message_service/internal/entity"
+	"github.com/rockkley/pushpost/services/message_service/internal/repository"
+)
+
+type ExportHandler struct {
+	repo repository.ExportRepository
+}
+
+func NewExportHandler(repo repository.ExportRepository) *ExportHandler {
+	return &ExportHandler{repo: repo}
+}
+
+// Collect отдаёт отправленные и полученные сообщения пользователя отдельными файлами.
+func (h *ExportHandler) Collect(ctx context.Context, userID uuid.UUID) (*export.Section, error) {
+	messages, err := h.repo.Messages(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sent := make([]*entity.Message, 0)
+	received := make([]*entity.Message, 0)
+
+	for _, m := range messages {
+		if m.SenderID == userID {
+			sent = append(sent, m)
+		} else {
+			received = append(received, m)
+		}
+	}
+
+	section := &export.Section{}
+
+	if err = section.AddJSON("messages_sent.json", sent); err != nil {
+		return nil, err
+	}
+
+	if err = section.AddJSON("messages_received.json", received); err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
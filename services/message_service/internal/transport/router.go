@@ -3,6 +3,7 @@ package transport
 import (
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/rockkley/pushpost/services/common_service/export"
 	handlerhttp "github.com/rockkley/pushpost/services/common_service/http"
 	"github.com/rockkley/pushpost/services/common_service/httplog"
 	"github.com/rockkley/pushpost/services/common_service/metrics"
@@ -11,17 +12,21 @@ import (
 	"log/slog"
 )
 
-func NewRouter(log *slog.Logger, h *myHTTP.MessageHandler) *chi.Mux {
+func NewRouter(log *slog.Logger, h *myHTTP.MessageHandler, exportHandler *myHTTP.ExportHandler) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(chimiddleware.RequestID)
 	r.Use(httplog.Logger(log))
 	r.Use(metrics.Middleware("message-service"))
 	r.Use(chimiddleware.Recoverer)
-	r.Use(commonmiddleware.RequireUserID)
 	r.Handle("/metrics", metrics.Handler())
 
+	// internal API, not exposed through the gateway
+	r.Get("/internal/export/{userID}", handlerhttp.MakeHandler(export.Handler(exportHandler.Collect)))
+
 	r.Route("/messages", func(r chi.Router) {
+		r.Use(commonmiddleware.RequireUserID)
+
 		r.Post("/", handlerhttp.MakeHandler(h.SendMessage))
 		r.Get("/unread/count", handlerhttp.MakeHandler(h.GetUnreadCount))
 		r.Get("/unread", handlerhttp.MakeHandler(h.GetUnreadMessages))
@@ -105,7 +105,7 @@ func main() {
 
 	defer profileClient.Close()
 
-	handlers := notifkafka.NewHandlers(uc, profileClient, appLog)
+	handlers := notifkafka.NewHandlers(uc, repopg.NewBlockRepository(db), profileClient, appLog)
 	router := notifkafka.NewRouter(handlers, appLog)
 
 	consumer := notifkafka.NewConsumer(
@@ -118,7 +118,8 @@ func main() {
 
 	handler := myHTTP.NewNotificationHandler(uc)
 	sseHandler := myHTTP.NewSSEHandler(rdb)
-	mux := transport.NewRouter(appLog, handler, sseHandler)
+	exportHandler := myHTTP.NewExportHandler(repopg.NewExportRepository(db), prefRepo, telegramRepo)
+	mux := transport.NewRouter(appLog, handler, sseHandler, exportHandler)
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.HTTP.Port),
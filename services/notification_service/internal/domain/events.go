@@ -38,3 +38,14 @@ type CommentMentionedPayload struct {
 	AuthorID      string   `json:"author_id"`
 	MentionedList []string `json:"mentioned_list"`
 }
+
+type UserExportReadyPayload struct {
+	UserID    string `json:"user_id"`
+	ExportID  string `json:"export_id"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type BlockPayload struct {
+	UserID   string `json:"user_id"`
+	TargetID string `json:"target_id"`
+}
@@ -16,6 +16,7 @@ const (
 	TypeMessageReceived       NotificationType = "message.received"
 	TypeCommentReplied        NotificationType = "comment.replied"
 	TypeCommentMentioned      NotificationType = "comment.mentioned"
+	TypeDataExportReady       NotificationType = "data_export.ready"
 )
 
 const (
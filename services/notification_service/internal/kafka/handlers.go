@@ -11,16 +11,30 @@ import (
 	"github.com/rockkley/pushpost/clients/profile_grpc"
 	"github.com/rockkley/pushpost/services/notification_service/internal/domain"
 	"github.com/rockkley/pushpost/services/notification_service/internal/entity"
+	"github.com/rockkley/pushpost/services/notification_service/internal/repository"
 )
 
 type Handlers struct {
 	uc            domain.NotificationUseCase
+	blocks        repository.BlockRepository
 	log           *slog.Logger
 	profileClient *profile_grpc.Client
 }
 
-func NewHandlers(uc domain.NotificationUseCase, profileClient *profile_grpc.Client, log *slog.Logger) *Handlers {
-	return &Handlers{uc: uc, profileClient: profileClient, log: log.With("component", "notification_handlers")}
+func NewHandlers(uc domain.NotificationUseCase, blocks repository.BlockRepository, profileClient *profile_grpc.Client, log *slog.Logger) *Handlers {
+	return &Handlers{uc: uc, blocks: blocks, profileClient: profileClient, log: log.With("component", "notification_handlers")}
+}
+
+// isBlocked — уведомления от пользователя, с которым есть блокировка, не доставляются.
+// Непарсящийся actorID блокировкой не считается.
+func (h *Handlers) isBlocked(ctx context.Context, recipientID uuid.UUID, actorID string) (bool, error) {
+	actor, err := uuid.Parse(actorID)
+
+	if err != nil {
+		return false, nil
+	}
+
+	return h.blocks.ExistsBetween(ctx, recipientID, actor)
 }
 
 func notifID(key string) uuid.UUID {
@@ -42,6 +56,10 @@ func (h *Handlers) HandleFriendRequestSent(ctx context.Context, payload json.Raw
 		return nil
 	}
 
+	if blocked, err := h.isBlocked(ctx, receiverID, p.SenderID); err != nil || blocked {
+		return err
+	}
+
 	return h.uc.CreateAndDeliver(ctx, &entity.Notification{
 		ID:     notifID("friend_request.received:" + p.RequestID),
 		UserID: receiverID,
@@ -136,6 +154,10 @@ func (h *Handlers) HandleMessageSent(ctx context.Context, payload json.RawMessag
 		return nil
 	}
 
+	if blocked, err := h.isBlocked(ctx, receiverID, p.SenderID); err != nil || blocked {
+		return err
+	}
+
 	return h.uc.CreateAndDeliver(ctx, &entity.Notification{
 		ID:     notifID("message.received:" + p.MessageID),
 		UserID: receiverID,
@@ -162,6 +184,11 @@ func (h *Handlers) HandleCommentReplied(ctx context.Context, payload json.RawMes
 	if p.ReplyAuthorID == p.OriginalAuthorID {
 		return nil
 	}
+
+	if blocked, err := h.isBlocked(ctx, userID, p.ReplyAuthorID); err != nil || blocked {
+		return err
+	}
+
 	return h.uc.CreateAndDeliver(ctx, &entity.Notification{
 		ID:     notifID("comment.replied:" + p.CommentID + ":" + p.OriginalAuthorID),
 		UserID: userID,
@@ -190,6 +217,9 @@ func (h *Handlers) HandleCommentMentioned(ctx context.Context, payload json.RawM
 		if err != nil || userID.String() == p.AuthorID {
 			continue
 		}
+		if blocked, err := h.isBlocked(ctx, userID, p.AuthorID); err != nil || blocked {
+			continue
+		}
 		_ = h.uc.CreateAndDeliver(ctx, &entity.Notification{
 			ID:     notifID("comment.mentioned:" + p.CommentID + ":" + userID.String()),
 			UserID: userID,
@@ -201,3 +231,73 @@ func (h *Handlers) HandleCommentMentioned(ctx context.Context, payload json.RawM
 	}
 	return nil
 }
+
+func (h *Handlers) HandleUserExportReady(ctx context.Context, payload json.RawMessage) error {
+	var p domain.UserExportReadyPayload
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("decode user.export_ready: %w", err)
+	}
+
+	userID, err := uuid.Parse(p.UserID)
+
+	if err != nil {
+		h.log.Warn("invalid user_id in user.export_ready, skipping",
+			slog.String("user_id", p.UserID))
+		return nil
+	}
+
+	return h.uc.CreateAndDeliver(ctx, &entity.Notification{
+		ID:     notifID("data_export.ready:" + p.ExportID),
+		UserID: userID,
+		Type:   entity.TypeDataExportReady,
+		Title:  "Архив с вашими данными готов",
+		Body:   "Скачать архив можно в настройках аккаунта до истечения срока хранения.",
+		Data:   map[string]string{"export_id": p.ExportID, "expires_at": p.ExpiresAt},
+	})
+}
+
+func (h *Handlers) HandleBlockCreated(ctx context.Context, payload json.RawMessage) error {
+	userID, targetID, ok, err := h.parseBlock(TopicBlockCreated, payload)
+
+	if err != nil || !ok {
+		return err
+	}
+
+	return h.blocks.Add(ctx, userID, targetID)
+}
+
+func (h *Handlers) HandleBlockRemoved(ctx context.Context, payload json.RawMessage) error {
+	userID, targetID, ok, err := h.parseBlock(TopicBlockRemoved, payload)
+
+	if err != nil || !ok {
+		return err
+	}
+
+	return h.blocks.Remove(ctx, userID, targetID)
+}
+
+// parseBlock возвращает ok=false для событий с битыми id — их пропускаем, а не ретраим.
+func (h *Handlers) parseBlock(topic string, payload json.RawMessage) (uuid.UUID, uuid.UUID, bool, error) {
+	var p domain.BlockPayload
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return uuid.Nil, uuid.Nil, false, fmt.Errorf("decode %s: %w", topic, err)
+	}
+
+	userID, err := uuid.Parse(p.UserID)
+
+	if err != nil {
+		h.log.Warn("invalid user_id in "+topic+", skipping", slog.String("user_id", p.UserID))
+		return uuid.Nil, uuid.Nil, false, nil
+	}
+
+	targetID, err := uuid.Parse(p.TargetID)
+
+	if err != nil {
+		h.log.Warn("invalid target_id in "+topic+", skipping", slog.String("target_id", p.TargetID))
+		return uuid.Nil, uuid.Nil, false, nil
+	}
+
+	return userID, targetID, true, nil
+}
@@ -40,6 +40,12 @@ func (r *EventRouter) Route(ctx context.Context, topic string, env Envelope) err
 		return r.handlers.HandleCommentReplied(ctx, env.Payload)
 	case TopicCommentMentioned:
 		return r.handlers.HandleCommentMentioned(ctx, env.Payload)
+	case TopicUserExportReady:
+		return r.handlers.HandleUserExportReady(ctx, env.Payload)
+	case TopicBlockCreated:
+		return r.handlers.HandleBlockCreated(ctx, env.Payload)
+	case TopicBlockRemoved:
+		return r.handlers.HandleBlockRemoved(ctx, env.Payload)
 	default:
 		r.log.Debug("unhandled event type, skipping",
 			slog.String("event_type", eventType),
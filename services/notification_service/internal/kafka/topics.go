@@ -7,6 +7,9 @@ const (
 	TopicMessageSent           = "message.sent"
 	TopicCommentReplied        = "comment.replied"
 	TopicCommentMentioned      = "comment.mentioned"
+	TopicUserExportReady       = "user.export_ready"
+	TopicBlockCreated          = "block.created"
+	TopicBlockRemoved          = "block.removed"
 )
 
 var ConsumedTopics = []string{
@@ -16,4 +19,7 @@ var ConsumedTopics = []string{
 	TopicMessageSent,
 	TopicCommentReplied,
 	TopicCommentMentioned,
+	TopicUserExportReady,
+	TopicBlockCreated,
+	TopicBlockRemoved,
 }
@@ -32,3 +32,13 @@ type LinkCodeStore interface {
 	Save(ctx context.Context, code string, userID uuid.UUID, ttl time.Duration) error
 	Pop(ctx context.Context, code string) (uuid.UUID, error)
 }
+
+type ExportRepository interface {
+	Notifications(ctx context.Context, userID uuid.UUID) ([]*entity.Notification, error)
+}
+
+type BlockRepository interface {
+	Add(ctx context.Context, userID, targetID uuid.UUID) error
+	Remove(ctx context.Context, userID, targetID uuid.UUID) error
+	ExistsBetween(ctx context.Context, user1, user2 uuid.UUID) (bool, error)
+}
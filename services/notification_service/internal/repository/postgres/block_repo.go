@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/database"
+	"github.com/rockkley/pushpost/services/notification_service/internal/repository"
+)
+
+// blockRepo хранит локальную копию блокировок; события могут прийти повторно,
+// поэтому Add и Remove идемпотентны.
+type blockRepo struct {
+	exec database.Executor
+}
+
+func NewBlockRepository(exec database.Executor) repository.BlockRepository {
+	return &blockRepo{exec: exec}
+}
+
+func (r *blockRepo) Add(ctx context.Context, userID, targetID uuid.UUID) error {
+	_, err := r.exec.ExecContext(ctx,
+		`INSERT INTO user_blocks (user_id, target_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		userID, targetID)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "add block")
+	}
+
+	return nil
+}
+
+func (r *blockRepo) Remove(ctx context.Context, userID, targetID uuid.UUID) error {
+	_, err := r.exec.ExecContext(ctx,
+		`DELETE FROM user_blocks WHERE user_id = $1 AND target_id = $2`,
+		userID, targetID)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "remove block")
+	}
+
+	return nil
+}
+
+// ExistsBetween — есть ли блокировка в любую сторону.
+func (r *blockRepo) ExistsBetween(ctx context.Context, user1, user2 uuid.UUID) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1 FROM user_blocks
+			WHERE (user_id = $1 AND target_id = $2) OR (user_id = $2 AND target_id = $1)
+		)`
+
+	var exists bool
+
+	if err := r.exec.QueryRowContext(ctx, query, user1, user2).Scan(&exists); err != nil {
+		return false, commonapperr.MapPostgresError(err, "check block")
+	}
+
+	return exists, nil
+}
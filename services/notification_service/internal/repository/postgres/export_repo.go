@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/database"
+	"github.com/rockkley/pushpost/services/notification_service/internal/entity"
+)
+
+// ExportRepository читает всю историю уведомлений пользователя для выгрузки данных.
+type ExportRepository struct{ exec database.Executor }
+
+func NewExportRepository(exec database.Executor) *ExportRepository {
+	return &ExportRepository{exec: exec}
+}
+
+func (r *ExportRepository) Notifications(ctx context.Context, userID uuid.UUID) ([]*entity.Notification, error) {
+	const query = `
+		SELECT id, user_id, type, title, body, data, read_at, created_at
+		FROM   notifications
+		WHERE  user_id = $1
+		ORDER  BY created_at`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "export notifications")
+	}
+
+	defer rows.Close()
+
+	return scanNotifications(rows)
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/export"
+	"github.com/rockkley/pushpost/services/notification_service/internal/repository"
+)
+
+type ExportHandler struct {
+	notifications repository.ExportRepository
+	preferences   repository.PreferenceRepository
+	telegram      repository.TelegramRepository
+}
+
+func NewExportHandler(
+	notifications repository.ExportRepository,
+	preferences repository.PreferenceRepository,
+	telegram repository.TelegramRepository,
+) *ExportHandler {
+	return &ExportHandler{notifications: notifications, preferences: preferences, telegram: telegram}
+}
+
+// Collect отдаёт уведомления, настройки каналов и привязку Telegram.
+// Без привязки telegram.json содержит null.
+func (h *ExportHandler) Collect(ctx context.Context, userID uuid.UUID) (*export.Section, error) {
+	notifications, err := h.notifications.Notifications(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	preferences, err := h.preferences.GetAll(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	binding, err := h.telegram.FindByUserID(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	section := &export.Section{}
+
+	for _, f := range []struct {
+		name string
+		data any
+	}{
+		{"notifications.json", notifications},
+		{"preferences.json", preferences},
+		{"telegram.json", binding},
+	} {
+		if err = section.AddJSON(f.name, f.data); err != nil {
+			return nil, err
+		}
+	}
+
+	return section, nil
+}
@@ -7,22 +7,26 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/rockkley/pushpost/services/common_service/export"
 	handlerhttp "github.com/rockkley/pushpost/services/common_service/http"
 	"github.com/rockkley/pushpost/services/common_service/httplog"
 	"github.com/rockkley/pushpost/services/common_service/metrics"
 	commonmiddleware "github.com/rockkley/pushpost/services/common_service/middleware"
 )
 
-func NewRouter(log *slog.Logger, h *myHTTP.NotificationHandler, sse *myHTTP.SSEHandler) *chi.Mux {
+func NewRouter(log *slog.Logger, h *myHTTP.NotificationHandler, sse *myHTTP.SSEHandler, exportHandler *myHTTP.ExportHandler) *chi.Mux {
 	r := chi.NewRouter()
 	r.Use(chimiddleware.RequestID)
 	r.Use(httplog.Logger(log))
 	r.Use(metrics.Middleware("notification-service"))
 	r.Use(chimiddleware.Recoverer)
-	r.Use(commonmiddleware.RequireUserID)
 	r.Handle("/metrics", metrics.Handler())
 
+	// internal API, not exposed through the gateway
+	r.Get("/internal/export/{userID}", handlerhttp.MakeHandler(export.Handler(exportHandler.Collect)))
+
 	r.Route("/notifications", func(r chi.Router) {
+		r.Use(commonmiddleware.RequireUserID)
 		r.Get("/stream", sse.Subscribe)
 		r.Group(func(r chi.Router) {
 			r.Use(chimiddleware.Timeout(10 * time.Second))
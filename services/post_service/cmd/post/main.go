@@ -108,7 +108,8 @@ func main() {
 	commentHandler := myHTTP.NewCommentHandler(commentUC)
 
 	sseHandler := myHTTP.NewFeedSSEHandler(rdb)
-	mux := transport.NewRouter(appLog, postHandler, commentHandler, sseHandler)
+	exportHandler := myHTTP.NewExportHandler(repopg.NewExportRepository(db))
+	mux := transport.NewRouter(appLog, postHandler, commentHandler, sseHandler, exportHandler)
 
 	// ── Kafka outbox worker ───────────────────────────────────────────────────
 	kafkaPublisher := kafkap.NewPublisher(cfg.Kafka.Brokers(), appLog)
@@ -136,12 +137,19 @@ func main() {
 			"post.deleted",
 			"friendship.created",
 			"friendship.deleted",
+			"block.created",
+			"block.removed",
+			"follow.created",
+			"follow.deleted",
 			"user.deleted",
+			"user.suspended",
+			"user.reinstated",
 		},
 		friendshipClient,
 		feedRepo,
 		postRepo,
 		commentsRepo,
+		repopg.NewBlockRepository(db),
 		notifier,
 		appLog,
 	)
@@ -12,6 +12,8 @@ import (
 	friendshipv1 "github.com/rockkley/pushpost/services/friendship_service/gen/friendshipv1"
 )
 
+const friendPageSize = 1000
+
 type GRPCClient struct {
 	conn   *grpc.ClientConn
 	client friendshipv1.FriendshipServiceClient
@@ -43,21 +45,91 @@ func (c *GRPCClient) Close() error {
 	return c.conn.Close()
 }
 
+// GetFriendIDs выгружает всех друзей постранично, чтобы большой список не упирался
+// в лимит размера одного gRPC-сообщения.
 func (c *GRPCClient) GetFriendIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
-	resp, err := c.client.GetFriendIDs(ctx, &friendshipv1.GetFriendIDsRequest{
-		UserId: userID.String(),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("grpc get friend ids: %w", err)
+	var (
+		ids   []uuid.UUID
+		token string
+	)
+
+	for {
+		resp, err := c.client.ListFriendIDs(ctx, &friendshipv1.ListFriendIDsRequest{
+			UserId:    userID.String(),
+			PageSize:  friendPageSize,
+			PageToken: token,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("grpc list friend ids: %w", err)
+		}
+
+		for _, s := range resp.FriendIds {
+			id, err := uuid.Parse(s)
+			if err != nil {
+				continue
+			}
+			ids = append(ids, id)
+		}
+
+		if resp.NextPageToken == "" {
+			return ids, nil
+		}
+		token = resp.NextPageToken
 	}
+}
 
-	ids := make([]uuid.UUID, 0, len(resp.FriendIds))
-	for _, s := range resp.FriendIds {
-		id, err := uuid.Parse(s)
+// GetFollowerIDs выгружает всех подписчиков постранично, как GetFriendIDs.
+func (c *GRPCClient) GetFollowerIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var (
+		ids   []uuid.UUID
+		token string
+	)
+
+	for {
+		resp, err := c.client.ListFollowerIDs(ctx, &friendshipv1.ListFollowerIDsRequest{
+			UserId:    userID.String(),
+			PageSize:  friendPageSize,
+			PageToken: token,
+		})
 		if err != nil {
-			continue
+			return nil, fmt.Errorf("grpc list follower ids: %w", err)
+		}
+
+		for _, s := range resp.FollowerIds {
+			id, err := uuid.Parse(s)
+			if err != nil {
+				continue
+			}
+			ids = append(ids, id)
 		}
-		ids = append(ids, id)
+
+		if resp.NextPageToken == "" {
+			return ids, nil
+		}
+		token = resp.NextPageToken
+	}
+}
+
+func (c *GRPCClient) AreFriends(ctx context.Context, user1, user2 uuid.UUID) (bool, error) {
+	resp, err := c.client.AreFriends(ctx, &friendshipv1.AreFriendsRequest{
+		User1Id: user1.String(),
+		User2Id: user2.String(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("grpc are friends: %w", err)
 	}
-	return ids, nil
+
+	return resp.AreFriends, nil
+}
+
+func (c *GRPCClient) IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	resp, err := c.client.IsFollowing(ctx, &friendshipv1.IsFollowingRequest{
+		FollowerId: followerID.String(),
+		FolloweeId: followeeID.String(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("grpc is following: %w", err)
+	}
+
+	return resp.Following, nil
 }
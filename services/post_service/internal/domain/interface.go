@@ -28,6 +28,9 @@ type UnitOfWorkInterface interface {
 
 type FriendshipClient interface {
 	GetFriendIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	GetFollowerIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	AreFriends(ctx context.Context, user1, user2 uuid.UUID) (bool, error)
+	IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error)
 }
 
 type CommentsResponse struct {
@@ -57,7 +60,8 @@ type PostUseCaseInterface interface {
 
 type CommentUseCaseInterface interface {
 	CreateComment(ctx context.Context, postID, authorID uuid.UUID, parentID *uuid.UUID, content string) (*entity.Comment, error)
-	GetPostComments(ctx context.Context, postID uuid.UUID, limit int, cursor string) (CommentsResponse, error)
+	// GetPostComments скрывает от viewerID комментарии пользователей, с которыми у него блокировка.
+	GetPostComments(ctx context.Context, postID, viewerID uuid.UUID, limit int, cursor string) (CommentsResponse, error)
 	UpdateComment(ctx context.Context, commentID, authorID uuid.UUID, content string) (*entity.Comment, error)
 	UpvoteComment(ctx context.Context, commentID, userID uuid.UUID) (*entity.Comment, error)
 	DownvoteComment(ctx context.Context, commentID, userID uuid.UUID) (*entity.Comment, error)
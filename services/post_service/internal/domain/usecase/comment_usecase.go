@@ -91,7 +91,7 @@ func (uc *CommentUseCase) CreateComment(ctx context.Context, postID, authorID uu
 	return comment, nil
 }
 
-func (uc *CommentUseCase) GetPostComments(ctx context.Context, postID uuid.UUID, limit int, cursorToken string) (domain.CommentsResponse, error) {
+func (uc *CommentUseCase) GetPostComments(ctx context.Context, postID, viewerID uuid.UUID, limit int, cursorToken string) (domain.CommentsResponse, error) {
 	if limit <= 0 || limit > 100 {
 		limit = defaultLimit
 	}
@@ -102,7 +102,7 @@ func (uc *CommentUseCase) GetPostComments(ctx context.Context, postID uuid.UUID,
 		return domain.CommentsResponse{}, commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid cursor")
 	}
 
-	comments, err := uc.uow.CommentReader().GetCommentsByPostID(ctx, postID, limit, after, afterID)
+	comments, err := uc.uow.CommentReader().GetCommentsByPostID(ctx, postID, viewerID, limit, after, afterID)
 
 	if err != nil {
 		return domain.CommentsResponse{}, err
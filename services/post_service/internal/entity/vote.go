@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// PostVote и CommentVote — голоса пользователя; Value: 1 или -1.
+type PostVote struct {
+	PostID    uuid.UUID `json:"post_id"`
+	Value     int       `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CommentVote struct {
+	CommentID uuid.UUID `json:"comment_id"`
+	Value     int       `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+}
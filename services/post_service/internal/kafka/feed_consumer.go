@@ -22,6 +22,7 @@ type FeedConsumer struct {
 	feedRepo     repository.FeedRepository
 	postRepo     repository.PostRepositoryInterface
 	commentsRepo repository.CommentRepositoryInterface
+	blockRepo    repository.BlockRepository
 	notifier     realtime.Notifier
 	log          *slog.Logger
 }
@@ -34,6 +35,7 @@ func NewFeedConsumer(
 	feedRepo repository.FeedRepository,
 	postRepo repository.PostRepositoryInterface,
 	commentsRepo repository.CommentRepositoryInterface,
+	blockRepo repository.BlockRepository,
 	notifier realtime.Notifier,
 	log *slog.Logger,
 ) *FeedConsumer {
@@ -52,6 +54,7 @@ func NewFeedConsumer(
 		feedRepo:     feedRepo,
 		postRepo:     postRepo,
 		commentsRepo: commentsRepo,
+		blockRepo:    blockRepo,
 		notifier:     notifier,
 		log:          log.With("component", "feed_consumer"),
 	}
@@ -117,8 +120,20 @@ func (c *FeedConsumer) handle(ctx context.Context, msg kafka.Message) error {
 		return c.handleFriendshipCreated(ctx, env.Payload)
 	case "friendship.deleted":
 		return c.handleFriendshipDeleted(ctx, env.Payload)
+	case "block.created":
+		return c.handleBlockCreated(ctx, env.Payload)
+	case "block.removed":
+		return c.handleBlockRemoved(ctx, env.Payload)
+	case "follow.created":
+		return c.handleFollowCreated(ctx, env.Payload)
+	case "follow.deleted":
+		return c.handleFollowDeleted(ctx, env.Payload)
 	case "user.deleted":
 		return c.handleUserDeleted(ctx, env.Payload)
+	case "user.suspended":
+		return c.handleUserSuspended(ctx, env.Payload)
+	case "user.reinstated":
+		return c.handleUserReinstated(ctx, env.Payload)
 	default:
 		return nil
 	}
@@ -159,12 +174,21 @@ func (c *FeedConsumer) handlePostCreated(ctx context.Context, payload json.RawMe
 		return fmt.Errorf("get friend ids for author %s: %w", authorID, err)
 	}
 
-	if err = c.feedRepo.InsertBatch(ctx, postID, friendIDs, insertedAt); err != nil {
+	followerIDs, err := c.friendship.GetFollowerIDs(ctx, authorID)
+
+	if err != nil {
+		return fmt.Errorf("get follower ids for author %s: %w", authorID, err)
+	}
+
+	// друг может быть и подписчиком — пост попадает в его ленту один раз
+	recipients := mergeRecipients(friendIDs, followerIDs)
+
+	if err = c.feedRepo.InsertBatch(ctx, postID, recipients, insertedAt); err != nil {
 		return fmt.Errorf("feed insert batch post=%s: %w", postID, err)
 	}
 
 	// Нотифицируем всех получателей через Redis Streams
-	if err = c.notifier.Publish(ctx, friendIDs, realtime.FeedEvent{
+	if err = c.notifier.Publish(ctx, recipients, realtime.FeedEvent{
 		Type:   realtime.EventPostAdded,
 		PostID: p.PostID,
 	}); err != nil {
@@ -174,7 +198,8 @@ func (c *FeedConsumer) handlePostCreated(ctx context.Context, payload json.RawMe
 
 	c.log.Info("feed populated",
 		slog.String("post_id", p.PostID),
-		slog.Int("recipients", len(friendIDs)),
+		slog.Int("friends", len(friendIDs)),
+		slog.Int("recipients", len(recipients)),
 	)
 
 	return nil
@@ -305,28 +330,207 @@ func (c *FeedConsumer) handleFriendshipDeleted(ctx context.Context, payload json
 		return nil
 	}
 
-	// Убираем посты каждого из ленты другого
-	if err = c.feedRepo.DeleteByAuthor(ctx, user, friend); err != nil {
-		return fmt.Errorf("delete friend posts from user feed: %w", err)
+	// Убираем посты каждого из ленты другого, если тот не остался подписчиком
+	if err = c.removeFriendPosts(ctx, user, friend); err != nil {
+		return err
 	}
 
-	if err = c.feedRepo.DeleteByAuthor(ctx, friend, user); err != nil {
-		return fmt.Errorf("delete user posts from friend feed: %w", err)
+	return c.removeFriendPosts(ctx, friend, user)
+}
+
+// removeFriendPosts убирает посты бывшего друга из ленты recipient и сообщает фронту,
+// чтобы он удалил их из DOM. Подписчик продолжает видеть посты автора.
+func (c *FeedConsumer) removeFriendPosts(ctx context.Context, recipientID, friendID uuid.UUID) error {
+	following, err := c.friendship.IsFollowing(ctx, recipientID, friendID)
+
+	if err != nil {
+		return fmt.Errorf("check follow %s->%s: %w", recipientID, friendID, err)
 	}
 
-	// Нотифицируем обоих - фронт удаляет посты из DOM
-	if err = c.notifier.Publish(ctx, []uuid.UUID{user}, realtime.FeedEvent{
+	if following {
+		return nil
+	}
+
+	if err = c.feedRepo.DeleteByAuthor(ctx, recipientID, friendID); err != nil {
+		return fmt.Errorf("delete friend posts from feed: %w", err)
+	}
+
+	if err = c.notifier.Publish(ctx, []uuid.UUID{recipientID}, realtime.FeedEvent{
 		Type:     realtime.EventFriendRemoved,
-		FriendID: p.FriendID,
+		FriendID: friendID.String(),
 	}); err != nil {
-		c.log.Warn("notify friend_removed (user) failed", slog.Any("error", err))
+		c.log.Warn("notify friend_removed failed", slog.Any("error", err))
 	}
 
-	if err = c.notifier.Publish(ctx, []uuid.UUID{friend}, realtime.FeedEvent{
-		Type:     realtime.EventFriendRemoved,
-		FriendID: p.UserID,
+	return nil
+}
+
+type followPayload struct {
+	FollowerID string `json:"follower_id"`
+	FolloweeID string `json:"followee_id"`
+}
+
+func (p followPayload) parse() (uuid.UUID, uuid.UUID, bool) {
+	follower, err := uuid.Parse(p.FollowerID)
+
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	followee, err := uuid.Parse(p.FolloweeID)
+
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return follower, followee, true
+}
+
+// handleFollowCreated добавляет в ленту подписчика последние посты автора, как при новой дружбе.
+func (c *FeedConsumer) handleFollowCreated(ctx context.Context, payload json.RawMessage) error {
+	var p followPayload
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		c.log.Warn("invalid follow.created payload, skipping")
+
+		return nil
+	}
+
+	follower, followee, ok := p.parse()
+
+	if !ok {
+		return nil
+	}
+
+	return c.backfillFeed(ctx, follower, followee)
+}
+
+// handleFollowDeleted убирает посты автора из ленты бывшего подписчика, если они не друзья.
+func (c *FeedConsumer) handleFollowDeleted(ctx context.Context, payload json.RawMessage) error {
+	var p followPayload
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		c.log.Warn("invalid follow.deleted payload, skipping")
+
+		return nil
+	}
+
+	follower, followee, ok := p.parse()
+
+	if !ok {
+		return nil
+	}
+
+	areFriends, err := c.friendship.AreFriends(ctx, follower, followee)
+
+	if err != nil {
+		return fmt.Errorf("check friendship %s-%s: %w", follower, followee, err)
+	}
+
+	if areFriends {
+		return nil
+	}
+
+	if err = c.feedRepo.DeleteByAuthor(ctx, follower, followee); err != nil {
+		return fmt.Errorf("delete unfollowed author posts from feed: %w", err)
+	}
+
+	if err = c.notifier.Publish(ctx, []uuid.UUID{follower}, realtime.FeedEvent{
+		Type:     realtime.EventAuthorHidden,
+		AuthorID: p.FolloweeID,
+	}); err != nil {
+		c.log.Warn("notify author_hidden (unfollow) failed", slog.Any("error", err))
+	}
+
+	return nil
+}
+
+type blockPayload struct {
+	UserID   string `json:"user_id"`
+	TargetID string `json:"target_id"`
+}
+
+func (p blockPayload) parse() (uuid.UUID, uuid.UUID, bool) {
+	user, err := uuid.Parse(p.UserID)
+
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	target, err := uuid.Parse(p.TargetID)
+
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return user, target, true
+}
+
+// handleBlockCreated убирает посты каждого из ленты другого. friendship.deleted приходит
+// только если они были друзьями, а посты могли остаться в ленте после прошлой дружбы.
+func (c *FeedConsumer) handleBlockCreated(ctx context.Context, payload json.RawMessage) error {
+	var p blockPayload
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		c.log.Warn("invalid block.created payload, skipping")
+
+		return nil
+	}
+
+	user, target, ok := p.parse()
+
+	if !ok {
+		return nil
+	}
+
+	if err := c.blockRepo.Add(ctx, user, target); err != nil {
+		return fmt.Errorf("add block %s->%s: %w", user, target, err)
+	}
+
+	if err := c.feedRepo.DeleteByAuthor(ctx, user, target); err != nil {
+		return fmt.Errorf("delete blocked user posts from feed: %w", err)
+	}
+
+	if err := c.feedRepo.DeleteByAuthor(ctx, target, user); err != nil {
+		return fmt.Errorf("delete blocker posts from blocked user feed: %w", err)
+	}
+
+	if err := c.notifier.Publish(ctx, []uuid.UUID{user}, realtime.FeedEvent{
+		Type:     realtime.EventAuthorHidden,
+		AuthorID: p.TargetID,
 	}); err != nil {
-		c.log.Warn("notify friend_removed (friend) failed", slog.Any("error", err))
+		c.log.Warn("notify author_hidden (blocker) failed", slog.Any("error", err))
+	}
+
+	if err := c.notifier.Publish(ctx, []uuid.UUID{target}, realtime.FeedEvent{
+		Type:     realtime.EventAuthorHidden,
+		AuthorID: p.UserID,
+	}); err != nil {
+		c.log.Warn("notify author_hidden (blocked) failed", slog.Any("error", err))
+	}
+
+	return nil
+}
+
+// handleBlockRemoved возвращает видимость комментариев; ленты не восстанавливаются —
+// после разблокировки пользователи больше не друзья.
+func (c *FeedConsumer) handleBlockRemoved(ctx context.Context, payload json.RawMessage) error {
+	var p blockPayload
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		c.log.Warn("invalid block.removed payload, skipping")
+
+		return nil
+	}
+
+	user, target, ok := p.parse()
+
+	if !ok {
+		return nil
+	}
+
+	if err := c.blockRepo.Remove(ctx, user, target); err != nil {
+		return fmt.Errorf("remove block %s->%s: %w", user, target, err)
 	}
 
 	return nil
@@ -362,6 +566,85 @@ func (c *FeedConsumer) handleUserDeleted(ctx context.Context, payload json.RawMe
 	return nil
 }
 
+// handleUserSuspended скрывает посты заблокированного пользователя из лент до разблокировки.
+func (c *FeedConsumer) handleUserSuspended(ctx context.Context, payload json.RawMessage) error {
+	var p struct {
+		UserID string `json:"user_id"`
+	}
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		c.log.Warn("invalid user.suspended payload, skipping")
+
+		return nil
+	}
+
+	userID, err := uuid.Parse(p.UserID)
+
+	if err != nil {
+		return nil
+	}
+
+	if err = c.feedRepo.HideAuthor(ctx, userID); err != nil {
+		return fmt.Errorf("hide author %s: %w", userID, err)
+	}
+
+	recipients, err := c.feedRepo.FindAuthorRecipients(ctx, userID)
+
+	if err != nil {
+		c.log.Warn("find recipients of suspended author failed", slog.Any("error", err))
+
+		return nil
+	}
+
+	// Фронт убирает посты автора из уже загруженной ленты
+	if err = c.notifier.Publish(ctx, recipients, realtime.FeedEvent{
+		Type:     realtime.EventAuthorHidden,
+		AuthorID: p.UserID,
+	}); err != nil {
+		c.log.Warn("notify author_hidden failed", slog.Any("error", err))
+	}
+
+	return nil
+}
+
+func (c *FeedConsumer) handleUserReinstated(ctx context.Context, payload json.RawMessage) error {
+	var p struct {
+		UserID string `json:"user_id"`
+	}
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		c.log.Warn("invalid user.reinstated payload, skipping")
+
+		return nil
+	}
+
+	userID, err := uuid.Parse(p.UserID)
+
+	if err != nil {
+		return nil
+	}
+
+	if err = c.feedRepo.UnhideAuthor(ctx, userID); err != nil {
+		return fmt.Errorf("unhide author %s: %w", userID, err)
+	}
+
+	recipients, err := c.feedRepo.FindAuthorRecipients(ctx, userID)
+
+	if err != nil {
+		c.log.Warn("find recipients of reinstated author failed", slog.Any("error", err))
+
+		return nil
+	}
+
+	if err = c.notifier.Publish(ctx, recipients, realtime.FeedEvent{
+		Type: realtime.EventBulkNewPosts,
+	}); err != nil {
+		c.log.Warn("notify bulk_new_posts (reinstated) failed", slog.Any("error", err))
+	}
+
+	return nil
+}
+
 func (c *FeedConsumer) backfillFeed(ctx context.Context, recipientID, authorID uuid.UUID) error {
 	posts, err := c.postRepo.GetByAuthor(ctx, authorID, 50, time.Now().Add(time.Hour), uuid.Max)
 
@@ -385,3 +668,22 @@ func (c *FeedConsumer) backfillFeed(ctx context.Context, recipientID, authorID u
 
 	return nil
 }
+
+// mergeRecipients объединяет списки получателей без повторов, сохраняя порядок.
+func mergeRecipients(lists ...[]uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{})
+	var merged []uuid.UUID
+
+	for _, list := range lists {
+		for _, id := range list {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+
+			seen[id] = struct{}{}
+			merged = append(merged, id)
+		}
+	}
+
+	return merged
+}
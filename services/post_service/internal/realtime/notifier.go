@@ -14,6 +14,7 @@ const (
 	EventPostDeleted   EventType = "post_deleted"
 	EventFriendRemoved EventType = "friend_removed"
 	EventBulkNewPosts  EventType = "bulk_new_posts"
+	EventAuthorHidden  EventType = "author_hidden"
 )
 
 type FeedEvent struct {
@@ -22,6 +23,7 @@ type FeedEvent struct {
 	FriendID string    `json:"friend_id,omitempty"`
 	Version  int       `json:"version,omitempty"`
 	PostIDs  []string  `json:"post_ids,omitempty"`
+	AuthorID string    `json:"author_id,omitempty"`
 }
 
 type Notifier interface {
@@ -24,7 +24,8 @@ type CommentRepositoryInterface interface {
 	CreateComment(ctx context.Context, comment *entity.Comment) error
 	FindCommentByID(ctx context.Context, commentID uuid.UUID) (*entity.Comment, error)
 	UpdateComment(ctx context.Context, comment *entity.Comment) error
-	GetCommentsByPostID(ctx context.Context, postID uuid.UUID, limit int, after time.Time, afterID uuid.UUID) ([]*entity.Comment, error)
+	// GetCommentsByPostID не возвращает комментарии авторов, заблокированных viewerID или заблокировавших его.
+	GetCommentsByPostID(ctx context.Context, postID, viewerID uuid.UUID, limit int, after time.Time, afterID uuid.UUID) ([]*entity.Comment, error)
 	SetCommentVote(ctx context.Context, commentID, userID uuid.UUID, value int) (*entity.Comment, error)
 	RemoveCommentVote(ctx context.Context, commentID, userID uuid.UUID) (*entity.Comment, error)
 	DeleteComment(ctx context.Context, commentID, authorID uuid.UUID) error
@@ -39,4 +40,20 @@ type FeedRepository interface {
 	DeleteByAuthor(ctx context.Context, recipientID, authorID uuid.UUID) error
 	DeleteUserFeed(ctx context.Context, userID uuid.UUID) error
 	DeleteByAuthorFromAllFeeds(ctx context.Context, authorID uuid.UUID) error
+	FindAuthorRecipients(ctx context.Context, authorID uuid.UUID) ([]uuid.UUID, error)
+	// HideAuthor скрывает посты автора из всех лент, не удаляя их; UnhideAuthor возвращает.
+	HideAuthor(ctx context.Context, authorID uuid.UUID) error
+	UnhideAuthor(ctx context.Context, authorID uuid.UUID) error
+}
+
+type BlockRepository interface {
+	Add(ctx context.Context, userID, targetID uuid.UUID) error
+	Remove(ctx context.Context, userID, targetID uuid.UUID) error
+}
+
+type ExportRepositoryInterface interface {
+	Posts(ctx context.Context, userID uuid.UUID) ([]*entity.Post, error)
+	Comments(ctx context.Context, userID uuid.UUID) ([]*entity.Comment, error)
+	PostVotes(ctx context.Context, userID uuid.UUID) ([]entity.PostVote, error)
+	CommentVotes(ctx context.Context, userID uuid.UUID) ([]entity.CommentVote, error)
 }
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/database"
+)
+
+// BlockRepository хранит локальную копию блокировок; события могут прийти повторно,
+// поэтому обе операции идемпотентны.
+type BlockRepository struct {
+	exec database.Executor
+}
+
+func NewBlockRepository(exec database.Executor) *BlockRepository {
+	return &BlockRepository{exec: exec}
+}
+
+func (r *BlockRepository) Add(ctx context.Context, userID, targetID uuid.UUID) error {
+	_, err := r.exec.ExecContext(ctx,
+		`INSERT INTO user_blocks (user_id, target_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		userID, targetID)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "add block")
+	}
+
+	return nil
+}
+
+func (r *BlockRepository) Remove(ctx context.Context, userID, targetID uuid.UUID) error {
+	_, err := r.exec.ExecContext(ctx,
+		`DELETE FROM user_blocks WHERE user_id = $1 AND target_id = $2`,
+		userID, targetID)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "remove block")
+	}
+
+	return nil
+}
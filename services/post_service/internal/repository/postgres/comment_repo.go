@@ -52,13 +52,15 @@ func (r *CommentRepository) CreateComment(ctx context.Context, comment *entity.C
 	return nil
 }
 
-func (r *CommentRepository) GetCommentsByPostID(ctx context.Context, postID uuid.UUID, limit int, after time.Time, afterID uuid.UUID) ([]*entity.Comment, error) {
+func (r *CommentRepository) GetCommentsByPostID(ctx context.Context, postID, viewerID uuid.UUID, limit int, after time.Time, afterID uuid.UUID) ([]*entity.Comment, error) {
 	query := `SELECT c.id, c.post_id, c.author_id, c.parent_id, c.reply_to_user_id, c.content,
 		c.upvotes_count, c.downvotes_count, c.upvotes_count-c.downvotes_count AS rating,
 		c.created_at, c.updated_at FROM comments c JOIN posts p ON p.id = c.post_id
 		WHERE c.post_id=$1 AND p.deleted_at IS NULL AND (c.created_at, c.id) > ($2, $3)
+		AND NOT EXISTS (SELECT 1 FROM user_blocks b
+			WHERE (b.user_id=$5 AND b.target_id=c.author_id) OR (b.user_id=c.author_id AND b.target_id=$5))
 		ORDER BY c.created_at ASC, c.id ASC LIMIT $4`
-	rows, err := r.exec.QueryContext(ctx, query, postID, after, afterID, limit)
+	rows, err := r.exec.QueryContext(ctx, query, postID, after, afterID, limit, viewerID)
 	if err != nil {
 		return nil, commonapperr.MapPostgresError(err, "get comments by post id")
 	}
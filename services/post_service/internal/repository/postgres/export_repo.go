@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/database"
+	"github.com/rockkley/pushpost/services/post_service/internal/entity"
+)
+
+// ExportRepository читает всё, что хранится о пользователе, для выгрузки данных.
+// В выгрузку попадают и удалённые посты: пока строка есть в базе, это данные пользователя.
+type ExportRepository struct{ exec database.Executor }
+
+func NewExportRepository(exec database.Executor) *ExportRepository {
+	return &ExportRepository{exec: exec}
+}
+
+func (r *ExportRepository) Posts(ctx context.Context, userID uuid.UUID) ([]*entity.Post, error) {
+	const query = `
+		SELECT id, author_id, content, version, likes_count, dislikes_count,
+		       likes_count - dislikes_count AS rating, created_at, updated_at, deleted_at
+		FROM   posts
+		WHERE  author_id = $1
+		ORDER  BY created_at`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "export posts")
+	}
+
+	defer rows.Close()
+
+	var posts []*entity.Post
+
+	for rows.Next() {
+		var p entity.Post
+
+		if err = rows.Scan(
+			&p.ID, &p.AuthorID, &p.Content, &p.Version, &p.LikesCount, &p.DislikesCount,
+			&p.Rating, &p.CreatedAt, &p.UpdatedAt, &p.DeletedAt,
+		); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan exported post")
+		}
+
+		posts = append(posts, &p)
+	}
+
+	return posts, rows.Err()
+}
+
+func (r *ExportRepository) Comments(ctx context.Context, userID uuid.UUID) ([]*entity.Comment, error) {
+	const query = `
+		SELECT id, post_id, author_id, parent_id, reply_to_user_id, content,
+		       upvotes_count, downvotes_count, upvotes_count - downvotes_count AS rating,
+		       created_at, updated_at
+		FROM   comments
+		WHERE  author_id = $1
+		ORDER  BY created_at`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "export comments")
+	}
+
+	defer rows.Close()
+
+	var comments []*entity.Comment
+
+	for rows.Next() {
+		var c entity.Comment
+
+		if err = rows.Scan(
+			&c.ID, &c.PostID, &c.AuthorID, &c.ParentID, &c.ReplyToUserID, &c.Content,
+			&c.UpvotesCount, &c.DownvotesCount, &c.Rating, &c.CreatedAt, &c.UpdatedAt,
+		); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan exported comment")
+		}
+
+		comments = append(comments, &c)
+	}
+
+	return comments, rows.Err()
+}
+
+func (r *ExportRepository) PostVotes(ctx context.Context, userID uuid.UUID) ([]entity.PostVote, error) {
+	const query = `
+		SELECT post_id, value, created_at, updated_at
+		FROM   post_votes
+		WHERE  user_id = $1
+		ORDER  BY created_at`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "export post votes")
+	}
+
+	defer rows.Close()
+
+	var votes []entity.PostVote
+
+	for rows.Next() {
+		var v entity.PostVote
+
+		if err = rows.Scan(&v.PostID, &v.Value, &v.CreatedAt, &v.UpdatedAt); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan exported post vote")
+		}
+
+		votes = append(votes, v)
+	}
+
+	return votes, rows.Err()
+}
+
+func (r *ExportRepository) CommentVotes(ctx context.Context, userID uuid.UUID) ([]entity.CommentVote, error) {
+	const query = `
+		SELECT comment_id, value, created_at
+		FROM   comment_votes
+		WHERE  user_id = $1
+		ORDER  BY created_at`
+
+	rows, err := r.exec.QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "export comment votes")
+	}
+
+	defer rows.Close()
+
+	var votes []entity.CommentVote
+
+	for rows.Next() {
+		var v entity.CommentVote
+
+		if err = rows.Scan(&v.CommentID, &v.Value, &v.CreatedAt); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan exported comment vote")
+		}
+
+		votes = append(votes, v)
+	}
+
+	return votes, rows.Err()
+}
@@ -14,6 +14,10 @@ import (
 	"github.com/rockkley/pushpost/services/post_service/internal/entity"
 )
 
+// maxFeedInsertBatch — получателей в одном INSERT; Postgres принимает не больше 65535 параметров,
+// а у автора с подписчиками получателей может быть больше.
+const maxFeedInsertBatch = 10000
+
 type FeedRepository struct {
 	exec database.Executor
 }
@@ -31,6 +35,15 @@ func (r *FeedRepository) InsertBatch(
 	if len(userIDs) == 0 {
 		return nil
 	}
+
+	for len(userIDs) > maxFeedInsertBatch {
+		if err := r.InsertBatch(ctx, postID, userIDs[:maxFeedInsertBatch], insertedAt); err != nil {
+			return err
+		}
+
+		userIDs = userIDs[maxFeedInsertBatch:]
+	}
+
 	slog.Info("inserting feed", slog.Int("users", len(userIDs)))
 	// Один INSERT со всеми получателями.
 	// Параметры: $1..$N - user_id, $(N+1) - post_id, $(N+2) - inserted_at
@@ -73,6 +86,7 @@ func (r *FeedRepository) GetFeed(
 		JOIN posts p ON p.id = f.post_id
 		WHERE f.user_id = $1
 		  AND p.deleted_at IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM hidden_authors h WHERE h.author_id = p.author_id)
 		  AND (f.inserted_at, f.post_id) < ($2, $3)
 		ORDER BY f.inserted_at DESC, f.post_id DESC
 		LIMIT $4`
@@ -105,6 +119,7 @@ func (r *FeedRepository) GetFeedSince(
 				JOIN posts p ON p.id = f.post_id
 				WHERE f.user_id = $1
 				  AND p.deleted_at IS NULL
+				  AND NOT EXISTS (SELECT 1 FROM hidden_authors h WHERE h.author_id = p.author_id)
 				  AND (f.inserted_at, f.post_id) > ($2, $3)
 				ORDER BY f.inserted_at , f.post_id
 				LIMIT $4`
@@ -202,6 +217,56 @@ func (r *FeedRepository) DeleteByAuthorFromAllFeeds(ctx context.Context, authorI
 	return nil
 }
 
+// FindAuthorRecipients возвращает пользователей, в чьих лентах есть посты автора.
+func (r *FeedRepository) FindAuthorRecipients(ctx context.Context, authorID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT DISTINCT f.user_id
+		FROM feeds f
+		JOIN posts p ON p.id = f.post_id
+		WHERE p.author_id = $1`
+
+	rows, err := r.exec.QueryContext(ctx, query, authorID)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "find author feed recipients")
+	}
+
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (r *FeedRepository) HideAuthor(ctx context.Context, authorID uuid.UUID) error {
+	_, err := r.exec.ExecContext(ctx,
+		`INSERT INTO hidden_authors (author_id) VALUES ($1) ON CONFLICT DO NOTHING`, authorID)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "hide author")
+	}
+
+	return nil
+}
+
+func (r *FeedRepository) UnhideAuthor(ctx context.Context, authorID uuid.UUID) error {
+	_, err := r.exec.ExecContext(ctx, `DELETE FROM hidden_authors WHERE author_id = $1`, authorID)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "unhide author")
+	}
+
+	return nil
+}
+
 func scanFeedPosts(rows *sql.Rows) ([]*entity.Post, error) {
 	var result []*entity.Post
 
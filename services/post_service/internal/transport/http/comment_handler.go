@@ -53,6 +53,10 @@ func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) e
 }
 
 func (h *CommentHandler) GetPostComments(w http.ResponseWriter, r *http.Request) error {
+	viewerID, ok := commonmiddleware.UserIDFromContext(r.Context())
+	if !ok {
+		return commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "missing user id")
+	}
 	postID, err := uuid.Parse(chi.URLParam(r, "postID"))
 	if err != nil {
 		return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid post id")
@@ -61,7 +65,7 @@ func (h *CommentHandler) GetPostComments(w http.ResponseWriter, r *http.Request)
 	if err != nil {
 		return err
 	}
-	resp, err := h.uc.GetPostComments(r.Context(), postID, limit, r.URL.Query().Get("cursor"))
+	resp, err := h.uc.GetPostComments(r.Context(), postID, viewerID, limit, r.URL.Query().Get("cursor"))
 	if err != nil {
 		return err
 	}
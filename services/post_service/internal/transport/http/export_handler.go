@@ -0,0 +1,62 @@
+package http
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/export"
+	"github.com/rockkley/pushpost/services/post_service/internal/repository"
+)
+
+type ExportHandler struct {
+	repo repository.ExportRepositoryInterface
+}
+
+func NewExportHandler(repo repository.ExportRepositoryInterface) *ExportHandler {
+	return &ExportHandler{repo: repo}
+}
+
+// Collect отдаёт посты, комментарии и голоса пользователя для выгрузки персональных данных.
+func (h *ExportHandler) Collect(ctx context.Context, userID uuid.UUID) (*export.Section, error) {
+	posts, err := h.repo.Posts(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := h.repo.Comments(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	postVotes, err := h.repo.PostVotes(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	commentVotes, err := h.repo.CommentVotes(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	section := &export.Section{}
+
+	for _, f := range []struct {
+		name string
+		data any
+	}{
+		{"posts.json", posts},
+		{"comments.json", comments},
+		{"post_votes.json", postVotes},
+		{"comment_votes.json", commentVotes},
+	} {
+		if err = section.AddJSON(f.name, f.data); err != nil {
+			return nil, err
+		}
+	}
+
+	return section, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/rockkley/pushpost/services/common_service/export"
 	handlerhttp "github.com/rockkley/pushpost/services/common_service/http"
 	"github.com/rockkley/pushpost/services/common_service/httplog"
 	"github.com/rockkley/pushpost/services/common_service/metrics"
@@ -13,7 +14,13 @@ import (
 	myHTTP "github.com/rockkley/pushpost/services/post_service/internal/transport/http"
 )
 
-func NewRouter(log *slog.Logger, h *myHTTP.PostHandler, ch *myHTTP.CommentHandler, sseHandler *myHTTP.FeedSSEHandler) *chi.Mux {
+func NewRouter(
+	log *slog.Logger,
+	h *myHTTP.PostHandler,
+	ch *myHTTP.CommentHandler,
+	sseHandler *myHTTP.FeedSSEHandler,
+	exportHandler *myHTTP.ExportHandler,
+) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(chimiddleware.RequestID)
@@ -28,6 +35,9 @@ func NewRouter(log *slog.Logger, h *myHTTP.PostHandler, ch *myHTTP.CommentHandle
 		w.Write([]byte(`{"status":"ok"}`))
 	})
 
+	// internal API, not exposed through the gateway
+	r.Get("/internal/export/{userID}", handlerhttp.MakeHandler(export.Handler(exportHandler.Collect)))
+
 	r.Group(func(r chi.Router) {
 		r.Use(commonmiddleware.RequireUserID)
 
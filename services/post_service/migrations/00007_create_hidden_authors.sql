@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- авторы, чьи посты скрыты из лент (заблокированные пользователи); строки в feeds
+-- остаются, чтобы после разблокировки посты вернулись на свои места
+CREATE TABLE hidden_authors
+(
+    author_id UUID PRIMARY KEY,
+    hidden_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS hidden_authors;
+-- +goose StatementEnd
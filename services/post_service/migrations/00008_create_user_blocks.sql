@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- проекция блокировок из friendship_service (block.created / block.removed):
+-- комментарии между заблокированными пользователями не показываются друг другу
+CREATE TABLE user_blocks
+(
+    user_id    UUID        NOT NULL,
+    target_id  UUID        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, target_id)
+);
+
+CREATE INDEX idx_user_blocks_target ON user_blocks (target_id, user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_blocks;
+-- +goose StatementEnd
@@ -4,7 +4,7 @@
 // 	protoc        (unknown)
 // source: internal/transport/grpc/profile.proto
 
-package v1
+package profilev1
 
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
@@ -197,6 +197,94 @@ func (x *GetProfileByUsernameResponse) GetIsPrivate() bool {
 	return false
 }
 
+type GetProfilePrivacyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfilePrivacyRequest) Reset() {
+	*x = GetProfilePrivacyRequest{}
+	mi := &file_internal_transport_grpc_profile_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfilePrivacyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfilePrivacyRequest) ProtoMessage() {}
+
+func (x *GetProfilePrivacyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_profile_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfilePrivacyRequest.ProtoReflect.Descriptor instead.
+func (*GetProfilePrivacyRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_profile_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetProfilePrivacyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetProfilePrivacyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsPrivate     bool                   `protobuf:"varint,1,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfilePrivacyResponse) Reset() {
+	*x = GetProfilePrivacyResponse{}
+	mi := &file_internal_transport_grpc_profile_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfilePrivacyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfilePrivacyResponse) ProtoMessage() {}
+
+func (x *GetProfilePrivacyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_profile_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfilePrivacyResponse.ProtoReflect.Descriptor instead.
+func (*GetProfilePrivacyResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_profile_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetProfilePrivacyResponse) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
 var File_internal_transport_grpc_profile_proto protoreflect.FileDescriptor
 
 const file_internal_transport_grpc_profile_proto_rawDesc = "" +
@@ -224,9 +312,15 @@ const file_internal_transport_grpc_profile_proto_rawDesc = "" +
 	"\vgithub_link\x18\v \x01(\tR\n" +
 	"githubLink\x12\x1d\n" +
 	"\n" +
-	"is_private\x18\f \x01(\bR\tisPrivate2{\n" +
+	"is_private\x18\f \x01(\bR\tisPrivate\"3\n" +
+	"\x18GetProfilePrivacyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\":\n" +
+	"\x19GetProfilePrivacyResponse\x12\x1d\n" +
+	"\n" +
+	"is_private\x18\x01 \x01(\bR\tisPrivate2\xdd\x01\n" +
 	"\x0eProfileService\x12i\n" +
-	"\x14GetProfileByUsername\x12'.profile.v1.GetProfileByUsernameRequest\x1a(.profile.v1.GetProfileByUsernameResponseBPZNgithub.com/rockkley/pushpost/services/profile_service/gen/profile/v1;profilev1b\x06proto3"
+	"\x14GetProfileByUsername\x12'.profile.v1.GetProfileByUsernameRequest\x1a(.profile.v1.GetProfileByUsernameResponse\x12`\n" +
+	"\x11GetProfilePrivacy\x12$.profile.v1.GetProfilePrivacyRequest\x1a%.profile.v1.GetProfilePrivacyResponseBPZNgithub.com/rockkley/pushpost/services/profile_service/gen/profile/v1;profilev1b\x06proto3"
 
 var (
 	file_internal_transport_grpc_profile_proto_rawDescOnce sync.Once
@@ -240,16 +334,20 @@ func file_internal_transport_grpc_profile_proto_rawDescGZIP() []byte {
 	return file_internal_transport_grpc_profile_proto_rawDescData
 }
 
-var file_internal_transport_grpc_profile_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_internal_transport_grpc_profile_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_internal_transport_grpc_profile_proto_goTypes = []any{
 	(*GetProfileByUsernameRequest)(nil),  // 0: profile.v1.GetProfileByUsernameRequest
 	(*GetProfileByUsernameResponse)(nil), // 1: profile.v1.GetProfileByUsernameResponse
+	(*GetProfilePrivacyRequest)(nil),     // 2: profile.v1.GetProfilePrivacyRequest
+	(*GetProfilePrivacyResponse)(nil),    // 3: profile.v1.GetProfilePrivacyResponse
 }
 var file_internal_transport_grpc_profile_proto_depIdxs = []int32{
 	0, // 0: profile.v1.ProfileService.GetProfileByUsername:input_type -> profile.v1.GetProfileByUsernameRequest
-	1, // 1: profile.v1.ProfileService.GetProfileByUsername:output_type -> profile.v1.GetProfileByUsernameResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
+	2, // 1: profile.v1.ProfileService.GetProfilePrivacy:input_type -> profile.v1.GetProfilePrivacyRequest
+	1, // 2: profile.v1.ProfileService.GetProfileByUsername:output_type -> profile.v1.GetProfileByUsernameResponse
+	3, // 3: profile.v1.ProfileService.GetProfilePrivacy:output_type -> profile.v1.GetProfilePrivacyResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -266,7 +364,7 @@ func file_internal_transport_grpc_profile_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_transport_grpc_profile_proto_rawDesc), len(file_internal_transport_grpc_profile_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -4,7 +4,7 @@
 // - protoc             (unknown)
 // source: internal/transport/grpc/profile.proto
 
-package v1
+package profilev1
 
 import (
 	context "context"
@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	ProfileService_GetProfileByUsername_FullMethodName = "/profile.v1.ProfileService/GetProfileByUsername"
+	ProfileService_GetProfilePrivacy_FullMethodName    = "/profile.v1.ProfileService/GetProfilePrivacy"
 )
 
 // ProfileServiceClient is the client API for ProfileService service.
@@ -27,6 +28,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ProfileServiceClient interface {
 	GetProfileByUsername(ctx context.Context, in *GetProfileByUsernameRequest, opts ...grpc.CallOption) (*GetProfileByUsernameResponse, error)
+	GetProfilePrivacy(ctx context.Context, in *GetProfilePrivacyRequest, opts ...grpc.CallOption) (*GetProfilePrivacyResponse, error)
 }
 
 type profileServiceClient struct {
@@ -47,11 +49,22 @@ func (c *profileServiceClient) GetProfileByUsername(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *profileServiceClient) GetProfilePrivacy(ctx context.Context, in *GetProfilePrivacyRequest, opts ...grpc.CallOption) (*GetProfilePrivacyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProfilePrivacyResponse)
+	err := c.cc.Invoke(ctx, ProfileService_GetProfilePrivacy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProfileServiceServer is the server API for ProfileService service.
 // All implementations must embed UnimplementedProfileServiceServer
 // for forward compatibility.
 type ProfileServiceServer interface {
 	GetProfileByUsername(context.Context, *GetProfileByUsernameRequest) (*GetProfileByUsernameResponse, error)
+	GetProfilePrivacy(context.Context, *GetProfilePrivacyRequest) (*GetProfilePrivacyResponse, error)
 	mustEmbedUnimplementedProfileServiceServer()
 }
 
@@ -65,6 +78,9 @@ type UnimplementedProfileServiceServer struct{}
 func (UnimplementedProfileServiceServer) GetProfileByUsername(context.Context, *GetProfileByUsernameRequest) (*GetProfileByUsernameResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetProfileByUsername not implemented")
 }
+func (UnimplementedProfileServiceServer) GetProfilePrivacy(context.Context, *GetProfilePrivacyRequest) (*GetProfilePrivacyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProfilePrivacy not implemented")
+}
 func (UnimplementedProfileServiceServer) mustEmbedUnimplementedProfileServiceServer() {}
 func (UnimplementedProfileServiceServer) testEmbeddedByValue()                        {}
 
@@ -104,6 +120,24 @@ func _ProfileService_GetProfileByUsername_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_GetProfilePrivacy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProfilePrivacyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetProfilePrivacy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_GetProfilePrivacy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetProfilePrivacy(ctx, req.(*GetProfilePrivacyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProfileService_ServiceDesc is the grpc.ServiceDesc for ProfileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +149,10 @@ var ProfileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProfileByUsername",
 			Handler:    _ProfileService_GetProfileByUsername_Handler,
 		},
+		{
+			MethodName: "GetProfilePrivacy",
+			Handler:    _ProfileService_GetProfilePrivacy_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/transport/grpc/profile.proto",
@@ -13,6 +13,7 @@ import (
 	"github.com/rockkley/pushpost/services/common_service/database"
 	profilev1 "github.com/rockkley/pushpost/services/profile_service/gen/profile/v1"
 	"github.com/rockkley/pushpost/services/profile_service/internal/config"
+	events "github.com/rockkley/pushpost/services/profile_service/internal/domain/events"
 	"github.com/rockkley/pushpost/services/profile_service/internal/domain/usecase"
 	"github.com/rockkley/pushpost/services/profile_service/internal/kafka"
 	repopg "github.com/rockkley/pushpost/services/profile_service/internal/repository/postgres"
@@ -65,10 +66,11 @@ func New(cfg *config.Config, log *slog.Logger) (*App, error) {
 	uc := usecase.NewProfileUseCase(profileRepo, *minioStorage, minioStorage.KeyFromURL)
 
 	userCreatedProcessor := kafka.NewUserCreatedProcessor(uc, log)
-	router := kafka.NewRouter(userCreatedProcessor, log)
+	usernameChangedProcessor := kafka.NewUsernameChangedProcessor(uc, log)
+	router := kafka.NewRouter(userCreatedProcessor, usernameChangedProcessor, log)
 	consumer := kafka.NewConsumer(
 		cfg.Kafka.Brokers(),
-		cfg.Kafka.Topic,
+		[]string{cfg.Kafka.Topic, events.EventUserUsernameChanged},
 		cfg.Kafka.GroupID,
 		router,
 		log,
@@ -78,7 +80,8 @@ func New(cfg *config.Config, log *slog.Logger) (*App, error) {
 	profilev1.RegisterProfileServiceServer(grpcSrv, grpctransport.NewProfileServer(uc, log))
 
 	httpHandler := profilehttp.NewProfileHandler(uc)
-	httpRouter := httptransport.NewRouter(log, httpHandler)
+	exportHandler := profilehttp.NewExportHandler(profileRepo, minioStorage, minioStorage.KeyFromURL)
+	httpRouter := httptransport.NewRouter(log, httpHandler, exportHandler)
 	httpSrv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.HTTP.Port),
 		Handler:      httpRouter,
@@ -2,8 +2,10 @@ package domain
 
 import "encoding/json"
 
+// Имя топика совпадает с типом события.
 const (
-	EventUserCreated = "user.created"
+	EventUserCreated         = "user.created"
+	EventUserUsernameChanged = "user.username_changed"
 )
 
 type Envelope struct {
@@ -16,3 +18,9 @@ type UserCreatedEvent struct {
 	Email    string `json:"email"`
 	Username string `json:"username"`
 }
+
+type UsernameChangedEvent struct {
+	UserID      string `json:"user_id"`
+	OldUsername string `json:"old_username"`
+	NewUsername string `json:"new_username"`
+}
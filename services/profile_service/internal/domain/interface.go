@@ -13,8 +13,10 @@ var ErrProfileNotFound = errors.New("profile not found")
 
 type ProfileUseCaseInterface interface {
 	GetByUsername(ctx context.Context, username string) (*entity.Profile, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.Profile, error)
 	CreateProfile(ctx context.Context, profile *entity.Profile) error
 	UpdateProfile(ctx context.Context, profile *entity.Profile) error
+	ChangeUsername(ctx context.Context, userID uuid.UUID, username string) error
 	UploadAvatar(ctx context.Context, userID uuid.UUID, r io.Reader, size int64, contentType string) (string, string, error)
 	Search(ctx context.Context, filter *dto.SearchProfilesQuery) ([]*entity.Profile, error)
 }
@@ -48,6 +48,10 @@ func (u *ProfileUseCase) GetByUsername(ctx context.Context, username string) (*e
 	return u.profileRepo.FindByUsername(ctx, username)
 }
 
+func (u *ProfileUseCase) GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.Profile, error) {
+	return u.profileRepo.FindByUserID(ctx, userID)
+}
+
 func (u *ProfileUseCase) CreateProfile(ctx context.Context, profile *entity.Profile) error {
 	return u.profileRepo.Create(ctx, profile)
 }
@@ -56,6 +60,11 @@ func (u *ProfileUseCase) UpdateProfile(ctx context.Context, profile *entity.Prof
 	return u.profileRepo.Update(ctx, profile)
 }
 
+// ChangeUsername применяет смену имени из user_service — там же проверяются уникальность и кулдаун.
+func (u *ProfileUseCase) ChangeUsername(ctx context.Context, userID uuid.UUID, username string) error {
+	return u.profileRepo.UpdateUsername(ctx, userID, username)
+}
+
 func (u *ProfileUseCase) Search(ctx context.Context, filter *dto.SearchProfilesQuery) ([]*entity.Profile, error) {
 	return u.profileRepo.Search(ctx, filter)
 }
@@ -22,10 +22,10 @@ type Consumer struct {
 	log    *slog.Logger
 }
 
-func NewConsumer(brokers []string, topic, groupID string, router EventRouter, log *slog.Logger) *Consumer {
+func NewConsumer(brokers []string, topics []string, groupID string, router EventRouter, log *slog.Logger) *Consumer {
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:        brokers,
-		Topic:          topic,
+		GroupTopics:    topics,
 		GroupID:        groupID,
 		MinBytes:       1,
 		MaxBytes:       10e6,
@@ -35,7 +35,7 @@ func NewConsumer(brokers []string, topic, groupID string, router EventRouter, lo
 	return &Consumer{
 		reader: reader,
 		router: router,
-		log:    log.With("component", "kafka_consumer", "topic", topic),
+		log:    log.With("component", "kafka_consumer", "topics", topics),
 	}
 }
 
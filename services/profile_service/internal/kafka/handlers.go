@@ -54,3 +54,41 @@ func (h *UserCreatedProcessor) Handle(ctx context.Context, evt domain2.UserCreat
 	)
 	return nil
 }
+
+type UsernameChangedHandler interface {
+	Handle(ctx context.Context, event domain2.UsernameChangedEvent) error
+}
+
+type UsernameChangedProcessor struct {
+	uc  domain.ProfileUseCaseInterface
+	log *slog.Logger
+}
+
+func NewUsernameChangedProcessor(uc domain.ProfileUseCaseInterface, log *slog.Logger) *UsernameChangedProcessor {
+	return &UsernameChangedProcessor{
+		uc:  uc,
+		log: log.With("handler", "username_changed"),
+	}
+}
+
+func (h *UsernameChangedProcessor) Handle(ctx context.Context, evt domain2.UsernameChangedEvent) error {
+	userID, err := uuid.Parse(evt.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user_id %q: %w", evt.UserID, err)
+	}
+
+	if err = h.uc.ChangeUsername(ctx, userID, evt.NewUsername); err != nil {
+		h.log.Error("failed to change profile username",
+			slog.String("user_id", evt.UserID),
+			slog.Any("error", err),
+		)
+		return err
+	}
+
+	h.log.Info("profile username changed",
+		slog.String("user_id", evt.UserID),
+		slog.String("old_username", evt.OldUsername),
+		slog.String("username", evt.NewUsername),
+	)
+	return nil
+}
@@ -11,14 +11,20 @@ import (
 )
 
 type Router struct {
-	userCreatedHandler UserCreatedHandler
-	log                *slog.Logger
+	userCreatedHandler     UserCreatedHandler
+	usernameChangedHandler UsernameChangedHandler
+	log                    *slog.Logger
 }
 
-func NewRouter(userCreatedHandler UserCreatedHandler, log *slog.Logger) *Router {
+func NewRouter(
+	userCreatedHandler UserCreatedHandler,
+	usernameChangedHandler UsernameChangedHandler,
+	log *slog.Logger,
+) *Router {
 	return &Router{
-		userCreatedHandler: userCreatedHandler,
-		log:                log.With("component", "event_router"),
+		userCreatedHandler:     userCreatedHandler,
+		usernameChangedHandler: usernameChangedHandler,
+		log:                    log.With("component", "event_router"),
 	}
 }
 
@@ -33,6 +39,15 @@ func (r *Router) Route(ctx context.Context, env domain.Envelope) error {
 
 		return r.userCreatedHandler.Handle(ctx, evt)
 
+	case domain.EventUserUsernameChanged:
+		var evt domain.UsernameChangedEvent
+
+		if err := json.Unmarshal(env.Payload, &evt); err != nil {
+			return apperror.InvalidErrorEnvelope(fmt.Errorf("decode user.username_changed: %w", err))
+		}
+
+		return r.usernameChangedHandler.Handle(ctx, evt)
+
 	default:
 		r.log.Warn("unhandled event, skipping", slog.String("event_type", env.EventType))
 
@@ -11,6 +11,7 @@ type ProfileRepositoryInterface interface {
 	Create(ctx context.Context, profile *entity.Profile) error
 	FindByUsername(ctx context.Context, username string) (*entity.Profile, error)
 	Update(ctx context.Context, profile *entity.Profile) error
+	UpdateUsername(ctx context.Context, userID uuid.UUID, username string) error
 	UpdateAvatar(ctx context.Context, userID uuid.UUID, avatarURL string, avatarThumbURL string) error
 	FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.Profile, error)
 	Search(ctx context.Context, filter *dto.SearchProfilesQuery) ([]*entity.Profile, error)
@@ -112,6 +112,32 @@ func (r *ProfileRepository) Update(ctx context.Context, profile *entity.Profile)
 	return nil
 }
 
+func (r *ProfileRepository) UpdateUsername(ctx context.Context, userID uuid.UUID, username string) error {
+	query := `
+		UPDATE profiles
+		SET    username = $2
+		WHERE  user_id = $1
+		  AND  deleted_at IS NULL`
+
+	res, err := r.exec.ExecContext(ctx, query, userID, username)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "update username")
+	}
+
+	affected, err := res.RowsAffected()
+
+	if err != nil {
+		return fmt.Errorf("update username rows affected: %w", err)
+	}
+
+	if affected == 0 {
+		return domain.ErrProfileNotFound
+	}
+
+	return nil
+}
+
 func (r *ProfileRepository) UpdateAvatar(ctx context.Context, userID uuid.UUID, avatarURL string, avatarThumbURL string) error {
 	query := `
 		UPDATE profiles
@@ -8,4 +8,5 @@ import (
 type ObjectStorage interface {
 	Upload(ctx context.Context, key string, r io.Reader, size int64, contentType string) (url string, err error)
 	Delete(ctx context.Context, key string) error
+	Get(ctx context.Context, key string) (data []byte, contentType string, err error)
 }
@@ -94,6 +94,28 @@ func (s *ObjectStorage) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+// Get читает объект целиком. Используется выгрузкой данных: аватары небольшие.
+func (s *ObjectStorage) Get(ctx context.Context, key string) ([]byte, string, error) {
+	obj, err := s.client.GetObject(ctx, s.bucketName, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, "", fmt.Errorf("minio: get object %q: %w", key, err)
+	}
+
+	defer obj.Close()
+
+	info, err := obj.Stat()
+	if err != nil {
+		return nil, "", fmt.Errorf("minio: stat object %q: %w", key, err)
+	}
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		return nil, "", fmt.Errorf("minio: read object %q: %w", key, err)
+	}
+
+	return data, info.ContentType, nil
+}
+
 // KeyFromURL извлекает ключ объекта из публичного URL.
 // Используется для удаления старого аватара перед загрузкой нового.
 func (s *ObjectStorage) KeyFromURL(avatarURL string) string {
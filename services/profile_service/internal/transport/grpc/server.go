@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -63,6 +64,34 @@ func (s *ProfileServer) GetProfileByUsername(
 	}, nil
 }
 
+func (s *ProfileServer) GetProfilePrivacy(
+	ctx context.Context,
+	req *profilev1.GetProfilePrivacyRequest,
+) (*profilev1.GetProfilePrivacyResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	profile, err := s.uc.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrProfileNotFound) {
+
+			return nil, status.Error(codes.NotFound, "profile not found")
+		}
+
+		s.log.Error("GetProfilePrivacy failed",
+			slog.String("user_id", req.UserId),
+			slog.Any("error", err),
+		)
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &profilev1.GetProfilePrivacyResponse{IsPrivate: profile.IsPrivate}, nil
+}
+
 func derefString(s *string) string {
 	if s == nil {
 
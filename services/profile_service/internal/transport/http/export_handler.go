@@ -0,0 +1,75 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"path"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/export"
+	"github.com/rockkley/pushpost/services/profile_service/internal/domain"
+	"github.com/rockkley/pushpost/services/profile_service/internal/repository"
+	storage "github.com/rockkley/pushpost/services/profile_service/internal/storage"
+)
+
+type ExportHandler struct {
+	repo       repository.ProfileRepositoryInterface
+	storage    storage.ObjectStorage
+	keyFromURL func(url string) string
+}
+
+func NewExportHandler(
+	repo repository.ProfileRepositoryInterface,
+	objStorage storage.ObjectStorage,
+	keyFromURL func(url string) string,
+) *ExportHandler {
+	return &ExportHandler{repo: repo, storage: objStorage, keyFromURL: keyFromURL}
+}
+
+// Collect отдаёт профиль и сами файлы аватара, а не только ссылки на них.
+// Удалённый профиль даёт пустую секцию.
+func (h *ExportHandler) Collect(ctx context.Context, userID uuid.UUID) (*export.Section, error) {
+	section := &export.Section{}
+
+	profile, err := h.repo.FindByUserID(ctx, userID)
+
+	if errors.Is(err, domain.ErrProfileNotFound) {
+		return section, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = section.AddJSON("profile.json", profile); err != nil {
+		return nil, err
+	}
+
+	for _, avatar := range []struct {
+		name string
+		url  *string
+	}{
+		{"avatar", profile.AvatarURL},
+		{"avatar_thumb", profile.AvatarThumbURL},
+	} {
+		if avatar.url == nil {
+			continue
+		}
+
+		key := h.keyFromURL(*avatar.url)
+
+		if key == "" {
+			continue
+		}
+
+		data, contentType, err := h.storage.Get(ctx, key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		section.Add(avatar.name+path.Ext(key), contentType, data)
+	}
+
+	return section, nil
+}
@@ -5,13 +5,14 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/rockkley/pushpost/services/common_service/export"
 	handlerhttp "github.com/rockkley/pushpost/services/common_service/http"
 	"github.com/rockkley/pushpost/services/common_service/httplog"
 	"github.com/rockkley/pushpost/services/common_service/metrics"
 	myHTTP "github.com/rockkley/pushpost/services/profile_service/internal/transport/http"
 )
 
-func NewRouter(log *slog.Logger, h *myHTTP.ProfileHandler) *chi.Mux {
+func NewRouter(log *slog.Logger, h *myHTTP.ProfileHandler, exportHandler *myHTTP.ExportHandler) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(chimiddleware.RequestID)
@@ -20,6 +21,9 @@ func NewRouter(log *slog.Logger, h *myHTTP.ProfileHandler) *chi.Mux {
 	r.Use(chimiddleware.Recoverer)
 	r.Handle("/metrics", metrics.Handler())
 
+	// internal API, not exposed through the gateway
+	r.Get("/internal/export/{userID}", handlerhttp.MakeHandler(export.Handler(exportHandler.Collect)))
+
 	r.Route("/profiles", func(r chi.Router) {
 		r.Get("/search", handlerhttp.MakeHandler(h.Search))
 		r.Get("/by-username/{username}", handlerhttp.MakeHandler(h.GetByUsername))
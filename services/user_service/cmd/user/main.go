@@ -8,8 +8,10 @@ import (
 	"github.com/rockkley/pushpost/services/common_service/outbox"
 	"github.com/rockkley/pushpost/services/common_service/outbox/kafka"
 	postgres2 "github.com/rockkley/pushpost/services/common_service/outbox/postgres"
+	"github.com/rockkley/pushpost/services/common_service/reserved"
 	stdlog "log"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -17,11 +19,17 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/rockkley/pushpost/services/common_service/database"
+	userv1 "github.com/rockkley/pushpost/services/user_service/gen/userv1"
 	"github.com/rockkley/pushpost/services/user_service/internal/config"
+	"github.com/rockkley/pushpost/services/user_service/internal/dataexport"
 	"github.com/rockkley/pushpost/services/user_service/internal/domain/usecase"
+	"github.com/rockkley/pushpost/services/user_service/internal/jobs"
 	"github.com/rockkley/pushpost/services/user_service/internal/repository/postgres"
+	miniostg "github.com/rockkley/pushpost/services/user_service/internal/storage/minio"
 	"github.com/rockkley/pushpost/services/user_service/internal/transport"
+	grpctransport "github.com/rockkley/pushpost/services/user_service/internal/transport/grpc"
 	myHTTP "github.com/rockkley/pushpost/services/user_service/internal/transport/http"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -58,9 +66,53 @@ func main() {
 	defer db.Close()
 
 	uow := postgres.NewUnitOfWork(db)
-	userUseCase := usecase.NewUserUseCase(uow)
+	userUseCase := usecase.NewUserUseCase(
+		uow,
+		reserved.NewPolicy(cfg.Username.Denylist),
+		cfg.Username.ChangeCooldown,
+		cfg.Username.RedirectPeriod,
+	)
+	exportStorage, err := miniostg.New(miniostg.Config{
+		Endpoint:        cfg.Storage.Endpoint,
+		AccessKeyID:     cfg.Storage.AccessKeyID,
+		SecretAccessKey: cfg.Storage.SecretAccessKey,
+		BucketName:      cfg.Storage.BucketName,
+		UseSSL:          cfg.Storage.UseSSL,
+		PublicEndpoint:  cfg.Storage.PublicEndpoint,
+		PublicUseSSL:    cfg.Storage.PublicUseSSL,
+		Region:          cfg.Storage.Region,
+	})
+
+	if err != nil {
+		appLog.Error("failed to init export storage", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	if err = exportStorage.EnsureBucket(context.Background()); err != nil {
+		appLog.Error("failed to ensure export bucket", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	exportCollector := dataexport.NewHTTPCollector([]dataexport.Source{
+		{Name: "profile", BaseURL: cfg.Export.ProfileServiceURL},
+		{Name: "posts", BaseURL: cfg.Export.PostServiceURL},
+		{Name: "friendship", BaseURL: cfg.Export.FriendshipServiceURL},
+		{Name: "messages", BaseURL: cfg.Export.MessageServiceURL},
+		{Name: "notifications", BaseURL: cfg.Export.NotificationServiceURL},
+	}, cfg.Export.RequestTimeout)
+
+	exportUseCase := usecase.NewDataExportUseCase(
+		uow,
+		exportCollector,
+		exportStorage,
+		cfg.Export.LinkTTL,
+		cfg.Export.RetryAfter,
+		cfg.Export.MaxAttempts,
+	)
+
 	userHandler := myHTTP.NewUserHandler(userUseCase)
-	mux := transport.NewRouter(appLog, userHandler)
+	exportHandler := myHTTP.NewExportHandler(exportUseCase)
+	mux := transport.NewRouter(appLog, userHandler, exportHandler)
 
 	kafkaPublisher := kafka.NewPublisher(cfg.Kafka.Brokers(), appLog)
 
@@ -79,6 +131,26 @@ func main() {
 		appLog,
 	)
 
+	deletionWorker := jobs.NewWorker("deletion_worker", userUseCase.FinalizeDueDeletions, jobs.WorkerConfig{
+		Interval:  cfg.Deletion.Interval,
+		BatchSize: cfg.Deletion.BatchSize,
+	}, appLog)
+
+	suspensionWorker := jobs.NewWorker("suspension_worker", userUseCase.LiftExpiredSuspensions, jobs.WorkerConfig{
+		Interval:  cfg.Suspension.Interval,
+		BatchSize: cfg.Suspension.BatchSize,
+	}, appLog)
+
+	exportWorker := jobs.NewWorker("export_worker", exportUseCase.ProcessExports, jobs.WorkerConfig{
+		Interval:  cfg.Export.Interval,
+		BatchSize: cfg.Export.BatchSize,
+	}, appLog)
+
+	exportExpiryWorker := jobs.NewWorker("export_expiry_worker", exportUseCase.ExpireExports, jobs.WorkerConfig{
+		Interval:  cfg.Export.ExpiryInterval,
+		BatchSize: cfg.Export.BatchSize,
+	}, appLog)
+
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.HTTP.Port),
 		Handler:      mux,
@@ -86,18 +158,39 @@ func main() {
 		WriteTimeout: cfg.HTTP.WriteTimeout,
 	}
 
+	grpcSrv := grpc.NewServer()
+	userv1.RegisterUserServiceServer(grpcSrv, grpctransport.NewUserServer(userUseCase, appLog))
+
+	grpcLis, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPC.Port))
+
+	if err != nil {
+		appLog.Error("failed to listen gRPC port", slog.Any("error", err))
+		os.Exit(1)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	defer cancel()
 
 	go outboxWorker.Run(ctx)
+	go deletionWorker.Run(ctx)
+	go suspensionWorker.Run(ctx)
+	go exportWorker.Run(ctx)
+	go exportExpiryWorker.Run(ctx)
 
-	serverErr := make(chan error, 1)
+	serverErr := make(chan error, 2)
 
 	go func() {
 		appLog.Info("user service started", slog.String("port", cfg.HTTP.Port))
-		if err = srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
-			serverErr <- err
+		if srvErr := srv.ListenAndServe(); !errors.Is(srvErr, http.ErrServerClosed) {
+			serverErr <- srvErr
+		}
+	}()
+
+	go func() {
+		appLog.Info("user gRPC server started", slog.String("port", cfg.GRPC.Port))
+		if srvErr := grpcSrv.Serve(grpcLis); srvErr != nil {
+			serverErr <- srvErr
 		}
 	}()
 
@@ -114,6 +207,9 @@ func main() {
 
 	cancel()
 
+	grpcSrv.GracefulStop()
+	appLog.Info("gRPC server stopped")
+
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
 	defer shutdownCancel()
 
@@ -1,11 +1,28 @@
 package apperror
 
 const (
-	CodeUserNotFound     = "user_not_found"
-	CodeUserDeleted      = "user_deleted"
-	CodeEmailExists      = "email_already_exists"
-	CodeUsernameExists   = "username_already_exists"
-	CodeUsernameReserved = "username_reserved"
+	CodeUserNotFound           = "user_not_found"
+	CodeUserDeleted            = "user_deleted"
+	CodeEmailExists            = "email_already_exists"
+	CodeUsernameExists         = "username_already_exists"
+	CodeUsernameReserved       = "username_reserved"
+	CodeUsernameForbidden      = "username_forbidden"
+	CodeUsernameConfusable     = "username_confusable"
+	CodeUsernameUnchanged      = "username_unchanged"
+	CodeUsernameChangeCooldown = "username_change_cooldown"
+
+	CodePasswordHashChanged = "password_hash_changed"
+
+	CodeDeletionAlreadyScheduled = "deletion_already_scheduled"
+	CodeDeletionNotScheduled     = "deletion_not_scheduled"
+	CodeUserNotActive            = "user_not_active"
+	CodeUserNotSuspended         = "user_not_suspended"
+
+	CodeMFANotEnabled       = "mfa_not_enabled"
+	CodeRecoveryCodeInvalid = "recovery_code_invalid"
+
+	CodeExportInProgress = "export_in_progress"
+	CodeExportNotFound   = "export_not_found"
 
 	CodeInvalidCredentials = "invalid_credentials"
 	CodeSessionExpired     = "session_expired"
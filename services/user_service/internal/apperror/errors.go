@@ -1,6 +1,8 @@
 package apperror
 
 import (
+	"time"
+
 	"github.com/rockkley/pushpost/services/common_service/apperror"
 )
 
@@ -36,6 +38,58 @@ func UsernameReserved() apperror.AppError {
 	return apperror.Conflict(CodeUsernameReserved, "username", "this username is reserved")
 }
 
+func UsernameForbidden() apperror.AppError {
+	return apperror.Conflict(CodeUsernameForbidden, "username", "username contains a forbidden word")
+}
+
+func UsernameConfusable() apperror.AppError {
+	return apperror.Conflict(CodeUsernameConfusable, "username", "username is too similar to an existing one")
+}
+
+func UsernameUnchanged() apperror.AppError {
+	return apperror.Conflict(CodeUsernameUnchanged, "username", "new username is the same as the current one")
+}
+
+func UsernameChangeCooldown(retryAfter time.Duration) apperror.AppError {
+	return apperror.TooManyRequests(CodeUsernameChangeCooldown, "username was changed recently", retryAfter)
+}
+
+func PasswordHashChanged() apperror.AppError {
+	return apperror.Conflict(CodePasswordHashChanged, "current_hash", "password hash has changed since it was read")
+}
+
+func DeletionAlreadyScheduled() apperror.AppError {
+	return apperror.Conflict(CodeDeletionAlreadyScheduled, "", "account deletion is already scheduled")
+}
+
+func DeletionNotScheduled() apperror.AppError {
+	return apperror.Conflict(CodeDeletionNotScheduled, "", "account deletion is not scheduled")
+}
+
+func UserNotActive() apperror.AppError {
+	return apperror.Forbidden(CodeUserNotActive, "account is not active")
+}
+
+func UserNotSuspended() apperror.AppError {
+	return apperror.Conflict(CodeUserNotSuspended, "", "account is not suspended")
+}
+
+func MFANotEnabled() apperror.AppError {
+	return apperror.NotFound(CodeMFANotEnabled, "two-factor authentication is not enabled")
+}
+
+func RecoveryCodeInvalid() apperror.AppError {
+	return apperror.NotFound(CodeRecoveryCodeInvalid, "recovery code is invalid or already used")
+}
+
+func ExportInProgress() apperror.AppError {
+	return apperror.Conflict(CodeExportInProgress, "", "data export is already in progress")
+}
+
+func ExportNotFound() apperror.AppError {
+	return apperror.NotFound(CodeExportNotFound, "no data export requested")
+}
+
 // -- Postgres constraint mapper
 
 func MapConstraint(constraintName string) apperror.AppError {
@@ -44,6 +98,8 @@ func MapConstraint(constraintName string) apperror.AppError {
 		return EmailAlreadyExists()
 	case "users_username_key", "idx_users_username_unique":
 		return UsernameAlreadyExists()
+	case "data_exports_one_active":
+		return ExportInProgress()
 	default:
 		return nil
 	}
@@ -8,9 +8,15 @@ import (
 )
 
 type Config struct {
-	HTTP     HTTPConfig
-	Database DatabaseConfig
-	Kafka    KafkaConfig
+	HTTP       HTTPConfig
+	GRPC       GRPCConfig
+	Database   DatabaseConfig
+	Kafka      KafkaConfig
+	Deletion   DeletionConfig
+	Suspension SuspensionConfig
+	Username   UsernameConfig
+	Export     ExportConfig
+	Storage    StorageConfig
 }
 
 type HTTPConfig struct {
@@ -20,6 +26,10 @@ type HTTPConfig struct {
 	ShutdownTimeout time.Duration `env:"HTTP_SHUTDOWN_TIMEOUT" env-default:"30s"`
 }
 
+type GRPCConfig struct {
+	Port string `env:"GRPC_PORT" env-default:"9080"`
+}
+
 type DatabaseConfig struct {
 	URL          string `env:"USER_DATABASE_URL" env-required:"true"`
 	MaxOpenConns int    `env:"DB_MAX_OPEN_CONNS" env-default:"25"`
@@ -30,6 +40,61 @@ type KafkaConfig struct {
 	BrokersRaw string `env:"KAFKA_BROKERS" env-required:"true" env-separator:"," env-default:"kafka:9092"`
 }
 
+// DeletionConfig — как часто удалять аккаунты с истёкшим сроком восстановления.
+type DeletionConfig struct {
+	Interval  time.Duration `env:"DELETION_JOB_INTERVAL" env-default:"1h"`
+	BatchSize int           `env:"DELETION_BATCH_SIZE"   env-default:"100"`
+}
+
+// SuspensionConfig — как часто снимать временные блокировки с истёкшим сроком.
+type SuspensionConfig struct {
+	Interval  time.Duration `env:"SUSPENSION_JOB_INTERVAL" env-default:"1m"`
+	BatchSize int           `env:"SUSPENSION_BATCH_SIZE"   env-default:"100"`
+}
+
+// UsernameConfig — ChangeCooldown: минимальный интервал между сменами имени;
+// RedirectPeriod: сколько прежнее имя ведёт на новое и недоступно другим;
+// Denylist: слова, запрещённые в любой части имени (брань, имитация бренда и персонала).
+type UsernameConfig struct {
+	ChangeCooldown time.Duration `env:"USERNAME_CHANGE_COOLDOWN" env-default:"720h"`
+	RedirectPeriod time.Duration `env:"USERNAME_REDIRECT_PERIOD" env-default:"2160h"`
+	Denylist       []string      `env:"USERNAME_DENYLIST"        env-separator:"," env-default:"pushpost,administrator,moderator"`
+}
+
+// ExportConfig — выгрузка персональных данных. *ServiceURL — адреса сервисов,
+// у которых задание забирает данные по /internal/export/{userID};
+// LinkTTL: сколько хранится архив и действует ссылка (подпись S3 — не дольше 7 суток);
+// ExpiryInterval: как часто удалять просроченные архивы;
+// RetryAfter: пауза перед повтором неудачной или зависшей попытки — должна с запасом
+// превышать время сбора со всех сервисов, иначе живую попытку заберут повторно.
+type ExportConfig struct {
+	ProfileServiceURL      string        `env:"PROFILE_SERVICE_URL"      env-default:"http://profile-service:8083"`
+	PostServiceURL         string        `env:"POST_SERVICE_URL"         env-default:"http://post-service:8085"`
+	FriendshipServiceURL   string        `env:"FRIENDSHIP_SERVICE_URL"   env-default:"http://friendship-service:8082"`
+	MessageServiceURL      string        `env:"MESSAGE_SERVICE_URL"      env-default:"http://message-service:8084"`
+	NotificationServiceURL string        `env:"NOTIFICATION_SERVICE_URL" env-default:"http://notification-service:8086"`
+	RequestTimeout         time.Duration `env:"EXPORT_REQUEST_TIMEOUT"   env-default:"30s"`
+	LinkTTL                time.Duration `env:"EXPORT_LINK_TTL"          env-default:"72h"`
+	RetryAfter             time.Duration `env:"EXPORT_RETRY_AFTER"       env-default:"15m"`
+	MaxAttempts            int           `env:"EXPORT_MAX_ATTEMPTS"      env-default:"3"`
+	Interval               time.Duration `env:"EXPORT_JOB_INTERVAL"      env-default:"30s"`
+	BatchSize              int           `env:"EXPORT_BATCH_SIZE"        env-default:"5"`
+	ExpiryInterval         time.Duration `env:"EXPORT_EXPIRY_INTERVAL"   env-default:"1h"`
+}
+
+// StorageConfig — закрытый бакет для архивов выгрузки. PublicEndpoint — адрес хранилища
+// снаружи кластера: на него указывают подписанные ссылки.
+type StorageConfig struct {
+	Endpoint        string `env:"STORAGE_ENDPOINT"          env-default:"minio:9000"`
+	AccessKeyID     string `env:"STORAGE_ACCESS_KEY_ID"     env-required:"true"`
+	SecretAccessKey string `env:"STORAGE_SECRET_ACCESS_KEY" env-required:"true"`
+	BucketName      string `env:"STORAGE_EXPORT_BUCKET"     env-default:"exports"`
+	UseSSL          bool   `env:"STORAGE_USE_SSL"           env-default:"false"`
+	PublicEndpoint  string `env:"STORAGE_PUBLIC_ENDPOINT"   env-default:"localhost:9000"`
+	PublicUseSSL    bool   `env:"STORAGE_PUBLIC_USE_SSL"    env-default:"false"`
+	Region          string `env:"STORAGE_REGION"            env-default:"us-east-1"`
+}
+
 func Load() (*Config, error) {
 	var cfg Config
 
@@ -56,6 +121,36 @@ func (c *Config) validate() error {
 		)
 	}
 
+	if c.Deletion.Interval <= 0 || c.Deletion.BatchSize <= 0 {
+
+		return fmt.Errorf("deletion job interval and batch size must be positive")
+	}
+
+	if c.Suspension.Interval <= 0 || c.Suspension.BatchSize <= 0 {
+
+		return fmt.Errorf("suspension job interval and batch size must be positive")
+	}
+
+	if c.Username.ChangeCooldown < 0 || c.Username.RedirectPeriod <= 0 {
+
+		return fmt.Errorf("username change cooldown must not be negative and redirect period must be positive")
+	}
+
+	if c.Export.LinkTTL <= 0 || c.Export.LinkTTL > 7*24*time.Hour {
+
+		return fmt.Errorf("export link ttl must be positive and at most 7 days")
+	}
+
+	if c.Export.Interval <= 0 || c.Export.ExpiryInterval <= 0 || c.Export.BatchSize <= 0 || c.Export.MaxAttempts <= 0 {
+
+		return fmt.Errorf("export job interval, batch size and max attempts must be positive")
+	}
+
+	if c.Export.RetryAfter <= 0 {
+
+		return fmt.Errorf("export retry delay must be positive")
+	}
+
 	if len(c.Kafka.Brokers()) == 0 {
 
 		return fmt.Errorf("kafka brokers list is empty")
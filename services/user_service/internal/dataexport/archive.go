@@ -0,0 +1,55 @@
+package dataexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/rockkley/pushpost/services/common_service/export"
+)
+
+const ContentTypeZip = "application/zip"
+
+// BuildArchive складывает секции в ZIP: файл сервиса name лежит в каталоге name/.
+// Каталоги идут в алфавитном порядке, чтобы архивы одного пользователя было удобно сравнивать.
+func BuildArchive(sections map[string]*export.Section, createdAt time.Time) ([]byte, error) {
+	names := make([]string, 0, len(sections))
+
+	for name := range sections {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+
+	for _, name := range names {
+		for _, f := range sections[name].Files {
+			w, err := zw.CreateHeader(&zip.FileHeader{
+				// имя файла приходит от другого сервиса — не даём ему выйти из каталога
+				Name:     path.Join(name, path.Clean("/" + f.Name)[1:]),
+				Method:   zip.Deflate,
+				Modified: createdAt,
+			})
+
+			if err != nil {
+				return nil, fmt.Errorf("add %s/%s: %w", name, f.Name, err)
+			}
+
+			if _, err = w.Write(f.Data); err != nil {
+				return nil, fmt.Errorf("write %s/%s: %w", name, f.Name, err)
+			}
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("close archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
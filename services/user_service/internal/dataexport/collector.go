@@ -0,0 +1,84 @@
+// Package dataexport собирает данные пользователя из сервисов и упаковывает их в архив.
+package dataexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/export"
+)
+
+// Source — сервис, отдающий свою часть выгрузки по GET {BaseURL}/internal/export/{userID}.
+// Name становится каталогом в архиве.
+type Source struct {
+	Name    string
+	BaseURL string
+}
+
+type HTTPCollector struct {
+	sources []Source
+	client  *http.Client
+}
+
+func NewHTTPCollector(sources []Source, timeout time.Duration) *HTTPCollector {
+	return &HTTPCollector{
+		sources: sources,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// Collect опрашивает все источники. Выгрузка должна быть полной, поэтому
+// ошибка любого источника прерывает сбор — задание повторит попытку позже.
+func (c *HTTPCollector) Collect(ctx context.Context, userID uuid.UUID) (map[string]*export.Section, error) {
+	sections := make(map[string]*export.Section, len(c.sources))
+
+	for _, src := range c.sources {
+		section, err := c.fetch(ctx, src, userID)
+
+		if err != nil {
+			return nil, fmt.Errorf("collect %s: %w", src.Name, err)
+		}
+
+		sections[src.Name] = section
+	}
+
+	return sections, nil
+}
+
+func (c *HTTPCollector) fetch(ctx context.Context, src Source, userID uuid.UUID) (*export.Section, error) {
+	endpoint := strings.TrimRight(src.BaseURL, "/") + "/internal/export/" + userID.String()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var section export.Section
+
+	if err = json.NewDecoder(resp.Body).Decode(&section); err != nil {
+		return nil, fmt.Errorf("decode section: %w", err)
+	}
+
+	return &section, nil
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "errors"
+
+type EnableMFADTO struct {
+	TOTPSecret         string   `json:"totp_secret"`
+	RecoveryCodeHashes []string `json:"recovery_code_hashes"`
+}
+
+func (dto *EnableMFADTO) Validate() error {
+	if dto.TOTPSecret == "" {
+		return errors.New("totp_secret is required")
+	}
+
+	if len(dto.RecoveryCodeHashes) == 0 {
+		return errors.New("recovery_code_hashes are required")
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const maxSuspensionReasonLength = 500
+
+// SuspendUserDTO — Until == nil означает бессрочную блокировку.
+type SuspendUserDTO struct {
+	Until  *time.Time `json:"until"`
+	Reason string     `json:"reason"`
+}
+
+func (dto *SuspendUserDTO) Validate() error {
+	dto.Reason = strings.TrimSpace(dto.Reason)
+
+	if dto.Reason == "" {
+		return errors.New("reason is required")
+	}
+
+	if len(dto.Reason) > maxSuspensionReasonLength {
+		return errors.New("reason is too long")
+	}
+
+	if dto.Until != nil && !dto.Until.After(time.Now()) {
+		return errors.New("until must be in the future")
+	}
+
+	return nil
+}
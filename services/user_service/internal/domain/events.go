@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type UserCreatedEvent struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
@@ -9,3 +11,35 @@ type UserCreatedEvent struct {
 type UserDeletedEvent struct {
 	UserID string `json:"user_id"`
 }
+
+// UserUpdatedEvent — ChangedFields перечисляет, что поменялось ("email", "password").
+type UserUpdatedEvent struct {
+	UserID        string   `json:"user_id"`
+	Username      string   `json:"username"`
+	Email         string   `json:"email"`
+	ChangedFields []string `json:"changed_fields"`
+}
+
+// UserSuspendedEvent — SuspendedUntil пуст для бессрочной блокировки.
+type UserSuspendedEvent struct {
+	UserID         string     `json:"user_id"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
+	Reason         string     `json:"reason"`
+}
+
+type UserReinstatedEvent struct {
+	UserID string `json:"user_id"`
+}
+
+// UserExportReadyEvent — архив хранится до ExpiresAt; ссылку клиент получает через GET /users/me/export.
+type UserExportReadyEvent struct {
+	UserID    string    `json:"user_id"`
+	ExportID  string    `json:"export_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type UsernameChangedEvent struct {
+	UserID      string `json:"user_id"`
+	OldUsername string `json:"old_username"`
+	NewUsername string `json:"new_username"`
+}
@@ -2,9 +2,13 @@ package domain
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/export"
 	"github.com/rockkley/pushpost/services/common_service/outbox"
+	"github.com/rockkley/pushpost/services/common_service/roles"
 	"github.com/rockkley/pushpost/services/user_service/internal/domain/dto"
 	"github.com/rockkley/pushpost/services/user_service/internal/entity"
 	"github.com/rockkley/pushpost/services/user_service/internal/repository"
@@ -14,17 +18,57 @@ type UserUseCaseInterface interface {
 	CreateUser(ctx context.Context, dto dto.CreateUserDTO) (*entity.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
+	GetUsers(ctx context.Context, ids []uuid.UUID) ([]*entity.User, error)
 	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
 	DeleteUser(ctx context.Context, userID uuid.UUID) error
 	ActivateUser(ctx context.Context, email string) error
+	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
+	RehashPassword(ctx context.Context, userID uuid.UUID, currentHash, newHash string) error
+	ChangeEmail(ctx context.Context, userID uuid.UUID, email string) error
+	SetRole(ctx context.Context, userID uuid.UUID, role roles.Role) error
+	ChangeUsername(ctx context.Context, userID uuid.UUID, username string) (*entity.User, error)
+	ResolveFormerUsername(ctx context.Context, username string) (*entity.User, error)
+	ScheduleDeletion(ctx context.Context, userID uuid.UUID, at time.Time) error
+	CancelDeletion(ctx context.Context, userID uuid.UUID) error
+	FinalizeDueDeletions(ctx context.Context, now time.Time, limit int) (int, error)
+	SuspendUser(ctx context.Context, userID uuid.UUID, req dto.SuspendUserDTO) error
+	ReinstateUser(ctx context.Context, userID uuid.UUID) error
+	LiftExpiredSuspensions(ctx context.Context, now time.Time, limit int) (int, error)
+	GetMFA(ctx context.Context, userID uuid.UUID) (*entity.MFA, error)
+	EnableMFA(ctx context.Context, userID uuid.UUID, req dto.EnableMFADTO) error
+	DisableMFA(ctx context.Context, userID uuid.UUID) error
+	ConsumeRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+}
+
+type DataExportUseCaseInterface interface {
+	RequestExport(ctx context.Context, userID uuid.UUID) (*entity.DataExport, error)
+	// GetLatestExport возвращает последнюю выгрузку и, если архив готов, свежую ссылку на него.
+	GetLatestExport(ctx context.Context, userID uuid.UUID) (*entity.DataExport, string, error)
+	ProcessExports(ctx context.Context, now time.Time, limit int) (int, error)
+	ExpireExports(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+// ExportCollector собирает секции выгрузки из других сервисов, ключ — имя сервиса.
+type ExportCollector interface {
+	Collect(ctx context.Context, userID uuid.UUID) (map[string]*export.Section, error)
+}
+
+type ExportStorage interface {
+	Upload(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	PresignedURL(ctx context.Context, key, filename string, ttl time.Duration) (string, error)
 }
 
 type Tx interface {
 	Users() repository.UserRepositoryInterface
 	Outbox() outbox.WriterInterface
+	MFA() repository.MFARepositoryInterface
+	Exports() repository.DataExportRepositoryInterface
 }
 
 type UnitOfWorkInterface interface {
 	Do(ctx context.Context, fn func(tx Tx) error) error
 	Reader() repository.UserRepositoryInterface
+	MFAReader() repository.MFARepositoryInterface
+	ExportReader() repository.DataExportRepositoryInterface
 }
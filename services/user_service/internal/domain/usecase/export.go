@@ -0,0 +1,339 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/ctxlog"
+	"github.com/rockkley/pushpost/services/common_service/export"
+	apperr "github.com/rockkley/pushpost/services/user_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/user_service/internal/dataexport"
+	"github.com/rockkley/pushpost/services/user_service/internal/domain"
+	"github.com/rockkley/pushpost/services/user_service/internal/entity"
+)
+
+// accountSection — имя каталога в архиве для данных самого user_service.
+const accountSection = "account"
+
+// DataExportUseCase собирает выгрузку персональных данных: запрос ставится в очередь,
+// задание опрашивает сервисы, кладёт ZIP в хранилище и сообщает о готовности через user.export_ready.
+// Ссылку на скачивание в событие не кладём: она выдаётся заново в GetLatestExport.
+type DataExportUseCase struct {
+	uow         domain.UnitOfWorkInterface
+	collector   domain.ExportCollector
+	storage     domain.ExportStorage
+	linkTTL     time.Duration
+	staleAfter  time.Duration
+	maxAttempts int
+}
+
+// NewDataExportUseCase — linkTTL: сколько архив хранится и действует ссылка;
+// staleAfter: через сколько зависшая или неудачная попытка повторяется.
+func NewDataExportUseCase(
+	uow domain.UnitOfWorkInterface,
+	collector domain.ExportCollector,
+	storage domain.ExportStorage,
+	linkTTL, staleAfter time.Duration,
+	maxAttempts int,
+) *DataExportUseCase {
+	return &DataExportUseCase{
+		uow:         uow,
+		collector:   collector,
+		storage:     storage,
+		linkTTL:     linkTTL,
+		staleAfter:  staleAfter,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// RequestExport ставит выгрузку в очередь. Пока предыдущая не собрана, новая не создаётся.
+func (u *DataExportUseCase) RequestExport(ctx context.Context, userID uuid.UUID) (*entity.DataExport, error) {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "DataExportUseCase.RequestExport"),
+		slog.String("user_id", userID.String()),
+	)
+
+	user, err := u.uow.Reader().FindByID(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeleted() {
+		return nil, apperr.UserNotFound()
+	}
+
+	e := &entity.DataExport{
+		ID:     uuid.New(),
+		UserID: userID,
+		Status: entity.ExportStatusPending,
+	}
+
+	err = u.uow.Do(ctx, func(tx domain.Tx) error {
+		return tx.Exports().Create(ctx, e)
+	})
+
+	if err != nil {
+		log.Warn("failed to request data export", slog.Any("error", err))
+
+		return nil, err
+	}
+
+	log.Info("data export requested", slog.String("export_id", e.ID.String()))
+
+	return e, nil
+}
+
+func (u *DataExportUseCase) GetLatestExport(ctx context.Context, userID uuid.UUID) (*entity.DataExport, string, error) {
+	e, err := u.uow.ExportReader().FindLatest(ctx, userID)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if e.Status != entity.ExportStatusReady || e.ObjectKey == nil || e.ExpiresAt == nil {
+		return e, "", nil
+	}
+
+	// ссылка не должна пережить сам архив
+	ttl := time.Until(*e.ExpiresAt)
+
+	if ttl <= 0 {
+		return e, "", nil
+	}
+
+	link, err := u.storage.PresignedURL(ctx, *e.ObjectKey, archiveName(e), ttl)
+
+	if err != nil {
+		return nil, "", commonapperr.Service("failed to sign download link", err)
+	}
+
+	return e, link, nil
+}
+
+// ProcessExports собирает до limit выгрузок из очереди. Неудачная попытка возвращает
+// выгрузку в очередь, после maxAttempts она помечается failed.
+func (u *DataExportUseCase) ProcessExports(ctx context.Context, now time.Time, limit int) (int, error) {
+	log := ctxlog.From(ctx).With(slog.String("op", "DataExportUseCase.ProcessExports"))
+
+	var claimed []*entity.DataExport
+
+	err := u.uow.Do(ctx, func(tx domain.Tx) error {
+		var err error
+		claimed, err = tx.Exports().Claim(ctx, now, now.Add(-u.staleAfter), limit)
+
+		return err
+	})
+
+	if err != nil {
+		log.Error("failed to claim data exports", slog.Any("error", err))
+
+		return 0, err
+	}
+
+	for _, e := range claimed {
+		elog := log.With(
+			slog.String("export_id", e.ID.String()),
+			slog.String("user_id", e.UserID.String()),
+		)
+
+		if err = u.build(ctx, e, now); err != nil {
+			u.fail(ctx, elog, e, err, now)
+
+			continue
+		}
+
+		elog.Info("data export ready")
+	}
+
+	return len(claimed), nil
+}
+
+func (u *DataExportUseCase) build(ctx context.Context, e *entity.DataExport, now time.Time) error {
+	account, err := u.accountSection(ctx, e.UserID)
+
+	if err != nil {
+		return err
+	}
+
+	sections, err := u.collector.Collect(ctx, e.UserID)
+
+	if err != nil {
+		return err
+	}
+
+	sections[accountSection] = account
+
+	archive, err := dataexport.BuildArchive(sections, now)
+
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("exports/%s/%s.zip", e.UserID, e.ID)
+
+	if err = u.storage.Upload(ctx, key, bytes.NewReader(archive), int64(len(archive)), dataexport.ContentTypeZip); err != nil {
+		return err
+	}
+
+	expiresAt := now.Add(u.linkTTL)
+
+	err = u.uow.Do(ctx, func(tx domain.Tx) error {
+		if err := tx.Exports().MarkReady(ctx, e.ID, key, now, expiresAt); err != nil {
+			return err
+		}
+
+		return insertUserEvent(ctx, tx, e.UserID, "user.export_ready", domain.UserExportReadyEvent{
+			UserID:    e.UserID.String(),
+			ExportID:  e.ID.String(),
+			ExpiresAt: expiresAt,
+		})
+	})
+
+	if err != nil {
+		return errors.Join(err, u.storage.Delete(ctx, key))
+	}
+
+	return nil
+}
+
+// accountSection — данные аккаунта без хеша пароля и секрета MFA.
+func (u *DataExportUseCase) accountSection(ctx context.Context, userID uuid.UUID) (*export.Section, error) {
+	user, err := u.uow.Reader().FindByID(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeleted() {
+		return nil, apperr.UserNotFound()
+	}
+
+	history, err := u.uow.Reader().UsernameHistory(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var mfaEnabledAt *time.Time
+
+	mfa, err := u.uow.MFAReader().Get(ctx, userID)
+
+	switch {
+	case err == nil:
+		mfaEnabledAt = &mfa.EnabledAt
+	case !isAppErrorCode(err, apperr.CodeMFANotEnabled):
+		return nil, err
+	}
+
+	section := &export.Section{}
+
+	err = section.AddJSON("account.json", struct {
+		ID                  uuid.UUID               `json:"id"`
+		Username            string                  `json:"username"`
+		Email               string                  `json:"email"`
+		Status              string                  `json:"status"`
+		Role                string                  `json:"role"`
+		CreatedAt           time.Time               `json:"created_at"`
+		UpdatedAt           time.Time               `json:"updated_at"`
+		DeletionScheduledAt *time.Time              `json:"deletion_scheduled_at,omitempty"`
+		SuspendedUntil      *time.Time              `json:"suspended_until,omitempty"`
+		SuspensionReason    string                  `json:"suspension_reason,omitempty"`
+		MFAEnabledAt        *time.Time              `json:"mfa_enabled_at,omitempty"`
+		UsernameHistory     []entity.UsernameChange `json:"username_history"`
+	}{
+		ID:                  user.ID,
+		Username:            user.Username,
+		Email:               user.Email,
+		Status:              user.Status,
+		Role:                user.Role,
+		CreatedAt:           user.CreatedAt,
+		UpdatedAt:           user.UpdatedAt,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+		SuspendedUntil:      user.SuspendedUntil,
+		SuspensionReason:    user.SuspensionReason,
+		MFAEnabledAt:        mfaEnabledAt,
+		UsernameHistory:     history,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+func (u *DataExportUseCase) fail(ctx context.Context, log *slog.Logger, e *entity.DataExport, cause error, now time.Time) {
+	var err error
+
+	if e.Attempts >= u.maxAttempts || isAppErrorCode(cause, apperr.CodeUserNotFound) {
+		log.Error("data export failed", slog.Int("attempts", e.Attempts), slog.Any("error", cause))
+
+		err = u.uow.ExportReader().MarkFailed(ctx, e.ID, cause.Error(), now)
+	} else {
+		log.Warn("data export attempt failed, will retry", slog.Int("attempts", e.Attempts), slog.Any("error", cause))
+
+		err = u.uow.ExportReader().Retry(ctx, e.ID, cause.Error())
+	}
+
+	if err != nil {
+		log.Error("failed to record data export failure", slog.Any("error", err))
+	}
+}
+
+// ExpireExports удаляет из хранилища до limit архивов, срок которых истёк к now.
+func (u *DataExportUseCase) ExpireExports(ctx context.Context, now time.Time, limit int) (int, error) {
+	log := ctxlog.From(ctx).With(slog.String("op", "DataExportUseCase.ExpireExports"))
+
+	expired, err := u.uow.ExportReader().ListExpired(ctx, now, limit)
+
+	if err != nil {
+		log.Error("failed to list expired data exports", slog.Any("error", err))
+
+		return 0, err
+	}
+
+	removed := 0
+
+	for _, e := range expired {
+		if e.ObjectKey != nil {
+			if err = u.storage.Delete(ctx, *e.ObjectKey); err != nil {
+				log.Error("failed to delete expired export archive",
+					slog.String("export_id", e.ID.String()),
+					slog.Any("error", err),
+				)
+
+				continue
+			}
+		}
+
+		if err = u.uow.ExportReader().MarkExpired(ctx, e.ID); err != nil {
+			log.Error("failed to mark data export expired",
+				slog.String("export_id", e.ID.String()),
+				slog.Any("error", err),
+			)
+
+			continue
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
+
+func archiveName(e *entity.DataExport) string {
+	return fmt.Sprintf("pushpost-export-%s.zip", e.CreatedAt.UTC().Format("2006-01-02"))
+}
+
+func isAppErrorCode(err error, code string) bool {
+	var appErr commonapperr.AppError
+
+	return errors.As(err, &appErr) && appErr.Code() == code
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/ctxlog"
+	apperr "github.com/rockkley/pushpost/services/user_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/user_service/internal/domain"
+	"github.com/rockkley/pushpost/services/user_service/internal/domain/dto"
+	"github.com/rockkley/pushpost/services/user_service/internal/entity"
+)
+
+func (u *UserUseCase) GetMFA(ctx context.Context, userID uuid.UUID) (*entity.MFA, error) {
+	mfa, err := u.uow.MFAReader().Get(ctx, userID)
+
+	if err != nil {
+		ctxlog.From(ctx).Debug("mfa not found",
+			slog.String("op", "UserUseCase.GetMFA"),
+			slog.String("user_id", userID.String()),
+			slog.Any("error", err),
+		)
+
+		return nil, err
+	}
+
+	return mfa, nil
+}
+
+// EnableMFA сохраняет секрет и заменяет набор кодов восстановления одной транзакцией.
+func (u *UserUseCase) EnableMFA(ctx context.Context, userID uuid.UUID, req dto.EnableMFADTO) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "UserUseCase.EnableMFA"),
+		slog.String("user_id", userID.String()),
+	)
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	err := u.uow.Do(ctx, func(tx domain.Tx) error {
+		user, err := tx.Users().FindByID(ctx, userID)
+
+		if err != nil {
+			return err
+		}
+
+		if user.IsDeleted() {
+			return apperr.UserNotFound()
+		}
+
+		if err = tx.MFA().Save(ctx, userID, req.TOTPSecret); err != nil {
+			return err
+		}
+
+		return tx.MFA().ReplaceRecoveryCodes(ctx, userID, req.RecoveryCodeHashes)
+	})
+
+	if err != nil {
+		log.Error("failed to enable mfa", slog.Any("error", err))
+
+		return err
+	}
+
+	log.Info("mfa enabled")
+
+	return nil
+}
+
+func (u *UserUseCase) DisableMFA(ctx context.Context, userID uuid.UUID) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "UserUseCase.DisableMFA"),
+		slog.String("user_id", userID.String()),
+	)
+
+	if err := u.uow.MFAReader().Delete(ctx, userID); err != nil {
+		log.Debug("failed to disable mfa", slog.Any("error", err))
+
+		return err
+	}
+
+	log.Info("mfa disabled")
+
+	return nil
+}
+
+func (u *UserUseCase) ConsumeRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "UserUseCase.ConsumeRecoveryCode"),
+		slog.String("user_id", userID.String()),
+	)
+
+	if err := u.uow.MFAReader().ConsumeRecoveryCode(ctx, userID, codeHash); err != nil {
+		log.Debug("recovery code rejected", slog.Any("error", err))
+
+		return err
+	}
+
+	log.Info("recovery code used")
+
+	return nil
+}
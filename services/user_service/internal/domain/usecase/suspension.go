@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/ctxlog"
+	apperr "github.com/rockkley/pushpost/services/user_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/user_service/internal/domain"
+	"github.com/rockkley/pushpost/services/user_service/internal/domain/dto"
+)
+
+// SuspendUser блокирует аккаунт до req.Until или бессрочно. Повторный вызов для
+// заблокированного аккаунта меняет срок и причину и снова публикует user.suspended.
+func (u *UserUseCase) SuspendUser(ctx context.Context, id uuid.UUID, req dto.SuspendUserDTO) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "UserUseCase.SuspendUser"),
+		slog.String("user_id", id.String()),
+	)
+
+	if err := req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	err := u.uow.Do(ctx, func(tx domain.Tx) error {
+		user, err := tx.Users().FindByID(ctx, id)
+
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case user.IsDeleted():
+			return apperr.UserNotFound()
+		// восстановление после блокировки возвращает статус active — так пропустились бы
+		// подтверждение email и запланированное удаление
+		case !user.IsActive() && !user.IsSuspended():
+			return apperr.UserNotActive()
+		}
+
+		if err = tx.Users().Suspend(ctx, id, req.Until, req.Reason); err != nil {
+			return err
+		}
+
+		return insertUserEvent(ctx, tx, id, "user.suspended", domain.UserSuspendedEvent{
+			UserID:         id.String(),
+			SuspendedUntil: req.Until,
+			Reason:         req.Reason,
+		})
+	})
+
+	if err != nil {
+		log.Warn("failed to suspend user", slog.Any("error", err))
+
+		return err
+	}
+
+	log.Info("user suspended", slog.Bool("permanent", req.Until == nil))
+
+	return nil
+}
+
+func (u *UserUseCase) ReinstateUser(ctx context.Context, id uuid.UUID) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "UserUseCase.ReinstateUser"),
+		slog.String("user_id", id.String()),
+	)
+
+	err := u.uow.Do(ctx, func(tx domain.Tx) error {
+		return u.reinstate(ctx, tx, id)
+	})
+
+	if err != nil {
+		log.Warn("failed to reinstate user", slog.Any("error", err))
+
+		return err
+	}
+
+	log.Info("user reinstated")
+
+	return nil
+}
+
+// LiftExpiredSuspensions снимает до limit временных блокировок, срок которых истёк к now.
+func (u *UserUseCase) LiftExpiredSuspensions(ctx context.Context, now time.Time, limit int) (int, error) {
+	log := ctxlog.From(ctx).With(slog.String("op", "UserUseCase.LiftExpiredSuspensions"))
+
+	ids, err := u.uow.Reader().ListExpiredSuspensions(ctx, now, limit)
+
+	if err != nil {
+		log.Error("failed to list expired suspensions", slog.Any("error", err))
+
+		return 0, err
+	}
+
+	lifted := 0
+
+	for _, id := range ids {
+		err = u.uow.Do(ctx, func(tx domain.Tx) error {
+			user, err := tx.Users().FindByID(ctx, id)
+
+			if err != nil {
+				return err
+			}
+
+			// модератор мог продлить блокировку между выборкой и транзакцией
+			if !user.IsSuspended() || user.SuspendedUntil == nil || user.SuspendedUntil.After(now) {
+				return errSuspensionChanged
+			}
+
+			return u.reinstate(ctx, tx, id)
+		})
+
+		switch {
+		case err == nil:
+			lifted++
+
+			log.Info("suspension expired", slog.String("user_id", id.String()))
+		case errors.Is(err, errSuspensionChanged):
+			log.Debug("suspension changed before it was lifted", slog.String("user_id", id.String()))
+		default:
+			log.Error("failed to lift suspension",
+				slog.String("user_id", id.String()),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return lifted, nil
+}
+
+var errSuspensionChanged = errors.New("suspension changed")
+
+func (u *UserUseCase) reinstate(ctx context.Context, tx domain.Tx, id uuid.UUID) error {
+	if err := tx.Users().Reinstate(ctx, id); err != nil {
+		return err
+	}
+
+	return insertUserEvent(ctx, tx, id, "user.reinstated", domain.UserReinstatedEvent{UserID: id.String()})
+}
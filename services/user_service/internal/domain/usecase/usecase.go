@@ -3,12 +3,16 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/rockkley/pushpost/services/common_service/ctxlog"
 	"github.com/rockkley/pushpost/services/common_service/outbox"
+	"github.com/rockkley/pushpost/services/common_service/reserved"
+	"github.com/rockkley/pushpost/services/common_service/roles"
 	"github.com/rockkley/pushpost/services/user_service/internal/domain"
-	"github.com/rockkley/pushpost/services/user_service/internal/reserved"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
@@ -18,11 +22,23 @@ import (
 )
 
 type UserUseCase struct {
-	uow domain.UnitOfWorkInterface
+	uow                 domain.UnitOfWorkInterface
+	usernamePolicy      *reserved.Policy
+	usernameCooldown    time.Duration
+	usernameRedirectTTL time.Duration
 }
 
-func NewUserUseCase(uow domain.UnitOfWorkInterface) *UserUseCase {
-	return &UserUseCase{uow: uow}
+func NewUserUseCase(
+	uow domain.UnitOfWorkInterface,
+	usernamePolicy *reserved.Policy,
+	usernameCooldown, usernameRedirectTTL time.Duration,
+) *UserUseCase {
+	return &UserUseCase{
+		uow:                 uow,
+		usernamePolicy:      usernamePolicy,
+		usernameCooldown:    usernameCooldown,
+		usernameRedirectTTL: usernameRedirectTTL,
+	}
 }
 
 func (u *UserUseCase) CreateUser(ctx context.Context, req dto.CreateUserDTO) (*entity.User, error) {
@@ -34,10 +50,6 @@ func (u *UserUseCase) CreateUser(ctx context.Context, req dto.CreateUserDTO) (*e
 		return nil, err
 	}
 
-	if reserved.IsReserved(req.Username) {
-		return nil, apperr.UsernameReserved()
-	}
-
 	user := &entity.User{
 		ID:           uuid.New(),
 		Username:     strings.ToLower(strings.TrimSpace(req.Username)),
@@ -45,37 +57,19 @@ func (u *UserUseCase) CreateUser(ctx context.Context, req dto.CreateUserDTO) (*e
 		PasswordHash: req.PasswordHash,
 	}
 
-	inner, err := json.Marshal(domain.UserCreatedEvent{
-		UserID:   user.ID.String(),
-		Username: user.Username,
-		Email:    user.Email,
-	})
-
-	type envelope struct {
-		EventType string          `json:"event_type"`
-		Payload   json.RawMessage `json:"payload"`
-	}
-
-	payload, err := json.Marshal(envelope{
-		EventType: "user.created",
-		Payload:   inner,
-	})
-
-	if err != nil {
-		return nil, commonapperr.Internal("marshal user.created event", err)
+	if err := u.checkUsername(ctx, u.uow.Reader(), user.Username, user.ID, time.Now()); err != nil {
+		return nil, err
 	}
 
-	err = u.uow.Do(ctx, func(tx domain.Tx) error {
-		if err = tx.Users().Create(ctx, user); err != nil {
+	err := u.uow.Do(ctx, func(tx domain.Tx) error {
+		if err := tx.Users().Create(ctx, user); err != nil {
 			return err
 		}
 
-		return tx.Outbox().Insert(ctx, &outbox.OutboxEvent{
-			ID:            uuid.New(),
-			AggregateID:   user.ID.String(),
-			AggregateType: "user",
-			EventType:     "user.created",
-			Payload:       payload,
+		return insertUserEvent(ctx, tx, user.ID, "user.created", domain.UserCreatedEvent{
+			UserID:   user.ID.String(),
+			Username: user.Username,
+			Email:    user.Email,
 		})
 	})
 
@@ -133,67 +127,238 @@ func (u *UserUseCase) GetUserByID(ctx context.Context, id uuid.UUID) (*entity.Us
 	return user, nil
 }
 
+// MaxUsersBatch — сколько пользователей можно запросить за раз через GetUsers.
+const MaxUsersBatch = 100
+
+// GetUsers возвращает пользователей в порядке ids; удалённые и несуществующие пропускаются.
+func (u *UserUseCase) GetUsers(ctx context.Context, ids []uuid.UUID) ([]*entity.User, error) {
+	unique := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+
+	for _, id := range ids {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			unique = append(unique, id)
+		}
+	}
+
+	if len(unique) > MaxUsersBatch {
+		return nil, commonapperr.Validation(
+			commonapperr.CodeFieldInvalid, "ids", fmt.Sprintf("at most %d ids per request", MaxUsersBatch),
+		)
+	}
+
+	found, err := u.uow.Reader().FindByIDs(ctx, unique)
+
+	if err != nil {
+		ctxlog.From(ctx).Error("failed to get users",
+			slog.String("op", "UserUseCase.GetUsers"),
+			slog.Any("error", err),
+		)
+
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*entity.User, len(found))
+
+	for _, user := range found {
+		byID[user.ID] = user
+	}
+
+	users := make([]*entity.User, 0, len(found))
+
+	for _, id := range unique {
+		if user, ok := byID[id]; ok {
+			users = append(users, user)
+		}
+	}
+
+	return users, nil
+}
+
 func (u *UserUseCase) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	log := ctxlog.From(ctx).With(
 		slog.String("op", "UserUseCase.DeleteUser"),
 		slog.String("user_id", id.String()),
 	)
 
-	inner, err := json.Marshal(domain.UserDeletedEvent{UserID: id.String()})
+	err := u.uow.Do(ctx, func(tx domain.Tx) error {
+		user, err := tx.Users().FindByID(ctx, id)
 
-	if err != nil {
-		return commonapperr.Internal("marshal user.deleted event", err)
-	}
+		if err != nil {
+			return err
+		}
 
-	type envelope struct {
-		EventType string          `json:"event_type"`
-		Payload   json.RawMessage `json:"payload"`
-	}
+		if user.IsDeleted() {
+			return apperr.UserNotFound()
+		}
 
-	payload, err := json.Marshal(envelope{
-		EventType: "user.deleted",
-		Payload:   inner,
+		return u.softDelete(ctx, tx, id)
 	})
 
 	if err != nil {
-		return commonapperr.Internal("marshal user.deleted envelope", err)
+		log.Error("failed to delete user", slog.Any("error", err))
+
+		return err
 	}
 
-	err = u.uow.Do(ctx, func(tx domain.Tx) error {
+	log.Info("user deleted")
+
+	return nil
+}
+
+// ScheduleDeletion переводит аккаунт в pending_deletion до момента at.
+// До этого аккаунт можно восстановить через CancelDeletion, после — его удалит FinalizeDueDeletions.
+func (u *UserUseCase) ScheduleDeletion(ctx context.Context, id uuid.UUID, at time.Time) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "UserUseCase.ScheduleDeletion"),
+		slog.String("user_id", id.String()),
+	)
+
+	err := u.uow.Do(ctx, func(tx domain.Tx) error {
 		user, err := tx.Users().FindByID(ctx, id)
 
 		if err != nil {
 			return err
 		}
 
-		if user.IsDeleted() {
+		switch {
+		case user.IsDeleted():
 			return apperr.UserNotFound()
+		case user.IsPendingDeletion():
+			return apperr.DeletionAlreadyScheduled()
+		// восстановление возвращает статус active, поэтому заблокированный аккаунт так бы разблокировался
+		case !user.IsActive():
+			return apperr.UserNotActive()
 		}
 
-		if err = tx.Users().SoftDelete(ctx, id); err != nil {
-			return err
-		}
+		return tx.Users().ScheduleDeletion(ctx, id, at)
+	})
 
-		return tx.Outbox().Insert(ctx, &outbox.OutboxEvent{
-			ID:            uuid.New(),
-			AggregateID:   id.String(),
-			AggregateType: "user",
-			EventType:     "user.deleted",
-			Payload:       payload,
-		})
+	if err != nil {
+		log.Warn("failed to schedule deletion", slog.Any("error", err))
+
+		return err
+	}
+
+	log.Info("user deletion scheduled", slog.Time("scheduled_for", at))
+
+	return nil
+}
+
+func (u *UserUseCase) CancelDeletion(ctx context.Context, id uuid.UUID) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "UserUseCase.CancelDeletion"),
+		slog.String("user_id", id.String()),
+	)
+
+	err := u.uow.Do(ctx, func(tx domain.Tx) error {
+		return tx.Users().CancelDeletion(ctx, id)
 	})
 
 	if err != nil {
-		log.Error("failed to delete user", slog.Any("error", err))
+		log.Warn("failed to cancel deletion", slog.Any("error", err))
 
 		return err
 	}
 
-	log.Info("user deleted")
+	log.Info("user deletion cancelled")
 
 	return nil
 }
 
+// FinalizeDueDeletions удаляет до limit аккаунтов с истёкшим сроком восстановления.
+// Каждый аккаунт удаляется в своей транзакции: ошибка на одном не откатывает остальные.
+func (u *UserUseCase) FinalizeDueDeletions(ctx context.Context, now time.Time, limit int) (int, error) {
+	log := ctxlog.From(ctx).With(slog.String("op", "UserUseCase.FinalizeDueDeletions"))
+
+	ids, err := u.uow.Reader().ListDueDeletions(ctx, now, limit)
+
+	if err != nil {
+		log.Error("failed to list due deletions", slog.Any("error", err))
+
+		return 0, err
+	}
+
+	deleted := 0
+
+	for _, id := range ids {
+		err = u.uow.Do(ctx, func(tx domain.Tx) error {
+			user, err := tx.Users().FindByID(ctx, id)
+
+			if err != nil {
+				return err
+			}
+
+			// пользователь мог восстановить аккаунт между выборкой и транзакцией
+			if !user.IsPendingDeletion() || user.IsDeleted() ||
+				user.DeletionScheduledAt == nil || user.DeletionScheduledAt.After(now) {
+				return errDeletionCancelled
+			}
+
+			return u.softDelete(ctx, tx, id)
+		})
+
+		switch {
+		case err == nil:
+			deleted++
+
+			log.Info("user deleted after grace period", slog.String("user_id", id.String()))
+		case errors.Is(err, errDeletionCancelled):
+			log.Debug("user deletion cancelled before finalization", slog.String("user_id", id.String()))
+		default:
+			log.Error("failed to finalize deletion",
+				slog.String("user_id", id.String()),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return deleted, nil
+}
+
+var errDeletionCancelled = errors.New("deletion cancelled")
+
+// softDelete помечает пользователя удалённым и пишет user.deleted в outbox той же транзакцией.
+func (u *UserUseCase) softDelete(ctx context.Context, tx domain.Tx, id uuid.UUID) error {
+	if err := tx.Users().SoftDelete(ctx, id); err != nil {
+		return err
+	}
+
+	return insertUserEvent(ctx, tx, id, "user.deleted", domain.UserDeletedEvent{UserID: id.String()})
+}
+
+// insertUserEvent пишет событие в outbox в том же конверте, что и остальные события пользователя.
+func insertUserEvent(ctx context.Context, tx domain.Tx, userID uuid.UUID, eventType string, event any) error {
+	inner, err := json.Marshal(event)
+
+	if err != nil {
+		return commonapperr.Internal("marshal "+eventType+" event", err)
+	}
+
+	type envelope struct {
+		EventType string          `json:"event_type"`
+		Payload   json.RawMessage `json:"payload"`
+	}
+
+	payload, err := json.Marshal(envelope{
+		EventType: eventType,
+		Payload:   inner,
+	})
+
+	if err != nil {
+		return commonapperr.Internal("marshal "+eventType+" envelope", err)
+	}
+
+	return tx.Outbox().Insert(ctx, &outbox.OutboxEvent{
+		ID:            uuid.New(),
+		AggregateID:   userID.String(),
+		AggregateType: "user",
+		EventType:     eventType,
+		Payload:       payload,
+	})
+}
+
 func (u *UserUseCase) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
 	log := ctxlog.From(ctx).With(
 		slog.String("op", "UserUseCase.GetUserByUsername"),
@@ -239,3 +404,142 @@ func (u *UserUseCase) ActivateUser(ctx context.Context, email string) error {
 
 	return nil
 }
+
+func (u *UserUseCase) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "UserUseCase.UpdatePassword"),
+		slog.String("user_id", userID.String()),
+	)
+
+	if passwordHash == "" {
+		return commonapperr.Validation(
+			commonapperr.CodeFieldRequired, "password_hash", "password_hash is required",
+		)
+	}
+
+	err := u.uow.Do(ctx, func(tx domain.Tx) error {
+		if err := tx.Users().UpdatePasswordHash(ctx, userID, passwordHash); err != nil {
+			return err
+		}
+
+		return u.insertUserUpdated(ctx, tx, userID, "password")
+	})
+
+	if err != nil {
+		log.Error("failed to update password hash", slog.Any("error", err))
+
+		return err
+	}
+
+	log.Info("password updated")
+
+	return nil
+}
+
+// RehashPassword заменяет хеш того же пароля на более стойкий. Пароль не меняется,
+// поэтому событие user.updated не публикуется; currentHash защищает от гонки со сменой пароля.
+func (u *UserUseCase) RehashPassword(ctx context.Context, userID uuid.UUID, currentHash, newHash string) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "UserUseCase.RehashPassword"),
+		slog.String("user_id", userID.String()),
+	)
+
+	if currentHash == "" || newHash == "" {
+		return commonapperr.Validation(
+			commonapperr.CodeFieldRequired, "new_hash", "current_hash and new_hash are required",
+		)
+	}
+
+	err := u.uow.Do(ctx, func(tx domain.Tx) error {
+		return tx.Users().ReplacePasswordHash(ctx, userID, currentHash, newHash)
+	})
+
+	if err != nil {
+		log.Warn("failed to rehash password", slog.Any("error", err))
+
+		return err
+	}
+
+	log.Debug("password rehashed")
+
+	return nil
+}
+
+func (u *UserUseCase) ChangeEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "UserUseCase.ChangeEmail"),
+		slog.String("user_id", userID.String()),
+	)
+
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	if email == "" {
+		return commonapperr.Validation(
+			commonapperr.CodeFieldRequired, "email", "email is required",
+		)
+	}
+
+	err := u.uow.Do(ctx, func(tx domain.Tx) error {
+		if err := tx.Users().UpdateEmail(ctx, userID, email); err != nil {
+			return err
+		}
+
+		return u.insertUserUpdated(ctx, tx, userID, "email")
+	})
+
+	if err != nil {
+		log.Error("failed to change email", slog.Any("error", err))
+
+		return err
+	}
+
+	log.Info("email changed")
+
+	return nil
+}
+
+// SetRole меняет роль пользователя. Новая роль попадёт в access-токен при следующем refresh.
+func (u *UserUseCase) SetRole(ctx context.Context, userID uuid.UUID, role roles.Role) error {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "UserUseCase.SetRole"),
+		slog.String("user_id", userID.String()),
+	)
+
+	if !role.Valid() {
+		return commonapperr.Validation(commonapperr.CodeFieldInvalid, "role", "unknown role")
+	}
+
+	err := u.uow.Do(ctx, func(tx domain.Tx) error {
+		if err := tx.Users().UpdateRole(ctx, userID, string(role)); err != nil {
+			return err
+		}
+
+		return u.insertUserUpdated(ctx, tx, userID, "role")
+	})
+
+	if err != nil {
+		log.Error("failed to set role", slog.Any("error", err))
+
+		return err
+	}
+
+	log.Info("role changed", slog.String("role", role.String()))
+
+	return nil
+}
+
+// insertUserUpdated пишет user.updated в outbox той же транзакцией, что и само изменение.
+func (u *UserUseCase) insertUserUpdated(ctx context.Context, tx domain.Tx, userID uuid.UUID, fields ...string) error {
+	user, err := tx.Users().FindByID(ctx, userID)
+
+	if err != nil {
+		return err
+	}
+
+	return insertUserEvent(ctx, tx, user.ID, "user.updated", domain.UserUpdatedEvent{
+		UserID:        user.ID.String(),
+		Username:      user.Username,
+		Email:         user.Email,
+		ChangedFields: fields,
+	})
+}
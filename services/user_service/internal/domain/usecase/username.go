@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/common_service/ctxlog"
+	"github.com/rockkley/pushpost/services/common_service/reserved"
+	apperr "github.com/rockkley/pushpost/services/user_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/user_service/internal/domain"
+	"github.com/rockkley/pushpost/services/user_service/internal/entity"
+	"github.com/rockkley/pushpost/services/user_service/internal/repository"
+)
+
+// ChangeUsername меняет имя не чаще раза в usernameCooldown. Прежнее имя остаётся
+// за пользователем на usernameRedirectTTL: по нему работает редирект и его нельзя занять.
+func (u *UserUseCase) ChangeUsername(ctx context.Context, userID uuid.UUID, username string) (*entity.User, error) {
+	log := ctxlog.From(ctx).With(
+		slog.String("op", "UserUseCase.ChangeUsername"),
+		slog.String("user_id", userID.String()),
+	)
+
+	username = strings.ToLower(strings.TrimSpace(username))
+
+	var (
+		user        *entity.User
+		oldUsername string
+	)
+
+	err := u.uow.Do(ctx, func(tx domain.Tx) error {
+		var err error
+
+		user, err = tx.Users().FindByID(ctx, userID)
+
+		if err != nil {
+			return err
+		}
+
+		if user.IsDeleted() {
+			return apperr.UserNotFound()
+		}
+
+		if user.Username == username {
+			return apperr.UsernameUnchanged()
+		}
+
+		now := time.Now()
+
+		last, err := tx.Users().LastUsernameChange(ctx, userID)
+
+		if err != nil {
+			return err
+		}
+
+		if last != nil {
+			if next := last.Add(u.usernameCooldown); now.Before(next) {
+				return apperr.UsernameChangeCooldown(next.Sub(now))
+			}
+		}
+
+		if err = u.checkUsername(ctx, tx.Users(), username, userID, now); err != nil {
+			return err
+		}
+
+		if err = tx.Users().UpdateUsername(ctx, userID, username); err != nil {
+			return err
+		}
+
+		if err = tx.Users().RecordUsernameChange(ctx, userID, user.Username); err != nil {
+			return err
+		}
+
+		oldUsername = user.Username
+		user.Username = username
+
+		return insertUserEvent(ctx, tx, userID, "user.username_changed", domain.UsernameChangedEvent{
+			UserID:      userID.String(),
+			OldUsername: oldUsername,
+			NewUsername: username,
+		})
+	})
+
+	if err != nil {
+		log.Warn("failed to change username", slog.Any("error", err))
+
+		return nil, err
+	}
+
+	log.Info("username changed", slog.String("old_username", oldUsername), slog.String("username", username))
+
+	return user, nil
+}
+
+// ResolveFormerUsername находит пользователя, который отказался от username в пределах
+// переходного периода.
+func (u *UserUseCase) ResolveFormerUsername(ctx context.Context, username string) (*entity.User, error) {
+	since := time.Now().Add(-u.usernameRedirectTTL)
+
+	ownerID, err := u.uow.Reader().FindUsernameOwner(ctx, username, since)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ownerID == uuid.Nil {
+		return nil, apperr.UserNotFound()
+	}
+
+	user, err := u.uow.Reader().FindByID(ctx, ownerID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeleted() {
+		return nil, apperr.UserNotFound()
+	}
+
+	return user, nil
+}
+
+// checkUsername проверяет имя по политике, затем ищет похожие имена других пользователей
+// и чужие прежние имена. Точное совпадение с текущим именем ловит и уникальный индекс,
+// но проверка скелета срабатывает раньше, поэтому различаем эти случаи здесь.
+func (u *UserUseCase) checkUsername(
+	ctx context.Context,
+	users repository.UserRepositoryInterface,
+	username string,
+	userID uuid.UUID,
+	now time.Time,
+) error {
+	switch err := u.usernamePolicy.Check(username); {
+	case errors.Is(err, reserved.ErrReserved):
+		return apperr.UsernameReserved()
+	case errors.Is(err, reserved.ErrForbidden):
+		return apperr.UsernameForbidden()
+	}
+
+	similar, err := users.FindUsernameBySkeleton(ctx, reserved.Skeleton(username), userID)
+
+	if err != nil {
+		return err
+	}
+
+	if similar != "" {
+		if strings.EqualFold(similar, username) {
+			return apperr.UsernameAlreadyExists()
+		}
+
+		return apperr.UsernameConfusable()
+	}
+
+	return u.checkFormerUsername(ctx, users, username, userID, now)
+}
+
+// checkFormerUsername не даёт занять чужое прежнее имя, пока по нему работает редирект.
+// Своё прежнее имя вернуть можно.
+func (u *UserUseCase) checkFormerUsername(
+	ctx context.Context,
+	users repository.UserRepositoryInterface,
+	username string,
+	userID uuid.UUID,
+	now time.Time,
+) error {
+	ownerID, err := users.FindUsernameOwner(ctx, username, now.Add(-u.usernameRedirectTTL))
+
+	if err != nil {
+		return err
+	}
+
+	if ownerID != uuid.Nil && ownerID != userID {
+		return apperr.UsernameAlreadyExists()
+	}
+
+	return nil
+}
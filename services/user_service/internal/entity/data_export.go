@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ExportStatusPending = "pending"
+	ExportStatusRunning = "running"
+	ExportStatusReady   = "ready"
+	ExportStatusFailed  = "failed"
+	// ExportStatusExpired — архив удалён из хранилища по истечении срока.
+	ExportStatusExpired = "expired"
+)
+
+// DataExport — запрос на выгрузку персональных данных.
+// ObjectKey и ExpiresAt заданы в статусе ready; Error — в статусе failed.
+type DataExport struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Status     string
+	Attempts   int
+	ObjectKey  *string
+	Error      *string
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	ExpiresAt  *time.Time
+}
+
+func (e *DataExport) InProgress() bool {
+	return e.Status == ExportStatusPending || e.Status == ExportStatusRunning
+}
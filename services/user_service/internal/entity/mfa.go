@@ -0,0 +1,14 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// MFA — секрет зашифрован на стороне auth_service, user_service хранит его как есть.
+type MFA struct {
+	UserID            uuid.UUID `json:"user_id"`
+	TOTPSecret        string    `json:"totp_secret"`
+	RecoveryCodesLeft int       `json:"recovery_codes_left"`
+	EnabledAt         time.Time `json:"enabled_at"`
+}
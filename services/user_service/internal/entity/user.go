@@ -10,6 +10,8 @@ const (
 	StatusActive   = "active"
 	StatusBlocked  = "blocked"
 	StatusDeleted  = "deleted"
+	// StatusPendingDeletion — пользователь запросил удаление, но ещё может восстановить аккаунт входом.
+	StatusPendingDeletion = "pending_deletion"
 )
 
 type User struct {
@@ -18,10 +20,26 @@ type User struct {
 	Email        string     `json:"email"`
 	PasswordHash string     `json:"password_hash"`
 	Status       string     `json:"status"`
+	Role         string     `json:"role"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
 	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	// DeletionScheduledAt задан только в статусе pending_deletion.
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+	// SuspendedUntil и SuspensionReason заданы в статусе blocked; nil — бессрочная блокировка.
+	SuspendedUntil   *time.Time `json:"suspended_until,omitempty"`
+	SuspensionReason string     `json:"suspension_reason,omitempty"`
+}
+
+// UsernameChange — прежнее имя пользователя и момент, когда от него отказались.
+type UsernameChange struct {
+	Username  string    `json:"username"`
+	ChangedAt time.Time `json:"changed_at"`
 }
 
 func (u *User) IsDeleted() bool { return u.DeletedAt != nil }
 func (u *User) IsActive() bool  { return u.Status == StatusActive }
+
+func (u *User) IsPendingDeletion() bool { return u.Status == StatusPendingDeletion }
+
+func (u *User) IsSuspended() bool { return u.Status == StatusBlocked }
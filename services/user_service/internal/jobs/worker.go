@@ -0,0 +1,79 @@
+// Package jobs периодически выполняет отложенные изменения аккаунтов:
+// удаление после срока восстановления, снятие временных блокировок.
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Batch обрабатывает до limit записей, срок которых наступил к now, и возвращает, сколько обработано.
+type Batch func(ctx context.Context, now time.Time, limit int) (int, error)
+
+type WorkerConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+type Worker struct {
+	batch     Batch
+	interval  time.Duration
+	batchSize int
+	log       *slog.Logger
+}
+
+func NewWorker(name string, batch Batch, cfg WorkerConfig, log *slog.Logger) *Worker {
+	if log == nil {
+		log = slog.Default()
+	}
+
+	return &Worker{
+		batch:     batch,
+		interval:  cfg.Interval,
+		batchSize: cfg.BatchSize,
+		log:       log.With("component", name),
+	}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.log.Info("job worker started",
+		slog.Duration("interval", w.interval),
+		slog.Int("batch_size", w.batchSize))
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("job worker stopped")
+
+			return
+
+		case <-ticker.C:
+			w.drain(ctx)
+		}
+	}
+}
+
+// drain разбирает очередь пачками, пока она не опустеет, чтобы не ждать следующего тика.
+func (w *Worker) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := w.batch(ctx, time.Now(), w.batchSize)
+
+		if err != nil {
+			w.log.Error("job batch failed", slog.Any("error", err))
+
+			return
+		}
+
+		if processed > 0 {
+			w.log.Info("job batch processed", slog.Int("count", processed))
+		}
+
+		if processed < w.batchSize {
+			return
+		}
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rockkley/pushpost/services/user_service/internal/entity"
@@ -10,10 +11,47 @@ import (
 type UserRepositoryInterface interface {
 	Create(ctx context.Context, user *entity.User) error
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
 	FindByUsername(ctx context.Context, username string) (*entity.User, error)
 	EmailExists(ctx context.Context, email string) (bool, error)
 	UsernameExists(ctx context.Context, username string) (bool, error)
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 	ActivateUser(ctx context.Context, email string) error
+	UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error
+	// ReplacePasswordHash меняет хеш, только если он всё ещё равен currentHash.
+	ReplacePasswordHash(ctx context.Context, id uuid.UUID, currentHash, newHash string) error
+	UpdateEmail(ctx context.Context, id uuid.UUID, email string) error
+	UpdateRole(ctx context.Context, id uuid.UUID, role string) error
+	UpdateUsername(ctx context.Context, id uuid.UUID, username string) error
+	RecordUsernameChange(ctx context.Context, id uuid.UUID, oldUsername string) error
+	LastUsernameChange(ctx context.Context, id uuid.UUID) (*time.Time, error)
+	UsernameHistory(ctx context.Context, id uuid.UUID) ([]entity.UsernameChange, error)
+	FindUsernameOwner(ctx context.Context, username string, since time.Time) (uuid.UUID, error)
+	FindUsernameBySkeleton(ctx context.Context, skeleton string, exceptID uuid.UUID) (string, error)
+	ScheduleDeletion(ctx context.Context, id uuid.UUID, at time.Time) error
+	CancelDeletion(ctx context.Context, id uuid.UUID) error
+	ListDueDeletions(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error)
+	Suspend(ctx context.Context, id uuid.UUID, until *time.Time, reason string) error
+	Reinstate(ctx context.Context, id uuid.UUID) error
+	ListExpiredSuspensions(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error)
+}
+
+type MFARepositoryInterface interface {
+	Get(ctx context.Context, userID uuid.UUID) (*entity.MFA, error)
+	Save(ctx context.Context, userID uuid.UUID, totpSecret string) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	Delete(ctx context.Context, userID uuid.UUID) error
+	ConsumeRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+}
+
+type DataExportRepositoryInterface interface {
+	Create(ctx context.Context, e *entity.DataExport) error
+	FindLatest(ctx context.Context, userID uuid.UUID) (*entity.DataExport, error)
+	Claim(ctx context.Context, now, staleBefore time.Time, limit int) ([]*entity.DataExport, error)
+	MarkReady(ctx context.Context, id uuid.UUID, objectKey string, finishedAt, expiresAt time.Time) error
+	Retry(ctx context.Context, id uuid.UUID, reason string) error
+	MarkFailed(ctx context.Context, id uuid.UUID, reason string, finishedAt time.Time) error
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.DataExport, error)
+	MarkExpired(ctx context.Context, id uuid.UUID) error
 }
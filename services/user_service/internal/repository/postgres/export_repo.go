@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/database"
+	"github.com/rockkley/pushpost/services/user_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/user_service/internal/entity"
+)
+
+const exportColumns = `id, user_id, status, attempts, object_key, error,
+		       created_at, started_at, finished_at, expires_at`
+
+type ExportRepository struct {
+	exec database.Executor
+}
+
+func NewExportRepository(exec database.Executor) *ExportRepository {
+	return &ExportRepository{exec: exec}
+}
+
+func (r *ExportRepository) Create(ctx context.Context, e *entity.DataExport) error {
+	const query = `
+		INSERT INTO data_exports (id, user_id, status)
+		VALUES ($1, $2, $3)
+		RETURNING created_at`
+
+	err := r.exec.QueryRowContext(ctx, query, e.ID, e.UserID, e.Status).Scan(&e.CreatedAt)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "create data export", apperror.MapConstraint)
+	}
+
+	return nil
+}
+
+func (r *ExportRepository) FindLatest(ctx context.Context, userID uuid.UUID) (*entity.DataExport, error) {
+	query := `
+		SELECT ` + exportColumns + `
+		FROM   data_exports
+		WHERE  user_id = $1
+		ORDER  BY created_at DESC
+		LIMIT  1`
+
+	e, err := scanExport(r.exec.QueryRowContext(ctx, query, userID))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ExportNotFound()
+		}
+
+		return nil, commonapperr.MapPostgresError(err, "find latest data export")
+	}
+
+	return e, nil
+}
+
+// Claim переводит до limit ожидающих выгрузок в running и возвращает их.
+// Попытка, начатая раньше staleBefore, считается брошенной (упавший под) или
+// неудачной — такая выгрузка забирается снова; так же выдерживается пауза между повторами.
+func (r *ExportRepository) Claim(ctx context.Context, now, staleBefore time.Time, limit int) ([]*entity.DataExport, error) {
+	query := `
+		UPDATE data_exports
+		SET    status = 'running', started_at = $1, attempts = attempts + 1
+		WHERE  id IN (
+			SELECT id
+			FROM   data_exports
+			WHERE  status IN ('pending', 'running')
+			  AND (started_at IS NULL OR started_at < $2)
+			ORDER  BY created_at
+			LIMIT  $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + exportColumns
+
+	rows, err := r.exec.QueryContext(ctx, query, now, staleBefore, limit)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "claim data exports")
+	}
+
+	defer rows.Close()
+
+	var exports []*entity.DataExport
+
+	for rows.Next() {
+		e, err := scanExport(rows)
+
+		if err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan data export")
+		}
+
+		exports = append(exports, e)
+	}
+
+	return exports, rows.Err()
+}
+
+func (r *ExportRepository) MarkReady(ctx context.Context, id uuid.UUID, objectKey string, finishedAt, expiresAt time.Time) error {
+	const query = `
+		UPDATE data_exports
+		SET    status = 'ready', object_key = $2, error = NULL, finished_at = $3, expires_at = $4
+		WHERE  id = $1 AND status = 'running'`
+
+	return r.update(ctx, "mark data export ready", query, id, objectKey, finishedAt, expiresAt)
+}
+
+// Retry возвращает выгрузку в очередь после неудачной попытки.
+func (r *ExportRepository) Retry(ctx context.Context, id uuid.UUID, reason string) error {
+	const query = `
+		UPDATE data_exports
+		SET    status = 'pending', error = $2
+		WHERE  id = $1 AND status = 'running'`
+
+	return r.update(ctx, "retry data export", query, id, reason)
+}
+
+func (r *ExportRepository) MarkFailed(ctx context.Context, id uuid.UUID, reason string, finishedAt time.Time) error {
+	const query = `
+		UPDATE data_exports
+		SET    status = 'failed', error = $2, finished_at = $3
+		WHERE  id = $1 AND status = 'running'`
+
+	return r.update(ctx, "mark data export failed", query, id, reason, finishedAt)
+}
+
+func (r *ExportRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.DataExport, error) {
+	query := `
+		SELECT ` + exportColumns + `
+		FROM   data_exports
+		WHERE  status = 'ready' AND expires_at <= $1
+		ORDER  BY expires_at
+		LIMIT  $2`
+
+	rows, err := r.exec.QueryContext(ctx, query, now, limit)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "list expired data exports")
+	}
+
+	defer rows.Close()
+
+	var exports []*entity.DataExport
+
+	for rows.Next() {
+		e, err := scanExport(rows)
+
+		if err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan data export")
+		}
+
+		exports = append(exports, e)
+	}
+
+	return exports, rows.Err()
+}
+
+func (r *ExportRepository) MarkExpired(ctx context.Context, id uuid.UUID) error {
+	const query = `
+		UPDATE data_exports
+		SET    status = 'expired', object_key = NULL
+		WHERE  id = $1 AND status = 'ready'`
+
+	return r.update(ctx, "mark data export expired", query, id)
+}
+
+func (r *ExportRepository) update(ctx context.Context, op, query string, args ...any) error {
+	res, err := r.exec.ExecContext(ctx, query, args...)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, op)
+	}
+
+	rows, err := res.RowsAffected()
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, op)
+	}
+
+	if rows == 0 {
+		return apperror.ExportNotFound()
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanExport(row rowScanner) (*entity.DataExport, error) {
+	var e entity.DataExport
+
+	err := row.Scan(
+		&e.ID, &e.UserID, &e.Status, &e.Attempts, &e.ObjectKey, &e.Error,
+		&e.CreatedAt, &e.StartedAt, &e.FinishedAt, &e.ExpiresAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/database"
+	"github.com/rockkley/pushpost/services/user_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/user_service/internal/entity"
+)
+
+type MFARepository struct {
+	exec database.Executor
+}
+
+func NewMFARepository(exec database.Executor) *MFARepository {
+	return &MFARepository{exec: exec}
+}
+
+func (r *MFARepository) Get(ctx context.Context, userID uuid.UUID) (*entity.MFA, error) {
+	const query = `
+		SELECT m.user_id, m.totp_secret, m.enabled_at,
+		       (SELECT COUNT(*) FROM user_mfa_recovery_codes c
+		        WHERE  c.user_id = m.user_id AND c.used_at IS NULL)
+		FROM   user_mfa m
+		WHERE  m.user_id = $1`
+
+	var m entity.MFA
+
+	err := r.exec.QueryRowContext(ctx, query, userID).Scan(
+		&m.UserID, &m.TOTPSecret, &m.EnabledAt, &m.RecoveryCodesLeft,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.MFANotEnabled()
+		}
+		return nil, commonapperr.MapPostgresError(err, "get mfa")
+	}
+
+	return &m, nil
+}
+
+func (r *MFARepository) Save(ctx context.Context, userID uuid.UUID, totpSecret string) error {
+	const query = `
+		INSERT INTO user_mfa (user_id, totp_secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET totp_secret = EXCLUDED.totp_secret, enabled_at = NOW()`
+
+	if _, err := r.exec.ExecContext(ctx, query, userID, totpSecret); err != nil {
+		return commonapperr.MapPostgresError(err, "save mfa")
+	}
+
+	return nil
+}
+
+func (r *MFARepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	const deleteQuery = `DELETE FROM user_mfa_recovery_codes WHERE user_id = $1`
+
+	if _, err := r.exec.ExecContext(ctx, deleteQuery, userID); err != nil {
+		return commonapperr.MapPostgresError(err, "delete recovery codes")
+	}
+
+	const insertQuery = `
+		INSERT INTO user_mfa_recovery_codes (user_id, code_hash)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`
+
+	for _, hash := range codeHashes {
+		if _, err := r.exec.ExecContext(ctx, insertQuery, userID, hash); err != nil {
+			return commonapperr.MapPostgresError(err, "insert recovery code")
+		}
+	}
+
+	return nil
+}
+
+func (r *MFARepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	const query = `DELETE FROM user_mfa WHERE user_id = $1`
+
+	result, err := r.exec.ExecContext(ctx, query, userID)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "delete mfa")
+	}
+
+	rows, err := result.RowsAffected()
+
+	if err != nil {
+		return commonapperr.Internal("failed to get rows affected", err)
+	}
+
+	if rows == 0 {
+		return apperror.MFANotEnabled()
+	}
+
+	return nil
+}
+
+// ConsumeRecoveryCode помечает код использованным; повторно тот же код не сработает.
+func (r *MFARepository) ConsumeRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	const query = `
+		UPDATE user_mfa_recovery_codes
+		SET    used_at = NOW()
+		WHERE  user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	result, err := r.exec.ExecContext(ctx, query, userID, codeHash)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "consume recovery code")
+	}
+
+	rows, err := result.RowsAffected()
+
+	if err != nil {
+		return commonapperr.Internal("failed to get rows affected", err)
+	}
+
+	if rows == 0 {
+		return apperror.RecoveryCodeInvalid()
+	}
+
+	return nil
+}
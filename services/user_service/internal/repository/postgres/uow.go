@@ -12,12 +12,16 @@ import (
 )
 
 type uowTx struct {
-	users  repository.UserRepositoryInterface
-	outbox outbox2.WriterInterface
+	users   repository.UserRepositoryInterface
+	outbox  outbox2.WriterInterface
+	mfa     repository.MFARepositoryInterface
+	exports repository.DataExportRepositoryInterface
 }
 
-func (t *uowTx) Users() repository.UserRepositoryInterface { return t.users }
-func (t *uowTx) Outbox() outbox2.WriterInterface           { return t.outbox }
+func (t *uowTx) Users() repository.UserRepositoryInterface         { return t.users }
+func (t *uowTx) Outbox() outbox2.WriterInterface                   { return t.outbox }
+func (t *uowTx) MFA() repository.MFARepositoryInterface            { return t.mfa }
+func (t *uowTx) Exports() repository.DataExportRepositoryInterface { return t.exports }
 
 type UnitOfWork struct {
 	db *sql.DB
@@ -31,6 +35,14 @@ func (u *UnitOfWork) Reader() repository.UserRepositoryInterface {
 	return NewUserRepository(u.db)
 }
 
+func (u *UnitOfWork) MFAReader() repository.MFARepositoryInterface {
+	return NewMFARepository(u.db)
+}
+
+func (u *UnitOfWork) ExportReader() repository.DataExportRepositoryInterface {
+	return NewExportRepository(u.db)
+}
+
 func (u *UnitOfWork) Do(ctx context.Context, fn func(domain.Tx) error) error {
 	sqlTx, err := u.db.BeginTx(ctx, nil)
 
@@ -41,8 +53,10 @@ func (u *UnitOfWork) Do(ctx context.Context, fn func(domain.Tx) error) error {
 	defer sqlTx.Rollback()
 
 	t := &uowTx{
-		users:  NewUserRepository(sqlTx),
-		outbox: outboxpg.NewWriterRepository(sqlTx),
+		users:   NewUserRepository(sqlTx),
+		outbox:  outboxpg.NewWriterRepository(sqlTx),
+		mfa:     NewMFARepository(sqlTx),
+		exports: NewExportRepository(sqlTx),
 	}
 
 	if err = fn(t); err != nil {
@@ -10,6 +10,8 @@ import (
 	"github.com/google/uuid"
 	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
 	"github.com/rockkley/pushpost/services/common_service/database"
+	"github.com/rockkley/pushpost/services/common_service/reserved"
+	"github.com/rockkley/pushpost/services/common_service/roles"
 	"github.com/rockkley/pushpost/services/user_service/internal/apperror"
 	"github.com/rockkley/pushpost/services/user_service/internal/entity"
 )
@@ -24,12 +26,12 @@ func NewUserRepository(exec database.Executor) *UserRepository {
 
 func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
 	const query = `
-		INSERT INTO users (id, username, email, password_hash)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO users (id, username, username_skeleton, email, password_hash)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING created_at, updated_at`
 
 	err := r.exec.QueryRowContext(ctx, query,
-		user.ID, user.Username, user.Email, user.PasswordHash,
+		user.ID, user.Username, reserved.Skeleton(user.Username), user.Email, user.PasswordHash,
 	).Scan(&user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
@@ -37,13 +39,15 @@ func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
 	}
 
 	user.Status = entity.StatusInactive
+	user.Role = string(roles.User)
 
 	return nil
 }
 
 func (r *UserRepository) FindByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
 	const query = `
-		SELECT id, username, email, password_hash, status, created_at, updated_at, deleted_at
+		SELECT id, username, email, password_hash, status, created_at, updated_at, deleted_at,
+		       deletion_scheduled_at, suspended_until, suspension_reason, role
 		FROM users
 		WHERE id = $1`
 
@@ -51,7 +55,8 @@ func (r *UserRepository) FindByID(ctx context.Context, userID uuid.UUID) (*entit
 
 	err := r.exec.QueryRowContext(ctx, query, userID).Scan(
 		&u.ID, &u.Username, &u.Email, &u.PasswordHash,
-		&u.Status, &u.CreatedAt, &u.UpdatedAt, &u.DeletedAt,
+		&u.Status, &u.CreatedAt, &u.UpdatedAt, &u.DeletedAt, &u.DeletionScheduledAt,
+		&u.SuspendedUntil, &u.SuspensionReason, &u.Role,
 	)
 
 	if err != nil {
@@ -66,7 +71,8 @@ func (r *UserRepository) FindByID(ctx context.Context, userID uuid.UUID) (*entit
 
 func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, status, created_at, updated_at, deleted_at
+		SELECT id, username, email, password_hash, status, created_at, updated_at, deleted_at,
+		       deletion_scheduled_at, suspended_until, suspension_reason, role
 		FROM users
 		WHERE email = $1 AND deleted_at IS NULL`
 
@@ -76,7 +82,8 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*ent
 
 	err := r.exec.QueryRowContext(ctx, query, email).Scan(
 		&u.ID, &u.Username, &u.Email, &u.PasswordHash,
-		&u.Status, &u.CreatedAt, &u.UpdatedAt, &u.DeletedAt,
+		&u.Status, &u.CreatedAt, &u.UpdatedAt, &u.DeletedAt, &u.DeletionScheduledAt,
+		&u.SuspendedUntil, &u.SuspensionReason, &u.Role,
 	)
 
 	if err != nil {
@@ -89,9 +96,53 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*ent
 	return &u, nil
 }
 
+// FindByIDs возвращает найденных пользователей в произвольном порядке; отсутствующие id пропускаются.
+func (r *UserRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	const query = `
+		SELECT id, username, email, password_hash, status, created_at, updated_at, deleted_at,
+		       deletion_scheduled_at, suspended_until, suspension_reason, role
+		FROM users
+		WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL`
+
+	rows, err := r.exec.QueryContext(ctx, query, ids)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "find users by ids")
+	}
+
+	defer rows.Close()
+
+	users := make([]*entity.User, 0, len(ids))
+
+	for rows.Next() {
+		var u entity.User
+
+		if err = rows.Scan(
+			&u.ID, &u.Username, &u.Email, &u.PasswordHash,
+			&u.Status, &u.CreatedAt, &u.UpdatedAt, &u.DeletedAt, &u.DeletionScheduledAt,
+			&u.SuspendedUntil, &u.SuspensionReason, &u.Role,
+		); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan user")
+		}
+
+		users = append(users, &u)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "iterate users")
+	}
+
+	return users, nil
+}
+
 func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, status, created_at, updated_at, deleted_at
+		SELECT id, username, email, password_hash, status, created_at, updated_at, deleted_at,
+		       deletion_scheduled_at, suspended_until, suspension_reason, role
 		FROM users
 		WHERE LOWER(username) = LOWER($1) AND deleted_at IS NULL`
 	username = strings.TrimSpace(username)
@@ -100,7 +151,8 @@ func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*
 
 	err := r.exec.QueryRowContext(ctx, query, username).Scan(
 		&u.ID, &u.Username, &u.Email, &u.PasswordHash,
-		&u.Status, &u.CreatedAt, &u.UpdatedAt, &u.DeletedAt,
+		&u.Status, &u.CreatedAt, &u.UpdatedAt, &u.DeletedAt, &u.DeletionScheduledAt,
+		&u.SuspendedUntil, &u.SuspensionReason, &u.Role,
 	)
 
 	if err != nil {
@@ -147,7 +199,7 @@ func (r *UserRepository) UsernameExists(ctx context.Context, username string) (b
 
 func (r *UserRepository) SoftDelete(ctx context.Context, userID uuid.UUID) error {
 	query := `
-		UPDATE users SET deleted_at = $1
+		UPDATE users SET deleted_at = $1, status = 'deleted', deletion_scheduled_at = NULL
 		WHERE id = $2 AND deleted_at IS NULL`
 
 	result, err := r.exec.ExecContext(ctx, query, time.Now(), userID)
@@ -194,3 +246,404 @@ func (r *UserRepository) ActivateUser(ctx context.Context, email string) error {
 
 	return nil
 }
+
+func (r *UserRepository) ReplacePasswordHash(ctx context.Context, id uuid.UUID, currentHash, newHash string) error {
+	const query = `
+		UPDATE users
+		SET    password_hash = $3, updated_at = NOW()
+		WHERE  id = $1 AND password_hash = $2 AND deleted_at IS NULL`
+
+	result, err := r.exec.ExecContext(ctx, query, id, currentHash, newHash)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "replace password hash")
+	}
+
+	rows, err := result.RowsAffected()
+
+	if err != nil {
+		return commonapperr.Internal("failed to get rows affected", err)
+	}
+
+	if rows == 0 {
+		return apperror.PasswordHashChanged()
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	const query = `
+		UPDATE users
+		SET    password_hash = $2, updated_at = NOW()
+		WHERE  id = $1 AND deleted_at IS NULL`
+
+	result, err := r.exec.ExecContext(ctx, query, id, passwordHash)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "update password hash")
+	}
+
+	rows, err := result.RowsAffected()
+
+	if err != nil {
+		return commonapperr.Internal("failed to get rows affected", err)
+	}
+
+	if rows == 0 {
+		return apperror.UserNotFound()
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateEmail(ctx context.Context, id uuid.UUID, email string) error {
+	const query = `
+		UPDATE users
+		SET    email = $2, updated_at = NOW()
+		WHERE  id = $1 AND deleted_at IS NULL`
+
+	result, err := r.exec.ExecContext(ctx, query, id, strings.ToLower(strings.TrimSpace(email)))
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "update email", apperror.MapConstraint)
+	}
+
+	rows, err := result.RowsAffected()
+
+	if err != nil {
+		return commonapperr.Internal("failed to get rows affected", err)
+	}
+
+	if rows == 0 {
+		return apperror.UserNotFound()
+	}
+
+	return nil
+}
+
+func (r *UserRepository) ScheduleDeletion(ctx context.Context, id uuid.UUID, at time.Time) error {
+	const query = `
+		UPDATE users
+		SET    status = 'pending_deletion', deletion_scheduled_at = $2, updated_at = NOW()
+		WHERE  id = $1 AND deleted_at IS NULL`
+
+	result, err := r.exec.ExecContext(ctx, query, id, at)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "schedule deletion")
+	}
+
+	rows, err := result.RowsAffected()
+
+	if err != nil {
+		return commonapperr.Internal("failed to get rows affected", err)
+	}
+
+	if rows == 0 {
+		return apperror.UserNotFound()
+	}
+
+	return nil
+}
+
+func (r *UserRepository) CancelDeletion(ctx context.Context, id uuid.UUID) error {
+	const query = `
+		UPDATE users
+		SET    status = 'active', deletion_scheduled_at = NULL, updated_at = NOW()
+		WHERE  id = $1 AND status = 'pending_deletion' AND deleted_at IS NULL`
+
+	result, err := r.exec.ExecContext(ctx, query, id)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "cancel deletion")
+	}
+
+	rows, err := result.RowsAffected()
+
+	if err != nil {
+		return commonapperr.Internal("failed to get rows affected", err)
+	}
+
+	if rows == 0 {
+		return apperror.DeletionNotScheduled()
+	}
+
+	return nil
+}
+
+// ListDueDeletions возвращает аккаунты, срок восстановления которых истёк к now.
+func (r *UserRepository) ListDueDeletions(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	const query = `
+		SELECT id
+		FROM   users
+		WHERE  status = 'pending_deletion' AND deleted_at IS NULL AND deletion_scheduled_at <= $1
+		ORDER  BY deletion_scheduled_at
+		LIMIT  $2`
+
+	rows, err := r.exec.QueryContext(ctx, query, now, limit)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "list due deletions")
+	}
+
+	defer rows.Close()
+
+	var ids []uuid.UUID
+
+	for rows.Next() {
+		var id uuid.UUID
+
+		if err = rows.Scan(&id); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan due deletion")
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "iterate due deletions")
+	}
+
+	return ids, nil
+}
+
+func (r *UserRepository) UpdateUsername(ctx context.Context, id uuid.UUID, username string) error {
+	const query = `
+		UPDATE users
+		SET    username = $2, username_skeleton = $3, updated_at = NOW()
+		WHERE  id = $1 AND deleted_at IS NULL`
+
+	result, err := r.exec.ExecContext(ctx, query, id, username, reserved.Skeleton(username))
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "update username", apperror.MapConstraint)
+	}
+
+	rows, err := result.RowsAffected()
+
+	if err != nil {
+		return commonapperr.Internal("failed to get rows affected", err)
+	}
+
+	if rows == 0 {
+		return apperror.UserNotFound()
+	}
+
+	return nil
+}
+
+// RecordUsernameChange сохраняет прежнее имя пользователя в истории.
+func (r *UserRepository) RecordUsernameChange(ctx context.Context, id uuid.UUID, oldUsername string) error {
+	const query = `INSERT INTO username_history (user_id, username) VALUES ($1, $2)`
+
+	if _, err := r.exec.ExecContext(ctx, query, id, oldUsername); err != nil {
+		return commonapperr.MapPostgresError(err, "record username change")
+	}
+
+	return nil
+}
+
+// LastUsernameChange возвращает nil, если пользователь ещё не менял имя.
+func (r *UserRepository) LastUsernameChange(ctx context.Context, id uuid.UUID) (*time.Time, error) {
+	const query = `SELECT MAX(changed_at) FROM username_history WHERE user_id = $1`
+
+	var last *time.Time
+
+	if err := r.exec.QueryRowContext(ctx, query, id).Scan(&last); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "get last username change")
+	}
+
+	return last, nil
+}
+
+func (r *UserRepository) UsernameHistory(ctx context.Context, id uuid.UUID) ([]entity.UsernameChange, error) {
+	const query = `
+		SELECT username, changed_at
+		FROM   username_history
+		WHERE  user_id = $1
+		ORDER  BY changed_at`
+
+	rows, err := r.exec.QueryContext(ctx, query, id)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "get username history")
+	}
+
+	defer rows.Close()
+
+	var history []entity.UsernameChange
+
+	for rows.Next() {
+		var c entity.UsernameChange
+
+		if err = rows.Scan(&c.Username, &c.ChangedAt); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan username history")
+		}
+
+		history = append(history, c)
+	}
+
+	return history, rows.Err()
+}
+
+// FindUsernameOwner возвращает пользователя, который последним отказался от username
+// после since, или uuid.Nil, если такого нет.
+func (r *UserRepository) FindUsernameOwner(ctx context.Context, username string, since time.Time) (uuid.UUID, error) {
+	const query = `
+		SELECT user_id
+		FROM   username_history
+		WHERE  LOWER(username) = LOWER($1) AND changed_at > $2
+		ORDER  BY changed_at DESC
+		LIMIT  1`
+
+	var owner uuid.UUID
+
+	err := r.exec.QueryRowContext(ctx, query, strings.TrimSpace(username), since).Scan(&owner)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, nil
+		}
+
+		return uuid.Nil, commonapperr.MapPostgresError(err, "find username owner")
+	}
+
+	return owner, nil
+}
+
+// FindUsernameBySkeleton возвращает имя другого пользователя с тем же скелетом
+// или пустую строку, если такого нет.
+func (r *UserRepository) FindUsernameBySkeleton(ctx context.Context, skeleton string, exceptID uuid.UUID) (string, error) {
+	const query = `
+		SELECT username
+		FROM   users
+		WHERE  username_skeleton = $1 AND id <> $2 AND deleted_at IS NULL
+		LIMIT  1`
+
+	var username string
+
+	err := r.exec.QueryRowContext(ctx, query, skeleton, exceptID).Scan(&username)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+
+		return "", commonapperr.MapPostgresError(err, "find username by skeleton")
+	}
+
+	return username, nil
+}
+
+func (r *UserRepository) UpdateRole(ctx context.Context, id uuid.UUID, role string) error {
+	const query = `
+		UPDATE users
+		SET    role = $2, updated_at = NOW()
+		WHERE  id = $1 AND deleted_at IS NULL`
+
+	result, err := r.exec.ExecContext(ctx, query, id, role)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "update user role")
+	}
+
+	rows, err := result.RowsAffected()
+
+	if err != nil {
+		return commonapperr.Internal("failed to get rows affected", err)
+	}
+
+	if rows == 0 {
+		return apperror.UserNotFound()
+	}
+
+	return nil
+}
+
+// Suspend блокирует аккаунт или меняет срок и причину уже действующей блокировки.
+func (r *UserRepository) Suspend(ctx context.Context, id uuid.UUID, until *time.Time, reason string) error {
+	const query = `
+		UPDATE users
+		SET    status = 'blocked', suspended_until = $2, suspension_reason = $3, updated_at = NOW()
+		WHERE  id = $1 AND status IN ('active', 'blocked') AND deleted_at IS NULL`
+
+	result, err := r.exec.ExecContext(ctx, query, id, until, reason)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "suspend user")
+	}
+
+	rows, err := result.RowsAffected()
+
+	if err != nil {
+		return commonapperr.Internal("failed to get rows affected", err)
+	}
+
+	if rows == 0 {
+		return apperror.UserNotFound()
+	}
+
+	return nil
+}
+
+func (r *UserRepository) Reinstate(ctx context.Context, id uuid.UUID) error {
+	const query = `
+		UPDATE users
+		SET    status = 'active', suspended_until = NULL, suspension_reason = '', updated_at = NOW()
+		WHERE  id = $1 AND status = 'blocked' AND deleted_at IS NULL`
+
+	result, err := r.exec.ExecContext(ctx, query, id)
+
+	if err != nil {
+		return commonapperr.MapPostgresError(err, "reinstate user")
+	}
+
+	rows, err := result.RowsAffected()
+
+	if err != nil {
+		return commonapperr.Internal("failed to get rows affected", err)
+	}
+
+	if rows == 0 {
+		return apperror.UserNotSuspended()
+	}
+
+	return nil
+}
+
+// ListExpiredSuspensions возвращает временно заблокированные аккаунты, срок блокировки которых истёк к now.
+func (r *UserRepository) ListExpiredSuspensions(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	const query = `
+		SELECT id
+		FROM   users
+		WHERE  status = 'blocked' AND deleted_at IS NULL AND suspended_until <= $1
+		ORDER  BY suspended_until
+		LIMIT  $2`
+
+	rows, err := r.exec.QueryContext(ctx, query, now, limit)
+
+	if err != nil {
+		return nil, commonapperr.MapPostgresError(err, "list expired suspensions")
+	}
+
+	defer rows.Close()
+
+	var ids []uuid.UUID
+
+	for rows.Next() {
+		var id uuid.UUID
+
+		if err = rows.Scan(&id); err != nil {
+			return nil, commonapperr.MapPostgresError(err, "scan expired suspension")
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonapperr.MapPostgresError(err, "iterate expired suspensions")
+	}
+
+	return ids, nil
+}
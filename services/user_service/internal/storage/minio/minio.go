@@ -0,0 +1,114 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+type Config struct {
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	BucketName      string
+	UseSSL          bool
+	// PublicEndpoint — адрес, по которому хранилище видно пользователям, e.g. "localhost:9000".
+	// Подпись ссылки включает хост, поэтому подписываем клиентом с этим адресом.
+	PublicEndpoint string
+	PublicUseSSL   bool
+	Region         string
+}
+
+// ObjectStorage хранит архивы выгрузок в закрытом бакете; скачать их можно только по подписанной ссылке.
+type ObjectStorage struct {
+	client     *minio.Client
+	signer     *minio.Client
+	bucketName string
+	region     string
+}
+
+func New(cfg Config) (*ObjectStorage, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("minio: new client: %w", err)
+	}
+
+	// с явным регионом клиент не ходит в хранилище за расположением бакета,
+	// поэтому публичный адрес может быть недоступен изнутри кластера
+	signer, err := minio.New(cfg.PublicEndpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		Secure: cfg.PublicUseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("minio: new signing client: %w", err)
+	}
+
+	return &ObjectStorage{
+		client:     client,
+		signer:     signer,
+		bucketName: cfg.BucketName,
+		region:     cfg.Region,
+	}, nil
+}
+
+// EnsureBucket создаёт закрытый бакет, если его нет. Вызывается при старте приложения.
+func (s *ObjectStorage) EnsureBucket(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.bucketName)
+	if err != nil {
+		return fmt.Errorf("minio: check bucket: %w", err)
+	}
+
+	if exists {
+		return nil
+	}
+
+	if err = s.client.MakeBucket(ctx, s.bucketName, minio.MakeBucketOptions{Region: s.region}); err != nil {
+		return fmt.Errorf("minio: make bucket: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ObjectStorage) Upload(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucketName, key, r, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return fmt.Errorf("minio: put object %q: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *ObjectStorage) Delete(ctx context.Context, key string) error {
+	err := s.client.RemoveObject(ctx, s.bucketName, key, minio.RemoveObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("minio: remove object %q: %w", key, err)
+	}
+
+	return nil
+}
+
+// PresignedURL возвращает ссылку на скачивание, действующую ttl.
+// filename задаёт имя файла, под которым браузер сохранит архив.
+func (s *ObjectStorage) PresignedURL(ctx context.Context, key, filename string, ttl time.Duration) (string, error) {
+	params := url.Values{}
+	params.Set("response-content-disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	u, err := s.signer.PresignedGetObject(ctx, s.bucketName, key, ttl, params)
+	if err != nil {
+		return "", fmt.Errorf("minio: presign object %q: %w", key, err)
+	}
+
+	return u.String(), nil
+}
@@ -0,0 +1,155 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	userv1 "github.com/rockkley/pushpost/services/user_service/gen/userv1"
+	apperr "github.com/rockkley/pushpost/services/user_service/internal/apperror"
+	"github.com/rockkley/pushpost/services/user_service/internal/domain"
+	"github.com/rockkley/pushpost/services/user_service/internal/entity"
+)
+
+type UserServer struct {
+	userv1.UnimplementedUserServiceServer
+	uc  domain.UserUseCaseInterface
+	log *slog.Logger
+}
+
+func NewUserServer(uc domain.UserUseCaseInterface, log *slog.Logger) *UserServer {
+	return &UserServer{uc: uc, log: log}
+}
+
+func (s *UserServer) GetUsers(
+	ctx context.Context,
+	req *userv1.GetUsersRequest,
+) (*userv1.GetUsersResponse, error) {
+	ids := make([]uuid.UUID, 0, len(req.Ids))
+
+	for _, raw := range req.Ids {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid id %q: %v", raw, err)
+		}
+		ids = append(ids, id)
+	}
+
+	users, err := s.uc.GetUsers(ctx, ids)
+	if err != nil {
+		return nil, s.toStatus("GetUsers", err)
+	}
+
+	resp := &userv1.GetUsersResponse{Users: make([]*userv1.User, 0, len(users))}
+
+	for _, u := range users {
+		resp.Users = append(resp.Users, toProto(u))
+	}
+
+	return resp, nil
+}
+
+func (s *UserServer) GetByUsername(
+	ctx context.Context,
+	req *userv1.GetByUsernameRequest,
+) (*userv1.GetUserResponse, error) {
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+
+	user, err := s.uc.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, s.toStatus("GetByUsername", err)
+	}
+
+	return &userv1.GetUserResponse{User: toProto(user)}, nil
+}
+
+func (s *UserServer) GetByEmail(
+	ctx context.Context,
+	req *userv1.GetByEmailRequest,
+) (*userv1.GetUserResponse, error) {
+	if req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	user, err := s.uc.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, s.toStatus("GetByEmail", err)
+	}
+
+	return &userv1.GetUserResponse{User: toProto(user)}, nil
+}
+
+// GetStatus, в отличие от остальных методов, отвечает и для удалённых аккаунтов:
+// потребителям важно отличать удалённого пользователя от несуществующего.
+func (s *UserServer) GetStatus(
+	ctx context.Context,
+	req *userv1.GetStatusRequest,
+) (*userv1.GetStatusResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+
+	user, err := s.uc.GetUserByID(ctx, userID)
+	if err != nil {
+		var appErr commonapperr.AppError
+
+		if errors.As(err, &appErr) && appErr.Code() == apperr.CodeUserDeleted {
+			return &userv1.GetStatusResponse{Status: entity.StatusDeleted}, nil
+		}
+
+		return nil, s.toStatus("GetStatus", err)
+	}
+
+	return &userv1.GetStatusResponse{
+		Status:              user.Status,
+		SuspendedUntil:      formatTime(user.SuspendedUntil),
+		DeletionScheduledAt: formatTime(user.DeletionScheduledAt),
+	}, nil
+}
+
+// toStatus переводит AppError в код gRPC; всё, что не ошибка клиента, скрывается за Internal.
+func (s *UserServer) toStatus(method string, err error) error {
+	var appErr commonapperr.AppError
+
+	if errors.As(err, &appErr) {
+		switch appErr.HTTPStatus() {
+		case http.StatusNotFound:
+			return status.Error(codes.NotFound, "user not found")
+		case http.StatusBadRequest, http.StatusUnprocessableEntity:
+			return status.Error(codes.InvalidArgument, appErr.Error())
+		}
+	}
+
+	s.log.Error(method+" failed", slog.Any("error", err))
+
+	return status.Error(codes.Internal, "internal error")
+}
+
+func toProto(u *entity.User) *userv1.User {
+	return &userv1.User{
+		Id:        u.ID.String(),
+		Username:  u.Username,
+		Email:     u.Email,
+		Status:    u.Status,
+		Role:      u.Role,
+		CreatedAt: u.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/user_service/internal/entity"
+)
+
+type GetUsersRequestDTO struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+func (dto *GetUsersRequestDTO) Validate() error {
+	if len(dto.IDs) == 0 {
+		return errors.New("ids are required")
+	}
+
+	return nil
+}
+
+// BatchUserDTO — публичная часть пользователя: /users/* доступен через gateway,
+// поэтому email и хеш пароля в пакетный ответ не попадают.
+type BatchUserDTO struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	Status    string    `json:"status"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GetUsersResponseDTO struct {
+	Users []BatchUserDTO `json:"users"`
+}
+
+func NewGetUsersResponseDTO(users []*entity.User) GetUsersResponseDTO {
+	resp := GetUsersResponseDTO{Users: make([]BatchUserDTO, 0, len(users))}
+
+	for _, u := range users {
+		resp.Users = append(resp.Users, BatchUserDTO{
+			ID:        u.ID,
+			Username:  u.Username,
+			Status:    u.Status,
+			Role:      u.Role,
+			CreatedAt: u.CreatedAt,
+		})
+	}
+
+	return resp
+}
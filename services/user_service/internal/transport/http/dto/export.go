@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rockkley/pushpost/services/user_service/internal/entity"
+)
+
+// DataExportDTO — DownloadURL есть только у готовой выгрузки, срок которой не истёк.
+type DataExportDTO struct {
+	ID          uuid.UUID  `json:"id"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	DownloadURL string     `json:"download_url,omitempty"`
+}
+
+func NewDataExportDTO(e *entity.DataExport, downloadURL string) DataExportDTO {
+	return DataExportDTO{
+		ID:          e.ID,
+		Status:      e.Status,
+		CreatedAt:   e.CreatedAt,
+		FinishedAt:  e.FinishedAt,
+		ExpiresAt:   e.ExpiresAt,
+		DownloadURL: downloadURL,
+	}
+}
@@ -0,0 +1,32 @@
+package dto
+
+import "errors"
+
+type EnableMFARequestDTO struct {
+	TOTPSecret         string   `json:"totp_secret"`
+	RecoveryCodeHashes []string `json:"recovery_code_hashes"`
+}
+
+func (dto *EnableMFARequestDTO) Validate() error {
+	if dto.TOTPSecret == "" {
+		return errors.New("totp_secret is required")
+	}
+
+	if len(dto.RecoveryCodeHashes) == 0 {
+		return errors.New("recovery_code_hashes are required")
+	}
+
+	return nil
+}
+
+type ConsumeRecoveryCodeRequestDTO struct {
+	CodeHash string `json:"code_hash"`
+}
+
+func (dto *ConsumeRecoveryCodeRequestDTO) Validate() error {
+	if dto.CodeHash == "" {
+		return errors.New("code_hash is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package dto
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rockkley/pushpost/services/common_service/roles"
+)
+
+type UpdatePasswordRequestDTO struct {
+	PasswordHash string `json:"password_hash"`
+}
+
+func (dto *UpdatePasswordRequestDTO) Validate() error {
+	if dto.PasswordHash == "" {
+		return errors.New("password_hash is required")
+	}
+
+	return nil
+}
+
+type RehashPasswordRequestDTO struct {
+	CurrentHash string `json:"current_hash"`
+	NewHash     string `json:"new_hash"`
+}
+
+func (dto *RehashPasswordRequestDTO) Validate() error {
+	if dto.CurrentHash == "" || dto.NewHash == "" {
+		return errors.New("current_hash and new_hash are required")
+	}
+
+	return nil
+}
+
+type ChangeEmailRequestDTO struct {
+	Email string `json:"email"`
+}
+
+func (dto *ChangeEmailRequestDTO) Validate() error {
+	if dto.Email == "" {
+		return errors.New("email is required")
+	}
+
+	return nil
+}
+
+var usernameRegex = regexp.MustCompile(`^[a-zA-Z0-9_]{3,30}$`)
+
+type ChangeUsernameRequestDTO struct {
+	Username string `json:"username"`
+}
+
+func (dto *ChangeUsernameRequestDTO) Validate() error {
+	dto.Username = strings.TrimSpace(dto.Username)
+
+	if !usernameRegex.MatchString(dto.Username) {
+		return errors.New("username must be 3-30 latin letters, digits or underscores")
+	}
+
+	return nil
+}
+
+type SetRoleRequestDTO struct {
+	Role string `json:"role"`
+}
+
+func (dto *SetRoleRequestDTO) Validate() error {
+	if _, ok := roles.Parse(dto.Role); !ok {
+		return errors.New("role must be one of user, moderator, admin")
+	}
+
+	return nil
+}
+
+type ScheduleDeletionRequestDTO struct {
+	ScheduledFor time.Time `json:"scheduled_for"`
+}
+
+func (dto *ScheduleDeletionRequestDTO) Validate() error {
+	if dto.ScheduledFor.IsZero() {
+		return errors.New("scheduled_for is required")
+	}
+
+	return nil
+}
+
+type SuspendUserRequestDTO struct {
+	Until  *time.Time `json:"until"`
+	Reason string     `json:"reason"`
+}
+
+func (dto *SuspendUserRequestDTO) Validate() error {
+	if dto.Reason == "" {
+		return errors.New("reason is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"net/http"
+
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/httperror"
+	commonmiddleware "github.com/rockkley/pushpost/services/common_service/middleware"
+	"github.com/rockkley/pushpost/services/user_service/internal/domain"
+	"github.com/rockkley/pushpost/services/user_service/internal/transport/http/dto"
+)
+
+type ExportHandler struct {
+	exportUseCase domain.DataExportUseCaseInterface
+}
+
+func NewExportHandler(exportUseCase domain.DataExportUseCaseInterface) *ExportHandler {
+	return &ExportHandler{exportUseCase: exportUseCase}
+}
+
+// RequestExport ставит выгрузку в очередь; о готовности пользователь узнает из уведомления.
+func (h *ExportHandler) RequestExport(w http.ResponseWriter, r *http.Request) error {
+	userID, ok := commonmiddleware.UserIDFromContext(r.Context())
+
+	if !ok {
+		return commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "missing user id")
+	}
+
+	e, err := h.exportUseCase.RequestExport(r.Context(), userID)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusAccepted, dto.NewDataExportDTO(e, ""))
+}
+
+func (h *ExportHandler) GetLatestExport(w http.ResponseWriter, r *http.Request) error {
+	userID, ok := commonmiddleware.UserIDFromContext(r.Context())
+
+	if !ok {
+		return commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "missing user id")
+	}
+
+	e, downloadURL, err := h.exportUseCase.GetLatestExport(r.Context(), userID)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, dto.NewDataExportDTO(e, downloadURL))
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
+	"github.com/rockkley/pushpost/services/common_service/httperror"
+	domaindto "github.com/rockkley/pushpost/services/user_service/internal/domain/dto"
+	"github.com/rockkley/pushpost/services/user_service/internal/transport/http/dto"
+)
+
+func (h *UserHandler) GetMFA(w http.ResponseWriter, r *http.Request) error {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+
+	if err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid user id")
+	}
+
+	mfa, err := h.userUseCase.GetMFA(r.Context(), id)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, mfa)
+}
+
+func (h *UserHandler) EnableMFA(w http.ResponseWriter, r *http.Request) error {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+
+	if err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid user id")
+	}
+
+	var req dto.EnableMFARequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	err = h.userUseCase.EnableMFA(r.Context(), id, domaindto.EnableMFADTO{
+		TOTPSecret:         req.TOTPSecret,
+		RecoveryCodeHashes: req.RecoveryCodeHashes,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "mfa enabled"})
+}
+
+func (h *UserHandler) DisableMFA(w http.ResponseWriter, r *http.Request) error {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+
+	if err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid user id")
+	}
+
+	if err = h.userUseCase.DisableMFA(r.Context(), id); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "mfa disabled"})
+}
+
+func (h *UserHandler) ConsumeRecoveryCode(w http.ResponseWriter, r *http.Request) error {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+
+	if err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid user id")
+	}
+
+	var req dto.ConsumeRecoveryCodeRequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	if err = h.userUseCase.ConsumeRecoveryCode(r.Context(), id, req.CodeHash); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "recovery code accepted"})
+}
@@ -6,7 +6,10 @@ import (
 	"github.com/google/uuid"
 	commonapperr "github.com/rockkley/pushpost/services/common_service/apperror"
 	"github.com/rockkley/pushpost/services/common_service/httperror"
+	commonmiddleware "github.com/rockkley/pushpost/services/common_service/middleware"
+	"github.com/rockkley/pushpost/services/common_service/roles"
 	"github.com/rockkley/pushpost/services/user_service/internal/domain"
+	domaindto "github.com/rockkley/pushpost/services/user_service/internal/domain/dto"
 	"github.com/rockkley/pushpost/services/user_service/internal/mapper"
 	"github.com/rockkley/pushpost/services/user_service/internal/transport/http/dto"
 	"net/http"
@@ -77,6 +80,26 @@ func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) error
 	return httperror.WriteJSON(w, http.StatusOK, user)
 }
 
+func (h *UserHandler) GetUsers(w http.ResponseWriter, r *http.Request) error {
+	var req dto.GetUsersRequestDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err := req.Validate(); err != nil {
+		return commonapperr.Validation(commonapperr.CodeFieldRequired, "ids", err.Error())
+	}
+
+	users, err := h.userUseCase.GetUsers(r.Context(), req.IDs)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, dto.NewGetUsersResponseDTO(users))
+}
+
 func (h *UserHandler) GetUserByUsername(w http.ResponseWriter, r *http.Request) error {
 	username := chi.URLParam(r, "username")
 
@@ -114,3 +137,233 @@ func (h *UserHandler) ActivateUser(w http.ResponseWriter, r *http.Request) error
 
 	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "user activated"})
 }
+
+func (h *UserHandler) UpdatePassword(w http.ResponseWriter, r *http.Request) error {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+
+	if err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid user id")
+	}
+
+	var req dto.UpdatePasswordRequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	if err = h.userUseCase.UpdatePassword(r.Context(), id, req.PasswordHash); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "password updated"})
+}
+
+func (h *UserHandler) RehashPassword(w http.ResponseWriter, r *http.Request) error {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+
+	if err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid user id")
+	}
+
+	var req dto.RehashPasswordRequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	if err = h.userUseCase.RehashPassword(r.Context(), id, req.CurrentHash, req.NewHash); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "password hash updated"})
+}
+
+func (h *UserHandler) ChangeEmail(w http.ResponseWriter, r *http.Request) error {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+
+	if err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid user id")
+	}
+
+	var req dto.ChangeEmailRequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	if err = h.userUseCase.ChangeEmail(r.Context(), id, req.Email); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "email changed"})
+}
+
+func (h *UserHandler) ChangeUsername(w http.ResponseWriter, r *http.Request) error {
+	userID, ok := commonmiddleware.UserIDFromContext(r.Context())
+
+	if !ok {
+		return commonapperr.Unauthorized(commonapperr.CodeUnauthorized, "missing user id")
+	}
+
+	var req dto.ChangeUsernameRequestDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err := req.Validate(); err != nil {
+		return commonapperr.Validation(commonapperr.CodeFieldInvalid, "username", err.Error())
+	}
+
+	user, err := h.userUseCase.ChangeUsername(r.Context(), userID, req.Username)
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"username": user.Username})
+}
+
+// GetUserByFormerUsername — для редиректа gateway со старого имени на текущее.
+func (h *UserHandler) GetUserByFormerUsername(w http.ResponseWriter, r *http.Request) error {
+	user, err := h.userUseCase.ResolveFormerUsername(r.Context(), chi.URLParam(r, "username"))
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, user)
+}
+
+func (h *UserHandler) SetRole(w http.ResponseWriter, r *http.Request) error {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+
+	if err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid user id")
+	}
+
+	var req dto.SetRoleRequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	if err = h.userUseCase.SetRole(r.Context(), id, roles.Role(req.Role)); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "role changed"})
+}
+
+func (h *UserHandler) ScheduleDeletion(w http.ResponseWriter, r *http.Request) error {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+
+	if err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid user id")
+	}
+
+	var req dto.ScheduleDeletionRequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	if err = h.userUseCase.ScheduleDeletion(r.Context(), id, req.ScheduledFor); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "deletion scheduled"})
+}
+
+// DeleteUser удаляет аккаунт сразу, без срока восстановления. Нужен auth_service, чтобы
+// откатить создание пользователя при входе через провайдера.
+func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) error {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+
+	if err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid user id")
+	}
+
+	if err = h.userUseCase.DeleteUser(r.Context(), id); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "user deleted"})
+}
+
+func (h *UserHandler) CancelDeletion(w http.ResponseWriter, r *http.Request) error {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+
+	if err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid user id")
+	}
+
+	if err = h.userUseCase.CancelDeletion(r.Context(), id); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "deletion cancelled"})
+}
+
+func (h *UserHandler) SuspendUser(w http.ResponseWriter, r *http.Request) error {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+
+	if err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid user id")
+	}
+
+	var req dto.SuspendUserRequestDTO
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, "invalid JSON")
+	}
+
+	if err = req.Validate(); err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeValidationFailed, err.Error())
+	}
+
+	err = h.userUseCase.SuspendUser(r.Context(), id, domaindto.SuspendUserDTO{
+		Until:  req.Until,
+		Reason: req.Reason,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "user suspended"})
+}
+
+func (h *UserHandler) ReinstateUser(w http.ResponseWriter, r *http.Request) error {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+
+	if err != nil {
+		return commonapperr.BadRequest(commonapperr.CodeFieldInvalid, "invalid user id")
+	}
+
+	if err = h.userUseCase.ReinstateUser(r.Context(), id); err != nil {
+		return err
+	}
+
+	return httperror.WriteJSON(w, http.StatusOK, map[string]string{"message": "user reinstated"})
+}
@@ -8,10 +8,11 @@ import (
 	handlerhttp "github.com/rockkley/pushpost/services/common_service/http"
 	"github.com/rockkley/pushpost/services/common_service/httplog"
 	"github.com/rockkley/pushpost/services/common_service/metrics"
+	commonmiddleware "github.com/rockkley/pushpost/services/common_service/middleware"
 	myHTTP "github.com/rockkley/pushpost/services/user_service/internal/transport/http"
 )
 
-func NewRouter(log *slog.Logger, userHandler *myHTTP.UserHandler) *chi.Mux {
+func NewRouter(log *slog.Logger, userHandler *myHTTP.UserHandler, exportHandler *myHTTP.ExportHandler) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(chimiddleware.RequestID)
@@ -24,9 +25,36 @@ func NewRouter(log *slog.Logger, userHandler *myHTTP.UserHandler) *chi.Mux {
 	r.Route("/users", func(r chi.Router) {
 		r.Post("/", handlerhttp.MakeHandler(userHandler.CreateUser))
 		r.Post("/activate", handlerhttp.MakeHandler(userHandler.ActivateUser))
+		r.Post("/batch", handlerhttp.MakeHandler(userHandler.GetUsers))
 		r.Get("/{id}", handlerhttp.MakeHandler(userHandler.GetUserByID))
 		r.Get("/by-email", handlerhttp.MakeHandler(userHandler.GetUserByEmail))
 		r.Get("/by-username/{username}", handlerhttp.MakeHandler(userHandler.GetUserByUsername))
+		// gateway проксирует /users/* с X-User-ID
+		r.Group(func(r chi.Router) {
+			r.Use(commonmiddleware.RequireUserID)
+
+			r.Patch("/me/username", handlerhttp.MakeHandler(userHandler.ChangeUsername))
+			r.Post("/me/export", handlerhttp.MakeHandler(exportHandler.RequestExport))
+			r.Get("/me/export", handlerhttp.MakeHandler(exportHandler.GetLatestExport))
+		})
+	})
+
+	// internal API, not exposed through the gateway
+	r.Route("/internal/users", func(r chi.Router) {
+		r.Get("/by-former-username/{username}", handlerhttp.MakeHandler(userHandler.GetUserByFormerUsername))
+		r.Delete("/{id}", handlerhttp.MakeHandler(userHandler.DeleteUser))
+		r.Put("/{id}/password", handlerhttp.MakeHandler(userHandler.UpdatePassword))
+		r.Put("/{id}/password-hash", handlerhttp.MakeHandler(userHandler.RehashPassword))
+		r.Put("/{id}/email", handlerhttp.MakeHandler(userHandler.ChangeEmail))
+		r.Put("/{id}/role", handlerhttp.MakeHandler(userHandler.SetRole))
+		r.Put("/{id}/deletion", handlerhttp.MakeHandler(userHandler.ScheduleDeletion))
+		r.Delete("/{id}/deletion", handlerhttp.MakeHandler(userHandler.CancelDeletion))
+		r.Put("/{id}/suspension", handlerhttp.MakeHandler(userHandler.SuspendUser))
+		r.Delete("/{id}/suspension", handlerhttp.MakeHandler(userHandler.ReinstateUser))
+		r.Get("/{id}/mfa", handlerhttp.MakeHandler(userHandler.GetMFA))
+		r.Put("/{id}/mfa", handlerhttp.MakeHandler(userHandler.EnableMFA))
+		r.Delete("/{id}/mfa", handlerhttp.MakeHandler(userHandler.DisableMFA))
+		r.Post("/{id}/mfa/recovery-codes/consume", handlerhttp.MakeHandler(userHandler.ConsumeRecoveryCode))
 	})
 
 	return r
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_mfa
+(
+    user_id     UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    totp_secret TEXT      NOT NULL,
+    enabled_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE user_mfa_recovery_codes
+(
+    user_id   UUID      NOT NULL REFERENCES user_mfa (user_id) ON DELETE CASCADE,
+    code_hash CHAR(64)  NOT NULL,
+    used_at   TIMESTAMP,
+
+    PRIMARY KEY (user_id, code_hash)
+);
+
+COMMENT ON COLUMN user_mfa.totp_secret IS 'encrypted by auth_service, opaque here';
+COMMENT ON COLUMN user_mfa_recovery_codes.code_hash IS 'sha256 hex of a one-time recovery code';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_mfa_recovery_codes;
+DROP TABLE IF EXISTS user_mfa;
+-- +goose StatementEnd
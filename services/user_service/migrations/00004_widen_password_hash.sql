@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- argon2id в формате PHC длиннее 60 символов; CHAR к тому же дополнял бы хеш пробелами
+ALTER TABLE users ALTER COLUMN password_hash TYPE VARCHAR(255);
+
+COMMENT ON COLUMN users.password_hash IS 'PHC string (argon2id) or legacy bcrypt';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- откат невозможен, пока в таблице есть argon2id-хеши: они не влезут в CHAR(60)
+ALTER TABLE users ALTER COLUMN password_hash TYPE CHAR(60);
+
+COMMENT ON COLUMN users.password_hash IS '60 chars';
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN deletion_scheduled_at TIMESTAMP;
+
+ALTER TABLE users DROP CONSTRAINT users_status_valid;
+ALTER TABLE users ADD CONSTRAINT users_status_valid
+    CHECK (status IN ('inactive', 'active', 'blocked', 'pending_deletion', 'deleted'));
+
+-- по нему задача удаления выбирает аккаунты, у которых истёк срок восстановления
+CREATE INDEX idx_users_deletion_due ON users (deletion_scheduled_at)
+    WHERE status = 'pending_deletion' AND deleted_at IS NULL;
+
+COMMENT ON COLUMN users.deletion_scheduled_at IS 'when a pending_deletion account is deleted for good';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE users SET status = 'active' WHERE status = 'pending_deletion';
+
+DROP INDEX IF EXISTS idx_users_deletion_due;
+
+ALTER TABLE users DROP CONSTRAINT users_status_valid;
+ALTER TABLE users ADD CONSTRAINT users_status_valid
+    CHECK (status IN ('inactive', 'active', 'blocked', 'deleted'));
+
+ALTER TABLE users DROP COLUMN deletion_scheduled_at;
+-- +goose StatementEnd
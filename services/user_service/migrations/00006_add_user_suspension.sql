@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- блокировка использует существующий статус blocked; suspended_until IS NULL — бессрочная
+ALTER TABLE users ADD COLUMN suspended_until TIMESTAMP;
+ALTER TABLE users ADD COLUMN suspension_reason TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_users_suspension_due ON users (suspended_until)
+    WHERE status = 'blocked' AND suspended_until IS NOT NULL AND deleted_at IS NULL;
+
+COMMENT ON COLUMN users.suspended_until IS 'when a temporary suspension is lifted, NULL for permanent';
+COMMENT ON COLUMN users.suspension_reason IS 'moderator note, not shown to the user';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_suspension_due;
+
+ALTER TABLE users DROP COLUMN suspension_reason;
+ALTER TABLE users DROP COLUMN suspended_until;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- роль попадает в access-токен; новые пользователи — обычные, модераторов и админов назначает админ
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'user';
+
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'moderator', 'admin'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users DROP COLUMN role;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- прежние имена пользователя: по ним gateway отдаёт 301 на новое имя, пока не истёк
+-- переходный период, и не даёт занять их другим
+CREATE TABLE username_history
+(
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    username   VARCHAR(30) NOT NULL,
+    changed_at TIMESTAMP   NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, changed_at)
+);
+
+CREATE INDEX idx_username_history_username ON username_history (LOWER(username), changed_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS username_history;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- скелет имени (reserved.Skeleton) нужен, чтобы не пускать похожие имена вроде adm1n рядом с admin.
+-- Существующие имена уже прошли проверку [a-zA-Z0-9_], для них хватает ASCII-правил.
+-- Индекс не уникальный: среди старых имён похожие пары уже могут быть.
+ALTER TABLE users ADD COLUMN username_skeleton TEXT;
+
+UPDATE users
+SET    username_skeleton = REPLACE(REPLACE(TRANSLATE(LOWER(username), '01i_', 'oll'), 'rn', 'm'), 'vv', 'w');
+
+ALTER TABLE users ALTER COLUMN username_skeleton SET NOT NULL;
+
+CREATE INDEX idx_users_username_skeleton ON users (username_skeleton) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_username_skeleton;
+ALTER TABLE users DROP COLUMN username_skeleton;
+-- +goose StatementEnd
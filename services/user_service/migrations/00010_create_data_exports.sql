@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- запросы на выгрузку персональных данных: задание собирает архив из всех сервисов,
+-- кладёт его в хранилище и держит до expires_at
+CREATE TABLE data_exports
+(
+    id          UUID        PRIMARY KEY,
+    user_id     UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    status      VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'running', 'ready', 'failed', 'expired')),
+    attempts    INT         NOT NULL DEFAULT 0,
+    object_key  TEXT,
+    error       TEXT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at  TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    expires_at  TIMESTAMPTZ
+);
+
+-- одновременно у пользователя может собираться только одна выгрузка
+CREATE UNIQUE INDEX data_exports_one_active
+    ON data_exports (user_id)
+    WHERE status IN ('pending', 'running');
+
+CREATE INDEX idx_data_exports_user ON data_exports (user_id, created_at DESC);
+
+CREATE INDEX idx_data_exports_queue
+    ON data_exports (created_at)
+    WHERE status IN ('pending', 'running');
+
+CREATE INDEX idx_data_exports_expiry
+    ON data_exports (expires_at)
+    WHERE status = 'ready';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS data_exports;
+-- +goose StatementEnd